@@ -31,7 +31,7 @@ func main() {
 		}
 	}()
 
-	if err := r.Run(); err != nil {
+	if err := r.RunSafe(); err != nil {
 		panic(err)
 	}
 }
@@ -2,15 +2,35 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode"
 
+	"github.com/davidmovas/Depthborn/internal/character/inventory"
 	"github.com/davidmovas/Depthborn/internal/item"
 	"github.com/davidmovas/Depthborn/pkg/identifier"
 	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrMaxTabsReached = errors.New("maximum number of stash tabs reached")
+	ErrTabOutOfRange  = errors.New("tab index out of range")
+	ErrTabFull        = errors.New("stash tab is full")
+	ErrSlotOccupied   = errors.New("slot is already occupied")
+	ErrItemNotFound   = errors.New("item not found")
+	ErrSlotOutOfRange = errors.New("slot out of range")
+	ErrStashCorrupted = errors.New("stash state is corrupted")
+	ErrItemLocked     = errors.New("item is locked")
+)
+
 // Stash represents account-wide shared storage with tabs
 // Unlike inventory, stash has NO weight limits - only slot limits per tab
 type Stash struct {
@@ -18,6 +38,13 @@ type Stash struct {
 
 	tabs    []*StashTab
 	maxTabs int
+
+	onTabExpanded []func(tabIndex, newCount int)
+
+	// Cached aggregates, kept in sync by tab stats hooks
+	totalItems atomic.Int64
+	totalValue atomic.Int64
+	usedSlots  atomic.Int64
 }
 
 // StashConfig holds configuration for creating a stash
@@ -59,12 +86,31 @@ func NewStash(cfg StashConfig) *Stash {
 	// Create initial tabs
 	for i := 0; i < cfg.InitialTabs; i++ {
 		tab := NewStashTab(fmt.Sprintf("Stash %d", i+1), cfg.SlotsPerTab)
+		s.attachTab(tab)
 		s.tabs = append(s.tabs, tab)
 	}
 
 	return s
 }
 
+// attachTab hooks tab stats into stash aggregates
+func (s *Stash) attachTab(tab *StashTab) {
+	items, value, slots := tab.setStatsHook(s.applyTabDelta)
+	s.applyTabDelta(items, value, slots)
+}
+
+// detachTab unhooks tab and removes its contribution from aggregates
+func (s *Stash) detachTab(tab *StashTab) {
+	items, value, slots := tab.setStatsHook(nil)
+	s.applyTabDelta(-items, -value, -slots)
+}
+
+func (s *Stash) applyTabDelta(items int, value int64, slots int) {
+	s.totalItems.Add(int64(items))
+	s.totalValue.Add(value)
+	s.usedSlots.Add(int64(slots))
+}
+
 // --- Tab Management ---
 
 // Tabs returns all stash tabs
@@ -94,7 +140,7 @@ func (s *Stash) AddTab(name string) error {
 	defer s.mu.Unlock()
 
 	if len(s.tabs) >= s.maxTabs {
-		return fmt.Errorf("maximum number of stash tabs reached (%d)", s.maxTabs)
+		return fmt.Errorf("%w (%d)", ErrMaxTabsReached, s.maxTabs)
 	}
 
 	// Use default slots per tab from first tab, or default
@@ -104,6 +150,7 @@ func (s *Stash) AddTab(name string) error {
 	}
 
 	tab := NewStashTab(name, slotsPerTab)
+	s.attachTab(tab)
 	s.tabs = append(s.tabs, tab)
 	return nil
 }
@@ -114,10 +161,11 @@ func (s *Stash) AddTabWithSlots(name string, slots int) error {
 	defer s.mu.Unlock()
 
 	if len(s.tabs) >= s.maxTabs {
-		return fmt.Errorf("maximum number of stash tabs reached (%d)", s.maxTabs)
+		return fmt.Errorf("%w (%d)", ErrMaxTabsReached, s.maxTabs)
 	}
 
 	tab := NewStashTab(name, slots)
+	s.attachTab(tab)
 	s.tabs = append(s.tabs, tab)
 	return nil
 }
@@ -128,7 +176,7 @@ func (s *Stash) RemoveTab(index int) error {
 	defer s.mu.Unlock()
 
 	if index < 0 || index >= len(s.tabs) {
-		return fmt.Errorf("tab index out of range: %d", index)
+		return fmt.Errorf("%w: %d", ErrTabOutOfRange, index)
 	}
 
 	if len(s.tabs) == 1 {
@@ -140,6 +188,7 @@ func (s *Stash) RemoveTab(index int) error {
 		return fmt.Errorf("cannot remove non-empty stash tab")
 	}
 
+	s.detachTab(s.tabs[index])
 	s.tabs = append(s.tabs[:index], s.tabs[index+1:]...)
 	return nil
 }
@@ -150,7 +199,7 @@ func (s *Stash) RenameTab(index int, name string) error {
 	defer s.mu.Unlock()
 
 	if index < 0 || index >= len(s.tabs) {
-		return fmt.Errorf("tab index out of range: %d", index)
+		return fmt.Errorf("%w: %d", ErrTabOutOfRange, index)
 	}
 
 	s.tabs[index].SetName(name)
@@ -163,13 +212,45 @@ func (s *Stash) SwapTabs(index1, index2 int) error {
 	defer s.mu.Unlock()
 
 	if index1 < 0 || index1 >= len(s.tabs) || index2 < 0 || index2 >= len(s.tabs) {
-		return fmt.Errorf("tab index out of range")
+		return ErrTabOutOfRange
 	}
 
 	s.tabs[index1], s.tabs[index2] = s.tabs[index2], s.tabs[index1]
 	return nil
 }
 
+// ExpandTab grows tab by additionalSlots, e.g. after a purchased upgrade.
+// New size is kept by SerializeState; OnTabExpanded fires after the change.
+func (s *Stash) ExpandTab(tabIndex, additionalSlots int) error {
+	if additionalSlots <= 0 {
+		return fmt.Errorf("%w: cannot expand by %d slots", ErrSlotOutOfRange, additionalSlots)
+	}
+
+	s.mu.Lock()
+	if tabIndex < 0 || tabIndex >= len(s.tabs) {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: %d", ErrTabOutOfRange, tabIndex)
+	}
+
+	tab := s.tabs[tabIndex]
+	newCount := tab.SlotCount() + additionalSlots
+	tab.SetSlotCount(newCount)
+	callbacks := slices.Clone(s.onTabExpanded)
+	s.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(tabIndex, newCount)
+	}
+	return nil
+}
+
+// OnTabExpanded registers callback invoked with tab index and new slot count after ExpandTab
+func (s *Stash) OnTabExpanded(callback func(tabIndex, newCount int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onTabExpanded = append(s.onTabExpanded, callback)
+}
+
 // TabCount returns number of tabs
 func (s *Stash) TabCount() int {
 	s.mu.RLock()
@@ -192,7 +273,7 @@ func (s *Stash) TransferToTab(ctx context.Context, itm item.Item, tabIndex int)
 	defer s.mu.Unlock()
 
 	if tabIndex < 0 || tabIndex >= len(s.tabs) {
-		return fmt.Errorf("tab index out of range: %d", tabIndex)
+		return fmt.Errorf("%w: %d", ErrTabOutOfRange, tabIndex)
 	}
 
 	// Find and remove item from its current tab
@@ -228,7 +309,7 @@ func (s *Stash) TransferToSlot(ctx context.Context, itm item.Item, tabIndex, slo
 	defer s.mu.Unlock()
 
 	if tabIndex < 0 || tabIndex >= len(s.tabs) {
-		return fmt.Errorf("tab index out of range: %d", tabIndex)
+		return fmt.Errorf("%w: %d", ErrTabOutOfRange, tabIndex)
 	}
 
 	// Find and remove item from its current tab
@@ -258,6 +339,349 @@ func (s *Stash) TransferToSlot(ctx context.Context, itm item.Item, tabIndex, slo
 	return nil
 }
 
+// TransferKeepingSlot moves item from its current tab to dstTab, landing at
+// the same slot index when that slot exists and is empty, otherwise at the
+// first free slot. Stacks are never merged. Moving within the same tab is a no-op.
+func (s *Stash) TransferKeepingSlot(ctx context.Context, itm item.Item, dstTab int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dstTab < 0 || dstTab >= len(s.tabs) {
+		return fmt.Errorf("%w: %d", ErrTabOutOfRange, dstTab)
+	}
+
+	var sourceTab *StashTab
+	sourceSlot := -1
+	for _, tab := range s.tabs {
+		if slot, ok := tab.slotOf(itm.ID()); ok {
+			sourceTab, sourceSlot = tab, slot
+			break
+		}
+	}
+	if sourceTab == nil {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itm.ID())
+	}
+
+	destination := s.tabs[dstTab]
+	if sourceTab == destination {
+		return nil
+	}
+
+	if _, err := sourceTab.Remove(ctx, itm.ID()); err != nil {
+		return fmt.Errorf("failed to remove from source tab: %w", err)
+	}
+
+	if err := destination.addPreferringSlot(sourceSlot, itm); err != nil {
+		_ = sourceTab.AddToSlot(ctx, sourceSlot, itm)
+		return fmt.Errorf("failed to add to destination tab: %w", err)
+	}
+
+	return nil
+}
+
+// DepositFrom moves all items from source inventories into tab, stacking where
+// possible. Items that don't fit stay in their source inventory; an item whose
+// transfer fails is put back into its original slot.
+// Returns number of items deposited.
+func (s *Stash) DepositFrom(ctx context.Context, sources []*inventory.BaseManager, tabIndex int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tabIndex < 0 || tabIndex >= len(s.tabs) {
+		return 0, fmt.Errorf("%w: %d", ErrTabOutOfRange, tabIndex)
+	}
+	tab := s.tabs[tabIndex]
+
+	deposited := 0
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+
+		for slot := 0; slot < source.SlotCount(); slot++ {
+			if err := ctx.Err(); err != nil {
+				return deposited, err
+			}
+
+			itm, ok := source.GetAtSlot(slot)
+			if !ok || !tab.canFitAll(itm) {
+				continue
+			}
+
+			removed, err := source.Remove(ctx, itm.ID())
+			if err != nil {
+				return deposited, fmt.Errorf("failed to remove from source inventory: %w", err)
+			}
+
+			if err := tab.Add(ctx, removed); err != nil {
+				if rbErr := source.AddToSlot(ctx, slot, removed); rbErr != nil {
+					return deposited, fmt.Errorf("failed to return %s to source inventory: %w", removed.ID(), rbErr)
+				}
+				continue
+			}
+			deposited++
+		}
+	}
+
+	return deposited, nil
+}
+
+// depositRecord tracks how single inventory item landed in tab so it can be undone
+type depositRecord struct {
+	slot     int
+	itm      item.Item
+	targetID string // existing stack topped up ("" = none)
+	merged   int    // units added to target stack
+	placed   bool   // item (or its remainder) occupies own slot
+}
+
+// DepositAllOfType moves every inventory item of itemType into tab, stacking
+// where possible, and leaves other items in place. Deposit is all-or-nothing:
+// if any matching item doesn't fit, already moved items are returned to their
+// original slots and merged stacks are restored.
+// Returns number of items deposited.
+func (s *Stash) DepositAllOfType(ctx context.Context, inv *inventory.BaseManager, itemType item.Type, tabIndex int) (int, error) {
+	if inv == nil {
+		return 0, fmt.Errorf("inventory cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tabIndex < 0 || tabIndex >= len(s.tabs) {
+		return 0, fmt.Errorf("%w: %d", ErrTabOutOfRange, tabIndex)
+	}
+	tab := s.tabs[tabIndex]
+
+	var records []depositRecord
+	fail := func(err error) (int, error) {
+		if rbErr := rollbackDeposit(ctx, inv, tab, records); rbErr != nil {
+			return 0, errors.Join(err, rbErr)
+		}
+		return 0, err
+	}
+
+	for slot := 0; slot < inv.SlotCount(); slot++ {
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+
+		itm, ok := inv.GetAtSlot(slot)
+		if !ok || itm.ItemType() != itemType {
+			continue
+		}
+		if !tab.canFitAll(itm) {
+			return fail(fmt.Errorf("%w: cannot fit %s", ErrTabFull, itm.ID()))
+		}
+
+		removed, err := inv.Remove(ctx, itm.ID())
+		if err != nil {
+			return fail(fmt.Errorf("failed to remove from inventory: %w", err))
+		}
+
+		record := depositRecord{slot: slot, itm: removed}
+		record.targetID, _ = tab.CanStackWith(removed)
+		before := removed.StackSize()
+
+		if err := tab.Add(ctx, removed); err != nil {
+			records = append(records, record)
+			return fail(fmt.Errorf("failed to add to tab: %w", err))
+		}
+
+		record.placed = tab.Contains(removed.ID())
+		if record.placed {
+			record.merged = before - removed.StackSize()
+		} else {
+			record.merged = before
+		}
+		records = append(records, record)
+	}
+
+	return len(records), nil
+}
+
+// rollbackDeposit undoes deposit records in reverse order
+func rollbackDeposit(ctx context.Context, inv *inventory.BaseManager, tab *StashTab, records []depositRecord) error {
+	var errs []error
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if record.placed {
+			if _, err := tab.Remove(ctx, record.itm.ID()); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if record.merged > 0 && record.targetID != "" {
+			tab.adjustStack(record.targetID, -record.merged)
+			if record.placed {
+				record.itm.AddStack(record.merged)
+			}
+		}
+		if err := inv.AddToSlot(ctx, record.slot, record.itm); err != nil {
+			errs = append(errs, fmt.Errorf("failed to return %s to inventory: %w", record.itm.ID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// OrganizeRule routes items matching Predicate to TargetTab
+type OrganizeRule struct {
+	Predicate func(item.Item) bool
+	TargetTab int
+}
+
+// OrganizeError lists items AutoOrganize could not move due to target capacity
+type OrganizeError struct {
+	Unplaced []string // item IDs left in their original tab
+}
+
+func (e *OrganizeError) Error() string {
+	return fmt.Sprintf("%d items could not be organized: %s", len(e.Unplaced), strings.Join(e.Unplaced, ", "))
+}
+
+func (e *OrganizeError) Unwrap() error {
+	return ErrTabFull
+}
+
+// AutoOrganize moves every item into the tab of its first matching rule.
+// Items without a matching rule and locked items stay where they are. Items that don't fit
+// their target tab are left in place and reported via *OrganizeError.
+func (s *Stash) AutoOrganize(ctx context.Context, rules []OrganizeRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rule := range rules {
+		if rule.TargetTab < 0 || rule.TargetTab >= len(s.tabs) {
+			return fmt.Errorf("%w: %d", ErrTabOutOfRange, rule.TargetTab)
+		}
+	}
+
+	type placement struct {
+		itm    item.Item
+		source *StashTab
+	}
+
+	// Snapshot first so items moved into later tabs are not processed twice
+	var pending []placement
+	for _, tab := range s.tabs {
+		for _, itm := range tab.GetAll() {
+			pending = append(pending, placement{itm: itm, source: tab})
+		}
+	}
+
+	var unplaced []string
+	for _, p := range pending {
+		target := s.matchRule(rules, p.itm)
+		if target == nil || target == p.source || p.source.IsItemLocked(p.itm.ID()) {
+			continue
+		}
+
+		if !target.canFitAll(p.itm) {
+			unplaced = append(unplaced, p.itm.ID())
+			continue
+		}
+
+		if _, err := p.source.Remove(ctx, p.itm.ID()); err != nil {
+			return fmt.Errorf("failed to remove from source tab: %w", err)
+		}
+		if err := target.Add(ctx, p.itm); err != nil {
+			_ = p.source.Add(ctx, p.itm)
+			return fmt.Errorf("failed to add to destination tab: %w", err)
+		}
+	}
+
+	if len(unplaced) > 0 {
+		return &OrganizeError{Unplaced: unplaced}
+	}
+	return nil
+}
+
+func (s *Stash) matchRule(rules []OrganizeRule, itm item.Item) *StashTab {
+	for _, rule := range rules {
+		if rule.Predicate != nil && rule.Predicate(itm) {
+			return s.tabs[rule.TargetTab]
+		}
+	}
+	return nil
+}
+
+// ConsolidateStacks merges partial stacks sharing a stack key within each tab,
+// pouring later stacks into earlier ones so they occupy the fewest slots.
+// Locked items are left untouched.
+// Returns number of merge steps performed.
+func (s *Stash) ConsolidateStacks(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merges := 0
+	for _, tab := range s.tabs {
+		n, err := consolidateStacks(ctx, []*StashTab{tab})
+		merges += n
+		if err != nil {
+			return merges, err
+		}
+	}
+	return merges, nil
+}
+
+// ConsolidateAcrossTabs is like ConsolidateStacks but treats all tabs as one
+// pool, filling stacks in earlier tabs from stacks in later tabs.
+func (s *Stash) ConsolidateAcrossTabs(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return consolidateStacks(ctx, s.tabs)
+}
+
+type stackRef struct {
+	tab *StashTab
+	itm item.Item
+}
+
+func consolidateStacks(ctx context.Context, tabs []*StashTab) (int, error) {
+	// Group stackable items by key in tab then slot order
+	var keys []string
+	groups := make(map[string][]stackRef)
+	for _, tab := range tabs {
+		for _, itm := range tab.itemsInSlotOrder() {
+			if itm.MaxStackSize() <= 1 || tab.IsItemLocked(itm.ID()) {
+				continue
+			}
+			key := itm.StackKey()
+			if _, seen := groups[key]; !seen {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], stackRef{tab: tab, itm: itm})
+		}
+	}
+
+	merges := 0
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return merges, err
+		}
+
+		group := groups[key]
+		for i, j := 0, len(group)-1; i < j; {
+			target, source := group[i], group[j]
+
+			space := target.itm.MaxStackSize() - target.itm.StackSize()
+			if space <= 0 {
+				i++
+				continue
+			}
+
+			amount := min(space, source.itm.StackSize())
+			target.tab.adjustStack(target.itm.ID(), amount)
+			if source.tab.adjustStack(source.itm.ID(), -amount) {
+				j--
+			}
+			merges++
+		}
+	}
+	return merges, nil
+}
+
 // FindItem searches all tabs for item
 func (s *Stash) FindItem(itemID string) (item.Item, int, bool) {
 	s.mu.RLock()
@@ -273,6 +697,16 @@ func (s *Stash) FindItem(itemID string) (item.Item, int, bool) {
 
 // --- Search & Filter (across all tabs) ---
 
+// RebuildSearchIndex rebuilds name search index of every tab
+func (s *Stash) RebuildSearchIndex() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, tab := range s.tabs {
+		tab.RebuildSearchIndex()
+	}
+}
+
 // Search finds items matching query string across all tabs
 func (s *Stash) Search(query string) []item.Item {
 	s.mu.RLock()
@@ -349,14 +783,7 @@ func (s *Stash) TotalSlots() int {
 
 // TotalUsedSlots returns total used slots across all tabs
 func (s *Stash) TotalUsedSlots() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	var total int
-	for _, tab := range s.tabs {
-		total += tab.UsedSlots()
-	}
-	return total
+	return int(s.usedSlots.Load())
 }
 
 // TotalFreeSlots returns total free slots across all tabs
@@ -373,42 +800,57 @@ func (s *Stash) TotalFreeSlots() int {
 
 // TotalCount returns total items across all tabs (unique stacks)
 func (s *Stash) TotalCount() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	var total int
-	for _, tab := range s.tabs {
-		total += tab.ItemCount()
-	}
-	return total
+	return int(s.usedSlots.Load())
 }
 
 // TotalItems returns total item count including stack sizes
 func (s *Stash) TotalItems() int {
+	return int(s.totalItems.Load())
+}
+
+// TotalValue returns combined value of all items
+func (s *Stash) TotalValue() int64 {
+	return s.totalValue.Load()
+}
+
+// TotalWeight returns combined weight of all items including stack sizes.
+// Informational only: stash has no weight limit.
+func (s *Stash) TotalWeight() float64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	var total int
+	var total float64
 	for _, tab := range s.tabs {
-		total += tab.TotalItems()
+		total += tab.TotalWeight()
 	}
 	return total
 }
 
-// TotalValue returns combined value of all items
-func (s *Stash) TotalValue() int64 {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// Recompute rebuilds cached totals by rescanning all tabs.
+// Needed after items are restored or mutated outside of stash operations.
+func (s *Stash) Recompute() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	var total int64
+	var items, value, slots int64
 	for _, tab := range s.tabs {
-		total += tab.TotalValue()
+		tab.Recompute()
+		tabItems, tabValue, tabSlots := tab.cachedStats()
+		items += int64(tabItems)
+		value += tabValue
+		slots += int64(tabSlots)
 	}
-	return total
+
+	s.totalItems.Store(items)
+	s.totalValue.Store(value)
+	s.usedSlots.Store(slots)
 }
 
 // --- Persistence ---
 
+// StashSchema names stash state for persist.DefaultMigrator migrations
+const StashSchema = "account.stash"
+
 // StashState holds serializable stash state
 type StashState struct {
 	MaxTabs int             `msgpack:"max_tabs"`
@@ -438,11 +880,17 @@ func (s *Stash) SerializeState() (map[string]any, error) {
 	if err := persist.DefaultCodec().Decode(data, &result); err != nil {
 		return nil, err
 	}
+	persist.DefaultMigrator().Stamp(StashSchema, result)
 	return result, nil
 }
 
 func (s *Stash) DeserializeState(stateData map[string]any) error {
-	data, err := persist.DefaultCodec().Encode(stateData)
+	migrated, err := persist.DefaultMigrator().MigrateState(StashSchema, stateData)
+	if err != nil {
+		return err
+	}
+
+	data, err := persist.DefaultCodec().Encode(migrated)
 	if err != nil {
 		return err
 	}
@@ -452,19 +900,45 @@ func (s *Stash) DeserializeState(stateData map[string]any) error {
 		return err
 	}
 
+	for i, tabState := range state.Tabs {
+		if len(tabState.ItemIDs) > 0 && len(tabState.ItemIDs) != tabState.Slots {
+			return fmt.Errorf("%w: tab %d has %d item IDs for %d slots",
+				ErrStashCorrupted, i, len(tabState.ItemIDs), tabState.Slots)
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.maxTabs = state.MaxTabs
 
+	for _, tab := range s.tabs {
+		s.detachTab(tab)
+	}
+
 	s.tabs = make([]*StashTab, len(state.Tabs))
 	for i, tabState := range state.Tabs {
 		s.tabs[i] = StashTabFromState(tabState)
+		s.attachTab(s.tabs[i])
 	}
 
 	return nil
 }
 
+// Verify checks that every tab's item index agrees with its slot array.
+// Call after restoring items to detect corrupted saves.
+func (s *Stash) Verify() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for i, tab := range s.tabs {
+		if err := tab.Verify(); err != nil {
+			return fmt.Errorf("tab %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 // =============================================================================
 // StashTab - Single tab in stash (slot-based, no weight limit)
 // =============================================================================
@@ -478,15 +952,39 @@ type StashTab struct {
 	color     string
 	slots     []item.Item    // slot index -> item (nil = empty)
 	itemIndex map[string]int // itemID -> slot index
+
+	// Lowercased name token -> item IDs, narrows Search candidates
+	searchIndex map[string]map[string]struct{}
+	// Item ID -> tokens it was indexed under, so renamed items unindex cleanly
+	indexedTokens map[string][]string
+
+	// Custom filter predicates by name; only active name is persisted
+	filterPresets map[string]func(item.Item) bool
+	activePreset  string
+
+	// Item IDs protected from removal and transfer
+	lockedItems map[string]struct{}
+
+	// Cached stats, updated on every mutation (see Recompute)
+	totalItems int
+	totalValue int64
+	onStats    tabStatsHook
 }
 
-// StashTabState holds serializable tab state
+// tabStatsHook receives stat deltas whenever tab contents change
+type tabStatsHook func(items int, value int64, slots int)
+
+// StashTabState holds serializable tab state.
+// ItemIDs is indexed by slot and always has Slots entries, "" marking empty slots.
 type StashTabState struct {
 	Name    string   `msgpack:"name"`
 	Icon    string   `msgpack:"icon"`
 	Color   string   `msgpack:"color"`
 	Slots   int      `msgpack:"slots"`
 	ItemIDs []string `msgpack:"item_ids,omitempty"`
+
+	FilterPreset  string   `msgpack:"filter_preset,omitempty"`
+	LockedItemIDs []string `msgpack:"locked_item_ids,omitempty"`
 }
 
 // NewStashTab creates a new stash tab
@@ -495,11 +993,16 @@ func NewStashTab(name string, slotCount int) *StashTab {
 		slotCount = 60
 	}
 	return &StashTab{
-		name:      name,
-		icon:      "default",
-		color:     "#ffffff",
-		slots:     make([]item.Item, slotCount),
-		itemIndex: make(map[string]int),
+		name:        name,
+		icon:        "default",
+		color:       "#ffffff",
+		slots:       make([]item.Item, slotCount),
+		itemIndex:   make(map[string]int),
+		searchIndex: make(map[string]map[string]struct{}),
+
+		indexedTokens: make(map[string][]string),
+		filterPresets: make(map[string]func(item.Item) bool),
+		lockedItems:   make(map[string]struct{}),
 	}
 }
 
@@ -508,6 +1011,10 @@ func StashTabFromState(state StashTabState) *StashTab {
 	tab := NewStashTab(state.Name, state.Slots)
 	tab.icon = state.Icon
 	tab.color = state.Color
+	tab.activePreset = state.FilterPreset
+	for _, id := range state.LockedItemIDs {
+		tab.lockedItems[id] = struct{}{}
+	}
 	// Items need to be restored separately by repository
 	return tab
 }
@@ -569,11 +1076,10 @@ func (t *StashTab) Add(ctx context.Context, itm item.Item) error {
 	// Find free slot
 	slot := t.findFreeSlotLocked()
 	if slot == -1 {
-		return fmt.Errorf("stash tab is full (no free slots)")
+		return fmt.Errorf("%w: no free slots", ErrTabFull)
 	}
 
-	t.slots[slot] = itm
-	t.itemIndex[itm.ID()] = slot
+	t.placeLocked(slot, itm)
 	return nil
 }
 
@@ -587,33 +1093,31 @@ func (t *StashTab) AddToSlot(ctx context.Context, slot int, itm item.Item) error
 	defer t.mu.Unlock()
 
 	if slot < 0 || slot >= len(t.slots) {
-		return fmt.Errorf("slot %d out of range (0-%d)", slot, len(t.slots)-1)
+		return fmt.Errorf("%w: slot %d (0-%d)", ErrSlotOutOfRange, slot, len(t.slots)-1)
 	}
 
 	if t.slots[slot] != nil {
-		return fmt.Errorf("slot %d is already occupied", slot)
+		return fmt.Errorf("%w: slot %d", ErrSlotOccupied, slot)
 	}
 
-	t.slots[slot] = itm
-	t.itemIndex[itm.ID()] = slot
+	t.placeLocked(slot, itm)
 	return nil
 }
 
-// Remove removes item by ID completely
+// Remove removes item by ID completely. Locked items are rejected.
 func (t *StashTab) Remove(ctx context.Context, itemID string) (item.Item, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	slot, exists := t.itemIndex[itemID]
 	if !exists {
-		return nil, fmt.Errorf("item with ID %s not found", itemID)
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	if t.isLockedLocked(itemID) {
+		return nil, fmt.Errorf("%w: %s", ErrItemLocked, itemID)
 	}
 
-	itm := t.slots[slot]
-	t.slots[slot] = nil
-	delete(t.itemIndex, itemID)
-
-	return itm, nil
+	return t.unplaceLocked(slot), nil
 }
 
 // RemoveAmount removes specific amount from a stack
@@ -627,7 +1131,10 @@ func (t *StashTab) RemoveAmount(ctx context.Context, itemID string, amount int)
 
 	slot, exists := t.itemIndex[itemID]
 	if !exists {
-		return nil, fmt.Errorf("item with ID %s not found", itemID)
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	if t.isLockedLocked(itemID) {
+		return nil, fmt.Errorf("%w: %s", ErrItemLocked, itemID)
 	}
 
 	itm := t.slots[slot]
@@ -635,13 +1142,11 @@ func (t *StashTab) RemoveAmount(ctx context.Context, itemID string, amount int)
 
 	if amount >= currentStack {
 		// Remove entire item
-		t.slots[slot] = nil
-		delete(t.itemIndex, itemID)
-		return itm, nil
+		return t.unplaceLocked(slot), nil
 	}
 
 	// Reduce stack size
-	itm.RemoveStack(amount)
+	t.restackLocked(itm, func() { itm.RemoveStack(amount) })
 
 	// Create new item for removed portion (clone with new ID)
 	removed := itm.Clone().(item.Item)
@@ -715,10 +1220,71 @@ func (t *StashTab) Clear(ctx context.Context) []item.Item {
 
 	t.slots = make([]item.Item, len(t.slots))
 	t.itemIndex = make(map[string]int)
+	t.searchIndex = make(map[string]map[string]struct{})
+	t.indexedTokens = make(map[string][]string)
+	t.lockedItems = make(map[string]struct{})
+	t.applyStatsLocked(-t.totalItems, -t.totalValue, -len(items))
 
 	return items
 }
 
+// --- Item Locks ---
+
+// LockItem protects stored item from Remove, transfers and stack consolidation
+func (t *StashTab) LockItem(itemID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.itemIndex[itemID]; !exists {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	t.lockedItems[itemID] = struct{}{}
+	return nil
+}
+
+// UnlockItem removes lock from item. Unlocking unlocked item is no-op.
+func (t *StashTab) UnlockItem(itemID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.itemIndex[itemID]; !exists {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	delete(t.lockedItems, itemID)
+	return nil
+}
+
+// IsItemLocked checks if item is locked
+func (t *StashTab) IsItemLocked(itemID string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.isLockedLocked(itemID)
+}
+
+// LockedItems returns IDs of locked items, sorted
+func (t *StashTab) LockedItems() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lockedIDsLocked()
+}
+
+func (t *StashTab) isLockedLocked(itemID string) bool {
+	_, locked := t.lockedItems[itemID]
+	return locked
+}
+
+func (t *StashTab) lockedIDsLocked() []string {
+	if len(t.lockedItems) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(t.lockedItems))
+	for id := range t.lockedItems {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 // --- Stack Operations ---
 
 // SplitStack splits a stack into two, returns the new stack
@@ -732,7 +1298,7 @@ func (t *StashTab) SplitStack(ctx context.Context, itemID string, amount int) (i
 
 	slot, exists := t.itemIndex[itemID]
 	if !exists {
-		return nil, fmt.Errorf("item with ID %s not found", itemID)
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
 	}
 
 	itm := t.slots[slot]
@@ -743,11 +1309,11 @@ func (t *StashTab) SplitStack(ctx context.Context, itemID string, amount int) (i
 	// Find free slot for new stack
 	newSlot := t.findFreeSlotLocked()
 	if newSlot == -1 {
-		return nil, fmt.Errorf("no free slot for split stack")
+		return nil, fmt.Errorf("%w: no free slot for split stack", ErrTabFull)
 	}
 
 	// Remove from original stack
-	itm.RemoveStack(amount)
+	t.restackLocked(itm, func() { itm.RemoveStack(amount) })
 
 	// Create new item (clone and set stack)
 	newItem := itm.Clone().(item.Item)
@@ -759,8 +1325,7 @@ func (t *StashTab) SplitStack(ctx context.Context, itemID string, amount int) (i
 		setter.SetID(identifier.New())
 	}
 
-	t.slots[newSlot] = newItem
-	t.itemIndex[newItem.ID()] = newSlot
+	t.placeLocked(newSlot, newItem)
 
 	return newItem, nil
 }
@@ -774,16 +1339,19 @@ func (t *StashTab) MergeStacks(ctx context.Context, sourceID, targetID string) e
 	targetSlot, targetExists := t.itemIndex[targetID]
 
 	if !sourceExists {
-		return fmt.Errorf("source item %s not found", sourceID)
+		return fmt.Errorf("%w: source %s", ErrItemNotFound, sourceID)
 	}
 	if !targetExists {
-		return fmt.Errorf("target item %s not found", targetID)
+		return fmt.Errorf("%w: target %s", ErrItemNotFound, targetID)
+	}
+	if t.isLockedLocked(sourceID) {
+		return fmt.Errorf("%w: source %s", ErrItemLocked, sourceID)
 	}
 
 	source := t.slots[sourceSlot]
 	target := t.slots[targetSlot]
 
-	if !target.CanStackWith(source) {
+	if !sameStack(target, source) {
 		return fmt.Errorf("items cannot be stacked together")
 	}
 
@@ -797,12 +1365,11 @@ func (t *StashTab) MergeStacks(ctx context.Context, sourceID, targetID string) e
 		amountToMove = availableSpace
 	}
 
-	target.AddStack(amountToMove)
-	source.RemoveStack(amountToMove)
+	t.restackLocked(target, func() { target.AddStack(amountToMove) })
+	t.restackLocked(source, func() { source.RemoveStack(amountToMove) })
 
 	if source.StackSize() <= 0 {
-		t.slots[sourceSlot] = nil
-		delete(t.itemIndex, sourceID)
+		t.unplaceLocked(sourceSlot)
 	}
 
 	return nil
@@ -817,7 +1384,7 @@ func (t *StashTab) CanStackWith(itm item.Item) (string, bool) {
 
 func (t *StashTab) canStackWithLocked(itm item.Item) (string, bool) {
 	for _, existing := range t.slots {
-		if existing != nil && existing.CanStackWith(itm) {
+		if existing != nil && sameStack(existing, itm) {
 			if existing.StackSize() < existing.MaxStackSize() {
 				return existing.ID(), true
 			}
@@ -826,6 +1393,11 @@ func (t *StashTab) canStackWithLocked(itm item.Item) (string, bool) {
 	return "", false
 }
 
+// sameStack reports whether two distinct items share a stack key
+func sameStack(existing, itm item.Item) bool {
+	return existing.ID() != itm.ID() && existing.StackKey() == itm.StackKey()
+}
+
 func (t *StashTab) mergeIntoExistingLocked(itm item.Item, targetID string) error {
 	targetSlot := t.itemIndex[targetID]
 	target := t.slots[targetSlot]
@@ -835,22 +1407,21 @@ func (t *StashTab) mergeIntoExistingLocked(itm item.Item, targetID string) error
 
 	if amountToAdd <= availableSpace {
 		// All fits in existing stack
-		target.AddStack(amountToAdd)
+		t.restackLocked(target, func() { target.AddStack(amountToAdd) })
 		return nil
 	}
 
 	// Partial stack - add what fits, then add remainder as new item
-	target.AddStack(availableSpace)
+	t.restackLocked(target, func() { target.AddStack(availableSpace) })
 
 	// Remainder needs new slot
 	slot := t.findFreeSlotLocked()
 	if slot == -1 {
-		return fmt.Errorf("stash tab is full")
+		return fmt.Errorf("%w: no free slot for remainder", ErrTabFull)
 	}
 
 	itm.RemoveStack(availableSpace)
-	t.slots[slot] = itm
-	t.itemIndex[itm.ID()] = slot
+	t.placeLocked(slot, itm)
 	return nil
 }
 
@@ -863,7 +1434,8 @@ func (t *StashTab) SlotCount() int {
 	return len(t.slots)
 }
 
-// SetSlotCount changes number of slots
+// SetSlotCount changes number of slots.
+// Shrinking is skipped while any removed slot holds an item; see SetSlotCountCompact.
 func (t *StashTab) SetSlotCount(count int) {
 	if count <= 0 {
 		return
@@ -872,28 +1444,55 @@ func (t *StashTab) SetSlotCount(count int) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	currentCount := len(t.slots)
-	if count == currentCount {
-		return
+	// Shrink - only if trailing slots are empty
+	for i := count; i < len(t.slots); i++ {
+		if t.slots[i] != nil {
+			return
+		}
+	}
+	t.resizeLocked(count)
+}
+
+// SetSlotCountCompact changes number of slots, moving items out of removed
+// slots into free lower slots first. Fails only if items can't all fit.
+func (t *StashTab) SetSlotCountCompact(count int) error {
+	if count <= 0 {
+		return fmt.Errorf("%w: %d", ErrSlotOutOfRange, count)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.itemIndex) > count {
+		return fmt.Errorf("%w: %d items don't fit in %d slots", ErrTabFull, len(t.itemIndex), count)
+	}
+
+	free := 0
+	for i := count; i < len(t.slots); i++ {
+		itm := t.slots[i]
+		if itm == nil {
+			continue
+		}
+		for t.slots[free] != nil {
+			free++
+		}
+		t.slots[free] = itm
+		t.slots[i] = nil
+		t.itemIndex[itm.ID()] = free
 	}
 
-	if count > currentCount {
-		// Expand
+	t.resizeLocked(count)
+	return nil
+}
+
+// resizeLocked expands or truncates slots; removed slots must be empty
+func (t *StashTab) resizeLocked(count int) {
+	if count > len(t.slots) {
 		newSlots := make([]item.Item, count)
 		copy(newSlots, t.slots)
 		t.slots = newSlots
 	} else {
-		// Shrink - only if trailing slots are empty
-		canShrink := true
-		for i := count; i < currentCount; i++ {
-			if t.slots[i] != nil {
-				canShrink = false
-				break
-			}
-		}
-		if canShrink {
-			t.slots = t.slots[:count]
-		}
+		t.slots = t.slots[:count]
 	}
 }
 
@@ -925,7 +1524,7 @@ func (t *StashTab) SwapSlots(ctx context.Context, slot1, slot2 int) error {
 
 	maxSlot := len(t.slots)
 	if slot1 < 0 || slot1 >= maxSlot || slot2 < 0 || slot2 >= maxSlot {
-		return fmt.Errorf("slot out of range")
+		return ErrSlotOutOfRange
 	}
 
 	item1 := t.slots[slot1]
@@ -951,12 +1550,12 @@ func (t *StashTab) MoveToSlot(ctx context.Context, itemID string, targetSlot int
 
 	maxSlot := len(t.slots)
 	if targetSlot < 0 || targetSlot >= maxSlot {
-		return fmt.Errorf("target slot %d out of range", targetSlot)
+		return fmt.Errorf("%w: target slot %d", ErrSlotOutOfRange, targetSlot)
 	}
 
 	currentSlot, exists := t.itemIndex[itemID]
 	if !exists {
-		return fmt.Errorf("item %s not found", itemID)
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
 	}
 
 	if currentSlot == targetSlot {
@@ -964,7 +1563,7 @@ func (t *StashTab) MoveToSlot(ctx context.Context, itemID string, targetSlot int
 	}
 
 	if t.slots[targetSlot] != nil {
-		return fmt.Errorf("target slot %d is occupied", targetSlot)
+		return fmt.Errorf("%w: target slot %d", ErrSlotOccupied, targetSlot)
 	}
 
 	itm := t.slots[currentSlot]
@@ -986,11 +1585,96 @@ func (t *StashTab) findFreeSlotLocked() int {
 
 // --- Search & Filter ---
 
-// Search finds items matching query string (name contains)
+// Search finds items matching query string (name contains), in slot order.
+// Candidates come from the name token index and are confirmed by substring match.
 func (t *StashTab) Search(query string) []item.Item {
 	query = strings.ToLower(query)
-	return t.Filter(func(itm item.Item) bool {
-		return strings.Contains(strings.ToLower(itm.Name()), query)
+
+	// Longest query token must lie inside a single name token
+	var longest string
+	for _, token := range nameTokens(query) {
+		if len(token) > len(longest) {
+			longest = token
+		}
+	}
+	if longest == "" {
+		return t.Filter(func(itm item.Item) bool {
+			return strings.Contains(strings.ToLower(itm.Name()), query)
+		})
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var slots []int
+	for token, ids := range t.searchIndex {
+		if !strings.Contains(token, longest) {
+			continue
+		}
+		for id := range ids {
+			if slot, ok := t.itemIndex[id]; ok {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	slices.Sort(slots)
+	slots = slices.Compact(slots)
+
+	var result []item.Item
+	for _, slot := range slots {
+		itm := t.slots[slot]
+		if strings.Contains(strings.ToLower(itm.Name()), query) {
+			result = append(result, itm)
+		}
+	}
+	return result
+}
+
+// RebuildSearchIndex rebuilds name token index from stored items.
+// Needed after item names change while stored.
+func (t *StashTab) RebuildSearchIndex() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.searchIndex = make(map[string]map[string]struct{})
+	t.indexedTokens = make(map[string][]string)
+	for _, itm := range t.slots {
+		if itm != nil {
+			t.indexNameLocked(itm)
+		}
+	}
+}
+
+func (t *StashTab) indexNameLocked(itm item.Item) {
+	tokens := nameTokens(strings.ToLower(itm.Name()))
+	for _, token := range tokens {
+		ids, ok := t.searchIndex[token]
+		if !ok {
+			ids = make(map[string]struct{})
+			t.searchIndex[token] = ids
+		}
+		ids[itm.ID()] = struct{}{}
+	}
+	t.indexedTokens[itm.ID()] = tokens
+}
+
+// unindexNameLocked drops tokens item was indexed under, not its current
+// name, which may have changed while stored
+func (t *StashTab) unindexNameLocked(itm item.Item) {
+	for _, token := range t.indexedTokens[itm.ID()] {
+		ids := t.searchIndex[token]
+		delete(ids, itm.ID())
+		if len(ids) == 0 {
+			delete(t.searchIndex, token)
+		}
+	}
+	delete(t.indexedTokens, itm.ID())
+}
+
+// nameTokens splits text into runs of letters and digits
+func nameTokens(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
 	})
 }
 
@@ -1050,6 +1734,114 @@ func (t *StashTab) Filter(predicate func(item.Item) bool) []item.Item {
 	return result
 }
 
+// --- Filter Presets ---
+
+// Built-in preset name prefixes, resolved without registration
+const (
+	PresetTypePrefix   = "type:"
+	PresetRarityPrefix = "rarity:"
+)
+
+// TypeFilterPreset returns built-in preset name showing only itemType
+func TypeFilterPreset(itemType item.Type) string {
+	return PresetTypePrefix + string(itemType)
+}
+
+// RarityFilterPreset returns built-in preset name showing only rarity
+func RarityFilterPreset(rarity item.Rarity) string {
+	return PresetRarityPrefix + strings.ToLower(rarity.String())
+}
+
+// builtinFilterPreset resolves "type:<type>" and "rarity:<rarity>" names
+func builtinFilterPreset(name string) (func(item.Item) bool, bool) {
+	if itemType, ok := strings.CutPrefix(name, PresetTypePrefix); ok && itemType != "" {
+		return func(itm item.Item) bool { return string(itm.ItemType()) == itemType }, true
+	}
+	if rarityName, ok := strings.CutPrefix(name, PresetRarityPrefix); ok {
+		for r := item.RarityCommon; r <= item.RarityMythic; r++ {
+			if strings.ToLower(r.String()) == rarityName {
+				return func(itm item.Item) bool { return itm.Rarity() == r }, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// SetFilterPreset registers named predicate, nil removes it.
+// Custom presets shadow built-in names.
+func (t *StashTab) SetFilterPreset(name string, predicate func(item.Item) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if predicate == nil {
+		delete(t.filterPresets, name)
+		return
+	}
+	t.filterPresets[name] = predicate
+}
+
+// FilterPresets returns names of registered custom presets
+func (t *StashTab) FilterPresets() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	names := make([]string, 0, len(t.filterPresets))
+	for name := range t.filterPresets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ApplyFilterPreset makes preset active and returns matching items.
+// Unknown names return nil and leave active preset unchanged.
+func (t *StashTab) ApplyFilterPreset(name string) []item.Item {
+	predicate, ok := t.filterPreset(name)
+	if !ok {
+		return nil
+	}
+
+	t.mu.Lock()
+	t.activePreset = name
+	t.mu.Unlock()
+
+	return t.Filter(predicate)
+}
+
+// ActiveFilterPreset returns name of active preset, empty when unfiltered
+func (t *StashTab) ActiveFilterPreset() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.activePreset
+}
+
+// ClearFilterPreset removes active preset
+func (t *StashTab) ClearFilterPreset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.activePreset = ""
+}
+
+// FilteredItems returns items matching active preset.
+// Returns all items when unfiltered or when restored preset is not registered yet.
+func (t *StashTab) FilteredItems() []item.Item {
+	predicate, ok := t.filterPreset(t.ActiveFilterPreset())
+	if !ok {
+		return t.itemsInSlotOrder()
+	}
+	return t.Filter(predicate)
+}
+
+func (t *StashTab) filterPreset(name string) (func(item.Item) bool, bool) {
+	t.mu.RLock()
+	predicate, ok := t.filterPresets[name]
+	t.mu.RUnlock()
+	if ok {
+		return predicate, true
+	}
+	return builtinFilterPreset(name)
+}
+
 // --- Stats ---
 
 // ItemCount returns number of unique items (stacks count as 1)
@@ -1063,28 +1855,151 @@ func (t *StashTab) ItemCount() int {
 func (t *StashTab) TotalItems() int {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
+	return t.totalItems
+}
 
-	var total int
+// TotalValue returns combined value of all items
+func (t *StashTab) TotalValue() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.totalValue
+}
+
+// TotalWeight returns combined weight of all items including stack sizes.
+// Informational only: tabs have no weight limit.
+func (t *StashTab) TotalWeight() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var total float64
 	for _, itm := range t.slots {
 		if itm != nil {
-			total += itm.StackSize()
+			total += itm.Weight() * float64(itm.StackSize())
 		}
 	}
 	return total
 }
 
-// TotalValue returns combined value of all items
-func (t *StashTab) TotalValue() int64 {
+// Recompute rebuilds cached stats by rescanning all slots.
+// Needed after items in the tab are mutated directly (e.g. value or stack changed).
+func (t *StashTab) Recompute() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var items int
+	var value int64
+	for _, itm := range t.slots {
+		if itm != nil {
+			stackItems, stackValue := stackStats(itm)
+			items += stackItems
+			value += stackValue
+		}
+	}
+
+	t.applyStatsLocked(items-t.totalItems, value-t.totalValue, 0)
+}
+
+func (t *StashTab) cachedStats() (items int, value int64, slots int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.totalItems, t.totalValue, len(t.itemIndex)
+}
+
+// setStatsHook replaces stats hook and returns current stats atomically
+func (t *StashTab) setStatsHook(hook tabStatsHook) (items int, value int64, slots int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onStats = hook
+	return t.totalItems, t.totalValue, len(t.itemIndex)
+}
+
+func (t *StashTab) applyStatsLocked(items int, value int64, slots int) {
+	if items == 0 && value == 0 && slots == 0 {
+		return
+	}
+	t.totalItems += items
+	t.totalValue += value
+	if t.onStats != nil {
+		t.onStats(items, value, slots)
+	}
+}
+
+// placeLocked puts item into slot and accounts for it in stats
+func (t *StashTab) placeLocked(slot int, itm item.Item) {
+	if t.slots[slot] != nil {
+		t.unplaceLocked(slot)
+	}
+	t.slots[slot] = itm
+	t.itemIndex[itm.ID()] = slot
+	t.indexNameLocked(itm)
+
+	items, value := stackStats(itm)
+	t.applyStatsLocked(items, value, 1)
+}
+
+// unplaceLocked empties slot and removes its item from stats
+func (t *StashTab) unplaceLocked(slot int) item.Item {
+	itm := t.slots[slot]
+	t.slots[slot] = nil
+	delete(t.itemIndex, itm.ID())
+	delete(t.lockedItems, itm.ID())
+	t.unindexNameLocked(itm)
+
+	items, value := stackStats(itm)
+	t.applyStatsLocked(-items, -value, -1)
+	return itm
+}
+
+// restackLocked runs a stack mutation on a stored item and records the stats delta
+func (t *StashTab) restackLocked(itm item.Item, mutate func()) {
+	beforeItems, beforeValue := stackStats(itm)
+	mutate()
+	afterItems, afterValue := stackStats(itm)
+	t.applyStatsLocked(afterItems-beforeItems, afterValue-beforeValue, 0)
+}
+
+// itemsInSlotOrder returns stored items ordered by slot
+func (t *StashTab) itemsInSlotOrder() []item.Item {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	var total int64
+	items := make([]item.Item, 0, len(t.itemIndex))
 	for _, itm := range t.slots {
 		if itm != nil {
-			total += itm.Value() * int64(itm.StackSize())
+			items = append(items, itm)
 		}
 	}
-	return total
+	return items
+}
+
+// adjustStack changes stored stack by delta, removing the item once empty.
+// Returns true if item was removed.
+func (t *StashTab) adjustStack(itemID string, delta int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	slot, exists := t.itemIndex[itemID]
+	if !exists {
+		return false
+	}
+
+	itm := t.slots[slot]
+	if delta > 0 {
+		t.restackLocked(itm, func() { itm.AddStack(delta) })
+	} else if delta < 0 {
+		t.restackLocked(itm, func() { itm.RemoveStack(-delta) })
+	}
+
+	if itm.StackSize() <= 0 {
+		t.unplaceLocked(slot)
+		return true
+	}
+	return false
+}
+
+func stackStats(itm item.Item) (int, int64) {
+	stack := itm.StackSize()
+	return stack, itm.Value() * int64(stack)
 }
 
 // --- Persistence ---
@@ -1106,9 +2021,38 @@ func (t *StashTab) ToState() StashTabState {
 		Color:   t.color,
 		Slots:   len(t.slots),
 		ItemIDs: itemIDs,
+
+		FilterPreset:  t.activePreset,
+		LockedItemIDs: t.lockedIDsLocked(),
 	}
 }
 
+// Verify checks that item index and slot array describe the same placement
+func (t *StashTab) Verify() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for slot, itm := range t.slots {
+		if itm == nil {
+			continue
+		}
+		indexed, ok := t.itemIndex[itm.ID()]
+		if !ok {
+			return fmt.Errorf("%w: item %s in slot %d is not indexed", ErrStashCorrupted, itm.ID(), slot)
+		}
+		if indexed != slot {
+			return fmt.Errorf("%w: item %s in slot %d is indexed at slot %d", ErrStashCorrupted, itm.ID(), slot, indexed)
+		}
+	}
+
+	for itemID, slot := range t.itemIndex {
+		if slot < 0 || slot >= len(t.slots) || t.slots[slot] == nil || t.slots[slot].ID() != itemID {
+			return fmt.Errorf("%w: index entry %s points to slot %d without it", ErrStashCorrupted, itemID, slot)
+		}
+	}
+	return nil
+}
+
 // GetItemIDs returns all item IDs in slot order
 func (t *StashTab) GetItemIDs() []string {
 	t.mu.RLock()
@@ -1134,11 +2078,10 @@ func (t *StashTab) AddDirect(itm item.Item) error {
 
 	slot := t.findFreeSlotLocked()
 	if slot == -1 {
-		return fmt.Errorf("no free slot")
+		return fmt.Errorf("%w: no free slot", ErrTabFull)
 	}
 
-	t.slots[slot] = itm
-	t.itemIndex[itm.ID()] = slot
+	t.placeLocked(slot, itm)
 	return nil
 }
 
@@ -1152,14 +2095,55 @@ func (t *StashTab) AddDirectToSlot(slot int, itm item.Item) error {
 	defer t.mu.Unlock()
 
 	if slot < 0 || slot >= len(t.slots) {
-		return fmt.Errorf("slot out of range")
+		return ErrSlotOutOfRange
 	}
 
-	t.slots[slot] = itm
-	t.itemIndex[itm.ID()] = slot
+	t.placeLocked(slot, itm)
 	return nil
 }
 
+// slotOf returns slot index holding item
+func (t *StashTab) slotOf(itemID string) (int, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	slot, ok := t.itemIndex[itemID]
+	return slot, ok
+}
+
+// addPreferringSlot places item at slot if it exists and is empty,
+// otherwise at first free slot
+func (t *StashTab) addPreferringSlot(slot int, itm item.Item) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slot < 0 || slot >= len(t.slots) || t.slots[slot] != nil {
+		slot = t.findFreeSlotLocked()
+	}
+	if slot == -1 {
+		return fmt.Errorf("%w: no free slots", ErrTabFull)
+	}
+
+	t.placeLocked(slot, itm)
+	return nil
+}
+
+// canFitAll checks that the whole stack fits without splitting across tabs
+func (t *StashTab) canFitAll(itm item.Item) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.findFreeSlotLocked() != -1 {
+		return true
+	}
+
+	if targetID, canStack := t.canStackWithLocked(itm); canStack {
+		target := t.slots[t.itemIndex[targetID]]
+		return target.MaxStackSize()-target.StackSize() >= itm.StackSize()
+	}
+	return false
+}
+
 // CanAdd checks if item can be added (slot check or can stack)
 func (t *StashTab) CanAdd(itm item.Item) bool {
 	if itm == nil {
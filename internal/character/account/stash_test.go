@@ -2,12 +2,17 @@ package account
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidmovas/Depthborn/internal/character/inventory"
 	"github.com/davidmovas/Depthborn/internal/item"
+	"github.com/davidmovas/Depthborn/internal/item/itemtest"
+	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
 func createTestItem(id, name string) item.Item {
@@ -29,6 +34,14 @@ func createStackableItem(id, name string, maxStack int) item.Item {
 	})
 }
 
+func itemIDs(items []item.Item) []string {
+	ids := make([]string, len(items))
+	for i, itm := range items {
+		ids[i] = itm.ID()
+	}
+	return ids
+}
+
 func TestStash(t *testing.T) {
 	t.Run("Creation", func(t *testing.T) {
 		t.Run("with defaults", func(t *testing.T) {
@@ -73,8 +86,7 @@ func TestStash(t *testing.T) {
 			stash := NewStash(cfg)
 
 			err := stash.AddTab("Third Tab")
-			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "maximum number")
+			assert.ErrorIs(t, err, ErrMaxTabsReached)
 		})
 
 		t.Run("AddTabWithSlots", func(t *testing.T) {
@@ -130,8 +142,7 @@ func TestStash(t *testing.T) {
 			stash := NewStash(DefaultStashConfig())
 
 			err := stash.RemoveTab(5)
-			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "out of range")
+			assert.ErrorIs(t, err, ErrTabOutOfRange)
 		})
 
 		t.Run("GetTab", func(t *testing.T) {
@@ -171,7 +182,7 @@ func TestStash(t *testing.T) {
 			stash := NewStash(DefaultStashConfig())
 
 			err := stash.RenameTab(5, "Invalid")
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, ErrTabOutOfRange)
 		})
 
 		t.Run("SwapTabs", func(t *testing.T) {
@@ -195,6 +206,38 @@ func TestStash(t *testing.T) {
 			assert.Equal(t, name2, tab0After.Name())
 			assert.Equal(t, name0, tab2After.Name())
 		})
+
+		t.Run("ExpandTab", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 5, SlotsPerTab: 10})
+			tab1, _ := stash.GetTab(1)
+			require.NoError(t, tab1.AddToSlot(ctx, 9, createTestItem("item-1", "Item 1")))
+
+			var events [][2]int
+			stash.OnTabExpanded(func(tabIndex, newCount int) {
+				events = append(events, [2]int{tabIndex, newCount})
+			})
+
+			require.NoError(t, stash.ExpandTab(1, 5))
+
+			assert.Equal(t, 15, tab1.SlotCount())
+			assert.Equal(t, 25, stash.TotalSlots())
+			assert.Equal(t, [][2]int{{1, 15}}, events)
+			itm, ok := tab1.GetAtSlot(9)
+			require.True(t, ok)
+			assert.Equal(t, "item-1", itm.ID())
+		})
+
+		t.Run("ExpandTab invalid input", func(t *testing.T) {
+			stash := NewStash(StashConfig{InitialTabs: 1, MaxTabs: 5, SlotsPerTab: 10})
+			fired := false
+			stash.OnTabExpanded(func(int, int) { fired = true })
+
+			assert.ErrorIs(t, stash.ExpandTab(3, 5), ErrTabOutOfRange)
+			assert.ErrorIs(t, stash.ExpandTab(0, 0), ErrSlotOutOfRange)
+			assert.Equal(t, 10, stash.TotalSlots())
+			assert.False(t, fired)
+		})
 	})
 
 	t.Run("Item Operations", func(t *testing.T) {
@@ -242,7 +285,7 @@ func TestStash(t *testing.T) {
 			itm := createTestItem("item-1", "Test")
 
 			err := stash.TransferToTab(ctx, itm, 99)
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, ErrTabOutOfRange)
 		})
 
 		t.Run("TransferToSlot", func(t *testing.T) {
@@ -266,6 +309,381 @@ func TestStash(t *testing.T) {
 			assert.True(t, ok)
 			assert.Equal(t, "item-1", found.ID())
 		})
+
+		t.Run("TransferKeepingSlot", func(t *testing.T) {
+			ctx := context.Background()
+			newStash := func() (*Stash, *StashTab, *StashTab) {
+				stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 5, SlotsPerTab: 20})
+				src, _ := stash.GetTab(0)
+				dst, _ := stash.GetTab(1)
+				return stash, src, dst
+			}
+
+			t.Run("lands in same slot", func(t *testing.T) {
+				stash, src, dst := newStash()
+				itm := createTestItem("item-1", "Test")
+				require.NoError(t, src.AddToSlot(ctx, 7, itm))
+
+				require.NoError(t, stash.TransferKeepingSlot(ctx, itm, 1))
+
+				assert.False(t, src.Contains("item-1"))
+				found, ok := dst.GetAtSlot(7)
+				require.True(t, ok)
+				assert.Equal(t, "item-1", found.ID())
+			})
+
+			t.Run("falls back to first free slot", func(t *testing.T) {
+				stash, src, dst := newStash()
+				itm := createTestItem("item-1", "Test")
+				require.NoError(t, src.AddToSlot(ctx, 7, itm))
+				require.NoError(t, dst.AddToSlot(ctx, 0, createTestItem("blocker-0", "Blocker")))
+				require.NoError(t, dst.AddToSlot(ctx, 7, createTestItem("blocker-7", "Blocker")))
+
+				require.NoError(t, stash.TransferKeepingSlot(ctx, itm, 1))
+
+				found, ok := dst.GetAtSlot(1)
+				require.True(t, ok)
+				assert.Equal(t, "item-1", found.ID())
+			})
+
+			t.Run("destination smaller than source index", func(t *testing.T) {
+				stash, src, _ := newStash()
+				require.NoError(t, stash.AddTabWithSlots("Small", 4))
+				small, _ := stash.GetTab(2)
+				itm := createTestItem("item-1", "Test")
+				require.NoError(t, src.AddToSlot(ctx, 15, itm))
+
+				require.NoError(t, stash.TransferKeepingSlot(ctx, itm, 2))
+
+				found, ok := small.GetAtSlot(0)
+				require.True(t, ok)
+				assert.Equal(t, "item-1", found.ID())
+			})
+
+			t.Run("full destination restores source slot", func(t *testing.T) {
+				stash, src, _ := newStash()
+				require.NoError(t, stash.AddTabWithSlots("Tiny", 1))
+				tiny, _ := stash.GetTab(2)
+				require.NoError(t, tiny.Add(ctx, createTestItem("blocker", "Blocker")))
+				itm := createTestItem("item-1", "Test")
+				require.NoError(t, src.AddToSlot(ctx, 5, itm))
+
+				err := stash.TransferKeepingSlot(ctx, itm, 2)
+				assert.ErrorIs(t, err, ErrTabFull)
+
+				found, ok := src.GetAtSlot(5)
+				require.True(t, ok)
+				assert.Equal(t, "item-1", found.ID())
+			})
+
+			t.Run("unknown item", func(t *testing.T) {
+				stash, _, _ := newStash()
+				err := stash.TransferKeepingSlot(ctx, createTestItem("ghost", "Ghost"), 1)
+				assert.ErrorIs(t, err, ErrItemNotFound)
+			})
+		})
+
+		t.Run("DepositFrom", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 1, MaxTabs: 1, SlotsPerTab: 3})
+
+			first := inventory.NewManager()
+			ore1 := createStackableItem("ore-1", "Iron Ore", 20)
+			ore1.AddStack(4)
+			require.NoError(t, first.Add(ctx, ore1))
+			require.NoError(t, first.Add(ctx, createTestItem("item-1", "Test 1")))
+			require.NoError(t, first.Add(ctx, createTestItem("item-2", "Test 2")))
+
+			second := inventory.NewManager()
+			ore2 := createStackableItem("ore-2", "Iron Ore", 20)
+			ore2.AddStack(9)
+			require.NoError(t, second.Add(ctx, ore2))
+			require.NoError(t, second.Add(ctx, createTestItem("item-3", "Test 3")))
+
+			deposited, err := stash.DepositFrom(ctx, []*inventory.BaseManager{first, second}, 0)
+			require.NoError(t, err)
+			assert.Equal(t, 4, deposited)
+
+			tab, _ := stash.GetTab(0)
+			assert.Equal(t, 3, tab.UsedSlots())
+			stack, ok := tab.Get("ore-1")
+			require.True(t, ok)
+			assert.Equal(t, 15, stack.StackSize())
+
+			assert.Equal(t, 0, first.Count())
+			assert.Equal(t, 1, second.Count())
+			assert.True(t, second.Contains("item-3"))
+			assert.Equal(t, 5.0, second.CurrentWeight())
+		})
+
+		t.Run("DepositAllOfType", func(t *testing.T) {
+			// Inventory: ore (5) | sword | ore (3) | cloth
+			setup := func(t *testing.T) *inventory.BaseManager {
+				ctx := context.Background()
+				inv := inventory.NewManager()
+				ore1 := createStackableItem("ore-1", "Iron Ore", 20)
+				ore1.AddStack(4)
+				ore2 := createStackableItem("ore-2", "Iron Ore", 20)
+				ore2.AddStack(2)
+				require.NoError(t, inv.AddToSlot(ctx, 0, ore1))
+				require.NoError(t, inv.AddToSlot(ctx, 1, itemtest.RolledEquipment("sword-1", 5)))
+				require.NoError(t, inv.AddToSlot(ctx, 2, ore2))
+				require.NoError(t, inv.AddToSlot(ctx, 3, createTestItem("cloth-1", "Cloth")))
+				return inv
+			}
+
+			t.Run("moves materials and leaves weapons", func(t *testing.T) {
+				ctx := context.Background()
+				stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 2, SlotsPerTab: 5})
+				tab, _ := stash.GetTab(1)
+				existing := createStackableItem("ore-0", "Iron Ore", 20)
+				existing.AddStack(9)
+				require.NoError(t, tab.Add(ctx, existing))
+				inv := setup(t)
+
+				deposited, err := stash.DepositAllOfType(ctx, inv, item.TypeMaterial, 1)
+				require.NoError(t, err)
+
+				assert.Equal(t, 3, deposited)
+				assert.Equal(t, []string{"sword-1"}, itemIDs(inv.GetAll()))
+				assert.Equal(t, 1.0, inv.CurrentWeight())
+				assert.Equal(t, 18, existing.StackSize())
+				assert.Equal(t, 2, tab.UsedSlots())
+				assert.True(t, tab.Contains("cloth-1"))
+			})
+
+			t.Run("rolls back on capacity failure", func(t *testing.T) {
+				ctx := context.Background()
+				stash := NewStash(StashConfig{InitialTabs: 1, MaxTabs: 1, SlotsPerTab: 2})
+				tab, _ := stash.GetTab(0)
+				existing := createStackableItem("ore-0", "Iron Ore", 20)
+				existing.AddStack(17)
+				require.NoError(t, tab.Add(ctx, existing))
+				inv := setup(t)
+				weight := inv.CurrentWeight()
+
+				// ore-1 overflows into last free slot, ore-2 stacks onto it, cloth no longer fits
+				deposited, err := stash.DepositAllOfType(ctx, inv, item.TypeMaterial, 0)
+				require.ErrorIs(t, err, ErrTabFull)
+
+				assert.Zero(t, deposited)
+				assert.Equal(t, 18, existing.StackSize())
+				assert.Equal(t, 1, tab.UsedSlots())
+				assert.Equal(t, 18, tab.TotalItems())
+				assert.NoError(t, tab.Verify())
+
+				assert.Equal(t, 4, inv.Count())
+				assert.Equal(t, weight, inv.CurrentWeight())
+				for slot, id := range []string{"ore-1", "sword-1", "ore-2", "cloth-1"} {
+					itm, ok := inv.GetAtSlot(slot)
+					require.True(t, ok)
+					assert.Equal(t, id, itm.ID())
+				}
+				ore1, _ := inv.Get("ore-1")
+				assert.Equal(t, 5, ore1.StackSize())
+			})
+
+			t.Run("out of range and nil inventory", func(t *testing.T) {
+				stash := NewStash(DefaultStashConfig())
+
+				_, err := stash.DepositAllOfType(context.Background(), inventory.NewManager(), item.TypeMaterial, 9)
+				assert.ErrorIs(t, err, ErrTabOutOfRange)
+				_, err = stash.DepositAllOfType(context.Background(), nil, item.TypeMaterial, 0)
+				assert.Error(t, err)
+			})
+		})
+
+		t.Run("DepositFrom out of range returns error", func(t *testing.T) {
+			stash := NewStash(DefaultStashConfig())
+
+			_, err := stash.DepositFrom(context.Background(), []*inventory.BaseManager{inventory.NewManager()}, 5)
+			assert.ErrorIs(t, err, ErrTabOutOfRange)
+		})
+	})
+
+	t.Run("AutoOrganize", func(t *testing.T) {
+		newItem := func(id string, itemType item.Type, rarity item.Rarity) item.Item {
+			return item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       id,
+				Name:     id,
+				ItemType: itemType,
+				Rarity:   rarity,
+				Weight:   1.0,
+			})
+		}
+		cfg := StashConfig{InitialTabs: 3, MaxTabs: 5, SlotsPerTab: 10}
+
+		t.Run("routes by type and rarity, first rule wins", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(cfg)
+			tab0, _ := stash.GetTab(0)
+			tab1, _ := stash.GetTab(1)
+			tab2, _ := stash.GetTab(2)
+
+			require.NoError(t, tab0.Add(ctx, newItem("potion", item.TypeConsumable, item.RarityCommon)))
+			require.NoError(t, tab0.Add(ctx, newItem("rare-ore", item.TypeMaterial, item.RarityRare)))
+			require.NoError(t, tab1.Add(ctx, newItem("rare-potion", item.TypeConsumable, item.RarityRare)))
+			require.NoError(t, tab2.Add(ctx, newItem("ore", item.TypeMaterial, item.RarityCommon)))
+
+			err := stash.AutoOrganize(ctx, []OrganizeRule{
+				{Predicate: func(i item.Item) bool { return i.Rarity() == item.RarityRare }, TargetTab: 2},
+				{Predicate: func(i item.Item) bool { return i.ItemType() == item.TypeConsumable }, TargetTab: 1},
+			})
+			require.NoError(t, err)
+
+			assert.True(t, tab1.Contains("potion"))
+			assert.True(t, tab2.Contains("rare-ore"))
+			assert.True(t, tab2.Contains("rare-potion"), "rarity rule matches first")
+			assert.True(t, tab2.Contains("ore"), "unmatched items stay in place")
+			assert.Equal(t, 0, tab0.ItemCount())
+			assert.Equal(t, 4, stash.TotalCount())
+		})
+
+		t.Run("stacks into target and reports unplaced", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 3, MaxTabs: 5, SlotsPerTab: 1})
+			tab0, _ := stash.GetTab(0)
+			tab1, _ := stash.GetTab(1)
+			tab2, _ := stash.GetTab(2)
+
+			existing := createStackableItem("gem-1", "Gem", 10)
+			existing.AddStack(3)
+			require.NoError(t, tab1.Add(ctx, existing))
+
+			fits := createStackableItem("gem-2", "Gem", 10)
+			fits.AddStack(2)
+			require.NoError(t, tab0.Add(ctx, fits))
+
+			tooBig := createStackableItem("gem-3", "Gem", 10)
+			tooBig.AddStack(6)
+			require.NoError(t, tab2.Add(ctx, tooBig))
+
+			err := stash.AutoOrganize(ctx, []OrganizeRule{
+				{Predicate: func(i item.Item) bool { return true }, TargetTab: 1},
+			})
+
+			var orgErr *OrganizeError
+			require.ErrorAs(t, err, &orgErr)
+			assert.ErrorIs(t, err, ErrTabFull)
+			assert.Equal(t, []string{"gem-3"}, orgErr.Unplaced)
+			assert.Equal(t, 7, existing.StackSize())
+			assert.Equal(t, 0, tab0.ItemCount())
+			assert.True(t, tab2.Contains("gem-3"))
+			assert.Equal(t, 14, stash.TotalItems())
+		})
+
+		t.Run("invalid target tab", func(t *testing.T) {
+			stash := NewStash(cfg)
+			err := stash.AutoOrganize(context.Background(), []OrganizeRule{{TargetTab: 7}})
+			assert.ErrorIs(t, err, ErrTabOutOfRange)
+		})
+	})
+
+	t.Run("ConsolidateStacks", func(t *testing.T) {
+		potion := func(id string, stack int) item.Item {
+			itm := createStackableItem(id, "Potion", 10)
+			itm.AddStack(stack - 1)
+			return itm
+		}
+
+		t.Run("three partial stacks become one and a remainder", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 1, MaxTabs: 2, SlotsPerTab: 10})
+			tab, _ := stash.GetTab(0)
+
+			first, second := potion("p1", 7), potion("p2", 6)
+			require.NoError(t, tab.AddToSlot(ctx, 0, first))
+			require.NoError(t, tab.AddToSlot(ctx, 3, second))
+			require.NoError(t, tab.AddToSlot(ctx, 5, potion("p3", 5)))
+			require.NoError(t, tab.AddToSlot(ctx, 6, createTestItem("ore", "Ore")))
+
+			merges, err := stash.ConsolidateStacks(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, 2, merges)
+			assert.Equal(t, 10, first.StackSize())
+			assert.Equal(t, 8, second.StackSize())
+			assert.False(t, tab.Contains("p3"))
+			assert.Equal(t, 3, tab.ItemCount())
+			assert.Equal(t, 19, stash.TotalItems())
+			assert.Equal(t, 3, stash.TotalCount())
+		})
+
+		t.Run("tabs are consolidated separately", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 2, SlotsPerTab: 10})
+			tab0, _ := stash.GetTab(0)
+			tab1, _ := stash.GetTab(1)
+
+			require.NoError(t, tab0.Add(ctx, potion("p1", 4)))
+			require.NoError(t, tab1.Add(ctx, potion("p2", 3)))
+
+			merges, err := stash.ConsolidateStacks(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 0, merges)
+			assert.True(t, tab1.Contains("p2"))
+
+			merges, err = stash.ConsolidateAcrossTabs(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 1, merges)
+			assert.False(t, tab1.Contains("p2"))
+
+			kept, _ := tab0.Get("p1")
+			assert.Equal(t, 7, kept.StackSize())
+			assert.Equal(t, 7, stash.TotalItems())
+			assert.Equal(t, 1, stash.TotalCount())
+		})
+	})
+
+	t.Run("Locked items", func(t *testing.T) {
+		t.Run("TransferToTab rejects locked item", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 2, SlotsPerTab: 10})
+			tab0, _ := stash.GetTab(0)
+			tab1, _ := stash.GetTab(1)
+
+			itm := createTestItem("item-1", "Test")
+			require.NoError(t, tab0.Add(ctx, itm))
+			require.NoError(t, tab0.LockItem("item-1"))
+
+			assert.ErrorIs(t, stash.TransferToTab(ctx, itm, 1), ErrItemLocked)
+			assert.ErrorIs(t, stash.TransferToSlot(ctx, itm, 1, 3), ErrItemLocked)
+			assert.True(t, tab0.Contains("item-1"))
+			assert.False(t, tab1.Contains("item-1"))
+		})
+
+		t.Run("ConsolidateStacks skips locked stacks", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 1, MaxTabs: 1, SlotsPerTab: 10})
+			tab, _ := stash.GetTab(0)
+
+			first, locked := createStackableItem("p1", "Potion", 10), createStackableItem("p2", "Potion", 10)
+			require.NoError(t, tab.AddToSlot(ctx, 0, first))
+			require.NoError(t, tab.AddToSlot(ctx, 1, locked))
+			require.NoError(t, tab.LockItem("p2"))
+
+			merges, err := stash.ConsolidateStacks(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, merges)
+			assert.Equal(t, 1, first.StackSize())
+			assert.Equal(t, 1, locked.StackSize())
+		})
+
+		t.Run("AutoOrganize leaves locked items in place", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 2, SlotsPerTab: 10})
+			tab0, _ := stash.GetTab(0)
+			tab1, _ := stash.GetTab(1)
+			require.NoError(t, tab0.Add(ctx, createTestItem("ore-1", "Ore")))
+			require.NoError(t, tab0.Add(ctx, createTestItem("ore-2", "Ore")))
+			require.NoError(t, tab0.LockItem("ore-1"))
+
+			require.NoError(t, stash.AutoOrganize(ctx, []OrganizeRule{
+				{Predicate: func(item.Item) bool { return true }, TargetTab: 1},
+			}))
+			assert.True(t, tab0.Contains("ore-1"))
+			assert.True(t, tab1.Contains("ore-2"))
+		})
 	})
 
 	t.Run("Search and Filter", func(t *testing.T) {
@@ -510,6 +928,91 @@ func TestStash(t *testing.T) {
 		})
 	})
 
+	t.Run("Cached totals stay exact", func(t *testing.T) {
+		ctx := context.Background()
+		stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 5, SlotsPerTab: 10})
+
+		// Rescans every tab the way totals were computed before caching
+		assertExact := func(t *testing.T) {
+			t.Helper()
+			var items, slots int
+			var value int64
+			for _, tab := range stash.Tabs() {
+				for _, itm := range tab.GetAll() {
+					items += itm.StackSize()
+					value += itm.Value() * int64(itm.StackSize())
+					slots++
+				}
+			}
+			assert.Equal(t, items, stash.TotalItems())
+			assert.Equal(t, value, stash.TotalValue())
+			assert.Equal(t, slots, stash.TotalUsedSlots())
+			assert.Equal(t, slots, stash.TotalCount())
+		}
+
+		valued := func(id string, value int64, maxStack int) item.Item {
+			return item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:           id,
+				Name:         "Gem",
+				ItemType:     item.TypeGem,
+				Value:        value,
+				MaxStackSize: maxStack,
+			})
+		}
+
+		tab0, _ := stash.GetTab(0)
+		tab1, _ := stash.GetTab(1)
+
+		gems := valued("gem-1", 10, 5)
+		gems.AddStack(3)
+		require.NoError(t, tab0.Add(ctx, gems))
+		assertExact(t)
+
+		// Overflows into a second stack
+		more := valued("gem-2", 10, 5)
+		more.AddStack(2)
+		require.NoError(t, tab0.Add(ctx, more))
+		assertExact(t)
+
+		_, err := tab0.SplitStack(ctx, "gem-1", 2)
+		require.NoError(t, err)
+		assertExact(t)
+
+		_, err = tab0.RemoveAmount(ctx, "gem-1", 1)
+		require.NoError(t, err)
+		assertExact(t)
+
+		require.NoError(t, tab1.AddToSlot(ctx, 3, valued("gem-3", 50, 1)))
+		assertExact(t)
+
+		itm, _, ok := stash.FindItem("gem-3")
+		require.True(t, ok)
+		require.NoError(t, stash.TransferToTab(ctx, itm, 0))
+		assertExact(t)
+
+		_, err = tab0.Remove(ctx, "gem-2")
+		require.NoError(t, err)
+		assertExact(t)
+
+		require.NoError(t, stash.AddTab("Extra"))
+		tab2, _ := stash.GetTab(2)
+		require.NoError(t, tab2.AddDirect(valued("gem-4", 7, 1)))
+		assertExact(t)
+
+		tab2.Clear(ctx)
+		require.NoError(t, stash.RemoveTab(2))
+		assertExact(t)
+
+		t.Run("Recompute picks up direct item mutation", func(t *testing.T) {
+			itm, _, ok := stash.FindItem("gem-1")
+			require.True(t, ok)
+			itm.(*item.BaseItem).SetValue(80)
+
+			stash.Recompute()
+			assertExact(t)
+		})
+	})
+
 	t.Run("Persistence", func(t *testing.T) {
 		t.Run("Serialization", func(t *testing.T) {
 			ctx := context.Background()
@@ -529,6 +1032,7 @@ func TestStash(t *testing.T) {
 			state, err := stash.SerializeState()
 			require.NoError(t, err)
 			assert.NotNil(t, state)
+			assert.Contains(t, state, persist.SchemaVersionKey)
 
 			// Deserialize to new stash
 			newStash := NewStash(DefaultStashConfig())
@@ -542,6 +1046,74 @@ func TestStash(t *testing.T) {
 			assert.Equal(t, "My Items", restoredTab.Name())
 			assert.Equal(t, "#ff0000", restoredTab.Color())
 		})
+
+		t.Run("expanded tab keeps size", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 5, SlotsPerTab: 10})
+			require.NoError(t, stash.ExpandTab(0, 20))
+			tab0, _ := stash.GetTab(0)
+			require.NoError(t, tab0.AddToSlot(ctx, 25, createTestItem("item-1", "Item 1")))
+
+			state, err := stash.SerializeState()
+			require.NoError(t, err)
+
+			itemIDs := state["tabs"].([]any)[0].(map[string]any)["item_ids"].([]any)
+			require.Len(t, itemIDs, 30)
+			assert.Equal(t, "item-1", itemIDs[25])
+
+			restored := NewStash(DefaultStashConfig())
+			require.NoError(t, restored.DeserializeState(state))
+
+			restoredTab0, _ := restored.GetTab(0)
+			restoredTab1, _ := restored.GetTab(1)
+			assert.Equal(t, 30, restoredTab0.SlotCount())
+			assert.Equal(t, 10, restoredTab1.SlotCount())
+		})
+
+		t.Run("item IDs keep slot positions", func(t *testing.T) {
+			tab := NewStashTab("Tab", 5)
+			require.NoError(t, tab.AddDirectToSlot(3, createTestItem("item-1", "Item 1")))
+
+			state := tab.ToState()
+			assert.Equal(t, []string{"", "", "", "item-1", ""}, state.ItemIDs)
+		})
+
+		t.Run("mismatched item IDs are rejected", func(t *testing.T) {
+			stash := NewStash(DefaultStashConfig())
+			state, err := stash.SerializeState()
+			require.NoError(t, err)
+
+			tabs := state["tabs"].([]any)
+			tabs[0].(map[string]any)["item_ids"] = []any{"item-1"}
+
+			err = NewStash(DefaultStashConfig()).DeserializeState(state)
+			assert.ErrorIs(t, err, ErrStashCorrupted)
+		})
+
+		t.Run("Verify", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 5, SlotsPerTab: 10})
+			tab1, _ := stash.GetTab(1)
+			require.NoError(t, tab1.Add(ctx, createTestItem("item-1", "Item 1")))
+			require.NoError(t, tab1.Add(ctx, createTestItem("item-2", "Item 2")))
+
+			require.NoError(t, stash.Verify())
+
+			tab1.mu.Lock()
+			tab1.itemIndex["item-2"] = 7
+			tab1.mu.Unlock()
+
+			err := stash.Verify()
+			require.ErrorIs(t, err, ErrStashCorrupted)
+			assert.Contains(t, err.Error(), "tab 1")
+
+			tab1.mu.Lock()
+			tab1.itemIndex["item-2"] = 1
+			tab1.itemIndex["ghost"] = 5
+			tab1.mu.Unlock()
+
+			assert.ErrorIs(t, stash.Verify(), ErrStashCorrupted)
+		})
 	})
 }
 
@@ -595,6 +1167,25 @@ func TestStashTab(t *testing.T) {
 			assert.Equal(t, 0, tab.ItemCount())
 		})
 
+		t.Run("errors wrap sentinels", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 1)
+
+			require.NoError(t, tab.AddToSlot(ctx, 0, createTestItem("item-1", "First")))
+
+			err := tab.AddToSlot(ctx, 0, createTestItem("item-2", "Second"))
+			assert.ErrorIs(t, err, ErrSlotOccupied)
+
+			err = tab.AddToSlot(ctx, 1, createTestItem("item-2", "Second"))
+			assert.ErrorIs(t, err, ErrSlotOutOfRange)
+
+			err = tab.Add(ctx, createTestItem("item-2", "Second"))
+			assert.ErrorIs(t, err, ErrTabFull)
+
+			_, err = tab.Remove(ctx, "nonexistent")
+			assert.ErrorIs(t, err, ErrItemNotFound)
+		})
+
 		t.Run("Get", func(t *testing.T) {
 			ctx := context.Background()
 			tab := NewStashTab("Test Tab", 100)
@@ -697,6 +1288,53 @@ func TestStashTab(t *testing.T) {
 				tab.SetSlotCount(50)
 				assert.Equal(t, 50, tab.SlotCount())
 			})
+
+			t.Run("shrink refused when trailing slot occupied", func(t *testing.T) {
+				ctx := context.Background()
+				tab := NewStashTab("Test Tab", 100)
+				require.NoError(t, tab.AddToSlot(ctx, 80, createTestItem("item-1", "Test")))
+
+				tab.SetSlotCount(50)
+				assert.Equal(t, 100, tab.SlotCount())
+			})
+		})
+
+		t.Run("SetSlotCountCompact", func(t *testing.T) {
+			t.Run("relocates high-slot items", func(t *testing.T) {
+				ctx := context.Background()
+				tab := NewStashTab("Test Tab", 10)
+				require.NoError(t, tab.AddToSlot(ctx, 0, createTestItem("item-1", "Test")))
+				require.NoError(t, tab.AddToSlot(ctx, 2, createTestItem("item-2", "Test")))
+				require.NoError(t, tab.AddToSlot(ctx, 7, createTestItem("item-3", "Test")))
+				require.NoError(t, tab.AddToSlot(ctx, 9, createTestItem("item-4", "Test")))
+
+				require.NoError(t, tab.SetSlotCountCompact(4))
+				assert.Equal(t, 4, tab.SlotCount())
+				assert.Equal(t, 4, tab.UsedSlots())
+
+				itm, ok := tab.GetAtSlot(1)
+				require.True(t, ok)
+				assert.Equal(t, "item-3", itm.ID())
+
+				itm, ok = tab.GetAtSlot(3)
+				require.True(t, ok)
+				assert.Equal(t, "item-4", itm.ID())
+
+				_, err := tab.Remove(ctx, "item-4")
+				require.NoError(t, err)
+			})
+
+			t.Run("error when items don't fit", func(t *testing.T) {
+				ctx := context.Background()
+				tab := NewStashTab("Test Tab", 10)
+				for i := 0; i < 5; i++ {
+					require.NoError(t, tab.Add(ctx, createTestItem(fmt.Sprintf("item-%d", i), "Test")))
+				}
+
+				assert.ErrorIs(t, tab.SetSlotCountCompact(4), ErrTabFull)
+				assert.Equal(t, 10, tab.SlotCount())
+				assert.Equal(t, 5, tab.UsedSlots())
+			})
 		})
 
 		t.Run("UsedSlots and FreeSlots", func(t *testing.T) {
@@ -796,6 +1434,45 @@ func TestStashTab(t *testing.T) {
 			assert.Equal(t, 3, remaining.StackSize())
 		})
 
+		t.Run("differently rolled equipment does not stack", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 100)
+
+			require.NoError(t, tab.Add(ctx, itemtest.RolledEquipment("sword-1", 4)))
+			require.NoError(t, tab.Add(ctx, itemtest.RolledEquipment("sword-2", 9)))
+
+			_, canStack := tab.CanStackWith(itemtest.RolledEquipment("sword-3", 6))
+			assert.False(t, canStack)
+			assert.Equal(t, 2, tab.ItemCount())
+			assert.Error(t, tab.MergeStacks(ctx, "sword-2", "sword-1"))
+		})
+
+		t.Run("rolled equipment never stacks", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 100)
+
+			// Same name, different rolls
+			flaming1 := itemtest.RolledEquipment("sword-1", 4)
+			flaming1.SetName("Flaming Sword")
+			flaming2 := itemtest.RolledEquipment("sword-2", 7)
+			flaming2.SetName("Flaming Sword")
+			// Identical rolls are still unique items
+			twin := itemtest.RolledEquipment("sword-3", 4)
+			twin.SetName("Flaming Sword")
+
+			require.NoError(t, tab.Add(ctx, flaming1))
+			_, canStack := tab.CanStackWith(flaming2)
+			assert.False(t, canStack)
+			_, canStack = tab.CanStackWith(twin)
+			assert.False(t, canStack)
+
+			require.NoError(t, tab.Add(ctx, flaming2))
+			require.NoError(t, tab.Add(ctx, twin))
+			assert.Equal(t, 3, tab.ItemCount())
+			assert.Equal(t, 1, flaming1.StackSize())
+			assert.Equal(t, 1, flaming1.MaxStackSize())
+		})
+
 		t.Run("CanStackWith", func(t *testing.T) {
 			ctx := context.Background()
 			tab := NewStashTab("Test Tab", 100)
@@ -835,6 +1512,63 @@ func TestStashTab(t *testing.T) {
 			assert.Len(t, results, 2)
 		})
 
+		t.Run("Search index follows mutations", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+
+			linear := func(query string) []item.Item {
+				query = strings.ToLower(query)
+				return tab.Filter(func(itm item.Item) bool {
+					return strings.Contains(strings.ToLower(itm.Name()), query)
+				})
+			}
+			queries := []string{"iron", "ron sw", "IRON SWORD", "bow", " ", "", "-", "shield"}
+			check := func(t *testing.T) {
+				for _, q := range queries {
+					assert.Equal(t, linear(q), tab.Search(q), "query %q", q)
+				}
+			}
+
+			require.NoError(t, tab.Add(ctx, createTestItem("sword-1", "Iron Sword")))
+			require.NoError(t, tab.Add(ctx, createTestItem("shield-1", "Iron Shield")))
+			require.NoError(t, tab.Add(ctx, createTestItem("bow-1", "Wooden Bow")))
+			require.NoError(t, tab.Add(ctx, createTestItem("sword-2", "Iron-Sword")))
+			check(t)
+
+			require.NoError(t, tab.SwapSlots(ctx, 0, 2))
+			require.NoError(t, tab.MoveToSlot(ctx, "shield-1", 7))
+			check(t)
+			assert.Equal(t, []string{"bow-1", "sword-1", "sword-2", "shield-1"}, itemIDs(tab.Search("o")))
+
+			_, err := tab.Remove(ctx, "sword-1")
+			require.NoError(t, err)
+			check(t)
+			assert.Len(t, tab.Search("sword"), 1)
+
+			tab.Clear(ctx)
+			assert.Empty(t, tab.Search("iron"))
+
+			// Rebuild from scratch after direct restore
+			require.NoError(t, tab.AddDirectToSlot(3, createTestItem("axe-1", "Iron Axe")))
+			tab.RebuildSearchIndex()
+			check(t)
+			assert.Len(t, tab.Search("axe"), 1)
+		})
+
+		t.Run("Search after item renamed while stored", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+
+			sword := createTestItem("sword-1", "Old Sword")
+			require.NoError(t, tab.Add(ctx, sword))
+			sword.(interface{ SetName(string) }).SetName("New Sword")
+
+			_, err := tab.Remove(ctx, "sword-1")
+			require.NoError(t, err)
+			assert.Empty(t, tab.Search("old"))
+			assert.Empty(t, tab.Search("sword"))
+		})
+
 		t.Run("FindByType", func(t *testing.T) {
 			ctx := context.Background()
 			tab := NewStashTab("Test Tab", 100)
@@ -931,6 +1665,66 @@ func TestStashTab(t *testing.T) {
 			})
 			assert.Len(t, results, 1)
 		})
+
+		t.Run("Filter presets", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+
+			sword := item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       "sword-1",
+				Name:     "Sword",
+				ItemType: item.TypeWeaponMelee,
+				Weight:   3.0,
+				Rarity:   item.RarityRare,
+			})
+			ore := item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       "ore-1",
+				Name:     "Iron Ore",
+				ItemType: item.TypeMaterial,
+				Weight:   1.0,
+				Rarity:   item.RarityCommon,
+			})
+			gem := item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       "gem-1",
+				Name:     "Ruby",
+				ItemType: item.TypeMaterial,
+				Weight:   0.1,
+				Rarity:   item.RarityRare,
+			})
+			require.NoError(t, tab.Add(ctx, sword))
+			require.NoError(t, tab.Add(ctx, ore))
+			require.NoError(t, tab.Add(ctx, gem))
+
+			assert.Empty(t, tab.ActiveFilterPreset())
+			assert.Len(t, tab.FilteredItems(), 3)
+
+			weapons := TypeFilterPreset(item.TypeWeaponMelee)
+			assert.Equal(t, []string{"sword-1"}, itemIDs(tab.ApplyFilterPreset(weapons)))
+			assert.Equal(t, weapons, tab.ActiveFilterPreset())
+
+			rares := RarityFilterPreset(item.RarityRare)
+			assert.Equal(t, []string{"sword-1", "gem-1"}, itemIDs(tab.ApplyFilterPreset(rares)))
+			assert.Equal(t, rares, tab.ActiveFilterPreset())
+
+			// Custom preset, switched to by name
+			tab.SetFilterPreset("light", func(itm item.Item) bool { return itm.Weight() < 2 })
+			assert.Equal(t, []string{"light"}, tab.FilterPresets())
+			assert.Equal(t, []string{"ore-1", "gem-1"}, itemIDs(tab.ApplyFilterPreset("light")))
+			assert.Equal(t, "light", tab.ActiveFilterPreset())
+			assert.Equal(t, []string{"ore-1", "gem-1"}, itemIDs(tab.FilteredItems()))
+
+			// Unknown preset keeps current one
+			assert.Nil(t, tab.ApplyFilterPreset("missing"))
+			assert.Equal(t, "light", tab.ActiveFilterPreset())
+
+			tab.SetFilterPreset("light", nil)
+			assert.Empty(t, tab.FilterPresets())
+
+			tab.ApplyFilterPreset(rares)
+			tab.ClearFilterPreset()
+			assert.Empty(t, tab.ActiveFilterPreset())
+			assert.Len(t, tab.FilteredItems(), 3)
+		})
 	})
 
 	t.Run("Metadata", func(t *testing.T) {
@@ -956,6 +1750,75 @@ func TestStashTab(t *testing.T) {
 		})
 	})
 
+	t.Run("Item Locks", func(t *testing.T) {
+		t.Run("locked item cannot be removed until unlocked", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+			require.NoError(t, tab.Add(ctx, createStackableItem("gem", "Gem", 10)))
+
+			require.NoError(t, tab.LockItem("gem"))
+			assert.True(t, tab.IsItemLocked("gem"))
+
+			_, err := tab.Remove(ctx, "gem")
+			assert.ErrorIs(t, err, ErrItemLocked)
+			_, err = tab.RemoveAmount(ctx, "gem", 1)
+			assert.ErrorIs(t, err, ErrItemLocked)
+			assert.True(t, tab.Contains("gem"))
+
+			require.NoError(t, tab.UnlockItem("gem"))
+			assert.False(t, tab.IsItemLocked("gem"))
+			removed, err := tab.Remove(ctx, "gem")
+			require.NoError(t, err)
+			assert.Equal(t, "gem", removed.ID())
+		})
+
+		t.Run("unknown item", func(t *testing.T) {
+			tab := NewStashTab("Test Tab", 10)
+			assert.ErrorIs(t, tab.LockItem("missing"), ErrItemNotFound)
+			assert.ErrorIs(t, tab.UnlockItem("missing"), ErrItemNotFound)
+			assert.False(t, tab.IsItemLocked("missing"))
+		})
+
+		t.Run("locked source cannot be merged away", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+			require.NoError(t, tab.AddToSlot(ctx, 0, createStackableItem("p1", "Potion", 10)))
+			require.NoError(t, tab.AddToSlot(ctx, 1, createStackableItem("p2", "Potion", 10)))
+			require.NoError(t, tab.LockItem("p2"))
+
+			assert.ErrorIs(t, tab.MergeStacks(ctx, "p2", "p1"), ErrItemLocked)
+			require.NoError(t, tab.MergeStacks(ctx, "p1", "p2"))
+			assert.False(t, tab.Contains("p1"))
+		})
+
+		t.Run("locks persist", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+			require.NoError(t, tab.Add(ctx, createTestItem("item-1", "Item 1")))
+			require.NoError(t, tab.Add(ctx, createTestItem("item-2", "Item 2")))
+			require.NoError(t, tab.LockItem("item-2"))
+
+			state := tab.ToState()
+			assert.Equal(t, []string{"item-2"}, state.LockedItemIDs)
+
+			restored := StashTabFromState(state)
+			require.NoError(t, restored.AddDirectToSlot(1, createTestItem("item-2", "Item 2")))
+			assert.True(t, restored.IsItemLocked("item-2"))
+			_, err := restored.Remove(ctx, "item-2")
+			assert.ErrorIs(t, err, ErrItemLocked)
+		})
+
+		t.Run("Clear drops locks", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+			require.NoError(t, tab.Add(ctx, createTestItem("item-1", "Item 1")))
+			require.NoError(t, tab.LockItem("item-1"))
+
+			tab.Clear(ctx)
+			assert.Empty(t, tab.LockedItems())
+		})
+	})
+
 	t.Run("Stats", func(t *testing.T) {
 		t.Run("TotalValue", func(t *testing.T) {
 			ctx := context.Background()
@@ -981,6 +1844,28 @@ func TestStashTab(t *testing.T) {
 
 			assert.Equal(t, int64(300), tab.TotalValue())
 		})
+
+		t.Run("TotalWeight", func(t *testing.T) {
+			ctx := context.Background()
+			stash := NewStash(StashConfig{InitialTabs: 2, MaxTabs: 5, SlotsPerTab: 10})
+			tab0, _ := stash.GetTab(0)
+			tab1, _ := stash.GetTab(1)
+
+			ore := createStackableItem("ore-1", "Iron Ore", 50)
+			ore.AddStack(9)
+			require.NoError(t, tab0.Add(ctx, ore))
+			require.NoError(t, tab0.Add(ctx, createTestItem("item-1", "Anvil")))
+			require.NoError(t, tab1.Add(ctx, createTestItem("item-2", "Shield")))
+
+			// 10 ore at 0.5 plus 5.0 single item
+			assert.InDelta(t, 10.0, tab0.TotalWeight(), 1e-9)
+			assert.InDelta(t, 5.0, tab1.TotalWeight(), 1e-9)
+			assert.InDelta(t, 15.0, stash.TotalWeight(), 1e-9)
+
+			_, err := tab0.RemoveAmount(ctx, "ore-1", 4)
+			require.NoError(t, err)
+			assert.InDelta(t, 13.0, stash.TotalWeight(), 1e-9)
+		})
 	})
 
 	t.Run("Persistence", func(t *testing.T) {
@@ -1013,6 +1898,31 @@ func TestStashTab(t *testing.T) {
 			assert.Equal(t, 150, tab.SlotCount())
 		})
 
+		t.Run("Active filter preset", func(t *testing.T) {
+			ctx := context.Background()
+			tab := NewStashTab("Test Tab", 10)
+			require.NoError(t, tab.Add(ctx, createTestItem("ore-1", "Iron Ore")))
+
+			materials := TypeFilterPreset(item.TypeMaterial)
+			tab.ApplyFilterPreset(materials)
+
+			restored := StashTabFromState(tab.ToState())
+			assert.Equal(t, materials, restored.ActiveFilterPreset())
+
+			// Custom closures are not persisted - name survives, filter falls back to all items
+			tab.SetFilterPreset("custom", func(item.Item) bool { return false })
+			tab.ApplyFilterPreset("custom")
+			assert.Empty(t, tab.FilteredItems())
+
+			restored = StashTabFromState(tab.ToState())
+			require.NoError(t, restored.AddDirect(createTestItem("ore-1", "Iron Ore")))
+			assert.Equal(t, "custom", restored.ActiveFilterPreset())
+			assert.Len(t, restored.FilteredItems(), 1)
+
+			restored.SetFilterPreset("custom", func(item.Item) bool { return false })
+			assert.Empty(t, restored.FilteredItems())
+		})
+
 		t.Run("GetItemIDs", func(t *testing.T) {
 			ctx := context.Background()
 			tab := NewStashTab("Test Tab", 10)
@@ -1064,3 +1974,47 @@ func TestStashTab(t *testing.T) {
 		})
 	})
 }
+
+func BenchmarkStashTotals(b *testing.B) {
+	ctx := context.Background()
+	stash := NewStash(StashConfig{InitialTabs: 10, MaxTabs: 10, SlotsPerTab: 60})
+
+	for i, tab := range stash.Tabs() {
+		for j := 0; j < 60; j++ {
+			_ = tab.Add(ctx, item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       fmt.Sprintf("item-%d-%d", i, j),
+				Name:     "Item",
+				ItemType: item.TypeWeaponMelee,
+				Value:    int64(j),
+			}))
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = stash.TotalValue()
+		_ = stash.TotalItems()
+		_ = stash.TotalUsedSlots()
+	}
+}
+
+func BenchmarkStashSearch(b *testing.B) {
+	ctx := context.Background()
+	stash := NewStash(StashConfig{InitialTabs: 10, MaxTabs: 10, SlotsPerTab: 60})
+	names := []string{"Iron Sword", "Wooden Bow", "Health Potion", "Chaos Orb", "Leather Boots", "Ruby Ring"}
+
+	for i, tab := range stash.Tabs() {
+		for j := 0; j < 60; j++ {
+			_ = tab.Add(ctx, item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       fmt.Sprintf("item-%d-%d", i, j),
+				Name:     fmt.Sprintf("%s %d", names[j%len(names)], j),
+				ItemType: item.TypeWeaponMelee,
+			}))
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = stash.Search("orb")
+	}
+}
@@ -98,7 +98,7 @@ func NewCharacter(cfg Config) *BaseCharacter {
 		cfg.Attributes = attribute.NewManager()
 	}
 	if cfg.Statuses == nil {
-		cfg.Statuses = status.NewManager()
+		cfg.Statuses = status.NewManagerWithConfig(status.ManagerConfig{Attributes: cfg.Attributes})
 	}
 	if cfg.Transform == nil {
 		cfg.Transform = spatial.NewTransform(spatial.NewPosition(0, 0, 0), 0)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/davidmovas/Depthborn/internal/core/entity"
+	"github.com/davidmovas/Depthborn/internal/item"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -267,6 +269,33 @@ func TestCharacterExperience(t *testing.T) {
 	assert.Equal(t, int64(100), char.Experience())
 }
 
+func TestCharacterUsesConsumable(t *testing.T) {
+	ctx := context.Background()
+
+	char, err := NewBuilder().
+		WithName("Hero").
+		WithHealth(40, 100).
+		Build(ctx)
+	require.NoError(t, err)
+
+	elixir := item.NewBaseConsumableWithConfig(item.ConsumableConfig{
+		BaseItemConfig: item.BaseItemConfig{Name: "Elixir"},
+		Effects: []item.ConsumableEffect{
+			item.NewHealEffect(30),
+			item.NewRestoreResourceEffect("mana", 25),
+			item.NewApplyStatusEffect("regeneration", 5000),
+		},
+	})
+
+	require.NoError(t, elixir.Use(ctx, char))
+	assert.Equal(t, 70.0, char.Health())
+	assert.Equal(t, 25.0, char.Resource("mana"))
+	assert.True(t, char.StatusEffects().Has("regeneration"))
+
+	char.RestoreResource(entity.ResourceHealth, 500)
+	assert.Equal(t, char.MaxHealth(), char.Health())
+}
+
 func TestCharacterClone(t *testing.T) {
 	ctx := context.Background()
 
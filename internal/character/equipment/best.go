@@ -0,0 +1,84 @@
+package equipment
+
+import (
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/item"
+)
+
+// =============================================================================
+// AUTO-EQUIP SELECTION
+// =============================================================================
+
+// ItemScorer rates candidate item, higher is better
+type ItemScorer func(itm item.Item) float64
+
+// BestForSlot picks highest-scoring candidate that can go into slot.
+// Non-equipment, broken and other-slot items are ignored; rings fit either
+// ring slot. Ties keep earlier candidate. Nil scorer uses ModifierScore.
+func BestForSlot(candidates []item.Item, slot item.EquipmentSlot, scorer ItemScorer) (item.Item, bool) {
+	if scorer == nil {
+		scorer = ModifierScore
+	}
+
+	var best item.Item
+	bestScore := 0.0
+	for _, candidate := range candidates {
+		equip, ok := candidate.(item.Equipment)
+		if !ok || equip.IsBroken() || !slotMatches(equip.Slot(), slot) {
+			continue
+		}
+
+		if score := scorer(candidate); best == nil || score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best, best != nil
+}
+
+// ScoreWeights converts modifier values into score points per modifier type.
+// Increased and more values are percents, so they get own weights to be
+// comparable with flat values. Overrides are not scored.
+type ScoreWeights struct {
+	Flat      float64
+	Increased float64
+	More      float64
+}
+
+// DefaultScoreWeights values flat point as 1, percent increased as 0.5 and
+// percent more as 1
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{Flat: 1, Increased: 0.5, More: 1}
+}
+
+// ModifierScore scores active modifiers equipment contributes to wearer
+// (base and affixes, scaled by durability) with DefaultScoreWeights.
+// Non-equipment scores zero.
+func ModifierScore(itm item.Item) float64 {
+	return WeightedModifierScorer(DefaultScoreWeights())(itm)
+}
+
+// WeightedModifierScorer scores contributed modifiers with weights
+func WeightedModifierScorer(weights ScoreWeights) ItemScorer {
+	return func(itm item.Item) float64 {
+		equip, ok := itm.(item.Equipment)
+		if !ok {
+			return 0
+		}
+
+		total := 0.0
+		for _, mod := range contributedModifiers(equip) {
+			if !mod.IsActive() {
+				continue
+			}
+			switch mod.Type() {
+			case attribute.ModFlat:
+				total += mod.Value() * weights.Flat
+			case attribute.ModIncreased:
+				total += mod.Value() * weights.Increased
+			case attribute.ModMore:
+				total += mod.Value() * weights.More
+			}
+		}
+		return total
+	}
+}
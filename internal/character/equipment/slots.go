@@ -0,0 +1,147 @@
+package equipment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidmovas/Depthborn/internal/character/inventory"
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/item"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrNotEquipment = errors.New("item is not equipment")
+	ErrSlotMismatch = errors.New("item does not fit slot")
+)
+
+// =============================================================================
+// EQUIPMENT SLOTS
+// =============================================================================
+
+// EquipmentSlots links character equipment with inventory.
+// Items are taken from inventory when equipped and replaced items go back there.
+type EquipmentSlots struct {
+	manager *BaseManager
+}
+
+// NewEquipmentSlots creates slots backed by new equipment manager
+func NewEquipmentSlots() *EquipmentSlots {
+	return NewEquipmentSlotsWithManager(NewManager())
+}
+
+// NewEquipmentSlotsWithManager creates slots backed by existing equipment manager
+func NewEquipmentSlotsWithManager(manager *BaseManager) *EquipmentSlots {
+	return &EquipmentSlots{manager: manager}
+}
+
+// Manager returns underlying equipment manager
+func (s *EquipmentSlots) Manager() *BaseManager {
+	return s.manager
+}
+
+// Get returns equipment in slot (nil if empty)
+func (s *EquipmentSlots) Get(slot item.EquipmentSlot) item.Equipment {
+	return s.manager.Get(FromItemSlot(slot))
+}
+
+// Equip moves item from inventory into slot.
+// Item already in slot is returned to inventory; on any failure both
+// inventory and slot are restored, and rollback errors are joined to result.
+func (s *EquipmentSlots) Equip(ctx context.Context, inv *inventory.BaseManager, itemID string, slot item.EquipmentSlot) error {
+	itm, ok := inv.Get(itemID)
+	if !ok {
+		return fmt.Errorf("%w: %s", inventory.ErrItemNotFound, itemID)
+	}
+
+	equip, ok := itm.(item.Equipment)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotEquipment, itemID)
+	}
+
+	if !slotMatches(equip.Slot(), slot) {
+		return fmt.Errorf("%w: %s is %s, not %s", ErrSlotMismatch, itemID, equip.Slot(), slot)
+	}
+
+	if _, err := inv.Remove(ctx, itemID); err != nil {
+		return err
+	}
+
+	target := FromItemSlot(slot)
+	previous, err := s.manager.EquipToSlot(ctx, target, equip)
+	if err != nil {
+		return errors.Join(err, inv.Add(ctx, equip))
+	}
+
+	if previous == nil {
+		return nil
+	}
+
+	if err := inv.Add(ctx, previous); err != nil {
+		// No room for replaced item: put everything back
+		err = fmt.Errorf("cannot return %s to inventory: %w", previous.ID(), err)
+		if _, restoreErr := s.manager.EquipToSlot(ctx, target, previous); restoreErr != nil {
+			return errors.Join(err, restoreErr)
+		}
+		return errors.Join(err, inv.Add(ctx, equip))
+	}
+
+	return nil
+}
+
+// Unequip moves item from slot back into inventory.
+// Item stays equipped when inventory has no room; rollback errors are joined to result.
+func (s *EquipmentSlots) Unequip(ctx context.Context, inv *inventory.BaseManager, slot item.EquipmentSlot) error {
+	target := FromItemSlot(slot)
+	equip, err := s.manager.Unequip(ctx, target)
+	if err != nil || equip == nil {
+		return err
+	}
+
+	if err := inv.Add(ctx, equip); err != nil {
+		_, restoreErr := s.manager.EquipToSlot(ctx, target, equip)
+		return errors.Join(err, restoreErr)
+	}
+	return nil
+}
+
+// EquippedModifiers returns modifiers equipped items contribute to owner, in
+// slot order: base attributes and rolled affixes, scaled by durability, as
+// applied on equip
+func (s *EquipmentSlots) EquippedModifiers() []attribute.Modifier {
+	equipped := s.manager.GetAll()
+
+	var mods []attribute.Modifier
+	for _, slot := range AllSlots() {
+		if equip := equipped[slot]; equip != nil {
+			mods = append(mods, contributedModifiers(equip)...)
+		}
+	}
+	return mods
+}
+
+// contributedModifiers returns modifiers equipment applies to wearer, falling
+// back to Attributes for implementations without contribution tracking
+func contributedModifiers(equip item.Equipment) []attribute.Modifier {
+	if contributor, ok := equip.(interface{ ContributedModifiers() []attribute.Modifier }); ok {
+		return contributor.ContributedModifiers()
+	}
+	return equip.Attributes()
+}
+
+// slotMatches reports whether item declaring itemSlot can go into slot.
+// Rings fit either ring slot.
+func slotMatches(itemSlot, slot item.EquipmentSlot) bool {
+	if itemSlot == slot {
+		return true
+	}
+	return isRingSlot(itemSlot) && isRingSlot(slot)
+}
+
+func isRingSlot(slot item.EquipmentSlot) bool {
+	return slot == item.SlotRing1 || slot == item.SlotRing2
+}
@@ -0,0 +1,263 @@
+package equipment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/character/inventory"
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/core/entity"
+	"github.com/davidmovas/Depthborn/internal/item"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+)
+
+func createModdedEquipment(id string, itemType item.Type, slot item.EquipmentSlot, strength float64) *item.BaseEquipment {
+	equip := item.NewEquipmentWithConfig(item.EquipmentConfig{
+		BaseItemConfig: item.BaseItemConfig{
+			ID:       id,
+			Name:     id,
+			ItemType: itemType,
+			Weight:   5.0,
+		},
+		Slot: slot,
+	})
+	equip.AddAttribute(attribute.NewModifier(id+"_str", attribute.ModFlat, strength, id))
+	return equip
+}
+
+func TestEquipmentSlotsEquip(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	sword := createModdedEquipment("sword-1", item.TypeWeaponMelee, item.SlotMainHand, 5)
+	require.NoError(t, inv.Add(ctx, sword))
+
+	require.NoError(t, slots.Equip(ctx, inv, "sword-1", item.SlotMainHand))
+
+	assert.False(t, inv.Contains("sword-1"))
+	assert.Equal(t, "sword-1", slots.Get(item.SlotMainHand).ID())
+
+	mods := slots.EquippedModifiers()
+	require.Len(t, mods, 1)
+	assert.Equal(t, 5.0, mods[0].Value())
+}
+
+func TestEquipmentSlotsAffixModifiers(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	sword := createModdedEquipment("sword-1", item.TypeWeaponMelee, item.SlotMainHand, 5)
+	sharp := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+		AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+	require.NoError(t, sword.Affixes().Add(affix.NewBaseInstance(sharp, []affix.RolledModifier{{Template: sharp.Modifiers()[0], Value: 8}})))
+	require.NoError(t, inv.Add(ctx, sword))
+
+	attrs := attribute.NewManager()
+	slots.Manager().SetOwner(entity.NewEntity(entity.Config{Name: "hero", AttributeManager: attrs}))
+	require.NoError(t, slots.Equip(ctx, inv, "sword-1", item.SlotMainHand))
+
+	mods := slots.EquippedModifiers()
+	require.Len(t, mods, 2)
+	for _, mod := range mods {
+		assert.Equal(t, "sword-1", mod.Source())
+	}
+
+	applied := attrs.GetModifiers(attribute.AttrPhysicalDamage)
+	require.Len(t, applied, 1)
+	assert.Equal(t, applied[0].ID(), mods[1].ID())
+	assert.Equal(t, 8.0, mods[1].Value())
+}
+
+func TestEquipmentSlotsSwapBack(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	oldSword := createModdedEquipment("sword-1", item.TypeWeaponMelee, item.SlotMainHand, 5)
+	newSword := createModdedEquipment("sword-2", item.TypeWeaponMelee, item.SlotMainHand, 12)
+	require.NoError(t, inv.Add(ctx, oldSword))
+	require.NoError(t, inv.Add(ctx, newSword))
+
+	require.NoError(t, slots.Equip(ctx, inv, "sword-1", item.SlotMainHand))
+	require.NoError(t, slots.Equip(ctx, inv, "sword-2", item.SlotMainHand))
+
+	assert.Equal(t, "sword-2", slots.Get(item.SlotMainHand).ID())
+	assert.True(t, inv.Contains("sword-1"))
+	assert.False(t, inv.Contains("sword-2"))
+
+	mods := slots.EquippedModifiers()
+	require.Len(t, mods, 1)
+	assert.Equal(t, 12.0, mods[0].Value())
+}
+
+func TestEquipmentSlotsSwapBackInventoryFull(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManagerWithConfig(inventory.Config{MaxSlots: 1, MaxWeight: 7})
+	slots := NewEquipmentSlots()
+
+	sword := createModdedEquipment("sword-1", item.TypeWeaponMelee, item.SlotMainHand, 5)
+	require.NoError(t, inv.Add(ctx, sword))
+	require.NoError(t, slots.Equip(ctx, inv, "sword-1", item.SlotMainHand))
+
+	dagger := item.NewEquipmentWithConfig(item.EquipmentConfig{
+		BaseItemConfig: item.BaseItemConfig{ID: "dagger-1", Name: "Dagger", ItemType: item.TypeWeaponMelee, Weight: 1},
+		Slot:           item.SlotMainHand,
+	})
+	require.NoError(t, inv.Add(ctx, dagger))
+	// Replaced sword no longer fits in inventory
+	inv.SetMaxWeight(2)
+
+	err := slots.Equip(ctx, inv, "dagger-1", item.SlotMainHand)
+	require.ErrorIs(t, err, inventory.ErrWeightExceeded)
+
+	assert.Equal(t, "sword-1", slots.Get(item.SlotMainHand).ID())
+	assert.True(t, inv.Contains("dagger-1"))
+}
+
+func TestEquipmentSlotsMismatch(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	helmet := createModdedEquipment("helmet-1", item.TypeArmorHead, item.SlotHead, 3)
+	require.NoError(t, inv.Add(ctx, helmet))
+
+	err := slots.Equip(ctx, inv, "helmet-1", item.SlotChest)
+	require.ErrorIs(t, err, ErrSlotMismatch)
+
+	assert.True(t, inv.Contains("helmet-1"))
+	assert.Nil(t, slots.Get(item.SlotChest))
+	assert.Empty(t, slots.EquippedModifiers())
+}
+
+func TestEquipmentSlotsRingsFitEitherSlot(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	ring := createModdedEquipment("ring-1", item.TypeAccessoryRing, item.SlotRing1, 2)
+	require.NoError(t, inv.Add(ctx, ring))
+
+	require.NoError(t, slots.Equip(ctx, inv, "ring-1", item.SlotRing2))
+	assert.Equal(t, "ring-1", slots.Get(item.SlotRing2).ID())
+}
+
+func TestEquipmentSlotsErrors(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	err := slots.Equip(ctx, inv, "missing", item.SlotMainHand)
+	assert.ErrorIs(t, err, inventory.ErrItemNotFound)
+
+	potion := item.NewBaseItemWithConfig(item.BaseItemConfig{ID: "potion-1", Name: "Potion", ItemType: item.TypeConsumable})
+	require.NoError(t, inv.Add(ctx, potion))
+
+	err = slots.Equip(ctx, inv, "potion-1", item.SlotMainHand)
+	assert.ErrorIs(t, err, ErrNotEquipment)
+	assert.True(t, inv.Contains("potion-1"))
+}
+
+func TestEquipmentSlotsAttributeRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	inv := inventory.NewManager()
+	slots := NewEquipmentSlots()
+
+	attrs := attribute.NewManager()
+	attrs.SetBase(attribute.AttrStrength, 10)
+	slots.Manager().SetOwner(entity.NewEntity(entity.Config{Name: "hero", AttributeManager: attrs}))
+
+	sword := item.NewEquipmentWithConfig(item.EquipmentConfig{
+		BaseItemConfig: item.BaseItemConfig{ID: "sword-1", Name: "Sword", ItemType: item.TypeWeaponMelee, Weight: 5.0},
+		Slot:           item.SlotMainHand,
+	})
+	sword.AddAttribute(attribute.NewModifier("sword_str", attribute.ModFlat, 5, string(attribute.AttrStrength)))
+	sharp := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+		AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+	require.NoError(t, sword.Affixes().Add(affix.NewBaseInstance(sharp, []affix.RolledModifier{{Template: sharp.Modifiers()[0], Value: 4}})))
+	require.NoError(t, inv.Add(ctx, sword))
+
+	// Equip and unequip twice: each cycle applies and strips exactly the same modifiers
+	for range 2 {
+		require.NoError(t, slots.Equip(ctx, inv, "sword-1", item.SlotMainHand))
+		assert.Equal(t, 15.0, attrs.Get(attribute.AttrStrength))
+		assert.Equal(t, 4.0, attrs.Get(attribute.AttrPhysicalDamage))
+
+		require.NoError(t, slots.Unequip(ctx, inv, item.SlotMainHand))
+		assert.Equal(t, 10.0, attrs.Get(attribute.AttrStrength))
+		assert.Equal(t, 0.0, attrs.Get(attribute.AttrPhysicalDamage))
+		assert.Empty(t, attrs.GetModifiers(attribute.AttrStrength))
+	}
+}
+
+func TestBestForSlot(t *testing.T) {
+	weak := createModdedEquipment("sword-weak", item.TypeWeaponMelee, item.SlotMainHand, 5)
+	strong := createModdedEquipment("sword-strong", item.TypeWeaponMelee, item.SlotMainHand, 12)
+	helmet := createModdedEquipment("helmet", item.TypeArmorHead, item.SlotHead, 50)
+	potion := item.NewBaseItemWithConfig(item.BaseItemConfig{ID: "potion", Name: "Potion", ItemType: item.TypeConsumable})
+
+	t.Run("picks higher scoring weapon", func(t *testing.T) {
+		best, ok := BestForSlot([]item.Item{weak, helmet, potion, strong}, item.SlotMainHand, nil)
+		require.True(t, ok)
+		assert.Equal(t, "sword-strong", best.ID())
+	})
+
+	t.Run("affixes count toward score", func(t *testing.T) {
+		rare := createModdedEquipment("sword-rare", item.TypeWeaponMelee, item.SlotMainHand, 3)
+		sharp := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+			AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+		require.NoError(t, rare.Affixes().Add(affix.NewBaseInstance(sharp, []affix.RolledModifier{{Template: sharp.Modifiers()[0], Value: 4}})))
+
+		best, ok := BestForSlot([]item.Item{weak, rare}, item.SlotMainHand, nil)
+		require.True(t, ok)
+		assert.Equal(t, "sword-rare", best.ID())
+	})
+
+	t.Run("percent modifiers are weighted", func(t *testing.T) {
+		percent := item.NewEquipmentWithConfig(item.EquipmentConfig{
+			BaseItemConfig: item.BaseItemConfig{ID: "sword-percent", Name: "sword-percent", ItemType: item.TypeWeaponMelee},
+			Slot:           item.SlotMainHand,
+		})
+		percent.AddAttribute(attribute.NewModifier("sword-percent_dmg", attribute.ModIncreased, 20, "sword-percent"))
+
+		assert.Equal(t, 10.0, ModifierScore(percent))
+		best, _ := BestForSlot([]item.Item{percent, strong}, item.SlotMainHand, nil)
+		assert.Equal(t, "sword-strong", best.ID())
+
+		best, _ = BestForSlot([]item.Item{percent, strong}, item.SlotMainHand, WeightedModifierScorer(ScoreWeights{Flat: 1, Increased: 1}))
+		assert.Equal(t, "sword-percent", best.ID())
+	})
+
+	t.Run("custom scorer", func(t *testing.T) {
+		lightest := func(itm item.Item) float64 { return -ModifierScore(itm) }
+
+		best, ok := BestForSlot([]item.Item{strong, weak}, item.SlotMainHand, lightest)
+		require.True(t, ok)
+		assert.Equal(t, "sword-weak", best.ID())
+	})
+
+	t.Run("rings fit either ring slot", func(t *testing.T) {
+		ring := createModdedEquipment("ring", item.TypeAccessoryRing, item.SlotRing1, 3)
+
+		best, ok := BestForSlot([]item.Item{helmet, ring}, item.SlotRing2, nil)
+		require.True(t, ok)
+		assert.Equal(t, "ring", best.ID())
+	})
+
+	t.Run("broken and non-slot items ignored", func(t *testing.T) {
+		broken := createModdedEquipment("sword-broken", item.TypeWeaponMelee, item.SlotMainHand, 99)
+		broken.SetDurability(0)
+
+		best, ok := BestForSlot([]item.Item{broken, helmet, potion, weak}, item.SlotMainHand, nil)
+		require.True(t, ok)
+		assert.Equal(t, "sword-weak", best.ID())
+
+		_, ok = BestForSlot([]item.Item{helmet, potion}, item.SlotMainHand, nil)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,347 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/item"
+)
+
+// DefaultMaxContainerDepth allows a bag inside a bag, but no deeper
+const DefaultMaxContainerDepth = 2
+
+// Container is an item holding other items in its own inventory
+type Container interface {
+	item.Item
+
+	// Inventory returns manager holding container contents
+	Inventory() Manager
+
+	// ContentsWeight returns weight contents add to container weight
+	ContentsWeight() float64
+
+	// WeightReduction returns fraction of contents weight container hides (0-1)
+	WeightReduction() float64
+
+	// CanHold checks container rules other than slots and weight
+	CanHold(itm item.Item) error
+}
+
+var (
+	_ Container      = (*Bag)(nil)
+	_ item.Container = (*Bag)(nil)
+)
+
+// Bag is a container item backed by its own BaseManager.
+// Weight carried by parent is bag weight plus contents weight reduced by WeightReduction.
+// Changes made directly through Inventory() need parent RecalculateWeight.
+type Bag struct {
+	*item.BaseItem
+
+	mu              sync.RWMutex
+	contents        *BaseManager
+	allowedTypes    []item.Type
+	weightReduction float64 // Fraction of contents weight ignored (0-1)
+}
+
+// NewBag turns container built by builder.Bag into a bag holding real items.
+// Items already in container are moved into the bag.
+func NewBag(container *item.BaseContainer) *Bag {
+	maxWeight := container.MaxWeight()
+	if maxWeight <= 0 {
+		maxWeight = math.MaxFloat64
+	}
+
+	b := &Bag{
+		BaseItem: container.BaseItem,
+		contents: NewManagerWithConfig(Config{
+			MaxSlots:  container.Capacity(),
+			MaxWeight: maxWeight,
+		}),
+		allowedTypes: container.AllowedTypes(),
+	}
+	for _, itm := range container.Clear() {
+		_ = b.contents.AddDirect(itm)
+	}
+	return b
+}
+
+func (b *Bag) Inventory() Manager {
+	return b.contents
+}
+
+// StackKey includes bag ID - bags hold contents and never stack
+func (b *Bag) StackKey() string {
+	return b.BaseItem.StackKey() + "|" + b.ID()
+}
+
+func (b *Bag) Capacity() int {
+	return b.contents.SlotCount()
+}
+
+func (b *Bag) Contents() []item.Item {
+	return b.contents.GetAll()
+}
+
+func (b *Bag) Add(itm item.Item) error {
+	if err := b.CanHold(itm); err != nil {
+		return err
+	}
+	return b.contents.Add(context.Background(), itm)
+}
+
+func (b *Bag) Remove(itemID string) (item.Item, error) {
+	return b.contents.Remove(context.Background(), itemID)
+}
+
+func (b *Bag) Contains(itemID string) bool {
+	return b.contents.Contains(itemID)
+}
+
+func (b *Bag) IsFull() bool {
+	return b.contents.IsFull()
+}
+
+// CanHold checks allowed item types
+func (b *Bag) CanHold(itm item.Item) error {
+	if itm == nil {
+		return fmt.Errorf("cannot add nil item")
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.allowedTypes) > 0 && !slices.Contains(b.allowedTypes, itm.ItemType()) {
+		return fmt.Errorf("%w: %s in %s", ErrNotAllowed, itm.ItemType(), b.Name())
+	}
+	return nil
+}
+
+func (b *Bag) WeightReduction() float64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.weightReduction
+}
+
+// SetWeightReduction sets fraction of contents weight the bag hides, clamped to 0-1
+func (b *Bag) SetWeightReduction(reduction float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.weightReduction = min(max(reduction, 0), 1)
+}
+
+func (b *Bag) ContentsWeight() float64 {
+	return b.contents.CurrentWeight() * (1 - b.WeightReduction())
+}
+
+// Weight returns bag weight plus reduced contents weight
+func (b *Bag) Weight() float64 {
+	return b.BaseItem.Weight() + b.ContentsWeight()
+}
+
+// TotalValue returns value of bag and contents
+func (b *Bag) TotalValue() int64 {
+	return b.BaseItem.TotalValue() + b.contents.TotalValue()
+}
+
+func (b *Bag) Clone() any {
+	base, ok := b.BaseItem.Clone().(*item.BaseItem)
+	if !ok {
+		return nil
+	}
+
+	b.mu.RLock()
+	clone := &Bag{
+		BaseItem:        base,
+		contents:        NewManagerWithConfig(Config{MaxSlots: b.contents.SlotCount(), MaxWeight: b.contents.MaxWeight()}),
+		allowedTypes:    slices.Clone(b.allowedTypes),
+		weightReduction: b.weightReduction,
+	}
+	b.mu.RUnlock()
+
+	for _, itm := range b.contents.GetAll() {
+		if cloned, ok := itm.Clone().(item.Item); ok {
+			_ = clone.contents.AddDirect(cloned)
+		}
+	}
+	return clone
+}
+
+// --- Container moves ---
+
+// containerPath locates container inside inventory
+type containerPath struct {
+	container Container
+	chain     []Container // Containers from top level down to container
+	root      item.Item   // Top-level ancestor (container itself when top-level)
+	level     int         // 1 = top-level
+	share     float64     // Fraction of contents weight visible at top level
+}
+
+// MoveIntoContainer moves top-level item into container.
+// Container may be top-level or nested inside another container.
+func (m *BaseManager) MoveIntoContainer(ctx context.Context, itemID, containerID string) error {
+	if itemID == containerID {
+		return fmt.Errorf("%w: %s into itself", ErrContainerCycle, itemID)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slot, exists := m.itemIndex[itemID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	itm := m.slots[slot]
+
+	path, err := m.findContainerLocked(containerID)
+	if err != nil {
+		return err
+	}
+	if path.root.ID() == itemID {
+		return fmt.Errorf("%w: %s is inside %s", ErrContainerCycle, containerID, itemID)
+	}
+	if path.level+containerDepth(itm) > m.maxContainerDepth {
+		return fmt.Errorf("%w: max depth %d", ErrNestingTooDeep, m.maxContainerDepth)
+	}
+	if err := path.container.CanHold(itm); err != nil {
+		return err
+	}
+	if err := path.container.Inventory().Add(ctx, itm); err != nil {
+		return err
+	}
+
+	m.slots[slot] = nil
+	delete(m.itemIndex, itemID)
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
+	path.refreshWeights()
+	m.recalculateWeightLocked()
+
+	m.mu.Unlock()
+	notifyQuickSlots()
+	m.mu.Lock()
+
+	m.notifyChangedLocked(ctx, path.root)
+	return nil
+}
+
+// MoveOutOfContainer moves item from container to free top-level slot
+func (m *BaseManager) MoveOutOfContainer(ctx context.Context, itemID, containerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path, err := m.findContainerLocked(containerID)
+	if err != nil {
+		return err
+	}
+	itm, exists := path.container.Inventory().Get(itemID)
+	if !exists {
+		return fmt.Errorf("%w: %s in %s", ErrItemNotFound, itemID, containerID)
+	}
+
+	slot := m.findFreeSlotLocked()
+	if slot == -1 {
+		return fmt.Errorf("%w: no free slots", ErrInventoryFull)
+	}
+
+	// Item stops being reduced by containers it leaves
+	itemWeight := m.getItemWeight(itm)
+	if added := itemWeight * (1 - path.share); m.currentWeight+added > m.maxWeight {
+		return fmt.Errorf("%w (current: %.2f, max: %.2f, item: %.2f)", ErrWeightExceeded,
+			m.currentWeight, m.maxWeight, itemWeight)
+	}
+
+	if _, err := path.container.Inventory().Remove(ctx, itemID); err != nil {
+		return err
+	}
+	m.slots[slot] = itm
+	m.itemIndex[itemID] = slot
+	path.refreshWeights()
+	m.recalculateWeightLocked()
+
+	m.notifyChangedLocked(ctx, path.root)
+	return nil
+}
+
+// findContainerLocked searches top-level items and nested containers
+func (m *BaseManager) findContainerLocked(containerID string) (containerPath, error) {
+	for _, itm := range m.slots {
+		if itm == nil {
+			continue
+		}
+		c, isContainer := itm.(Container)
+		if itm.ID() == containerID {
+			if !isContainer {
+				return containerPath{}, fmt.Errorf("%w: %s", ErrNotContainer, containerID)
+			}
+			return containerPath{container: c, chain: []Container{c}, root: itm, level: 1, share: 1 - c.WeightReduction()}, nil
+		}
+		if !isContainer {
+			continue
+		}
+		top := containerPath{container: c, chain: []Container{c}, root: itm, level: 1, share: 1 - c.WeightReduction()}
+		if path, ok := findNestedContainer(c, containerID, top); ok {
+			return path, nil
+		}
+	}
+	return containerPath{}, fmt.Errorf("%w: %s", ErrItemNotFound, containerID)
+}
+
+func findNestedContainer(parent Container, containerID string, path containerPath) (containerPath, bool) {
+	for _, itm := range parent.Inventory().GetAll() {
+		c, ok := itm.(Container)
+		if !ok {
+			continue
+		}
+		next := containerPath{
+			container: c,
+			chain:     append(slices.Clone(path.chain), c),
+			root:      path.root,
+			level:     path.level + 1,
+			share:     path.share * (1 - c.WeightReduction()),
+		}
+		if itm.ID() == containerID {
+			return next, true
+		}
+		if found, ok := findNestedContainer(c, containerID, next); ok {
+			return found, true
+		}
+	}
+	return containerPath{}, false
+}
+
+// refreshWeights recalculates cached weights from innermost container outwards
+func (p containerPath) refreshWeights() {
+	for _, c := range slices.Backward(p.chain) {
+		if r, ok := c.Inventory().(interface{ RecalculateWeight() }); ok {
+			r.RecalculateWeight()
+		}
+	}
+}
+
+// containerDepth returns 0 for plain items, 1 for container of plain items and so on
+func containerDepth(itm item.Item) int {
+	c, ok := itm.(Container)
+	if !ok {
+		return 0
+	}
+	depth := 1
+	for _, inner := range c.Inventory().GetAll() {
+		depth = max(depth, 1+containerDepth(inner))
+	}
+	return depth
+}
+
+// notifyChangedLocked fires changed callbacks for itm, releasing lock meanwhile
+func (m *BaseManager) notifyChangedLocked(ctx context.Context, itm item.Item) {
+	callbacks := append([]ItemCallback{}, m.onChangedCallbacks...)
+	m.mu.Unlock()
+	for _, cb := range callbacks {
+		cb(ctx, itm)
+	}
+	m.mu.Lock()
+}
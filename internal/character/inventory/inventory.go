@@ -81,6 +81,15 @@ type FilterFunc func(item item.Item) bool
 // ItemCallback is invoked for inventory events
 type ItemCallback func(ctx context.Context, item item.Item)
 
+// SlotCountCallback is invoked when slot count changes
+type SlotCountCallback func(oldCount, newCount int)
+
+// MaxWeightCallback is invoked when weight capacity changes
+type MaxWeightCallback func(oldWeight, newWeight float64)
+
+// QuickSlotCallback is invoked when hotkey binding is cleared
+type QuickSlotCallback func(hotkey int, itemID string)
+
 // Equipment manages equipped items
 type Equipment interface {
 	// Equip equips item to slot
@@ -2,7 +2,11 @@ package inventory
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -12,6 +16,38 @@ import (
 	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrInventoryFull  = errors.New("inventory is full")
+	ErrWeightExceeded = errors.New("inventory weight limit exceeded")
+	ErrSlotOccupied   = errors.New("slot is already occupied")
+	ErrItemNotFound   = errors.New("item not found")
+	ErrSlotOutOfRange = errors.New("slot out of range")
+	ErrNotConsumable  = errors.New("item is not consumable")
+	ErrCannotUse      = errors.New("item cannot be used")
+	ErrNotContainer   = errors.New("item is not a container")
+	ErrNotAllowed     = errors.New("item type not allowed in container")
+	ErrNestingTooDeep = errors.New("container nesting too deep")
+	ErrContainerCycle = errors.New("container cannot hold itself")
+
+	ErrQuickSlotOutOfRange = errors.New("quick slot out of range")
+)
+
+const (
+	// weightScale sets precision (1e-6) that current weight is rounded to
+	weightScale = 1e6
+
+	// weightResyncInterval is number of incremental weight updates
+	// between full recalculations from items
+	weightResyncInterval = 1024
+
+	// MaxQuickSlots is number of hotkeys (1..MaxQuickSlots) items can be bound to
+	MaxQuickSlots = 5
+)
+
 // Manager handles character inventory with weight and slot limits
 type Manager interface {
 	// --- Basic Operations ---
@@ -40,6 +76,17 @@ type Manager interface {
 	// Clear removes all items
 	Clear(ctx context.Context) []item.Item
 
+	// RemoveWhere removes every item matching predicate, returns removed items
+	RemoveWhere(ctx context.Context, predicate func(item.Item) bool) ([]item.Item, error)
+
+	// --- Containers ---
+
+	// MoveIntoContainer moves top-level item into container (top-level or nested)
+	MoveIntoContainer(ctx context.Context, itemID, containerID string) error
+
+	// MoveOutOfContainer moves item from container to free top-level slot
+	MoveOutOfContainer(ctx context.Context, itemID, containerID string) error
+
 	// --- Stack Operations ---
 
 	// SplitStack splits a stack into two, returns the new stack
@@ -51,6 +98,15 @@ type Manager interface {
 	// CanStackWith checks if item can stack with existing items
 	CanStackWith(itm item.Item) (string, bool)
 
+	// ConsumeAmount removes up to amount units across stacks sharing stackKey,
+	// returns number of units actually consumed
+	ConsumeAmount(ctx context.Context, stackKey string, amount int) (int, error)
+
+	// --- Usage ---
+
+	// UseItem uses consumable on target, removing it once its stack is depleted
+	UseItem(ctx context.Context, itemID string, target item.EffectTarget) error
+
 	// --- Slot Management ---
 
 	// SlotCount returns number of slots
@@ -71,6 +127,35 @@ type Manager interface {
 	// MoveToSlot moves item to a different slot
 	MoveToSlot(ctx context.Context, itemID string, targetSlot int) error
 
+	// LockSlot pins slot so Sort and Compact leave it untouched
+	LockSlot(slot int) error
+
+	// UnlockSlot releases slot lock
+	UnlockSlot(slot int)
+
+	// IsSlotLocked checks if slot is locked
+	IsSlotLocked(slot int) bool
+
+	// LockedSlots returns locked slot indexes in ascending order
+	LockedSlots() []int
+
+	// --- Grid Addressing ---
+
+	// GridWidth returns number of columns used for grid addressing
+	GridWidth() int
+
+	// SetGridWidth sets number of columns (<= 0 means a single row)
+	SetGridWidth(width int)
+
+	// SlotAt converts grid coordinates to slot index
+	SlotAt(row, col int) (int, bool)
+
+	// CoordOf converts slot index to grid coordinates, (-1, -1) if out of range
+	CoordOf(slot int) (row, col int)
+
+	// AddToCoord adds an item at grid coordinates
+	AddToCoord(ctx context.Context, row, col int, itm item.Item) error
+
 	// --- Weight Management ---
 
 	// CurrentWeight returns current total weight
@@ -90,6 +175,10 @@ type Manager interface {
 	// CanAdd checks if item can be added (weight + slot check)
 	CanAdd(itm item.Item) bool
 
+	// HowManyCanFit returns how many units of item could be added
+	// considering partial stacks, free slots and remaining weight
+	HowManyCanFit(itm item.Item) int
+
 	// IsFull returns true if no more items can be added
 	IsFull() bool
 
@@ -133,12 +222,41 @@ type Manager interface {
 
 	// --- Sorting ---
 
-	// Sort sorts inventory by given criteria
+	// Sort sorts items in unlocked slots by given criteria
 	Sort(criteria SortBy, ascending bool)
 
+	// Compact moves items in unlocked slots forward, closing gaps
+	Compact()
+
 	// GetSorted returns sorted copy without modifying internal order
 	GetSorted(criteria SortBy, ascending bool) []item.Item
 
+	// PinItem marks item as favorite so sorting always places it first
+	PinItem(itemID string) error
+
+	// UnpinItem removes favorite mark from item
+	UnpinItem(itemID string)
+
+	// IsPinned checks if item is pinned
+	IsPinned(itemID string) bool
+
+	// PinnedItems returns pinned item IDs in pin order
+	PinnedItems() []string
+
+	// --- Quick Slots ---
+
+	// SetQuickSlot binds item to hotkey; empty itemID clears binding
+	SetQuickSlot(hotkey int, itemID string) error
+
+	// QuickSlotItem returns item bound to hotkey
+	QuickSlotItem(hotkey int) (item.Item, bool)
+
+	// QuickSlots returns hotkey -> itemID bindings
+	QuickSlots() map[int]string
+
+	// UseQuickSlot uses item bound to hotkey
+	UseQuickSlot(ctx context.Context, hotkey int, target item.EffectTarget) error
+
 	// --- Stats ---
 
 	// TotalValue returns combined value of all items
@@ -164,6 +282,28 @@ type Manager interface {
 	// OnItemChanged registers callback when item stack changes
 	OnItemChanged(callback ItemCallback)
 
+	// OnSlotCountChanged registers callback when slot count changes
+	OnSlotCountChanged(callback SlotCountCallback)
+
+	// OnMaxWeightChanged registers callback when weight capacity changes
+	OnMaxWeightChanged(callback MaxWeightCallback)
+
+	// OnQuickSlotCleared registers callback when bound item leaves inventory
+	OnQuickSlotCleared(callback QuickSlotCallback)
+
+	// --- View ---
+
+	// View returns live read-only view for display code
+	View() *ReadOnlyView
+
+	// --- Snapshot ---
+
+	// Snapshot captures slot contents and weight for later Restore
+	Snapshot() InventorySnapshot
+
+	// Restore rebuilds contents captured by Snapshot without firing callbacks
+	Restore(snapshot InventorySnapshot)
+
 	// --- Persistence ---
 
 	// SerializeState converts state to map for persistence
@@ -171,6 +311,12 @@ type Manager interface {
 
 	// DeserializeState restores state from map
 	DeserializeState(state map[string]any) error
+
+	// SerializeFull converts state to map with item payloads embedded
+	SerializeFull() (map[string]any, error)
+
+	// DeserializeFull restores state and items from SerializeFull output
+	DeserializeFull(state map[string]any) error
 }
 
 // SortBy defines how to sort inventory
@@ -196,18 +342,55 @@ type BaseManager struct {
 	itemIndex map[string]int // itemID -> slot index
 	maxSlots  int
 	maxWeight float64
+	gridWidth int // columns for grid addressing, 0 = single row
+
+	maxContainerDepth int
+
+	lockedSlots map[int]struct{}
+	pinned      []string       // itemIDs sorted first, in pin order
+	quickSlots  map[int]string // hotkey -> itemID
+
+	// effectiveValue makes TotalValue price equipment by rolled affixes
+	effectiveValue bool
+
+	// consumeLargestFirst makes ConsumeAmount drain largest stacks first
+	consumeLargestFirst bool
 
 	currentWeight float64
+	weightChanges int // incremental weight updates since last recalculation
 
 	onAddedCallbacks   []ItemCallback
 	onRemovedCallbacks []ItemCallback
 	onChangedCallbacks []ItemCallback
+
+	onSlotCountCallbacks []SlotCountCallback
+	onMaxWeightCallbacks []MaxWeightCallback
+
+	onQuickSlotClearedCallbacks []QuickSlotCallback
+
+	// capacityEventsOnRestore makes deserialization fire capacity callbacks
+	capacityEventsOnRestore bool
 }
 
 // Config holds configuration for creating an inventory manager
 type Config struct {
 	MaxSlots  int
 	MaxWeight float64
+	GridWidth int
+
+	// MaxContainerDepth limits bag nesting (0 = DefaultMaxContainerDepth)
+	MaxContainerDepth int
+
+	// EffectiveValue makes TotalValue use affix-aware equipment value
+	EffectiveValue bool
+
+	// ConsumeLargestFirst makes ConsumeAmount drain largest stacks first
+	// (default drains smallest stacks first to free slots)
+	ConsumeLargestFirst bool
+
+	// CapacityEventsOnRestore fires slot count and max weight callbacks
+	// when deserialization changes them
+	CapacityEventsOnRestore bool
 }
 
 // DefaultConfig returns default configuration
@@ -235,11 +418,26 @@ func NewManagerWithConfig(cfg Config) *BaseManager {
 		maxWeight = 100.0
 	}
 
+	maxContainerDepth := cfg.MaxContainerDepth
+	if maxContainerDepth <= 0 {
+		maxContainerDepth = DefaultMaxContainerDepth
+	}
+
 	return &BaseManager{
 		slots:     make([]item.Item, maxSlots),
 		itemIndex: make(map[string]int),
 		maxSlots:  maxSlots,
 		maxWeight: maxWeight,
+		gridWidth: max(cfg.GridWidth, 0),
+
+		maxContainerDepth: maxContainerDepth,
+		lockedSlots:       make(map[int]struct{}),
+		quickSlots:        make(map[int]string),
+
+		effectiveValue:      cfg.EffectiveValue,
+		consumeLargestFirst: cfg.ConsumeLargestFirst,
+
+		capacityEventsOnRestore: cfg.CapacityEventsOnRestore,
 	}
 }
 
@@ -261,13 +459,13 @@ func (m *BaseManager) Add(ctx context.Context, itm item.Item) error {
 	// Find free slot
 	slot := m.findFreeSlotLocked()
 	if slot == -1 {
-		return fmt.Errorf("inventory is full (no free slots)")
+		return fmt.Errorf("%w: no free slots", ErrInventoryFull)
 	}
 
 	// Check weight
 	itemWeight := m.getItemWeight(itm)
 	if m.currentWeight+itemWeight > m.maxWeight {
-		return fmt.Errorf("inventory weight limit exceeded (current: %.2f, max: %.2f, item: %.2f)",
+		return fmt.Errorf("%w (current: %.2f, max: %.2f, item: %.2f)", ErrWeightExceeded,
 			m.currentWeight, m.maxWeight, itemWeight)
 	}
 
@@ -282,17 +480,23 @@ func (m *BaseManager) AddToSlot(ctx context.Context, slot int, itm item.Item) er
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.addToFreeSlotLocked(ctx, slot, itm)
+}
+
+// addToFreeSlotLocked validates slot and weight, then places the item
+func (m *BaseManager) addToFreeSlotLocked(ctx context.Context, slot int, itm item.Item) error {
 	if slot < 0 || slot >= m.maxSlots {
-		return fmt.Errorf("slot %d out of range (0-%d)", slot, m.maxSlots-1)
+		return fmt.Errorf("%w: slot %d (0-%d)", ErrSlotOutOfRange, slot, m.maxSlots-1)
 	}
 
 	if m.slots[slot] != nil {
-		return fmt.Errorf("slot %d is already occupied", slot)
+		return fmt.Errorf("%w: slot %d", ErrSlotOccupied, slot)
 	}
 
 	itemWeight := m.getItemWeight(itm)
 	if m.currentWeight+itemWeight > m.maxWeight {
-		return fmt.Errorf("inventory weight limit exceeded")
+		return fmt.Errorf("%w (current: %.2f, max: %.2f, item: %.2f)", ErrWeightExceeded,
+			m.currentWeight, m.maxWeight, itemWeight)
 	}
 
 	return m.addToSlotLocked(ctx, slot, itm)
@@ -301,7 +505,7 @@ func (m *BaseManager) AddToSlot(ctx context.Context, slot int, itm item.Item) er
 func (m *BaseManager) addToSlotLocked(ctx context.Context, slot int, itm item.Item) error {
 	m.slots[slot] = itm
 	m.itemIndex[itm.ID()] = slot
-	m.currentWeight += m.getItemWeight(itm)
+	m.adjustWeightLocked(m.getItemWeight(itm))
 
 	// Trigger callbacks (copy to avoid holding lock)
 	callbacks := append([]ItemCallback{}, m.onAddedCallbacks...)
@@ -320,22 +524,22 @@ func (m *BaseManager) Remove(ctx context.Context, itemID string) (item.Item, err
 
 	slot, exists := m.itemIndex[itemID]
 	if !exists {
-		return nil, fmt.Errorf("item with ID %s not found", itemID)
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
 	}
 
 	itm := m.slots[slot]
 	m.slots[slot] = nil
 	delete(m.itemIndex, itemID)
-	m.currentWeight -= m.getItemWeight(itm)
-	if m.currentWeight < 0 {
-		m.currentWeight = 0
-	}
+	m.adjustWeightLocked(-m.getItemWeight(itm))
 
 	callbacks := append([]ItemCallback{}, m.onRemovedCallbacks...)
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
 	m.mu.Unlock()
 	for _, cb := range callbacks {
 		cb(ctx, itm)
 	}
+	notifyQuickSlots()
 	m.mu.Lock()
 
 	return itm, nil
@@ -351,7 +555,7 @@ func (m *BaseManager) RemoveAmount(ctx context.Context, itemID string, amount in
 
 	slot, exists := m.itemIndex[itemID]
 	if !exists {
-		return nil, fmt.Errorf("item with ID %s not found", itemID)
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
 	}
 
 	itm := m.slots[slot]
@@ -361,16 +565,16 @@ func (m *BaseManager) RemoveAmount(ctx context.Context, itemID string, amount in
 		// Remove entire item
 		m.slots[slot] = nil
 		delete(m.itemIndex, itemID)
-		m.currentWeight -= m.getItemWeight(itm)
-		if m.currentWeight < 0 {
-			m.currentWeight = 0
-		}
+		m.adjustWeightLocked(-m.getItemWeight(itm))
 
 		callbacks := append([]ItemCallback{}, m.onRemovedCallbacks...)
+		m.releasePinsLocked()
+		notifyQuickSlots := m.releaseQuickSlotsLocked()
 		m.mu.Unlock()
 		for _, cb := range callbacks {
 			cb(ctx, itm)
 		}
+		notifyQuickSlots()
 		m.mu.Lock()
 
 		return itm, nil
@@ -380,7 +584,7 @@ func (m *BaseManager) RemoveAmount(ctx context.Context, itemID string, amount in
 	oldWeight := m.getItemWeight(itm)
 	itm.RemoveStack(amount)
 	newWeight := m.getItemWeight(itm)
-	m.currentWeight -= oldWeight - newWeight
+	m.adjustWeightLocked(newWeight - oldWeight)
 
 	// Create new item for removed portion
 	removed := itm.Clone().(item.Item)
@@ -447,6 +651,8 @@ func (m *BaseManager) Clear(ctx context.Context) []item.Item {
 	m.currentWeight = 0
 
 	callbacks := append([]ItemCallback{}, m.onRemovedCallbacks...)
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
 	m.mu.Unlock()
 
 	for _, itm := range items {
@@ -454,10 +660,55 @@ func (m *BaseManager) Clear(ctx context.Context) []item.Item {
 			cb(ctx, itm)
 		}
 	}
+	notifyQuickSlots()
 
 	return items
 }
 
+// RemoveWhere removes all items matching predicate in slot order.
+// Matches are collected before anything is removed, so the predicate sees a
+// consistent inventory; weight is recalculated once afterwards.
+func (m *BaseManager) RemoveWhere(ctx context.Context, predicate func(item.Item) bool) ([]item.Item, error) {
+	if predicate == nil {
+		return nil, fmt.Errorf("predicate cannot be nil")
+	}
+
+	m.mu.Lock()
+
+	var matched []int
+	for slot, itm := range m.slots {
+		if itm != nil && predicate(itm) {
+			matched = append(matched, slot)
+		}
+	}
+
+	removed := make([]item.Item, 0, len(matched))
+	for _, slot := range matched {
+		itm := m.slots[slot]
+		m.slots[slot] = nil
+		delete(m.itemIndex, itm.ID())
+		removed = append(removed, itm)
+	}
+
+	if len(removed) > 0 {
+		m.recalculateWeightLocked()
+	}
+
+	callbacks := append([]ItemCallback{}, m.onRemovedCallbacks...)
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
+	m.mu.Unlock()
+
+	for _, itm := range removed {
+		for _, cb := range callbacks {
+			cb(ctx, itm)
+		}
+	}
+	notifyQuickSlots()
+
+	return removed, nil
+}
+
 // --- Stack Operations ---
 
 func (m *BaseManager) SplitStack(ctx context.Context, itemID string, amount int) (item.Item, error) {
@@ -470,7 +721,7 @@ func (m *BaseManager) SplitStack(ctx context.Context, itemID string, amount int)
 
 	slot, exists := m.itemIndex[itemID]
 	if !exists {
-		return nil, fmt.Errorf("item with ID %s not found", itemID)
+		return nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
 	}
 
 	itm := m.slots[slot]
@@ -481,7 +732,7 @@ func (m *BaseManager) SplitStack(ctx context.Context, itemID string, amount int)
 	// Find free slot for new stack
 	newSlot := m.findFreeSlotLocked()
 	if newSlot == -1 {
-		return nil, fmt.Errorf("no free slot for split stack")
+		return nil, fmt.Errorf("%w: no free slot for split stack", ErrInventoryFull)
 	}
 
 	// Remove from original stack
@@ -525,16 +776,16 @@ func (m *BaseManager) MergeStacks(ctx context.Context, sourceID, targetID string
 	targetSlot, targetExists := m.itemIndex[targetID]
 
 	if !sourceExists {
-		return fmt.Errorf("source item %s not found", sourceID)
+		return fmt.Errorf("%w: source %s", ErrItemNotFound, sourceID)
 	}
 	if !targetExists {
-		return fmt.Errorf("target item %s not found", targetID)
+		return fmt.Errorf("%w: target %s", ErrItemNotFound, targetID)
 	}
 
 	source := m.slots[sourceSlot]
 	target := m.slots[targetSlot]
 
-	if !target.CanStackWith(source) {
+	if !sameStack(target, source) {
 		return fmt.Errorf("items cannot be stacked together")
 	}
 
@@ -557,11 +808,14 @@ func (m *BaseManager) MergeStacks(ctx context.Context, sourceID, targetID string
 	}
 
 	callbacks := append([]ItemCallback{}, m.onChangedCallbacks...)
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
 	m.mu.Unlock()
 
 	for _, cb := range callbacks {
 		cb(ctx, target)
 	}
+	notifyQuickSlots()
 
 	m.mu.Lock()
 	return nil
@@ -575,7 +829,7 @@ func (m *BaseManager) CanStackWith(itm item.Item) (string, bool) {
 
 func (m *BaseManager) canStackWithLocked(itm item.Item) (string, bool) {
 	for _, existing := range m.slots {
-		if existing != nil && existing.CanStackWith(itm) {
+		if existing != nil && sameStack(existing, itm) {
 			if existing.StackSize() < existing.MaxStackSize() {
 				return existing.ID(), true
 			}
@@ -584,43 +838,192 @@ func (m *BaseManager) canStackWithLocked(itm item.Item) (string, bool) {
 	return "", false
 }
 
+// ConsumeAmount drains up to amount units from stacks whose StackKey equals
+// stackKey. Smaller stacks are drained first unless ConsumeLargestFirst is set,
+// ties go by slot order. Depleted stacks are removed and fire OnItemRemoved,
+// reduced stacks fire OnItemChanged. When inventory holds less than amount,
+// every matching stack is drained and the smaller total is returned.
+func (m *BaseManager) ConsumeAmount(ctx context.Context, stackKey string, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("amount must be positive")
+	}
+
+	m.mu.Lock()
+
+	var matching []int
+	for slot, itm := range m.slots {
+		if itm != nil && itm.StackKey() == stackKey {
+			matching = append(matching, slot)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		a, b := m.slots[matching[i]].StackSize(), m.slots[matching[j]].StackSize()
+		if m.consumeLargestFirst {
+			return a > b
+		}
+		return a < b
+	})
+
+	consumed := 0
+	var removed, changed []item.Item
+	for _, slot := range matching {
+		if consumed == amount {
+			break
+		}
+		itm := m.slots[slot]
+		take := min(itm.StackSize(), amount-consumed)
+		consumed += take
+
+		if take == itm.StackSize() {
+			m.slots[slot] = nil
+			delete(m.itemIndex, itm.ID())
+			removed = append(removed, itm)
+			continue
+		}
+		itm.RemoveStack(take)
+		changed = append(changed, itm)
+	}
+
+	if consumed > 0 {
+		m.recalculateWeightLocked()
+	}
+
+	removedCallbacks := append([]ItemCallback{}, m.onRemovedCallbacks...)
+	changedCallbacks := append([]ItemCallback{}, m.onChangedCallbacks...)
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
+	m.mu.Unlock()
+
+	for _, itm := range removed {
+		for _, cb := range removedCallbacks {
+			cb(ctx, itm)
+		}
+	}
+	for _, itm := range changed {
+		for _, cb := range changedCallbacks {
+			cb(ctx, itm)
+		}
+	}
+	notifyQuickSlots()
+
+	return consumed, nil
+}
+
+// sameStack reports whether two distinct items share a stack key
+func sameStack(existing, itm item.Item) bool {
+	return existing.ID() != itm.ID() && existing.StackKey() == itm.StackKey()
+}
+
+// mergeIntoExistingLocked tops up target stack and places any overflow in a
+// free slot. Slot and weight are validated before anything is mutated, so a
+// failed add leaves both the inventory and the caller's stack untouched.
 func (m *BaseManager) mergeIntoExistingLocked(ctx context.Context, itm item.Item, targetID string) error {
 	targetSlot := m.itemIndex[targetID]
 	target := m.slots[targetSlot]
 
-	availableSpace := target.MaxStackSize() - target.StackSize()
-	amountToAdd := itm.StackSize()
-
-	if amountToAdd <= availableSpace {
-		// All fits in existing stack
-		oldWeight := m.getItemWeight(target)
-		target.AddStack(amountToAdd)
-		newWeight := m.getItemWeight(target)
-		m.currentWeight += newWeight - oldWeight
+	availableSpace := max(target.MaxStackSize()-target.StackSize(), 0)
+	amountToAdd := min(itm.StackSize(), availableSpace)
+	overflow := itm.StackSize() > availableSpace
 
-		callbacks := append([]ItemCallback{}, m.onChangedCallbacks...)
-		m.mu.Unlock()
-		for _, cb := range callbacks {
-			cb(ctx, target)
+	// Remainder needs new slot
+	slot := -1
+	if overflow {
+		slot = m.findFreeSlotLocked()
+		if slot == -1 {
+			return fmt.Errorf("%w: no free slot for remainder", ErrInventoryFull)
 		}
-		m.mu.Lock()
-		return nil
 	}
 
-	// Partial stack - add what fits, then add remainder as new item
+	itemWeight := m.getItemWeight(itm)
+	if m.currentWeight+itemWeight > m.maxWeight {
+		return fmt.Errorf("%w (current: %.2f, max: %.2f, item: %.2f)", ErrWeightExceeded,
+			m.currentWeight, m.maxWeight, itemWeight)
+	}
+
 	oldWeight := m.getItemWeight(target)
-	target.AddStack(availableSpace)
-	newWeight := m.getItemWeight(target)
-	m.currentWeight += (newWeight - oldWeight)
+	target.AddStack(amountToAdd)
+	m.adjustWeightLocked(m.getItemWeight(target) - oldWeight)
 
-	// Remainder needs new slot
-	slot := m.findFreeSlotLocked()
-	if slot == -1 {
-		return fmt.Errorf("inventory is full")
+	callbacks := append([]ItemCallback{}, m.onChangedCallbacks...)
+
+	var err error
+	if overflow {
+		itm.RemoveStack(amountToAdd)
+		err = m.addToSlotLocked(ctx, slot, itm)
 	}
 
-	itm.RemoveStack(availableSpace)
-	return m.addToSlotLocked(ctx, slot, itm)
+	m.mu.Unlock()
+	for _, cb := range callbacks {
+		cb(ctx, target)
+	}
+	m.mu.Lock()
+	return err
+}
+
+// --- Usage ---
+
+// UseItem invokes consumable's Use, which spends a charge and shrinks the stack
+// when charges run out. Weight is updated and OnItemChanged fires while units
+// remain; a depleted stack is removed and fires OnItemRemoved.
+func (m *BaseManager) UseItem(ctx context.Context, itemID string, target item.EffectTarget) error {
+	// Weight is read with item so it matches what inventory accounted for
+	m.mu.RLock()
+	slot, exists := m.itemIndex[itemID]
+	var itm item.Item
+	var oldWeight float64
+	if exists {
+		itm = m.slots[slot]
+		oldWeight = m.getItemWeight(itm)
+	}
+	m.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+
+	consumable, ok := itm.(item.Consumable)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotConsumable, itemID)
+	}
+
+	if !consumable.CanUse(target) {
+		return fmt.Errorf("%w: %s has no charges or is on cooldown", ErrCannotUse, itemID)
+	}
+
+	// Use outside lock: effects may touch the owner's inventory
+	if err := consumable.Use(ctx, target); err != nil {
+		return fmt.Errorf("failed to use %s: %w", itemID, err)
+	}
+
+	m.mu.Lock()
+
+	// Item may have been moved or removed while effect applied
+	slot, exists = m.itemIndex[itemID]
+	if !exists {
+		m.mu.Unlock()
+		return nil
+	}
+
+	var callbacks []ItemCallback
+	if itm.StackSize() <= 0 {
+		m.slots[slot] = nil
+		delete(m.itemIndex, itemID)
+		m.adjustWeightLocked(-oldWeight)
+		callbacks = append(callbacks, m.onRemovedCallbacks...)
+	} else {
+		m.adjustWeightLocked(m.getItemWeight(itm) - oldWeight)
+		callbacks = append(callbacks, m.onChangedCallbacks...)
+	}
+	m.releasePinsLocked()
+	notifyQuickSlots := m.releaseQuickSlotsLocked()
+
+	m.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(ctx, itm)
+	}
+	notifyQuickSlots()
+	return nil
 }
 
 // --- Slot Management ---
@@ -637,8 +1040,15 @@ func (m *BaseManager) SetSlotCount(count int) {
 	}
 
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	oldCount := m.maxSlots
+	m.setSlotCountLocked(count)
+	notify := m.capacityNotifierLocked(oldCount, m.maxWeight)
+	m.mu.Unlock()
+
+	notify()
+}
 
+func (m *BaseManager) setSlotCountLocked(count int) {
 	if count == m.maxSlots {
 		return
 	}
@@ -661,6 +1071,16 @@ func (m *BaseManager) SetSlotCount(count int) {
 		if canShrink {
 			m.slots = m.slots[:count]
 			m.maxSlots = count
+			m.dropLockedSlotsFromLocked(count)
+		}
+	}
+}
+
+// dropLockedSlotsFromLocked unlocks slots at index count and beyond
+func (m *BaseManager) dropLockedSlotsFromLocked(count int) {
+	for slot := range m.lockedSlots {
+		if slot >= count {
+			delete(m.lockedSlots, slot)
 		}
 	}
 }
@@ -682,7 +1102,7 @@ func (m *BaseManager) SwapSlots(ctx context.Context, slot1, slot2 int) error {
 	defer m.mu.Unlock()
 
 	if slot1 < 0 || slot1 >= m.maxSlots || slot2 < 0 || slot2 >= m.maxSlots {
-		return fmt.Errorf("slot out of range")
+		return ErrSlotOutOfRange
 	}
 
 	item1 := m.slots[slot1]
@@ -706,12 +1126,12 @@ func (m *BaseManager) MoveToSlot(ctx context.Context, itemID string, targetSlot
 	defer m.mu.Unlock()
 
 	if targetSlot < 0 || targetSlot >= m.maxSlots {
-		return fmt.Errorf("target slot %d out of range", targetSlot)
+		return fmt.Errorf("%w: target slot %d", ErrSlotOutOfRange, targetSlot)
 	}
 
 	currentSlot, exists := m.itemIndex[itemID]
 	if !exists {
-		return fmt.Errorf("item %s not found", itemID)
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
 	}
 
 	if currentSlot == targetSlot {
@@ -719,7 +1139,7 @@ func (m *BaseManager) MoveToSlot(ctx context.Context, itemID string, targetSlot
 	}
 
 	if m.slots[targetSlot] != nil {
-		return fmt.Errorf("target slot %d is occupied", targetSlot)
+		return fmt.Errorf("%w: target slot %d", ErrSlotOccupied, targetSlot)
 	}
 
 	itm := m.slots[currentSlot]
@@ -730,6 +1150,116 @@ func (m *BaseManager) MoveToSlot(ctx context.Context, itemID string, targetSlot
 	return nil
 }
 
+func (m *BaseManager) LockSlot(slot int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slot < 0 || slot >= m.maxSlots {
+		return fmt.Errorf("%w: slot %d (0-%d)", ErrSlotOutOfRange, slot, m.maxSlots-1)
+	}
+	m.lockedSlots[slot] = struct{}{}
+	return nil
+}
+
+func (m *BaseManager) UnlockSlot(slot int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.lockedSlots, slot)
+}
+
+func (m *BaseManager) IsSlotLocked(slot int) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, locked := m.lockedSlots[slot]
+	return locked
+}
+
+func (m *BaseManager) LockedSlots() []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lockedSlotsLocked()
+}
+
+func (m *BaseManager) lockedSlotsLocked() []int {
+	result := make([]int, 0, len(m.lockedSlots))
+	for slot := range m.lockedSlots {
+		result = append(result, slot)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// --- Grid Addressing ---
+
+func (m *BaseManager) GridWidth() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.gridWidthLocked()
+}
+
+func (m *BaseManager) SetGridWidth(width int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gridWidth = max(width, 0)
+}
+
+// gridWidthLocked returns effective width; unset width lays all slots in one row
+func (m *BaseManager) gridWidthLocked() int {
+	if m.gridWidth <= 0 {
+		return max(m.maxSlots, 1)
+	}
+	return m.gridWidth
+}
+
+// SlotAt returns false for coordinates outside the grid,
+// including cells past the last slot when the last row is partial
+func (m *BaseManager) SlotAt(row, col int) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.slotAtLocked(row, col)
+}
+
+func (m *BaseManager) slotAtLocked(row, col int) (int, bool) {
+	width := m.gridWidthLocked()
+	if row < 0 || col < 0 || col >= width {
+		return -1, false
+	}
+
+	slot := row*width + col
+	if slot >= m.maxSlots {
+		return -1, false
+	}
+	return slot, true
+}
+
+func (m *BaseManager) CoordOf(slot int) (row, col int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if slot < 0 || slot >= m.maxSlots {
+		return -1, -1
+	}
+
+	width := m.gridWidthLocked()
+	return slot / width, slot % width
+}
+
+func (m *BaseManager) AddToCoord(ctx context.Context, row, col int, itm item.Item) error {
+	if itm == nil {
+		return fmt.Errorf("cannot add nil item")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slot, ok := m.slotAtLocked(row, col)
+	if !ok {
+		return fmt.Errorf("%w: row %d, col %d", ErrSlotOutOfRange, row, col)
+	}
+
+	return m.addToFreeSlotLocked(ctx, slot, itm)
+}
+
 func (m *BaseManager) findFreeSlotLocked() int {
 	for i, itm := range m.slots {
 		if itm == nil {
@@ -758,8 +1288,12 @@ func (m *BaseManager) SetMaxWeight(weight float64) {
 		return
 	}
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	oldWeight := m.maxWeight
 	m.maxWeight = weight
+	notify := m.capacityNotifierLocked(m.maxSlots, oldWeight)
+	m.mu.Unlock()
+
+	notify()
 }
 
 func (m *BaseManager) AvailableWeight() float64 {
@@ -796,7 +1330,52 @@ func (m *BaseManager) CanAdd(itm item.Item) bool {
 	return m.currentWeight+m.getItemWeight(itm) <= m.maxWeight
 }
 
-func (m *BaseManager) IsFull() bool {
+func (m *BaseManager) HowManyCanFit(itm item.Item) int {
+	if itm == nil {
+		return 0
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	maxStack := itm.MaxStackSize()
+	if maxStack < 1 {
+		maxStack = 1
+	}
+
+	// Room left in existing partial stacks
+	count := 0
+	freeSlots := 0
+	for _, existing := range m.slots {
+		if existing == nil {
+			freeSlots++
+			continue
+		}
+		if sameStack(existing, itm) {
+			if space := existing.MaxStackSize() - existing.StackSize(); space > 0 {
+				count += space
+			}
+		}
+	}
+
+	// Fresh stacks in free slots
+	count += freeSlots * maxStack
+
+	// Weight limit
+	if unitWeight := itm.Weight(); unitWeight > 0 && !itm.WeightExempt() {
+		available := m.maxWeight - m.currentWeight
+		if available <= 0 {
+			return 0
+		}
+		if byWeight := int(available / unitWeight); byWeight < count {
+			count = byWeight
+		}
+	}
+
+	return count
+}
+
+func (m *BaseManager) IsFull() bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return m.findFreeSlotLocked() == -1
@@ -899,22 +1478,43 @@ func (m *BaseManager) Sort(criteria SortBy, ascending bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	items := m.unlockedItemsLocked()
+	m.sortItems(items, criteria, ascending)
+	m.placeUnlockedLocked(items)
+}
+
+func (m *BaseManager) Compact() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.placeUnlockedLocked(m.unlockedItemsLocked())
+}
+
+// unlockedItemsLocked returns items outside locked slots in slot order
+func (m *BaseManager) unlockedItemsLocked() []item.Item {
 	items := make([]item.Item, 0, len(m.itemIndex))
-	for _, itm := range m.slots {
-		if itm != nil {
+	for i, itm := range m.slots {
+		if _, locked := m.lockedSlots[i]; itm != nil && !locked {
 			items = append(items, itm)
 		}
 	}
+	return items
+}
 
-	m.sortItems(items, criteria, ascending)
-
-	// Rebuild slots
-	m.slots = make([]item.Item, m.maxSlots)
-	m.itemIndex = make(map[string]int)
-
-	for i, itm := range items {
-		m.slots[i] = itm
-		m.itemIndex[itm.ID()] = i
+// placeUnlockedLocked fills unlocked slots with items in order; locked slots stay put
+func (m *BaseManager) placeUnlockedLocked(items []item.Item) {
+	next := 0
+	for i := range m.slots {
+		if _, locked := m.lockedSlots[i]; locked {
+			continue
+		}
+		if next < len(items) {
+			m.slots[i] = items[next]
+			m.itemIndex[items[next].ID()] = i
+			next++
+		} else {
+			m.slots[i] = nil
+		}
 	}
 }
 
@@ -933,6 +1533,57 @@ func (m *BaseManager) GetSorted(criteria SortBy, ascending bool) []item.Item {
 	return items
 }
 
+// PinItem pins item present in inventory; pinning again keeps original order
+func (m *BaseManager) PinItem(itemID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.itemIndex[itemID]; !exists {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	if !slices.Contains(m.pinned, itemID) {
+		m.pinned = append(m.pinned, itemID)
+	}
+	return nil
+}
+
+func (m *BaseManager) UnpinItem(itemID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pinned = slices.DeleteFunc(m.pinned, func(id string) bool { return id == itemID })
+}
+
+func (m *BaseManager) IsPinned(itemID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Contains(m.pinned, itemID)
+}
+
+func (m *BaseManager) PinnedItems() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.pinned)
+}
+
+// releasePinsLocked unpins items no longer in inventory
+func (m *BaseManager) releasePinsLocked() {
+	m.pinned = slices.DeleteFunc(m.pinned, func(itemID string) bool {
+		_, exists := m.itemIndex[itemID]
+		return !exists
+	})
+}
+
+// pinnedPresentLocked returns pins of items still in inventory, in pin order
+func (m *BaseManager) pinnedPresentLocked() []string {
+	result := make([]string, 0, len(m.pinned))
+	for _, itemID := range m.pinned {
+		if _, exists := m.itemIndex[itemID]; exists {
+			result = append(result, itemID)
+		}
+	}
+	return result
+}
+
 func (m *BaseManager) sortItems(items []item.Item, criteria SortBy, ascending bool) {
 	sort.Slice(items, func(i, j int) bool {
 		var less bool
@@ -959,6 +1610,119 @@ func (m *BaseManager) sortItems(items []item.Item, criteria SortBy, ascending bo
 		}
 		return less
 	})
+
+	if len(m.pinned) == 0 {
+		return
+	}
+
+	// Pinned items lead in pin order, rest keep criteria order
+	rank := make(map[string]int, len(m.pinned))
+	for i, itemID := range m.pinned {
+		rank[itemID] = i
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		ri, pinnedI := rank[items[i].ID()]
+		rj, pinnedJ := rank[items[j].ID()]
+		if pinnedI && pinnedJ {
+			return ri < rj
+		}
+		return pinnedI && !pinnedJ
+	})
+}
+
+// --- Quick Slots ---
+
+// SetQuickSlot binds item to hotkey 1..MaxQuickSlots, replacing previous
+// binding. Empty itemID clears binding without firing OnQuickSlotCleared.
+func (m *BaseManager) SetQuickSlot(hotkey int, itemID string) error {
+	if hotkey < 1 || hotkey > MaxQuickSlots {
+		return fmt.Errorf("%w: %d", ErrQuickSlotOutOfRange, hotkey)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if itemID == "" {
+		delete(m.quickSlots, hotkey)
+		return nil
+	}
+	if _, exists := m.itemIndex[itemID]; !exists {
+		return fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	m.quickSlots[hotkey] = itemID
+	return nil
+}
+
+func (m *BaseManager) QuickSlotItem(hotkey int) (item.Item, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	slot, exists := m.itemIndex[m.quickSlots[hotkey]]
+	if !exists {
+		return nil, false
+	}
+	return m.slots[slot], true
+}
+
+func (m *BaseManager) QuickSlots() map[int]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.quickSlots)
+}
+
+// UseQuickSlot uses bound item like UseItem; depleted item clears its binding
+func (m *BaseManager) UseQuickSlot(ctx context.Context, hotkey int, target item.EffectTarget) error {
+	if hotkey < 1 || hotkey > MaxQuickSlots {
+		return fmt.Errorf("%w: %d", ErrQuickSlotOutOfRange, hotkey)
+	}
+
+	m.mu.RLock()
+	itemID, bound := m.quickSlots[hotkey]
+	m.mu.RUnlock()
+
+	if !bound {
+		return fmt.Errorf("%w: no item bound to quick slot %d", ErrItemNotFound, hotkey)
+	}
+	return m.UseItem(ctx, itemID, target)
+}
+
+// releaseQuickSlotsLocked drops bindings of items no longer in inventory.
+// Returned func fires OnQuickSlotCleared in hotkey order; call it after unlock.
+func (m *BaseManager) releaseQuickSlotsLocked() func() {
+	var hotkeys []int
+	cleared := make(map[int]string)
+	for hotkey, itemID := range m.quickSlots {
+		if _, exists := m.itemIndex[itemID]; !exists {
+			hotkeys = append(hotkeys, hotkey)
+			cleared[hotkey] = itemID
+			delete(m.quickSlots, hotkey)
+		}
+	}
+	if len(hotkeys) == 0 {
+		return func() {}
+	}
+	slices.Sort(hotkeys)
+
+	callbacks := append([]QuickSlotCallback{}, m.onQuickSlotClearedCallbacks...)
+	return func() {
+		for _, hotkey := range hotkeys {
+			for _, cb := range callbacks {
+				cb(hotkey, cleared[hotkey])
+			}
+		}
+	}
+}
+
+// quickSlotBindingsLocked returns bindings of items still in inventory, by hotkey
+func (m *BaseManager) quickSlotBindingsLocked() []QuickSlotBinding {
+	result := make([]QuickSlotBinding, 0, len(m.quickSlots))
+	for hotkey, itemID := range m.quickSlots {
+		if _, exists := m.itemIndex[itemID]; exists {
+			result = append(result, QuickSlotBinding{Hotkey: hotkey, ItemID: itemID})
+		}
+	}
+	slices.SortFunc(result, func(a, b QuickSlotBinding) int { return a.Hotkey - b.Hotkey })
+	return result
 }
 
 // --- Stats ---
@@ -970,12 +1734,22 @@ func (m *BaseManager) TotalValue() int64 {
 	var total int64
 	for _, itm := range m.slots {
 		if itm != nil {
-			total += itm.Value() * int64(itm.StackSize())
+			total += m.itemValue(itm) * int64(itm.StackSize())
 		}
 	}
 	return total
 }
 
+// itemValue returns unit value, affix-aware for equipment if enabled
+func (m *BaseManager) itemValue(itm item.Item) int64 {
+	if m.effectiveValue {
+		if equip, ok := itm.(item.Equipment); ok {
+			return equip.EffectiveValue()
+		}
+	}
+	return itm.Value()
+}
+
 func (m *BaseManager) TotalWeight() float64 {
 	return m.CurrentWeight()
 }
@@ -1018,19 +1792,217 @@ func (m *BaseManager) OnItemChanged(callback ItemCallback) {
 	m.onChangedCallbacks = append(m.onChangedCallbacks, callback)
 }
 
+func (m *BaseManager) OnSlotCountChanged(callback SlotCountCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSlotCountCallbacks = append(m.onSlotCountCallbacks, callback)
+}
+
+func (m *BaseManager) OnMaxWeightChanged(callback MaxWeightCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onMaxWeightCallbacks = append(m.onMaxWeightCallbacks, callback)
+}
+
+func (m *BaseManager) OnQuickSlotCleared(callback QuickSlotCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onQuickSlotClearedCallbacks = append(m.onQuickSlotClearedCallbacks, callback)
+}
+
+// capacityNotifierLocked compares current capacity against old values and
+// returns func firing callbacks for those that changed. Call it after unlock.
+func (m *BaseManager) capacityNotifierLocked(oldCount int, oldWeight float64) func() {
+	newCount, newWeight := m.maxSlots, m.maxWeight
+
+	var slotCallbacks []SlotCountCallback
+	if newCount != oldCount {
+		slotCallbacks = append(slotCallbacks, m.onSlotCountCallbacks...)
+	}
+	var weightCallbacks []MaxWeightCallback
+	if newWeight != oldWeight {
+		weightCallbacks = append(weightCallbacks, m.onMaxWeightCallbacks...)
+	}
+
+	return func() {
+		for _, cb := range slotCallbacks {
+			cb(oldCount, newCount)
+		}
+		for _, cb := range weightCallbacks {
+			cb(oldWeight, newWeight)
+		}
+	}
+}
+
+// --- View ---
+
+// View returns read-only view over this manager
+func (m *BaseManager) View() *ReadOnlyView {
+	return NewReadOnlyView(m)
+}
+
+// --- Snapshot ---
+
+// InventorySnapshot is a point-in-time copy of inventory contents.
+// It holds item references, not clones: Restore puts the same items back
+// into their slots and resets their stack sizes, while other changes made
+// to those items in between (durability, affixes) are kept.
+type InventorySnapshot struct {
+	slots  []item.Item
+	stacks []int
+	weight float64
+}
+
+// SlotCount returns number of slots captured
+func (s InventorySnapshot) SlotCount() int {
+	return len(s.slots)
+}
+
+// Weight returns weight captured
+func (s InventorySnapshot) Weight() float64 {
+	return s.weight
+}
+
+func (m *BaseManager) Snapshot() InventorySnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := InventorySnapshot{
+		slots:  make([]item.Item, len(m.slots)),
+		stacks: make([]int, len(m.slots)),
+		weight: m.currentWeight,
+	}
+	for i, itm := range m.slots {
+		if itm != nil {
+			snapshot.slots[i] = itm
+			snapshot.stacks[i] = itm.StackSize()
+		}
+	}
+	return snapshot
+}
+
+func (m *BaseManager) Restore(snapshot InventorySnapshot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.slots = make([]item.Item, len(snapshot.slots))
+	m.maxSlots = len(snapshot.slots)
+	m.itemIndex = make(map[string]int)
+
+	for i, itm := range snapshot.slots {
+		if itm == nil {
+			continue
+		}
+		if diff := snapshot.stacks[i] - itm.StackSize(); diff > 0 {
+			itm.AddStack(diff)
+		} else if diff < 0 {
+			itm.RemoveStack(-diff)
+		}
+		m.slots[i] = itm
+		m.itemIndex[itm.ID()] = i
+	}
+	m.currentWeight = snapshot.weight
+
+	// Locks of slots the snapshot does not have go with them
+	m.dropLockedSlotsFromLocked(m.maxSlots)
+
+	// Pins and bindings of items missing from snapshot are dropped silently
+	m.releasePinsLocked()
+	m.releaseQuickSlotsLocked()
+}
+
+// StackChange reports item whose stack size differs between snapshots
+type StackChange struct {
+	ItemID string
+	Old    int
+	New    int
+}
+
+// InventoryDelta lists changes between two inventory snapshots.
+// Item moves between slots are not reported.
+type InventoryDelta struct {
+	Added   []string
+	Removed []string
+	Stacks  []StackChange
+}
+
+// IsEmpty reports whether snapshots hold the same items and stack sizes
+func (d InventoryDelta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Stacks) == 0
+}
+
+// Diff compares two snapshots by item ID.
+// Results follow slot order of the snapshot the item was found in.
+func Diff(before, after InventorySnapshot) InventoryDelta {
+	beforeStacks := before.stacksByID()
+	afterStacks := after.stacksByID()
+
+	var delta InventoryDelta
+	for i, itm := range after.slots {
+		if itm == nil {
+			continue
+		}
+		oldStack, ok := beforeStacks[itm.ID()]
+		if !ok {
+			delta.Added = append(delta.Added, itm.ID())
+			continue
+		}
+		if newStack := after.stacks[i]; newStack != oldStack {
+			delta.Stacks = append(delta.Stacks, StackChange{ItemID: itm.ID(), Old: oldStack, New: newStack})
+		}
+	}
+	for _, itm := range before.slots {
+		if itm == nil {
+			continue
+		}
+		if _, ok := afterStacks[itm.ID()]; !ok {
+			delta.Removed = append(delta.Removed, itm.ID())
+		}
+	}
+	return delta
+}
+
+func (s InventorySnapshot) stacksByID() map[string]int {
+	stacks := make(map[string]int, len(s.slots))
+	for i, itm := range s.slots {
+		if itm != nil {
+			stacks[itm.ID()] = s.stacks[i]
+		}
+	}
+	return stacks
+}
+
 // --- Persistence ---
 
+// StateSchema names inventory state for persist.DefaultMigrator migrations
+const StateSchema = "inventory.state"
+
 // State holds serializable inventory state
 type State struct {
 	ItemIDs   []string `msgpack:"item_ids"`
 	MaxSlots  int      `msgpack:"max_slots"`
 	MaxWeight float64  `msgpack:"max_weight"`
+	GridWidth int      `msgpack:"grid_width"`
+
+	LockedSlots []int    `msgpack:"locked_slots,omitempty"`
+	PinnedItems []string `msgpack:"pinned_items,omitempty"`
+
+	QuickSlots []QuickSlotBinding `msgpack:"quick_slots,omitempty"`
+}
+
+// QuickSlotBinding is persisted hotkey -> item binding
+type QuickSlotBinding struct {
+	Hotkey int    `msgpack:"hotkey"`
+	ItemID string `msgpack:"item_id"`
 }
 
 func (m *BaseManager) SerializeState() (map[string]any, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return m.serializeStateLocked()
+}
 
+func (m *BaseManager) serializeStateLocked() (map[string]any, error) {
 	itemIDs := make([]string, m.maxSlots)
 	for i, itm := range m.slots {
 		if itm != nil {
@@ -1042,6 +2014,12 @@ func (m *BaseManager) SerializeState() (map[string]any, error) {
 		ItemIDs:   itemIDs,
 		MaxSlots:  m.maxSlots,
 		MaxWeight: m.maxWeight,
+		GridWidth: m.gridWidth,
+
+		LockedSlots: m.lockedSlotsLocked(),
+		PinnedItems: m.pinnedPresentLocked(),
+
+		QuickSlots: m.quickSlotBindingsLocked(),
 	}
 
 	data, err := persist.DefaultCodec().Encode(state)
@@ -1053,22 +2031,117 @@ func (m *BaseManager) SerializeState() (map[string]any, error) {
 	if err := persist.DefaultCodec().Decode(data, &result); err != nil {
 		return nil, err
 	}
+	persist.DefaultMigrator().Stamp(StateSchema, result)
 	return result, nil
 }
 
 func (m *BaseManager) DeserializeState(stateData map[string]any) error {
-	data, err := persist.DefaultCodec().Encode(stateData)
-	if err != nil {
+	var state State
+	if err := decodeState(stateData, &state); err != nil {
 		return err
 	}
 
-	var state State
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	m.mu.Lock()
+	notify := m.restoreStateLocked(state)
+	m.mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// FullState is State with every item payload embedded, keyed by slot
+type FullState struct {
+	State `msgpack:",inline"`
+
+	Items []persist.TypedValue `msgpack:"items"` // Empty Type = empty slot
+}
+
+// SerializeFull converts state to map with items embedded, so inventory can be
+// restored without separate item store. Items must be registered persist types.
+// State and items are captured under one lock, so they always match.
+func (m *BaseManager) SerializeFull() (map[string]any, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	state, err := m.serializeStateLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]persist.TypedValue, len(m.slots))
+	for i, itm := range m.slots {
+		if itm == nil {
+			continue
+		}
+		tv, err := persist.DefaultTypeRegistry().Wrap(itm)
+		if err != nil {
+			return nil, fmt.Errorf("slot %d: %w", i, err)
+		}
+		items[i] = tv
+	}
+
+	state["items"] = items
+	return state, nil
+}
+
+// DeserializeFull restores state and items written by SerializeFull
+func (m *BaseManager) DeserializeFull(stateData map[string]any) error {
+	var state FullState
+	if err := decodeState(stateData, &state); err != nil {
 		return err
 	}
+	if len(state.Items) > state.MaxSlots {
+		return fmt.Errorf("%w: %d items for %d slots", ErrSlotOutOfRange, len(state.Items), state.MaxSlots)
+	}
+
+	items := make(map[int]item.Item, len(state.Items))
+	for slot, tv := range state.Items {
+		if tv.Type == "" {
+			continue
+		}
+		v, err := persist.DefaultTypeRegistry().Unwrap(tv)
+		if err != nil {
+			return fmt.Errorf("slot %d: %w", slot, err)
+		}
+		itm, ok := v.(item.Item)
+		if !ok {
+			return fmt.Errorf("%w: slot %d holds %T", persist.ErrTypeMismatch, slot, v)
+		}
+		items[slot] = itm
+	}
 
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	notify := m.restoreStateLocked(state.State)
+	for slot, itm := range items {
+		m.slots[slot] = itm
+		m.itemIndex[itm.ID()] = slot
+	}
+	m.recalculateWeightLocked()
+	m.mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// decodeState upgrades stateData through registered StateSchema migrations
+// and decodes it into target
+func decodeState(stateData map[string]any, target any) error {
+	migrated, err := persist.DefaultMigrator().MigrateState(StateSchema, stateData)
+	if err != nil {
+		return err
+	}
+
+	data, err := persist.DefaultCodec().Encode(migrated)
+	if err != nil {
+		return err
+	}
+	return persist.DefaultCodec().Decode(data, target)
+}
+
+// restoreStateLocked applies limits and locks from state and empties slots.
+// Returned func fires capacity callbacks if enabled by config; call it after unlock.
+func (m *BaseManager) restoreStateLocked(state State) func() {
+	oldCount, oldWeight := m.maxSlots, m.maxWeight
 
 	m.maxSlots = state.MaxSlots
 	if m.maxSlots <= 0 {
@@ -1080,11 +2153,31 @@ func (m *BaseManager) DeserializeState(stateData map[string]any) error {
 		m.maxWeight = 100.0
 	}
 
+	m.gridWidth = max(state.GridWidth, 0)
+
+	m.lockedSlots = make(map[int]struct{}, len(state.LockedSlots))
+	for _, slot := range state.LockedSlots {
+		if slot >= 0 && slot < m.maxSlots {
+			m.lockedSlots[slot] = struct{}{}
+		}
+	}
+	m.pinned = slices.Clone(state.PinnedItems)
+
+	m.quickSlots = make(map[int]string, len(state.QuickSlots))
+	for _, binding := range state.QuickSlots {
+		if binding.Hotkey >= 1 && binding.Hotkey <= MaxQuickSlots && binding.ItemID != "" {
+			m.quickSlots[binding.Hotkey] = binding.ItemID
+		}
+	}
+
 	m.slots = make([]item.Item, m.maxSlots)
 	m.itemIndex = make(map[string]int)
 	m.currentWeight = 0
 
-	return nil
+	if !m.capacityEventsOnRestore {
+		return func() {}
+	}
+	return m.capacityNotifierLocked(oldCount, oldWeight)
 }
 
 // --- Helper methods for persistence ---
@@ -1114,12 +2207,12 @@ func (m *BaseManager) AddDirect(itm item.Item) error {
 
 	slot := m.findFreeSlotLocked()
 	if slot == -1 {
-		return fmt.Errorf("no free slot")
+		return fmt.Errorf("%w: no free slot", ErrInventoryFull)
 	}
 
 	m.slots[slot] = itm
 	m.itemIndex[itm.ID()] = slot
-	m.currentWeight += m.getItemWeight(itm)
+	m.adjustWeightLocked(m.getItemWeight(itm))
 
 	return nil
 }
@@ -1134,12 +2227,12 @@ func (m *BaseManager) AddDirectToSlot(slot int, itm item.Item) error {
 	defer m.mu.Unlock()
 
 	if slot < 0 || slot >= m.maxSlots {
-		return fmt.Errorf("slot out of range")
+		return ErrSlotOutOfRange
 	}
 
 	m.slots[slot] = itm
 	m.itemIndex[itm.ID()] = slot
-	m.currentWeight += m.getItemWeight(itm)
+	m.adjustWeightLocked(m.getItemWeight(itm))
 
 	return nil
 }
@@ -1148,15 +2241,40 @@ func (m *BaseManager) AddDirectToSlot(slot int, itm item.Item) error {
 func (m *BaseManager) RecalculateWeight() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.recalculateWeightLocked()
+}
 
-	m.currentWeight = 0
+func (m *BaseManager) recalculateWeightLocked() {
+	total := 0.0
 	for _, itm := range m.slots {
 		if itm != nil {
-			m.currentWeight += m.getItemWeight(itm)
+			total += m.getItemWeight(itm)
 		}
 	}
+	m.currentWeight = roundWeight(total)
+	m.weightChanges = 0
 }
 
+// adjustWeightLocked applies weight delta rounded to weightScale, falling
+// back to full recalculation every weightResyncInterval changes so float
+// drift never builds up over long sessions
+func (m *BaseManager) adjustWeightLocked(delta float64) {
+	m.weightChanges++
+	if m.weightChanges >= weightResyncInterval {
+		m.recalculateWeightLocked()
+		return
+	}
+	m.currentWeight = roundWeight(max(m.currentWeight+delta, 0))
+}
+
+func roundWeight(weight float64) float64 {
+	return math.Round(weight*weightScale) / weightScale
+}
+
+// getItemWeight returns carried weight of whole stack (0 for weight-exempt items)
 func (m *BaseManager) getItemWeight(itm item.Item) float64 {
+	if itm.WeightExempt() {
+		return 0
+	}
 	return itm.Weight() * float64(itm.StackSize())
 }
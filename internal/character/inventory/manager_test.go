@@ -2,12 +2,19 @@ package inventory
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
 	"github.com/davidmovas/Depthborn/internal/item"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+	"github.com/davidmovas/Depthborn/internal/item/builder"
+	"github.com/davidmovas/Depthborn/internal/item/itemtest"
+	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
 func createTestItem(id, name string, weight float64) item.Item {
@@ -29,6 +36,20 @@ func createStackableItem(id, name string, weight float64, maxStack int) item.Ite
 	})
 }
 
+func createPotion(id string, stack, charges int) *item.BaseConsumable {
+	potion := item.NewBaseConsumableWithConfig(item.ConsumableConfig{
+		BaseItemConfig: item.BaseItemConfig{
+			ID:           id,
+			Name:         "Healing Potion",
+			Weight:       0.5,
+			MaxStackSize: 10,
+		},
+		Charges: charges,
+	})
+	potion.AddStack(stack - 1)
+	return potion
+}
+
 func TestManager(t *testing.T) {
 	t.Run("Creation", func(t *testing.T) {
 		t.Run("with defaults", func(t *testing.T) {
@@ -77,8 +98,7 @@ func TestManager(t *testing.T) {
 				itm := createTestItem("item-1", "Heavy Item", 60.0)
 				err := mgr.Add(ctx, itm)
 
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), "weight limit exceeded")
+				assert.ErrorIs(t, err, ErrWeightExceeded)
 				assert.Equal(t, 0, mgr.Count())
 			})
 
@@ -105,8 +125,27 @@ func TestManager(t *testing.T) {
 				_ = mgr.AddToSlot(ctx, 0, itm1)
 				err := mgr.AddToSlot(ctx, 0, itm2)
 
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), "already occupied")
+				assert.ErrorIs(t, err, ErrSlotOccupied)
+			})
+
+			t.Run("to out of range slot returns error", func(t *testing.T) {
+				ctx := context.Background()
+				mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+				err := mgr.AddToSlot(ctx, 10, createTestItem("item-1", "Test", 1.0))
+				assert.ErrorIs(t, err, ErrSlotOutOfRange)
+
+				err = mgr.AddToSlot(ctx, -1, createTestItem("item-2", "Test", 1.0))
+				assert.ErrorIs(t, err, ErrSlotOutOfRange)
+			})
+
+			t.Run("full inventory returns error", func(t *testing.T) {
+				ctx := context.Background()
+				mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 1})
+
+				require.NoError(t, mgr.Add(ctx, createTestItem("item-1", "Item 1", 1.0)))
+				err := mgr.Add(ctx, createTestItem("item-2", "Item 2", 1.0))
+				assert.ErrorIs(t, err, ErrInventoryFull)
 			})
 		})
 
@@ -130,8 +169,7 @@ func TestManager(t *testing.T) {
 				mgr := NewManager()
 
 				_, err := mgr.Remove(ctx, "nonexistent")
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), "not found")
+				assert.ErrorIs(t, err, ErrItemNotFound)
 			})
 		})
 
@@ -190,6 +228,75 @@ func TestManager(t *testing.T) {
 			assert.Equal(t, 0, mgr.Count())
 			assert.Equal(t, 0.0, mgr.CurrentWeight())
 		})
+
+		t.Run("RemoveWhere", func(t *testing.T) {
+			newItem := func(id string, rarity item.Rarity, weight float64, tags ...string) item.Item {
+				return item.NewBaseItemWithConfig(item.BaseItemConfig{
+					ID:       id,
+					Name:     id,
+					ItemType: item.TypeMaterial,
+					Weight:   weight,
+					Rarity:   rarity,
+					Tags:     tags,
+				})
+			}
+
+			t.Run("by rarity", func(t *testing.T) {
+				ctx := context.Background()
+				mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+				_ = mgr.Add(ctx, newItem("junk-1", item.RarityCommon, 2.0))
+				_ = mgr.Add(ctx, newItem("rare-1", item.RarityRare, 5.0))
+				_ = mgr.Add(ctx, newItem("junk-2", item.RarityCommon, 3.0))
+
+				var notified []string
+				mgr.OnItemRemoved(func(ctx context.Context, itm item.Item) {
+					notified = append(notified, itm.ID())
+				})
+
+				removed, err := mgr.RemoveWhere(ctx, func(i item.Item) bool {
+					return i.Rarity() == item.RarityCommon
+				})
+				require.NoError(t, err)
+
+				assert.Len(t, removed, 2)
+				assert.Equal(t, []string{"junk-1", "junk-2"}, notified)
+				assert.Equal(t, 1, mgr.Count())
+				assert.True(t, mgr.Contains("rare-1"))
+				assert.InDelta(t, 5.0, mgr.CurrentWeight(), 0.001)
+			})
+
+			t.Run("by tag", func(t *testing.T) {
+				ctx := context.Background()
+				mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+				_ = mgr.Add(ctx, newItem("letter", item.RarityCommon, 0.1, "quest"))
+				_ = mgr.Add(ctx, newItem("ore", item.RarityCommon, 4.0))
+				_ = mgr.Add(ctx, newItem("idol", item.RarityRare, 1.0, "quest", "relic"))
+
+				removed, err := mgr.RemoveWhere(ctx, func(i item.Item) bool {
+					return i.Tags().Has("quest")
+				})
+				require.NoError(t, err)
+
+				assert.Len(t, removed, 2)
+				assert.Equal(t, 1, mgr.Count())
+				assert.True(t, mgr.Contains("ore"))
+				assert.InDelta(t, 4.0, mgr.CurrentWeight(), 0.001)
+			})
+
+			t.Run("no matches and nil predicate", func(t *testing.T) {
+				ctx := context.Background()
+				mgr := NewManager()
+				_ = mgr.Add(ctx, createTestItem("item-1", "Item 1", 1.0))
+
+				removed, err := mgr.RemoveWhere(ctx, func(item.Item) bool { return false })
+				require.NoError(t, err)
+				assert.Empty(t, removed)
+				assert.Equal(t, 1, mgr.Count())
+
+				_, err = mgr.RemoveWhere(ctx, nil)
+				assert.Error(t, err)
+			})
+		})
 	})
 
 	t.Run("Slot Operations", func(t *testing.T) {
@@ -226,6 +333,60 @@ func TestManager(t *testing.T) {
 			assert.False(t, ok)
 		})
 
+		t.Run("Locked slots", func(t *testing.T) {
+			ctx := context.Background()
+			newInventory := func(t *testing.T) *BaseManager {
+				mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 6})
+				quest := item.NewBaseItemWithConfig(item.BaseItemConfig{ID: "amulet", Name: "Ancient Amulet", ItemType: item.TypeQuest})
+				require.NoError(t, mgr.AddToSlot(ctx, 0, createTestItem("c", "Copper", 1.0)))
+				require.NoError(t, mgr.AddToSlot(ctx, 2, quest))
+				require.NoError(t, mgr.AddToSlot(ctx, 3, createTestItem("a", "Amber", 1.0)))
+				require.NoError(t, mgr.AddToSlot(ctx, 5, createTestItem("b", "Bone", 1.0)))
+				require.NoError(t, mgr.LockSlot(2))
+				return mgr
+			}
+
+			t.Run("sort leaves locked quest item in place", func(t *testing.T) {
+				mgr := newInventory(t)
+
+				mgr.Sort(SortByName, true)
+
+				assert.Equal(t, []string{"a", "b", "amulet", "c", "", ""}, mgr.GetItemIDs())
+				itm, ok := mgr.GetAtSlot(2)
+				require.True(t, ok)
+				assert.Equal(t, "amulet", itm.ID())
+				got, _ := mgr.Get("c")
+				assert.Equal(t, "Copper", got.Name())
+			})
+
+			t.Run("compact skips locked slots", func(t *testing.T) {
+				mgr := newInventory(t)
+				require.NoError(t, mgr.LockSlot(1))
+
+				mgr.Compact()
+
+				assert.Equal(t, []string{"c", "", "amulet", "a", "b", ""}, mgr.GetItemIDs())
+			})
+
+			t.Run("lock bookkeeping", func(t *testing.T) {
+				mgr := newInventory(t)
+				assert.ErrorIs(t, mgr.LockSlot(6), ErrSlotOutOfRange)
+				require.NoError(t, mgr.LockSlot(4))
+				assert.Equal(t, []int{2, 4}, mgr.LockedSlots())
+
+				state, err := mgr.SerializeState()
+				require.NoError(t, err)
+				restored := NewManager()
+				require.NoError(t, restored.DeserializeState(state))
+				assert.True(t, restored.IsSlotLocked(4))
+
+				mgr.UnlockSlot(2)
+				assert.False(t, mgr.IsSlotLocked(2))
+				mgr.Sort(SortByName, true)
+				assert.Equal(t, []string{"a", "amulet", "b", "c", "", ""}, mgr.GetItemIDs())
+			})
+		})
+
 		t.Run("SetSlotCount", func(t *testing.T) {
 			t.Run("expand", func(t *testing.T) {
 				mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
@@ -243,6 +404,73 @@ func TestManager(t *testing.T) {
 		})
 	})
 
+	t.Run("Grid Addressing", func(t *testing.T) {
+		t.Run("coordinate math with partial last row", func(t *testing.T) {
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100, GridWidth: 4})
+
+			slot, ok := mgr.SlotAt(0, 0)
+			assert.True(t, ok)
+			assert.Equal(t, 0, slot)
+
+			slot, ok = mgr.SlotAt(1, 3)
+			assert.True(t, ok)
+			assert.Equal(t, 7, slot)
+
+			slot, ok = mgr.SlotAt(2, 1)
+			assert.True(t, ok)
+			assert.Equal(t, 9, slot)
+
+			_, ok = mgr.SlotAt(2, 2)
+			assert.False(t, ok, "past last slot in partial row")
+			_, ok = mgr.SlotAt(0, 4)
+			assert.False(t, ok, "column beyond width")
+			_, ok = mgr.SlotAt(-1, 0)
+			assert.False(t, ok)
+
+			row, col := mgr.CoordOf(9)
+			assert.Equal(t, 2, row)
+			assert.Equal(t, 1, col)
+
+			row, col = mgr.CoordOf(10)
+			assert.Equal(t, -1, row)
+			assert.Equal(t, -1, col)
+
+			for s := 0; s < mgr.SlotCount(); s++ {
+				r, c := mgr.CoordOf(s)
+				back, ok := mgr.SlotAt(r, c)
+				require.True(t, ok)
+				assert.Equal(t, s, back)
+			}
+		})
+
+		t.Run("unset width is a single row", func(t *testing.T) {
+			mgr := NewManagerWithConfig(Config{MaxSlots: 5, MaxWeight: 100})
+
+			assert.Equal(t, 5, mgr.GridWidth())
+			slot, ok := mgr.SlotAt(0, 4)
+			assert.True(t, ok)
+			assert.Equal(t, 4, slot)
+			_, ok = mgr.SlotAt(1, 0)
+			assert.False(t, ok)
+		})
+
+		t.Run("AddToCoord", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 6, MaxWeight: 100, GridWidth: 3})
+
+			require.NoError(t, mgr.AddToCoord(ctx, 1, 2, createTestItem("item-1", "Item 1", 1.0)))
+			found, ok := mgr.GetAtSlot(5)
+			require.True(t, ok)
+			assert.Equal(t, "item-1", found.ID())
+
+			err := mgr.AddToCoord(ctx, 1, 2, createTestItem("item-2", "Item 2", 1.0))
+			assert.ErrorIs(t, err, ErrSlotOccupied)
+
+			err = mgr.AddToCoord(ctx, 2, 0, createTestItem("item-2", "Item 2", 1.0))
+			assert.ErrorIs(t, err, ErrSlotOutOfRange)
+		})
+	})
+
 	t.Run("Weight Management", func(t *testing.T) {
 		t.Run("tracks weight correctly", func(t *testing.T) {
 			ctx := context.Background()
@@ -269,6 +497,66 @@ func TestManager(t *testing.T) {
 			mgr.SetMaxWeight(-50)
 			assert.Equal(t, 200.0, mgr.MaxWeight())
 		})
+
+		t.Run("weight-exempt items are not counted", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+			require.NoError(t, mgr.Add(ctx, createTestItem("item-1", "Test", 95.0)))
+
+			relic := item.NewBaseItemWithConfig(item.BaseItemConfig{
+				ID:       "relic-1",
+				Name:     "Ancient Relic",
+				ItemType: item.TypeQuest,
+				Weight:   50.0,
+				Tags:     []string{item.TagWeightExempt},
+			})
+
+			assert.True(t, mgr.CanAdd(relic))
+			require.NoError(t, mgr.Add(ctx, relic))
+			assert.Equal(t, 95.0, mgr.CurrentWeight())
+			assert.Equal(t, 5.0, mgr.AvailableWeight())
+
+			mgr.RecalculateWeight()
+			assert.Equal(t, 95.0, mgr.CurrentWeight())
+
+			_, err := mgr.Remove(ctx, "relic-1")
+			require.NoError(t, err)
+			assert.Equal(t, 95.0, mgr.CurrentWeight())
+		})
+
+		t.Run("no float drift over many changes", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 1000, MaxSlots: 40})
+
+			// Keep 0.1-weight ore stack resident so removals subtract from non-zero totals
+			ore := createStackableItem("ore", "Ore", 0.1, 1000)
+			ore.AddStack(299)
+			require.NoError(t, mgr.Add(ctx, ore))
+
+			weights := []float64{0.1, 0.2, 0.3, 0.7, 1.1, 2.35}
+			for i := range 5000 {
+				id := fmt.Sprintf("junk-%d", i%20)
+				if mgr.Contains(id) {
+					_, err := mgr.Remove(ctx, id)
+					require.NoError(t, err)
+				} else {
+					require.NoError(t, mgr.Add(ctx, createTestItem(id, "Junk", weights[i%len(weights)])))
+				}
+				if i%7 == 0 {
+					_, err := mgr.RemoveAmount(ctx, "ore", 1)
+					require.NoError(t, err)
+					require.NoError(t, mgr.Add(ctx, createStackableItem(fmt.Sprintf("ore-%d", i), "Ore", 0.1, 1000)))
+				}
+			}
+
+			for i := range 20 {
+				_, _ = mgr.Remove(ctx, fmt.Sprintf("junk-%d", i))
+			}
+
+			assert.Equal(t, 30.0, mgr.CurrentWeight())
+			assert.Equal(t, 970.0, mgr.AvailableWeight())
+			assert.Equal(t, 0.03, mgr.WeightPercent())
+		})
 	})
 
 	t.Run("Capacity Checks", func(t *testing.T) {
@@ -286,6 +574,48 @@ func TestManager(t *testing.T) {
 			assert.False(t, mgr.CanAdd(heavyItem))
 		})
 
+		t.Run("HowManyCanFit", func(t *testing.T) {
+			newInventory := func(maxWeight float64) *BaseManager {
+				ctx := context.Background()
+				mgr := NewManagerWithConfig(Config{MaxWeight: maxWeight, MaxSlots: 3})
+
+				partial := createStackableItem("ore-1", "Iron Ore", 1.0, 10)
+				partial.AddStack(3)
+				require.NoError(t, mgr.Add(ctx, partial))
+				require.NoError(t, mgr.Add(ctx, item.NewBaseItemWithConfig(item.BaseItemConfig{
+					ID:       "sword",
+					Name:     "Sword",
+					ItemType: item.TypeWeaponMelee,
+					Weight:   10.0,
+				})))
+				return mgr
+			}
+
+			t.Run("partial stack plus free slots", func(t *testing.T) {
+				mgr := newInventory(100)
+				probe := createStackableItem("ore-2", "Iron Ore", 1.0, 10)
+
+				// 6 left in partial stack + 1 free slot * 10
+				assert.Equal(t, 16, mgr.HowManyCanFit(probe))
+			})
+
+			t.Run("weight limit binds", func(t *testing.T) {
+				mgr := newInventory(20)
+				probe := createStackableItem("ore-2", "Iron Ore", 1.0, 10)
+
+				// 20 - 4 (ore) - 10 (sword) = 6 units of weight left
+				assert.Equal(t, 6, mgr.HowManyCanFit(probe))
+			})
+
+			t.Run("nothing fits", func(t *testing.T) {
+				mgr := newInventory(14)
+				probe := createStackableItem("ore-2", "Iron Ore", 1.0, 10)
+
+				assert.Equal(t, 0, mgr.HowManyCanFit(probe))
+				assert.Equal(t, 0, mgr.HowManyCanFit(nil))
+			})
+		})
+
 		t.Run("Contains", func(t *testing.T) {
 			ctx := context.Background()
 			mgr := NewManager()
@@ -311,6 +641,77 @@ func TestManager(t *testing.T) {
 		})
 	})
 
+	t.Run("Usage", func(t *testing.T) {
+		t.Run("single use removes item", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 1, 1)))
+
+			var removed []string
+			mgr.OnItemRemoved(func(ctx context.Context, itm item.Item) {
+				removed = append(removed, itm.ID())
+			})
+
+			require.NoError(t, mgr.UseItem(ctx, "potion", nil))
+
+			assert.False(t, mgr.Contains("potion"))
+			assert.Equal(t, []string{"potion"}, removed)
+			assert.InDelta(t, 0.0, mgr.CurrentWeight(), 0.001)
+		})
+
+		t.Run("multi stack decrements", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			potion := createPotion("potion", 3, 1)
+			require.NoError(t, mgr.Add(ctx, potion))
+
+			changed := 0
+			mgr.OnItemChanged(func(ctx context.Context, itm item.Item) { changed++ })
+
+			require.NoError(t, mgr.UseItem(ctx, "potion", nil))
+
+			assert.True(t, mgr.Contains("potion"))
+			assert.Equal(t, 2, potion.StackSize())
+			assert.Equal(t, 1, changed)
+			assert.InDelta(t, 1.0, mgr.CurrentWeight(), 0.001)
+		})
+
+		t.Run("charges spent before stack", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			flask := createPotion("flask", 1, 2)
+			require.NoError(t, mgr.Add(ctx, flask))
+
+			require.NoError(t, mgr.UseItem(ctx, "flask", nil))
+			assert.Equal(t, 1, flask.Charges())
+			assert.True(t, mgr.Contains("flask"))
+
+			require.NoError(t, mgr.UseItem(ctx, "flask", nil))
+			assert.False(t, mgr.Contains("flask"))
+		})
+
+		t.Run("depleted stack is removed", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 2, 1)))
+
+			require.NoError(t, mgr.UseItem(ctx, "potion", nil))
+			require.NoError(t, mgr.UseItem(ctx, "potion", nil))
+
+			assert.False(t, mgr.Contains("potion"))
+			assert.ErrorIs(t, mgr.UseItem(ctx, "potion", nil), ErrItemNotFound)
+		})
+
+		t.Run("non-consumable returns error", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.Add(ctx, createTestItem("ore", "Iron Ore", 1.0)))
+
+			assert.ErrorIs(t, mgr.UseItem(ctx, "ore", nil), ErrNotConsumable)
+			assert.True(t, mgr.Contains("ore"))
+		})
+	})
+
 	t.Run("Stack Operations", func(t *testing.T) {
 		t.Run("auto-stacking on add", func(t *testing.T) {
 			ctx := context.Background()
@@ -328,6 +729,71 @@ func TestManager(t *testing.T) {
 			assert.Equal(t, 2, found.StackSize())
 		})
 
+		t.Run("overflow into new slot", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+			partial := createStackableItem("ore-1", "Iron Ore", 1.5, 10)
+			partial.AddStack(7) // Stack of 8
+			require.NoError(t, mgr.Add(ctx, partial))
+
+			var changed, added int
+			mgr.OnItemChanged(func(ctx context.Context, itm item.Item) { changed++ })
+			mgr.OnItemAdded(func(ctx context.Context, itm item.Item) { added++ })
+
+			incoming := createStackableItem("ore-2", "Iron Ore", 1.5, 10)
+			incoming.AddStack(6) // Stack of 7
+			require.NoError(t, mgr.Add(ctx, incoming))
+
+			assert.Equal(t, 2, mgr.Count())
+			assert.Equal(t, 10, partial.StackSize())
+			assert.Equal(t, 5, incoming.StackSize())
+			assert.Equal(t, 1, changed)
+			assert.Equal(t, 1, added)
+
+			var expected float64
+			for _, itm := range mgr.GetAll() {
+				expected += itm.Weight() * float64(itm.StackSize())
+			}
+			assert.InDelta(t, 22.5, expected, 1e-9)
+			assert.InDelta(t, expected, mgr.CurrentWeight(), 1e-9)
+		})
+
+		t.Run("overflow without free slot changes nothing", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 1})
+
+			partial := createStackableItem("ore-1", "Iron Ore", 1.0, 10)
+			partial.AddStack(7) // Stack of 8
+			require.NoError(t, mgr.Add(ctx, partial))
+
+			incoming := createStackableItem("ore-2", "Iron Ore", 1.0, 10)
+			incoming.AddStack(4) // Stack of 5
+			err := mgr.Add(ctx, incoming)
+
+			require.ErrorIs(t, err, ErrInventoryFull)
+			assert.Equal(t, 8, partial.StackSize())
+			assert.Equal(t, 5, incoming.StackSize())
+			assert.InDelta(t, 8.0, mgr.CurrentWeight(), 1e-9)
+		})
+
+		t.Run("merge respects weight limit", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 10, MaxSlots: 10})
+
+			partial := createStackableItem("ore-1", "Iron Ore", 1.0, 10)
+			partial.AddStack(5) // Stack of 6
+			require.NoError(t, mgr.Add(ctx, partial))
+
+			incoming := createStackableItem("ore-2", "Iron Ore", 1.0, 10)
+			incoming.AddStack(4) // Stack of 5
+			err := mgr.Add(ctx, incoming)
+
+			require.ErrorIs(t, err, ErrWeightExceeded)
+			assert.Equal(t, 6, partial.StackSize())
+			assert.InDelta(t, 6.0, mgr.CurrentWeight(), 1e-9)
+		})
+
 		t.Run("SplitStack", func(t *testing.T) {
 			ctx := context.Background()
 			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
@@ -382,6 +848,120 @@ func TestManager(t *testing.T) {
 			assert.Equal(t, 3, remaining.StackSize())
 		})
 
+		t.Run("ConsumeAmount", func(t *testing.T) {
+			// Three iron stacks of 8, 3 and 5 in slots 0-2 plus unrelated wood
+			setup := func(cfg Config) (*BaseManager, []item.Item) {
+				ctx := context.Background()
+				mgr := NewManagerWithConfig(cfg)
+				var stacks []item.Item
+				for i, size := range []int{8, 3, 5} {
+					ore := createStackableItem(fmt.Sprintf("iron-%d", i), "Iron", 1.0, 10)
+					ore.AddStack(size - 1)
+					require.NoError(t, mgr.AddToSlot(ctx, i, ore))
+					stacks = append(stacks, ore)
+				}
+				require.NoError(t, mgr.AddToSlot(ctx, 3, createStackableItem("wood-1", "Wood", 2.0, 10)))
+				return mgr, stacks
+			}
+
+			t.Run("spans three stacks smallest first", func(t *testing.T) {
+				ctx := context.Background()
+				mgr, stacks := setup(Config{MaxWeight: 100, MaxSlots: 10})
+				var removed, changed []string
+				mgr.OnItemRemoved(func(ctx context.Context, itm item.Item) { removed = append(removed, itm.ID()) })
+				mgr.OnItemChanged(func(ctx context.Context, itm item.Item) { changed = append(changed, itm.ID()) })
+
+				consumed, err := mgr.ConsumeAmount(ctx, stacks[0].StackKey(), 12)
+				require.NoError(t, err)
+
+				assert.Equal(t, 12, consumed)
+				assert.Equal(t, []string{"iron-1", "iron-2"}, removed)
+				assert.Equal(t, []string{"iron-0"}, changed)
+				assert.Equal(t, 4, stacks[0].StackSize())
+				assert.Equal(t, 2, mgr.Count())
+				assert.InDelta(t, 6.0, mgr.CurrentWeight(), 1e-9)
+			})
+
+			t.Run("largest first when configured", func(t *testing.T) {
+				ctx := context.Background()
+				mgr, stacks := setup(Config{MaxWeight: 100, MaxSlots: 10, ConsumeLargestFirst: true})
+
+				consumed, err := mgr.ConsumeAmount(ctx, stacks[0].StackKey(), 10)
+				require.NoError(t, err)
+
+				assert.Equal(t, 10, consumed)
+				assert.False(t, mgr.Contains("iron-0"))
+				assert.Equal(t, 3, stacks[1].StackSize())
+				assert.Equal(t, 3, stacks[2].StackSize())
+				assert.InDelta(t, 8.0, mgr.CurrentWeight(), 1e-9)
+			})
+
+			t.Run("insufficient total drains everything", func(t *testing.T) {
+				ctx := context.Background()
+				mgr, stacks := setup(Config{MaxWeight: 100, MaxSlots: 10})
+
+				consumed, err := mgr.ConsumeAmount(ctx, stacks[0].StackKey(), 50)
+				require.NoError(t, err)
+
+				assert.Equal(t, 16, consumed)
+				assert.Equal(t, 1, mgr.Count())
+				assert.True(t, mgr.Contains("wood-1"))
+				assert.InDelta(t, 2.0, mgr.CurrentWeight(), 1e-9)
+			})
+
+			t.Run("unknown key and invalid amount", func(t *testing.T) {
+				ctx := context.Background()
+				mgr, stacks := setup(Config{MaxWeight: 100, MaxSlots: 10})
+
+				consumed, err := mgr.ConsumeAmount(ctx, "missing", 5)
+				require.NoError(t, err)
+				assert.Zero(t, consumed)
+
+				_, err = mgr.ConsumeAmount(ctx, stacks[0].StackKey(), 0)
+				assert.Error(t, err)
+				assert.Equal(t, 4, mgr.Count())
+			})
+		})
+
+		t.Run("differently rolled equipment does not stack", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+			require.NoError(t, mgr.Add(ctx, itemtest.RolledEquipment("sword-1", 4)))
+			require.NoError(t, mgr.Add(ctx, itemtest.RolledEquipment("sword-2", 9)))
+
+			_, canStack := mgr.CanStackWith(itemtest.RolledEquipment("sword-3", 6))
+			assert.False(t, canStack)
+			assert.Equal(t, 2, mgr.Count())
+			assert.Error(t, mgr.MergeStacks(ctx, "sword-2", "sword-1"))
+		})
+
+		t.Run("rolled equipment never stacks", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+			// Same name, different rolls
+			flaming1 := itemtest.RolledEquipment("sword-1", 4)
+			flaming1.SetName("Flaming Sword")
+			flaming2 := itemtest.RolledEquipment("sword-2", 7)
+			flaming2.SetName("Flaming Sword")
+			// Identical rolls are still unique items
+			twin := itemtest.RolledEquipment("sword-3", 4)
+			twin.SetName("Flaming Sword")
+
+			require.NoError(t, mgr.Add(ctx, flaming1))
+			_, canStack := mgr.CanStackWith(flaming2)
+			assert.False(t, canStack)
+			_, canStack = mgr.CanStackWith(twin)
+			assert.False(t, canStack)
+
+			require.NoError(t, mgr.Add(ctx, flaming2))
+			require.NoError(t, mgr.Add(ctx, twin))
+			assert.Equal(t, 3, mgr.Count())
+			assert.Equal(t, 1, flaming1.StackSize())
+			assert.Equal(t, 1, flaming1.MaxStackSize())
+		})
+
 		t.Run("CanStackWith", func(t *testing.T) {
 			ctx := context.Background()
 			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
@@ -559,6 +1139,166 @@ func TestManager(t *testing.T) {
 			assert.Equal(t, 20.0, sorted[1].Weight())
 			assert.Equal(t, 50.0, sorted[2].Weight())
 		})
+
+		t.Run("pinned items lead sorted output", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+			require.NoError(t, mgr.Add(ctx, createTestItem("heavy", "Heavy", 50.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("light", "Light", 5.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("medium", "Medium", 20.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("anvil", "Anvil", 10.0)))
+
+			require.NoError(t, mgr.PinItem("medium"))
+			require.NoError(t, mgr.PinItem("heavy"))
+			require.NoError(t, mgr.PinItem("medium"))
+			assert.ErrorIs(t, mgr.PinItem("ghost"), ErrItemNotFound)
+			assert.True(t, mgr.IsPinned("heavy"))
+			assert.Equal(t, []string{"medium", "heavy"}, mgr.PinnedItems())
+
+			ids := func(items []item.Item) []string {
+				result := make([]string, len(items))
+				for i, itm := range items {
+					result[i] = itm.ID()
+				}
+				return result
+			}
+
+			assert.Equal(t, []string{"medium", "heavy", "light", "anvil"}, ids(mgr.GetSorted(SortByWeight, true)))
+			assert.Equal(t, []string{"medium", "heavy", "light", "anvil"}, ids(mgr.GetSorted(SortByName, false)))
+			assert.Equal(t, []string{"medium", "heavy", "anvil", "light"}, ids(mgr.GetSorted(SortByName, true)))
+
+			mgr.Sort(SortByWeight, false)
+			assert.Equal(t, []string{"medium", "heavy", "anvil", "light"}, ids(mgr.GetAll()))
+
+			mgr.UnpinItem("medium")
+			assert.False(t, mgr.IsPinned("medium"))
+			assert.Equal(t, []string{"heavy", "light", "anvil", "medium"}, ids(mgr.GetSorted(SortByWeight, true)))
+		})
+
+		t.Run("pins persist and drop removed items", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+			require.NoError(t, mgr.Add(ctx, createTestItem("a", "Alpha", 1.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("b", "Bravo", 1.0)))
+			require.NoError(t, mgr.PinItem("b"))
+			require.NoError(t, mgr.PinItem("a"))
+			_, err := mgr.Remove(ctx, "a")
+			require.NoError(t, err)
+			assert.False(t, mgr.IsPinned("a"))
+			assert.Equal(t, []string{"b"}, mgr.PinnedItems())
+
+			state, err := mgr.SerializeState()
+			require.NoError(t, err)
+
+			restored := NewManager()
+			require.NoError(t, restored.DeserializeState(state))
+			assert.Equal(t, []string{"b"}, restored.PinnedItems())
+		})
+
+		t.Run("re-added item is not pinned", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 10})
+
+			potion := createPotion("potion", 1, 1)
+			require.NoError(t, mgr.Add(ctx, potion))
+			require.NoError(t, mgr.PinItem("potion"))
+			_, err := mgr.Remove(ctx, "potion")
+			require.NoError(t, err)
+
+			require.NoError(t, mgr.Add(ctx, potion))
+			assert.False(t, mgr.IsPinned("potion"))
+			assert.Empty(t, mgr.PinnedItems())
+		})
+	})
+
+	t.Run("Quick Slots", func(t *testing.T) {
+		t.Run("binding", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 2, 1)))
+
+			require.NoError(t, mgr.SetQuickSlot(1, "potion"))
+			assert.ErrorIs(t, mgr.SetQuickSlot(0, "potion"), ErrQuickSlotOutOfRange)
+			assert.ErrorIs(t, mgr.SetQuickSlot(MaxQuickSlots+1, "potion"), ErrQuickSlotOutOfRange)
+			assert.ErrorIs(t, mgr.SetQuickSlot(2, "ghost"), ErrItemNotFound)
+
+			bound, ok := mgr.QuickSlotItem(1)
+			require.True(t, ok)
+			assert.Equal(t, "potion", bound.ID())
+			assert.Equal(t, map[int]string{1: "potion"}, mgr.View().QuickSlots())
+
+			require.NoError(t, mgr.SetQuickSlot(1, ""))
+			_, ok = mgr.QuickSlotItem(1)
+			assert.False(t, ok)
+		})
+
+		t.Run("using clears binding once depleted", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 2, 1)))
+			require.NoError(t, mgr.SetQuickSlot(3, "potion"))
+
+			var cleared []string
+			mgr.OnQuickSlotCleared(func(hotkey int, itemID string) {
+				cleared = append(cleared, fmt.Sprintf("%d:%s", hotkey, itemID))
+			})
+
+			require.NoError(t, mgr.UseQuickSlot(ctx, 3, nil))
+			_, ok := mgr.QuickSlotItem(3)
+			assert.True(t, ok)
+			assert.Empty(t, cleared)
+
+			require.NoError(t, mgr.UseQuickSlot(ctx, 3, nil))
+			assert.False(t, mgr.Contains("potion"))
+			assert.Equal(t, []string{"3:potion"}, cleared)
+			assert.ErrorIs(t, mgr.UseQuickSlot(ctx, 3, nil), ErrItemNotFound)
+		})
+
+		t.Run("removal clears binding", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.Add(ctx, createTestItem("sword", "Sword", 1.0)))
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 1, 1)))
+			require.NoError(t, mgr.SetQuickSlot(1, "sword"))
+			require.NoError(t, mgr.SetQuickSlot(2, "potion"))
+			require.NoError(t, mgr.SetQuickSlot(5, "potion"))
+
+			var cleared []int
+			mgr.OnQuickSlotCleared(func(hotkey int, itemID string) {
+				cleared = append(cleared, hotkey)
+			})
+
+			_, err := mgr.Remove(ctx, "potion")
+			require.NoError(t, err)
+			assert.Equal(t, []int{2, 5}, cleared)
+			assert.Equal(t, map[int]string{1: "sword"}, mgr.QuickSlots())
+
+			mgr.Clear(ctx)
+			assert.Equal(t, []int{2, 5, 1}, cleared)
+			assert.Empty(t, mgr.QuickSlots())
+		})
+
+		t.Run("bindings persist", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			sword := createTestItem("sword", "Sword", 1.0)
+			require.NoError(t, mgr.Add(ctx, sword))
+			require.NoError(t, mgr.SetQuickSlot(4, "sword"))
+
+			state, err := mgr.SerializeState()
+			require.NoError(t, err)
+
+			restored := NewManager()
+			require.NoError(t, restored.DeserializeState(state))
+			assert.Equal(t, map[int]string{4: "sword"}, restored.QuickSlots())
+
+			require.NoError(t, restored.AddDirect(sword))
+			bound, ok := restored.QuickSlotItem(4)
+			require.True(t, ok)
+			assert.Equal(t, "sword", bound.ID())
+		})
 	})
 
 	t.Run("Stats", func(t *testing.T) {
@@ -587,6 +1327,20 @@ func TestManager(t *testing.T) {
 			assert.Equal(t, int64(300), mgr.TotalValue())
 		})
 
+		t.Run("TotalValue with EffectiveValue", func(t *testing.T) {
+			ctx := context.Background()
+			equip := itemtest.RolledEquipment("sword-1", 10)
+			equip.SetValue(100)
+
+			plain := NewManager()
+			require.NoError(t, plain.Add(ctx, equip))
+			assert.Equal(t, int64(100), plain.TotalValue())
+
+			effective := NewManagerWithConfig(Config{EffectiveValue: true})
+			require.NoError(t, effective.Add(ctx, equip.Clone().(item.Item)))
+			assert.Equal(t, int64(120), effective.TotalValue())
+		})
+
 		t.Run("SlotPercent", func(t *testing.T) {
 			ctx := context.Background()
 			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
@@ -598,6 +1352,42 @@ func TestManager(t *testing.T) {
 		})
 	})
 
+	t.Run("View", func(t *testing.T) {
+		t.Run("reflects live changes", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			view := mgr.View()
+
+			assert.Zero(t, view.Count())
+
+			require.NoError(t, mgr.Add(ctx, createTestItem("item-1", "Iron Ore", 10.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("item-2", "Copper Ore", 5.0)))
+
+			assert.Equal(t, 2, view.Count())
+			assert.True(t, view.Contains("item-1"))
+			assert.Equal(t, 15.0, view.CurrentWeight())
+			assert.Len(t, view.Search("ore"), 2)
+
+			_, err := mgr.Remove(ctx, "item-1")
+			require.NoError(t, err)
+			assert.False(t, view.Contains("item-1"))
+			assert.Equal(t, 0.1, view.SlotPercent())
+		})
+
+		t.Run("has no mutating methods", func(t *testing.T) {
+			viewType := reflect.TypeOf(NewManager().View())
+			for _, name := range []string{
+				"Add", "AddToSlot", "Remove", "RemoveAmount", "Clear", "RemoveWhere",
+				"SplitStack", "MergeStacks", "ConsumeAmount", "UseItem",
+				"SetSlotCount", "SwapSlots", "MoveToSlot", "LockSlot", "UnlockSlot",
+				"SetMaxWeight", "Sort", "Compact", "Restore", "DeserializeState",
+			} {
+				_, ok := viewType.MethodByName(name)
+				assert.False(t, ok, "view exposes %s", name)
+			}
+		})
+	})
+
 	t.Run("Callbacks", func(t *testing.T) {
 		t.Run("OnItemAdded and OnItemRemoved", func(t *testing.T) {
 			ctx := context.Background()
@@ -621,6 +1411,183 @@ func TestManager(t *testing.T) {
 			_, _ = mgr.Remove(ctx, "item-1")
 			assert.Equal(t, []string{"item-1"}, removedItems)
 		})
+
+		t.Run("capacity changes", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+
+			var slotChanges [][2]int
+			var weightChanges [][2]float64
+			mgr.OnSlotCountChanged(func(oldCount, newCount int) {
+				slotChanges = append(slotChanges, [2]int{oldCount, newCount})
+			})
+			mgr.OnMaxWeightChanged(func(oldWeight, newWeight float64) {
+				weightChanges = append(weightChanges, [2]float64{oldWeight, newWeight})
+			})
+
+			mgr.SetSlotCount(16)
+			mgr.SetSlotCount(16)
+			mgr.SetMaxWeight(150)
+			mgr.SetMaxWeight(150)
+			mgr.SetMaxWeight(-1)
+			assert.Equal(t, [][2]int{{10, 16}}, slotChanges)
+			assert.Equal(t, [][2]float64{{100, 150}}, weightChanges)
+
+			// Shrink blocked by item in trailing slot changes nothing
+			require.NoError(t, mgr.AddToSlot(ctx, 15, createTestItem("item-1", "Test", 1)))
+			mgr.SetSlotCount(12)
+			assert.Len(t, slotChanges, 1)
+		})
+
+		t.Run("capacity changes on deserialize", func(t *testing.T) {
+			source := NewManagerWithConfig(Config{MaxSlots: 30, MaxWeight: 250})
+			state, err := source.SerializeState()
+			require.NoError(t, err)
+
+			silent := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			fired := 0
+			silent.OnSlotCountChanged(func(int, int) { fired++ })
+			silent.OnMaxWeightChanged(func(float64, float64) { fired++ })
+			require.NoError(t, silent.DeserializeState(state))
+			assert.Zero(t, fired)
+			assert.Equal(t, 30, silent.SlotCount())
+
+			notified := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100, CapacityEventsOnRestore: true})
+			var slotChanges [][2]int
+			var weightChanges [][2]float64
+			notified.OnSlotCountChanged(func(oldCount, newCount int) {
+				slotChanges = append(slotChanges, [2]int{oldCount, newCount})
+			})
+			notified.OnMaxWeightChanged(func(oldWeight, newWeight float64) {
+				weightChanges = append(weightChanges, [2]float64{oldWeight, newWeight})
+			})
+			require.NoError(t, notified.DeserializeState(state))
+			assert.Equal(t, [][2]int{{10, 30}}, slotChanges)
+			assert.Equal(t, [][2]float64{{100, 250}}, weightChanges)
+
+			// Same capacity restored again fires nothing
+			require.NoError(t, notified.DeserializeState(state))
+			assert.Len(t, slotChanges, 1)
+			assert.Len(t, weightChanges, 1)
+		})
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		t.Run("speculative changes are reverted", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 5, MaxWeight: 100})
+
+			ore := createStackableItem("ore", "Iron Ore", 1.0, 20)
+			ore.AddStack(4)
+			require.NoError(t, mgr.AddToSlot(ctx, 2, ore))
+			require.NoError(t, mgr.Add(ctx, createTestItem("sword", "Sword", 10.0)))
+
+			snapshot := mgr.Snapshot()
+			beforeWeight := mgr.CurrentWeight()
+			beforeIDs := mgr.GetItemIDs()
+
+			events := 0
+			mgr.OnItemAdded(func(ctx context.Context, itm item.Item) { events++ })
+			mgr.OnItemRemoved(func(ctx context.Context, itm item.Item) { events++ })
+
+			// Merge into existing stack, add new items, remove one
+			more := createStackableItem("ore-2", "Iron Ore", 1.0, 20)
+			more.AddStack(2)
+			require.NoError(t, mgr.Add(ctx, more))
+			require.NoError(t, mgr.Add(ctx, createTestItem("shield", "Shield", 15.0)))
+			_, err := mgr.Remove(ctx, "sword")
+			require.NoError(t, err)
+			mgr.SetSlotCount(8)
+			require.Equal(t, 8, ore.StackSize())
+
+			events = 0
+			mgr.Restore(snapshot)
+
+			assert.Zero(t, events)
+			assert.Equal(t, beforeIDs, mgr.GetItemIDs())
+			assert.Equal(t, beforeWeight, mgr.CurrentWeight())
+			assert.Equal(t, 5, mgr.SlotCount())
+			assert.Equal(t, 5, ore.StackSize())
+			assert.True(t, mgr.Contains("sword"))
+			assert.False(t, mgr.Contains("shield"))
+
+			itm, ok := mgr.GetAtSlot(2)
+			require.True(t, ok)
+			assert.Same(t, ore, itm)
+
+			// Index is rebuilt, so normal operations keep working
+			_, err = mgr.Remove(ctx, "sword")
+			require.NoError(t, err)
+			assert.Equal(t, 5.0, mgr.CurrentWeight())
+		})
+
+		t.Run("restore drops locks beyond snapshot slots", func(t *testing.T) {
+			mgr := NewManagerWithConfig(Config{MaxSlots: 5, MaxWeight: 100})
+			require.NoError(t, mgr.LockSlot(1))
+			snapshot := mgr.Snapshot()
+
+			mgr.SetSlotCount(8)
+			require.NoError(t, mgr.LockSlot(6))
+			mgr.Restore(snapshot)
+
+			assert.Equal(t, []int{1}, mgr.LockedSlots())
+			assert.False(t, mgr.IsSlotLocked(6))
+
+			// Growing again does not bring stale lock back
+			mgr.SetSlotCount(8)
+			assert.False(t, mgr.IsSlotLocked(6))
+		})
+
+		t.Run("snapshot can be restored more than once", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManager()
+			snapshot := mgr.Snapshot()
+
+			for i := 0; i < 2; i++ {
+				require.NoError(t, mgr.Add(ctx, createTestItem("item-1", "Item", 5.0)))
+				mgr.Restore(snapshot)
+				assert.Equal(t, 0, mgr.UsedSlots())
+				assert.Equal(t, 0.0, mgr.CurrentWeight())
+			}
+		})
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		t.Run("added, removed and stack changes", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+
+			ore := createStackableItem("ore", "Iron Ore", 1.0, 20)
+			ore.AddStack(4)
+			require.NoError(t, mgr.Add(ctx, ore))
+			require.NoError(t, mgr.Add(ctx, createTestItem("sword", "Sword", 10.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("helmet", "Helmet", 5.0)))
+
+			before := mgr.Snapshot()
+
+			_, err := mgr.Remove(ctx, "sword")
+			require.NoError(t, err)
+			require.NoError(t, mgr.Add(ctx, createTestItem("shield", "Shield", 15.0)))
+			ore.AddStack(3)
+
+			delta := Diff(before, mgr.Snapshot())
+
+			assert.False(t, delta.IsEmpty())
+			assert.Equal(t, []string{"shield"}, delta.Added)
+			assert.Equal(t, []string{"sword"}, delta.Removed)
+			assert.Equal(t, []StackChange{{ItemID: "ore", Old: 5, New: 8}}, delta.Stacks)
+		})
+
+		t.Run("moved item is not a change", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxSlots: 10, MaxWeight: 100})
+			require.NoError(t, mgr.AddToSlot(ctx, 0, createTestItem("sword", "Sword", 10.0)))
+
+			before := mgr.Snapshot()
+			require.NoError(t, mgr.MoveToSlot(ctx, "sword", 4))
+
+			assert.True(t, Diff(before, mgr.Snapshot()).IsEmpty())
+		})
 	})
 
 	t.Run("Persistence", func(t *testing.T) {
@@ -681,6 +1648,232 @@ func TestManager(t *testing.T) {
 
 			assert.Equal(t, 150.0, newMgr.MaxWeight())
 			assert.Equal(t, 25, newMgr.SlotCount())
+			assert.Equal(t, 25, newMgr.GridWidth())
+		})
+
+		t.Run("Serialization is schema-tagged", func(t *testing.T) {
+			mgr := NewManagerWithConfig(Config{MaxWeight: 80, MaxSlots: 10})
+
+			state, err := mgr.SerializeState()
+			require.NoError(t, err)
+			assert.Contains(t, state, persist.SchemaVersionKey)
+
+			// Saves written before tagging load as version 0
+			delete(state, persist.SchemaVersionKey)
+			newMgr := NewManager()
+			require.NoError(t, newMgr.DeserializeState(state))
+			assert.Equal(t, 80.0, newMgr.MaxWeight())
+
+			state[persist.SchemaVersionKey] = persist.DefaultMigrator().CurrentVersion(StateSchema) + 1
+			assert.ErrorIs(t, newMgr.DeserializeState(state), persist.ErrFutureVersion)
+		})
+
+		t.Run("Serialization keeps grid width", func(t *testing.T) {
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 20, GridWidth: 6})
+
+			state, err := mgr.SerializeState()
+			require.NoError(t, err)
+
+			newMgr := NewManager()
+			require.NoError(t, newMgr.DeserializeState(state))
+			assert.Equal(t, 6, newMgr.GridWidth())
+
+			row, col := newMgr.CoordOf(19)
+			assert.Equal(t, 3, row)
+			assert.Equal(t, 1, col)
+		})
+
+		t.Run("Full serialization embeds items", func(t *testing.T) {
+			ctx := context.Background()
+			mgr := NewManagerWithConfig(Config{MaxWeight: 100, MaxSlots: 8})
+
+			sword := item.NewEquipmentWithConfig(item.EquipmentConfig{
+				BaseItemConfig: item.BaseItemConfig{
+					ID:       "sword-1",
+					Name:     "Iron Sword",
+					ItemType: item.TypeWeaponMelee,
+					Rarity:   item.RarityRare,
+					Weight:   4.0,
+				},
+				Slot:          item.SlotMainHand,
+				MaxDurability: 80,
+			})
+			sword.DamageItem(15)
+			sharp := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+				AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+			require.NoError(t, sword.Affixes().Add(affix.NewBaseInstance(sharp, []affix.RolledModifier{{Template: sharp.Modifiers()[0], Value: 7.5}})))
+			if _, registered := affix.GlobalRegistry().Get("sharp"); !registered {
+				require.NoError(t, affix.GlobalRegistry().Register(sharp)) // loading validates rolls against registry
+			}
+
+			ore := createStackableItem("ore-1", "Iron Ore", 0.5, 50)
+			ore.AddStack(11)
+
+			require.NoError(t, mgr.AddToSlot(ctx, 2, sword))
+			require.NoError(t, mgr.AddToSlot(ctx, 5, ore))
+			require.NoError(t, mgr.LockSlot(5))
+
+			state, err := mgr.SerializeFull()
+			require.NoError(t, err)
+
+			restored := NewManager()
+			require.NoError(t, restored.DeserializeFull(state))
+
+			assert.Equal(t, 8, restored.SlotCount())
+			assert.Equal(t, []string{"", "", "sword-1", "", "", "ore-1", "", ""}, restored.GetItemIDs())
+			assert.True(t, restored.IsSlotLocked(5))
+			assert.InDelta(t, mgr.CurrentWeight(), restored.CurrentWeight(), 1e-9)
+
+			got, ok := restored.Get("sword-1")
+			require.True(t, ok)
+			gotSword, ok := got.(*item.BaseEquipment)
+			require.True(t, ok)
+			assert.Equal(t, "Iron Sword", gotSword.Name())
+			assert.Equal(t, item.RarityRare, gotSword.Rarity())
+			assert.Equal(t, 65.0, gotSword.Durability())
+			assert.Equal(t, item.SlotMainHand, gotSword.Slot())
+			require.Equal(t, 1, gotSword.Affixes().Count())
+			assert.Equal(t, sword.Affixes().GetAll()[0].RolledValues(), gotSword.Affixes().GetAll()[0].RolledValues())
+			assert.Equal(t, sword.StackKey(), gotSword.StackKey())
+
+			gotOre, ok := restored.Get("ore-1")
+			require.True(t, ok)
+			assert.Equal(t, 12, gotOre.StackSize())
+
+			// ID-only state stays free of payloads
+			idState, err := mgr.SerializeState()
+			require.NoError(t, err)
+			assert.NotContains(t, idState, "items")
+		})
+
+		t.Run("Full serialization rejects unregistered items", func(t *testing.T) {
+			mgr := NewManager()
+			require.NoError(t, mgr.AddDirect(NewBag(builder.Bag("Pouch", 2).ID("bag").Build())))
+
+			_, err := mgr.SerializeFull()
+			assert.ErrorIs(t, err, persist.ErrTypeUnknown)
+		})
+	})
+	t.Run("Containers", func(t *testing.T) {
+		ctx := context.Background()
+		newBag := func(id string, capacity int) *Bag {
+			return NewBag(builder.Bag("Pouch", capacity).ID(id).Weight(1.0).Build())
+		}
+
+		t.Run("put items into bag and read them back", func(t *testing.T) {
+			mgr := NewManager()
+			bag := newBag("bag", 4)
+			require.NoError(t, mgr.Add(ctx, bag))
+			require.NoError(t, mgr.Add(ctx, createTestItem("ore", "Iron Ore", 2.0)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("gem", "Ruby", 0.5)))
+
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "ore", "bag"))
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "gem", "bag"))
+
+			assert.False(t, mgr.Contains("ore"))
+			assert.Equal(t, 1, mgr.Count())
+			assert.True(t, bag.Contains("ore"))
+			stored, ok := bag.Inventory().Get("gem")
+			require.True(t, ok)
+			assert.Equal(t, "Ruby", stored.Name())
+			assert.Len(t, bag.Contents(), 2)
+
+			// Contents weight is carried through the bag
+			assert.InDelta(t, 3.5, mgr.CurrentWeight(), 0.001)
+
+			require.NoError(t, mgr.MoveOutOfContainer(ctx, "ore", "bag"))
+			assert.True(t, mgr.Contains("ore"))
+			assert.False(t, bag.Contains("ore"))
+			assert.InDelta(t, 3.5, mgr.CurrentWeight(), 0.001)
+		})
+
+		t.Run("moving into bag releases pins and quick slots", func(t *testing.T) {
+			mgr := NewManager()
+			require.NoError(t, mgr.Add(ctx, newBag("bag", 4)))
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 2, 1)))
+			require.NoError(t, mgr.SetQuickSlot(1, "potion"))
+			require.NoError(t, mgr.PinItem("potion"))
+
+			var cleared []string
+			mgr.OnQuickSlotCleared(func(hotkey int, itemID string) {
+				cleared = append(cleared, fmt.Sprintf("%d:%s", hotkey, itemID))
+			})
+
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "potion", "bag"))
+			assert.Empty(t, mgr.QuickSlots())
+			assert.Empty(t, mgr.PinnedItems())
+			assert.Equal(t, []string{"1:potion"}, cleared)
+		})
+
+		t.Run("weight reduction lightens parent", func(t *testing.T) {
+			mgr := NewManagerWithConfig(Config{MaxSlots: 5, MaxWeight: 10})
+			bag := newBag("bag", 4)
+			bag.SetWeightReduction(0.5)
+			require.NoError(t, mgr.Add(ctx, bag))
+			require.NoError(t, mgr.Add(ctx, createTestItem("ore", "Iron Ore", 8.0)))
+
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "ore", "bag"))
+			assert.InDelta(t, 5.0, mgr.CurrentWeight(), 0.001)
+
+			// Room for a heavy item only while ore stays in the bag
+			require.NoError(t, mgr.Add(ctx, createTestItem("anvil", "Anvil", 4.0)))
+			err := mgr.MoveOutOfContainer(ctx, "ore", "bag")
+			assert.ErrorIs(t, err, ErrWeightExceeded)
+			assert.True(t, bag.Contains("ore"))
+		})
+
+		t.Run("bag rules", func(t *testing.T) {
+			mgr := NewManager()
+			herbs := NewBag(builder.Bag("Herb Pouch", 1).ID("herbs").AllowTypes(item.TypeMaterial).Build())
+			require.NoError(t, mgr.Add(ctx, herbs))
+			require.NoError(t, mgr.Add(ctx, createTestItem("leaf", "Leaf", 0.1)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("root", "Root", 0.1)))
+			require.NoError(t, mgr.Add(ctx, createPotion("potion", 1, 1)))
+
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "potion", "herbs"), ErrNotAllowed)
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "leaf", "herbs"))
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "root", "herbs"), ErrInventoryFull)
+			assert.True(t, mgr.Contains("root"))
+
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "root", "potion"), ErrNotContainer)
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "root", "missing"), ErrItemNotFound)
+			assert.ErrorIs(t, mgr.MoveOutOfContainer(ctx, "root", "herbs"), ErrItemNotFound)
+		})
+
+		t.Run("nesting depth and cycles", func(t *testing.T) {
+			mgr := NewManager()
+			outer, middle, inner := newBag("outer", 4), newBag("middle", 4), newBag("inner", 4)
+			for _, itm := range []item.Item{outer, middle, inner, createTestItem("ore", "Iron Ore", 1.0)} {
+				require.NoError(t, mgr.Add(ctx, itm))
+			}
+
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "middle", "outer"))
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "outer", "middle"), ErrContainerCycle)
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "outer", "outer"), ErrContainerCycle)
+			assert.ErrorIs(t, mgr.MoveIntoContainer(ctx, "inner", "middle"), ErrNestingTooDeep)
+
+			// Plain items may go into nested bag
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "ore", "middle"))
+			assert.True(t, middle.Contains("ore"))
+			assert.InDelta(t, 4.0, mgr.CurrentWeight(), 0.001)
+
+			require.NoError(t, mgr.MoveOutOfContainer(ctx, "ore", "middle"))
+			assert.True(t, mgr.Contains("ore"))
+		})
+
+		t.Run("changed callback reports top-level container", func(t *testing.T) {
+			mgr := NewManager()
+			require.NoError(t, mgr.Add(ctx, newBag("bag", 2)))
+			require.NoError(t, mgr.Add(ctx, createTestItem("ore", "Iron Ore", 1.0)))
+
+			var changed []string
+			var removed int
+			mgr.OnItemChanged(func(ctx context.Context, itm item.Item) { changed = append(changed, itm.ID()) })
+			mgr.OnItemRemoved(func(ctx context.Context, itm item.Item) { removed++ })
+
+			require.NoError(t, mgr.MoveIntoContainer(ctx, "ore", "bag"))
+			assert.Equal(t, []string{"bag"}, changed)
+			assert.Zero(t, removed)
 		})
 	})
 }
@@ -0,0 +1,120 @@
+package inventory
+
+import (
+	"github.com/davidmovas/Depthborn/internal/item"
+)
+
+// ReadOnlyView exposes query, search and stat methods of a Manager.
+// It wraps the same backing store, so changes made through the manager
+// are visible immediately; it offers no way to mutate inventory.
+type ReadOnlyView struct {
+	manager Manager
+}
+
+// NewReadOnlyView wraps manager in a read-only view
+func NewReadOnlyView(manager Manager) *ReadOnlyView {
+	return &ReadOnlyView{manager: manager}
+}
+
+// --- Items ---
+
+func (v *ReadOnlyView) Get(itemID string) (item.Item, bool) { return v.manager.Get(itemID) }
+
+func (v *ReadOnlyView) GetAtSlot(slot int) (item.Item, bool) { return v.manager.GetAtSlot(slot) }
+
+func (v *ReadOnlyView) GetAll() []item.Item { return v.manager.GetAll() }
+
+func (v *ReadOnlyView) Contains(itemID string) bool { return v.manager.Contains(itemID) }
+
+func (v *ReadOnlyView) Count() int { return v.manager.Count() }
+
+func (v *ReadOnlyView) TotalItems() int { return v.manager.TotalItems() }
+
+// --- Slots ---
+
+func (v *ReadOnlyView) SlotCount() int { return v.manager.SlotCount() }
+
+func (v *ReadOnlyView) UsedSlots() int { return v.manager.UsedSlots() }
+
+func (v *ReadOnlyView) FreeSlots() int { return v.manager.FreeSlots() }
+
+func (v *ReadOnlyView) IsSlotLocked(slot int) bool { return v.manager.IsSlotLocked(slot) }
+
+func (v *ReadOnlyView) LockedSlots() []int { return v.manager.LockedSlots() }
+
+func (v *ReadOnlyView) GridWidth() int { return v.manager.GridWidth() }
+
+func (v *ReadOnlyView) SlotAt(row, col int) (int, bool) { return v.manager.SlotAt(row, col) }
+
+func (v *ReadOnlyView) CoordOf(slot int) (row, col int) { return v.manager.CoordOf(slot) }
+
+// --- Capacity ---
+
+func (v *ReadOnlyView) CurrentWeight() float64 { return v.manager.CurrentWeight() }
+
+func (v *ReadOnlyView) MaxWeight() float64 { return v.manager.MaxWeight() }
+
+func (v *ReadOnlyView) AvailableWeight() float64 { return v.manager.AvailableWeight() }
+
+func (v *ReadOnlyView) CanAdd(itm item.Item) bool { return v.manager.CanAdd(itm) }
+
+func (v *ReadOnlyView) HowManyCanFit(itm item.Item) int { return v.manager.HowManyCanFit(itm) }
+
+func (v *ReadOnlyView) CanStackWith(itm item.Item) (string, bool) { return v.manager.CanStackWith(itm) }
+
+func (v *ReadOnlyView) IsFull() bool { return v.manager.IsFull() }
+
+// --- Search & Filter ---
+
+func (v *ReadOnlyView) Search(query string) []item.Item { return v.manager.Search(query) }
+
+func (v *ReadOnlyView) FindByType(itemType item.Type) []item.Item {
+	return v.manager.FindByType(itemType)
+}
+
+func (v *ReadOnlyView) FindByRarity(rarity item.Rarity) []item.Item {
+	return v.manager.FindByRarity(rarity)
+}
+
+func (v *ReadOnlyView) FindByTag(tag string) []item.Item { return v.manager.FindByTag(tag) }
+
+func (v *ReadOnlyView) FindByTags(tags ...string) []item.Item { return v.manager.FindByTags(tags...) }
+
+func (v *ReadOnlyView) FindByAnyTag(tags ...string) []item.Item {
+	return v.manager.FindByAnyTag(tags...)
+}
+
+func (v *ReadOnlyView) FindByLevel(minLevel, maxLevel int) []item.Item {
+	return v.manager.FindByLevel(minLevel, maxLevel)
+}
+
+func (v *ReadOnlyView) FindStackable() []item.Item { return v.manager.FindStackable() }
+
+func (v *ReadOnlyView) Filter(predicate func(item.Item) bool) []item.Item {
+	return v.manager.Filter(predicate)
+}
+
+// GetSorted returns sorted copy; inventory order is left untouched
+func (v *ReadOnlyView) GetSorted(criteria SortBy, ascending bool) []item.Item {
+	return v.manager.GetSorted(criteria, ascending)
+}
+
+func (v *ReadOnlyView) IsPinned(itemID string) bool { return v.manager.IsPinned(itemID) }
+
+func (v *ReadOnlyView) PinnedItems() []string { return v.manager.PinnedItems() }
+
+func (v *ReadOnlyView) QuickSlotItem(hotkey int) (item.Item, bool) {
+	return v.manager.QuickSlotItem(hotkey)
+}
+
+func (v *ReadOnlyView) QuickSlots() map[int]string { return v.manager.QuickSlots() }
+
+// --- Stats ---
+
+func (v *ReadOnlyView) TotalValue() int64 { return v.manager.TotalValue() }
+
+func (v *ReadOnlyView) TotalWeight() float64 { return v.manager.TotalWeight() }
+
+func (v *ReadOnlyView) WeightPercent() float64 { return v.manager.WeightPercent() }
+
+func (v *ReadOnlyView) SlotPercent() float64 { return v.manager.SlotPercent() }
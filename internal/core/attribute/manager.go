@@ -92,6 +92,21 @@ func (m *BaseManager) RemoveAllModifiers(attr Type, modType ModifierType) {
 	}
 }
 
+// RemoveBySource strips every modifier from source, e.g. all affixes of unequipped item
+func (m *BaseManager) RemoveBySource(source string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := 0
+	for attr, set := range m.modifiers {
+		if n := set.RemoveBySource(source); n > 0 {
+			removed += n
+			m.markDirty(attr)
+		}
+	}
+	return removed
+}
+
 func (m *BaseManager) GetModifiers(attr Type) []Modifier {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -163,6 +178,92 @@ func (m *BaseManager) Restore(snapshot map[Type]float64) {
 	}
 }
 
+// ManagerData holds base values and modifiers of manager.
+// Formulas are code and are not captured.
+type ManagerData struct {
+	BaseValues map[Type]float64        `msgpack:"base_values,omitempty"`
+	Modifiers  map[Type][]ModifierData `msgpack:"modifiers,omitempty"`
+}
+
+// ModifierData holds modifier state
+type ModifierData struct {
+	ID       string       `msgpack:"id"`
+	Type     ModifierType `msgpack:"type"`
+	Value    float64      `msgpack:"value"`
+	Source   string       `msgpack:"source"`
+	Priority int          `msgpack:"priority"`
+	Active   bool         `msgpack:"active"`
+}
+
+// GetData captures base values and every modifier, inactive included, with
+// values at call time, e.g. to undo temporary buffs mid-combat
+func (m *BaseManager) GetData() ManagerData {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data := ManagerData{
+		BaseValues: make(map[Type]float64, len(m.baseValues)),
+		Modifiers:  make(map[Type][]ModifierData, len(m.modifiers)),
+	}
+	for attr, value := range m.baseValues {
+		data.BaseValues[attr] = value
+	}
+	for attr, set := range m.modifiers {
+		mods := set.GetAll()
+		if bs, ok := set.(*BaseSet); ok {
+			mods = bs.all()
+		}
+		if len(mods) == 0 {
+			continue
+		}
+		entries := make([]ModifierData, len(mods))
+		for i, mod := range mods {
+			entries[i] = ModifierData{
+				ID:       mod.ID(),
+				Type:     mod.Type(),
+				Value:    mod.Value(),
+				Source:   mod.Source(),
+				Priority: mod.Priority(),
+				Active:   mod.IsActive(),
+			}
+		}
+		data.Modifiers[attr] = entries
+	}
+	return data
+}
+
+// RestoreData replaces base values and modifiers with captured data.
+// Modifiers come back as BaseModifier; formulas are kept.
+func (m *BaseManager) RestoreData(data ManagerData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.baseValues = make(map[Type]float64, len(data.BaseValues))
+	for attr, value := range data.BaseValues {
+		m.baseValues[attr] = value
+	}
+
+	m.modifiers = make(map[Type]Set, len(data.Modifiers))
+	for attr, entries := range data.Modifiers {
+		set := NewSet()
+		for _, entry := range entries {
+			mod := &BaseModifier{
+				id:       entry.ID,
+				modType:  entry.Type,
+				value:    entry.Value,
+				source:   entry.Source,
+				priority: entry.Priority,
+				active:   entry.Active,
+			}
+			set.Add(mod)
+		}
+		m.modifiers[attr] = set
+	}
+
+	m.cache = make(map[Type]float64)
+	m.dirty = make(map[Type]bool)
+}
+
 func (m *BaseManager) SetFormula(attr Type, formula Formula) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
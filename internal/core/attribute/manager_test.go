@@ -0,0 +1,109 @@
+package attribute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagerRemoveBySource(t *testing.T) {
+	newManager := func() *BaseManager {
+		m := NewManager()
+		m.SetBase(AttrStrength, 10)
+		m.SetBase(AttrArmor, 100)
+
+		m.AddModifier(AttrStrength, NewModifier("sword_str", ModFlat, 5, "sword"))
+		m.AddModifier(AttrArmor, NewModifier("sword_armor", ModIncreased, 20, "sword"))
+		m.AddModifier(AttrStrength, NewModifier("ring_str", ModFlat, 3, "ring"))
+		return m
+	}
+
+	t.Run("removes only modifiers from source", func(t *testing.T) {
+		m := newManager()
+		assert.Equal(t, 18.0, m.Get(AttrStrength))
+		assert.Equal(t, 120.0, m.Get(AttrArmor))
+
+		assert.Equal(t, 2, m.RemoveBySource("sword"))
+
+		assert.Equal(t, 13.0, m.Get(AttrStrength))
+		assert.Equal(t, 100.0, m.Get(AttrArmor))
+		assert.Len(t, m.GetModifiers(AttrStrength), 1)
+		assert.Equal(t, "ring", m.GetModifiers(AttrStrength)[0].Source())
+	})
+
+	t.Run("inactive modifiers are removed too", func(t *testing.T) {
+		m := newManager()
+		inactive := NewModifier("sword_crit", ModFlat, 2, "sword")
+		inactive.(*BaseModifier).SetActive(false)
+		m.AddModifier(AttrCritChance, inactive)
+
+		assert.Equal(t, 3, m.RemoveBySource("sword"))
+	})
+
+	t.Run("unknown source removes nothing", func(t *testing.T) {
+		m := newManager()
+
+		assert.Equal(t, 0, m.RemoveBySource("helmet"))
+		assert.Equal(t, 18.0, m.Get(AttrStrength))
+	})
+}
+
+func TestManagerDataRestore(t *testing.T) {
+	t.Run("restores removed buff with its source", func(t *testing.T) {
+		m := NewManager()
+		m.SetBase(AttrStrength, 10)
+		m.SetBase(AttrArmor, 100)
+		m.AddModifier(AttrStrength, NewModifier("ring_str", ModFlat, 3, "ring"))
+		m.AddModifier(AttrStrength, NewModifierWithPriority("rage", ModMore, 50, "buff:rage", 2))
+		m.AddModifier(AttrArmor, NewModifier("rage_armor", ModFlat, -20, "buff:rage"))
+
+		strength, armor := m.Get(AttrStrength), m.Get(AttrArmor)
+		data := m.GetData()
+
+		assert.Equal(t, 2, m.RemoveBySource("buff:rage"))
+		m.SetBase(AttrStrength, 1)
+		assert.NotEqual(t, strength, m.Get(AttrStrength))
+
+		m.RestoreData(data)
+		assert.Equal(t, strength, m.Get(AttrStrength))
+		assert.Equal(t, armor, m.Get(AttrArmor))
+		assert.Equal(t, 10.0, m.GetBase(AttrStrength))
+
+		var rage Modifier
+		for _, mod := range m.GetModifiers(AttrStrength) {
+			if mod.ID() == "rage" {
+				rage = mod
+			}
+		}
+		if assert.NotNil(t, rage) {
+			assert.Equal(t, "buff:rage", rage.Source())
+			assert.Equal(t, ModMore, rage.Type())
+			assert.Equal(t, 50.0, rage.Value())
+			assert.Equal(t, 2, rage.Priority())
+		}
+
+		// Restored buff can still be removed by source
+		assert.Equal(t, 2, m.RemoveBySource("buff:rage"))
+	})
+
+	t.Run("captures values and inactive modifiers", func(t *testing.T) {
+		m := NewManager()
+		m.SetBase(AttrStrength, 10)
+		aura := NewModifier("aura", ModFlat, 5, "aura").(*BaseModifier)
+		aura.SetActive(false)
+		m.AddModifier(AttrStrength, aura)
+
+		data := m.GetData()
+		assert.Equal(t, []ModifierData{{ID: "aura", Type: ModFlat, Value: 5, Source: "aura", Active: false}},
+			data.Modifiers[AttrStrength])
+
+		// Later changes to original modifier don't leak into captured data
+		aura.SetValue(100)
+		aura.SetActive(true)
+		assert.Equal(t, 110.0, m.Get(AttrStrength))
+
+		m.RestoreData(data)
+		assert.Equal(t, 10.0, m.Get(AttrStrength))
+		assert.Empty(t, m.GetModifiers(AttrStrength))
+	})
+}
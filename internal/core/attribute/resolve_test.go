@@ -0,0 +1,86 @@
+package attribute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	t.Run("no modifiers returns base", func(t *testing.T) {
+		assert.Equal(t, 50.0, Resolve(50, nil))
+	})
+
+	t.Run("flat then increased then more", func(t *testing.T) {
+		mods := []Modifier{
+			NewModifier("more1", ModMore, 50, "skill"),
+			NewModifier("inc1", ModIncreased, 20, "passive"),
+			NewModifier("flat1", ModFlat, 10, "gear"),
+			NewModifier("inc2", ModIncreased, 30, "gear"),
+			NewModifier("more2", ModMore, 100, "support"),
+		}
+
+		// (100 + 10) * (1 + 0.5) * 1.5 * 2.0
+		assert.InDelta(t, 495.0, Resolve(100, mods), 1e-9)
+	})
+
+	t.Run("increased is additive and more is multiplicative", func(t *testing.T) {
+		increased := []Modifier{
+			NewModifier("a", ModIncreased, 50, ""),
+			NewModifier("b", ModIncreased, 50, ""),
+		}
+		more := []Modifier{
+			NewModifier("a", ModMore, 50, ""),
+			NewModifier("b", ModMore, 50, ""),
+		}
+
+		assert.InDelta(t, 200.0, Resolve(100, increased), 1e-9)
+		assert.InDelta(t, 225.0, Resolve(100, more), 1e-9)
+	})
+
+	t.Run("override takes precedence", func(t *testing.T) {
+		mods := []Modifier{
+			NewModifier("flat", ModFlat, 100, ""),
+			NewModifier("more", ModMore, 100, ""),
+			NewModifierWithPriority("low", ModOverride, 1, "", 1),
+			NewModifierWithPriority("high", ModOverride, 7, "", 10),
+		}
+
+		assert.Equal(t, 7.0, Resolve(100, mods))
+	})
+
+	t.Run("higher priority override wins regardless of order", func(t *testing.T) {
+		high := NewModifierWithPriority("high", ModOverride, 7, "", 10)
+		low := NewModifierWithPriority("low", ModOverride, 1, "", 1)
+
+		assert.Equal(t, 7.0, Resolve(100, []Modifier{high, low}))
+		assert.Equal(t, 7.0, Resolve(100, []Modifier{low, high}))
+	})
+
+	t.Run("later override wins priority tie", func(t *testing.T) {
+		first := NewModifierWithPriority("first", ModOverride, 3, "", 5)
+		second := NewModifierWithPriority("second", ModOverride, 9, "", 5)
+
+		assert.Equal(t, 9.0, Resolve(100, []Modifier{first, second}))
+	})
+
+	t.Run("inactive modifiers are ignored", func(t *testing.T) {
+		override := NewModifier("override", ModOverride, 1, "")
+		override.(*BaseModifier).SetActive(false)
+
+		mods := []Modifier{
+			override,
+			NewModifier("flat", ModFlat, 5, ""),
+		}
+
+		assert.Equal(t, 15.0, Resolve(10, mods))
+	})
+
+	t.Run("set apply matches resolve", func(t *testing.T) {
+		set := NewSet()
+		set.Add(NewModifier("flat", ModFlat, 10, ""))
+		set.Add(NewModifier("inc", ModIncreased, 100, ""))
+
+		assert.InDelta(t, 40.0, set.Apply(10), 1e-9)
+	})
+}
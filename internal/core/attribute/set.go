@@ -22,6 +22,18 @@ func (s *BaseSet) Remove(modifierID string) {
 	delete(s.modifiers, modifierID)
 }
 
+// RemoveBySource removes active and inactive modifiers from source
+func (s *BaseSet) RemoveBySource(source string) int {
+	removed := 0
+	for id, mod := range s.modifiers {
+		if mod.Source() == source {
+			delete(s.modifiers, id)
+			removed++
+		}
+	}
+	return removed
+}
+
 func (s *BaseSet) GetAll() []Modifier {
 	result := make([]Modifier, 0, len(s.modifiers))
 	for _, mod := range s.modifiers {
@@ -32,6 +44,16 @@ func (s *BaseSet) GetAll() []Modifier {
 	return result
 }
 
+// all returns active and inactive modifiers sorted by ID
+func (s *BaseSet) all() []Modifier {
+	result := make([]Modifier, 0, len(s.modifiers))
+	for _, mod := range s.modifiers {
+		result = append(result, mod)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID() < result[j].ID() })
+	return result
+}
+
 func (s *BaseSet) GetByType(modType ModifierType) []Modifier {
 	result := make([]Modifier, 0)
 	for _, mod := range s.modifiers {
@@ -47,28 +69,45 @@ func (s *BaseSet) Clear() {
 }
 
 func (s *BaseSet) Apply(baseValue float64) float64 {
-	mods := s.GetAll()
+	return Resolve(baseValue, s.GetAll())
+}
+
+// Resolve computes final value from base and modifiers.
+// Order: override -> flat -> summed increased -> each more separately.
+// Modifiers apply in ascending priority, so last override applied (highest
+// priority, later one on ties) wins. Inactive modifiers are ignored.
+func Resolve(base float64, mods []Modifier) float64 {
+	active := make([]Modifier, 0, len(mods))
+	for _, mod := range mods {
+		if mod != nil && mod.IsActive() {
+			active = append(active, mod)
+		}
+	}
 
-	sort.Slice(mods, func(i, j int) bool {
-		return mods[i].Priority() > mods[j].Priority()
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Priority() < active[j].Priority()
 	})
 
-	for _, mod := range mods {
+	var override Modifier
+	for _, mod := range active {
 		if mod.Type() == ModOverride {
-			return mod.Value()
+			override = mod
 		}
 	}
+	if override != nil {
+		return override.Value()
+	}
 
-	value := baseValue
+	value := base
 
-	for _, mod := range mods {
+	for _, mod := range active {
 		if mod.Type() == ModFlat {
 			value += mod.Value()
 		}
 	}
 
 	increasedSum := 0.0
-	for _, mod := range mods {
+	for _, mod := range active {
 		if mod.Type() == ModIncreased {
 			increasedSum += mod.Value()
 		}
@@ -77,7 +116,7 @@ func (s *BaseSet) Apply(baseValue float64) float64 {
 		value *= 1.0 + increasedSum/100.0
 	}
 
-	for _, mod := range mods {
+	for _, mod := range active {
 		if mod.Type() == ModMore {
 			value *= 1.0 + mod.Value()/100.0
 		}
@@ -64,6 +64,10 @@ type Manager interface {
 	// RemoveAllModifiers removes all modifiers of specified type
 	RemoveAllModifiers(attr Type, modType ModifierType)
 
+	// RemoveBySource removes modifiers created by source from all attributes
+	// and returns how many were removed
+	RemoveBySource(source string) int
+
 	// GetModifiers returns all modifiers for attribute
 	GetModifiers(attr Type) []Modifier
 
@@ -75,6 +79,12 @@ type Manager interface {
 
 	// Restore restores attributes from snapshot
 	Restore(snapshot map[Type]float64)
+
+	// GetData captures base values and modifiers with their sources
+	GetData() ManagerData
+
+	// RestoreData replaces base values and modifiers with captured data
+	RestoreData(data ManagerData)
 }
 
 // Modifier changes attribute value
@@ -91,7 +101,7 @@ type Modifier interface {
 	// Source returns what created this modifier (item, buff, skill, etc)
 	Source() string
 
-	// Priority returns application order (higher applies first)
+	// Priority returns application order (higher applies later and wins overrides)
 	Priority() int
 
 	// IsActive returns true if modifier should be applied
@@ -138,6 +148,9 @@ type Set interface {
 	// GetByType returns modifiers of specific type
 	GetByType(modType ModifierType) []Modifier
 
+	// RemoveBySource removes modifiers created by source, returns count removed
+	RemoveBySource(source string) int
+
 	// Clear removes all modifiers
 	Clear()
 
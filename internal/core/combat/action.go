@@ -535,6 +535,21 @@ type ActionQueue interface {
 
 	// Sort sorts queue by priority
 	Sort()
+
+	// Advance removes and returns next action to resolve (engine resolve phase)
+	Advance() (Action, bool)
+
+	// Interrupt drops interruptible actions, reporting each as EventActionFailed
+	Interrupt(reason string) []Action
+
+	// Reject drops queued action that failed validation, reporting it as EventActionFailed
+	Reject(action Action, reason string) bool
+
+	// LastInterrupt returns reason of most recent interrupt
+	LastInterrupt() string
+
+	// OnActionFailed registers callback for interrupted and rejected actions
+	OnActionFailed(callback ActionFailedCallback)
 }
 
 // ActionFactory creates action instances
@@ -0,0 +1,111 @@
+package combat
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// flagEvents maps result flags to timeline events they produce
+var flagEvents = map[ResultFlag]EventType{
+	FlagCritical:  EventCriticalHit,
+	FlagMissed:    EventMissed,
+	FlagBlocked:   EventBlocked,
+	FlagEvaded:    EventEvaded,
+	FlagCountered: EventCountered,
+}
+
+// ActionResultEvents derives timeline events from action outcome: one
+// action performed (or failed) event, one damage/healing event per target
+// and one event per recognized result flag. Participant IDs list actor
+// first, followed by affected targets. All events share one TimelineClock
+// timestamp.
+func ActionResultEvents(participant Participant, action Action, result ActionResult, encounter Encounter) []TimelineEvent {
+	actorID := participant.EntityID()
+	round := encounter.RoundNumber()
+	turn := 0
+	if order := encounter.TurnOrder(); order != nil {
+		turn = order.TurnNumber()
+	}
+	now := TimelineClock()
+	base := map[string]any{"action_id": action.ID(), "action_type": action.Type()}
+	event := func(eventType EventType, targets []string, data map[string]any, severity EventSeverity, description string) TimelineEvent {
+		merged := maps.Clone(base)
+		maps.Copy(merged, data)
+		return NewTimelineEvent(TimelineEventConfig{
+			Type:           eventType,
+			Timestamp:      now,
+			Round:          round,
+			Turn:           turn,
+			ParticipantIDs: append([]string{actorID}, targets...),
+			Data:           merged,
+			Description:    description,
+			Severity:       severity,
+		})
+	}
+
+	targets := action.TargetIDs()
+	events := make([]TimelineEvent, 0, 1+len(result.DamageDealt)+len(result.HealingDone)+len(result.Flags))
+
+	if result.Success {
+		events = append(events, event(EventActionPerformed, targets, nil, SeverityLow,
+			fmt.Sprintf("%s uses %s", actorID, action.ID())))
+	} else {
+		events = append(events, event(EventActionFailed, targets, map[string]any{"message": result.Message}, SeverityLow,
+			fmt.Sprintf("%s fails to use %s", actorID, action.ID())))
+	}
+
+	for _, targetID := range slices.Sorted(maps.Keys(result.DamageDealt)) {
+		damage := result.DamageDealt[targetID]
+		events = append(events, event(EventDamageDealt, []string{targetID}, map[string]any{"damage": damage}, SeverityNormal,
+			fmt.Sprintf("%s deals %g damage to %s", actorID, damage, targetID)))
+	}
+
+	for _, targetID := range slices.Sorted(maps.Keys(result.HealingDone)) {
+		healing := result.HealingDone[targetID]
+		events = append(events, event(EventHealingDone, []string{targetID}, map[string]any{"healing": healing}, SeverityNormal,
+			fmt.Sprintf("%s heals %s for %g", actorID, targetID, healing)))
+	}
+
+	for _, flag := range result.Flags {
+		eventType, ok := flagEvents[flag]
+		if !ok {
+			continue
+		}
+		severity := SeverityNormal
+		if flag == FlagCritical {
+			severity = SeverityHigh
+		}
+		events = append(events, event(eventType, targets, map[string]any{"flag": flag}, severity,
+			fmt.Sprintf("%s: %s", action.ID(), flag)))
+	}
+
+	return events
+}
+
+// InterruptedActionEvent converts queued action dropped by interrupt into
+// EventActionFailed timeline event shaped like ActionResultEvents failure,
+// with reason instead of message. Nil encounter leaves round and turn zero.
+func InterruptedActionEvent(event ActionFailedEvent, encounter Encounter) TimelineEvent {
+	round, turn := 0, 0
+	if encounter != nil {
+		round = encounter.RoundNumber()
+		if order := encounter.TurnOrder(); order != nil {
+			turn = order.TurnNumber()
+		}
+	}
+
+	return NewTimelineEvent(TimelineEventConfig{
+		Type:           EventActionFailed,
+		Round:          round,
+		Turn:           turn,
+		ParticipantIDs: []string{event.ParticipantID},
+		Data: map[string]any{
+			"action_id":   event.ActionID,
+			"action_type": event.ActionType,
+			"reason":      event.Reason,
+		},
+		Description: fmt.Sprintf("%s is interrupted: %s", event.ActionID, event.Reason),
+		Severity:    SeverityLow,
+	})
+}
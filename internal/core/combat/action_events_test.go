@@ -0,0 +1,137 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultAction struct {
+	*testAction
+
+	result ActionResult
+}
+
+func (a *resultAction) Type() ActionType { return ActionAttack }
+
+func (a *resultAction) Execute(ctx context.Context, encounter Encounter) (ActionResult, error) {
+	return a.result, nil
+}
+
+type turnCounter struct {
+	TurnOrder
+
+	turn int
+}
+
+func (o *turnCounter) TurnNumber() int { return o.turn }
+
+type resultEncounter struct {
+	*conditionEncounter
+
+	order *turnCounter
+}
+
+func (e *resultEncounter) TurnOrder() TurnOrder { return e.order }
+
+func TestRecordActionResults(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(result ActionResult) (*BaseTurnProcessor, *BaseTimeline, *testParticipant, *resultEncounter) {
+		caster := &testParticipant{id: "caster"}
+		target := &testParticipant{id: "target"}
+		enc := &resultEncounter{
+			conditionEncounter: &conditionEncounter{
+				testEncounter: &testEncounter{participants: map[string]Participant{"caster": caster, "target": target}},
+				round:         3,
+			},
+			order: &turnCounter{turn: 2},
+		}
+		caster.actions = []Action{&resultAction{
+			testAction: &testAction{id: "slash", targets: []string{"target"}},
+			result:     result,
+		}}
+
+		proc := NewBaseTurnProcessor()
+		timeline := NewTimeline()
+		proc.RecordActionResults(timeline)
+		return proc, timeline, caster, enc
+	}
+
+	t.Run("critical hit records action, damage and crit", func(t *testing.T) {
+		proc, timeline, caster, enc := setup(ActionResult{
+			Success:     true,
+			DamageDealt: map[string]float64{"target": 42},
+			Flags:       []ResultFlag{FlagCritical, FlagKilled},
+		})
+
+		require.NoError(t, proc.ProcessTurn(ctx, caster, enc))
+		require.Equal(t, 3, timeline.Size())
+
+		performed := timeline.GetEventsByType(EventActionPerformed)
+		require.Len(t, performed, 1)
+		assert.Equal(t, []string{"caster", "target"}, performed[0].ParticipantIDs())
+		assert.Equal(t, 3, performed[0].Round())
+		assert.Equal(t, 2, performed[0].Turn())
+		assert.Equal(t, "slash", performed[0].Data()["action_id"])
+
+		crits := timeline.GetEventsByType(EventCriticalHit)
+		require.Len(t, crits, 1)
+		assert.Equal(t, []string{"caster", "target"}, crits[0].ParticipantIDs())
+		assert.Equal(t, SeverityHigh, crits[0].Severity())
+
+		stats := timeline.Export().Statistics
+		assert.Equal(t, 42.0, stats.TotalDamage)
+		assert.Equal(t, 1, stats.CriticalHits)
+		assert.Equal(t, 1, stats.TotalActions)
+	})
+
+	t.Run("miss and healing", func(t *testing.T) {
+		proc, timeline, caster, enc := setup(ActionResult{
+			Success:     true,
+			HealingDone: map[string]float64{"caster": 10},
+			Flags:       []ResultFlag{FlagMissed},
+		})
+
+		require.NoError(t, proc.ProcessTurn(ctx, caster, enc))
+
+		healing := timeline.GetEventsByType(EventHealingDone)
+		require.Len(t, healing, 1)
+		assert.Equal(t, []string{"caster", "caster"}, healing[0].ParticipantIDs())
+		assert.Equal(t, 10.0, healing[0].Data()["healing"])
+		assert.Len(t, timeline.GetEventsByType(EventMissed), 1)
+		assert.Empty(t, timeline.GetEventsByType(EventDamageDealt))
+	})
+
+	t.Run("events are stamped for window queries", func(t *testing.T) {
+		now := int64(5000)
+		useTimelineClock(t, func() int64 { return now })
+		_, timeline, caster, enc := setup(ActionResult{})
+
+		result := ActionResult{Success: true, DamageDealt: map[string]float64{"target": 7}, Flags: []ResultFlag{FlagCritical}}
+		for _, event := range ActionResultEvents(caster, caster.actions[0], result, enc) {
+			timeline.Record(event)
+		}
+		now = 12000
+		for _, event := range ActionResultEvents(caster, caster.actions[0], ActionResult{Success: true}, enc) {
+			timeline.Record(event)
+		}
+
+		assert.Len(t, timeline.GetEventsInWindow(4000, 6000), 3)
+		assert.Len(t, timeline.GetEventsInWindow(10000, 13000), 1)
+		assert.Empty(t, timeline.GetEventsInWindow(0, 1000))
+	})
+
+	t.Run("failed action", func(t *testing.T) {
+		proc, timeline, caster, enc := setup(ActionResult{Success: false, Message: "fizzled"})
+
+		require.NoError(t, proc.ProcessTurn(ctx, caster, enc))
+
+		failed := timeline.GetEventsByType(EventActionFailed)
+		require.Len(t, failed, 1)
+		assert.Equal(t, "fizzled", failed[0].Data()["message"])
+		assert.Empty(t, timeline.GetEventsByType(EventActionPerformed))
+	})
+}
@@ -0,0 +1,236 @@
+package combat
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrNilAction = errors.New("action is nil")
+)
+
+// =============================================================================
+// ACTION QUEUE
+// =============================================================================
+
+// ActionFailedEvent reports queued action that was dropped before resolving
+type ActionFailedEvent struct {
+	Type          EventType // Always EventActionFailed
+	ParticipantID string
+	ActionID      string
+	ActionType    ActionType
+	Reason        string
+}
+
+// ActionFailedCallback is invoked for every dropped action
+type ActionFailedCallback func(event ActionFailedEvent)
+
+var _ ActionQueue = (*BaseActionQueue)(nil)
+
+// BaseActionQueue holds pending actions of single participant (channels, queued casts).
+// Engine drains it one action per resolve phase via Advance.
+type BaseActionQueue struct {
+	mu sync.RWMutex
+
+	participantID string
+	actions       []Action
+	lastInterrupt string
+
+	onActionFailed []ActionFailedCallback
+}
+
+// NewActionQueue creates empty queue for participant
+func NewActionQueue(participantID string) *BaseActionQueue {
+	return &BaseActionQueue{participantID: participantID}
+}
+
+// ParticipantID returns queue owner
+func (q *BaseActionQueue) ParticipantID() string {
+	return q.participantID
+}
+
+func (q *BaseActionQueue) Enqueue(action Action) error {
+	if action == nil {
+		return ErrNilAction
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.actions = append(q.actions, action)
+	return nil
+}
+
+func (q *BaseActionQueue) Dequeue() (Action, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.actions) == 0 {
+		return nil, false
+	}
+
+	action := q.actions[0]
+	q.actions[0] = nil
+	q.actions = q.actions[1:]
+	return action, true
+}
+
+func (q *BaseActionQueue) Peek() (Action, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if len(q.actions) == 0 {
+		return nil, false
+	}
+	return q.actions[0], true
+}
+
+func (q *BaseActionQueue) GetAll() []Action {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return slices.Clone(q.actions)
+}
+
+func (q *BaseActionQueue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.actions = nil
+}
+
+func (q *BaseActionQueue) Size() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.actions)
+}
+
+func (q *BaseActionQueue) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+func (q *BaseActionQueue) Remove(actionID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, action := range q.actions {
+		if action.ID() == actionID {
+			q.actions = slices.Delete(q.actions, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *BaseActionQueue) Contains(actionID string) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return slices.ContainsFunc(q.actions, func(action Action) bool {
+		return action.ID() == actionID
+	})
+}
+
+func (q *BaseActionQueue) Priority(action Action) int {
+	return action.Priority()
+}
+
+// Sort orders queue by priority, highest first; equal priorities keep queue order
+func (q *BaseActionQueue) Sort() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	sort.SliceStable(q.actions, func(i, j int) bool {
+		return q.actions[i].Priority() > q.actions[j].Priority()
+	})
+}
+
+// Advance is Dequeue for engine resolve phase
+func (q *BaseActionQueue) Advance() (Action, bool) {
+	return q.Dequeue()
+}
+
+// Interrupt drops every action that can be interrupted and reports each
+// as EventActionFailed with reason. Uninterruptible actions stay queued.
+// Returns dropped actions.
+func (q *BaseActionQueue) Interrupt(reason string) []Action {
+	q.mu.Lock()
+
+	var dropped []Action
+	kept := q.actions[:0]
+	for _, action := range q.actions {
+		if action.CanBeInterrupted() {
+			dropped = append(dropped, action)
+		} else {
+			kept = append(kept, action)
+		}
+	}
+	clear(q.actions[len(kept):])
+	q.actions = kept
+
+	if len(dropped) > 0 {
+		q.lastInterrupt = reason
+	}
+	callbacks := slices.Clone(q.onActionFailed)
+	q.mu.Unlock()
+
+	for _, action := range dropped {
+		q.notifyFailed(callbacks, action, reason)
+	}
+	return dropped
+}
+
+// Reject drops queued action that failed validation and reports it
+// as EventActionFailed with reason. Returns false if action is not queued.
+func (q *BaseActionQueue) Reject(action Action, reason string) bool {
+	q.mu.Lock()
+	idx := slices.IndexFunc(q.actions, func(queued Action) bool {
+		return queued.ID() == action.ID()
+	})
+	if idx < 0 {
+		q.mu.Unlock()
+		return false
+	}
+	q.actions = slices.Delete(q.actions, idx, idx+1)
+	callbacks := slices.Clone(q.onActionFailed)
+	q.mu.Unlock()
+
+	q.notifyFailed(callbacks, action, reason)
+	return true
+}
+
+func (q *BaseActionQueue) notifyFailed(callbacks []ActionFailedCallback, action Action, reason string) {
+	event := ActionFailedEvent{
+		Type:          EventActionFailed,
+		ParticipantID: q.participantID,
+		ActionID:      action.ID(),
+		ActionType:    action.Type(),
+		Reason:        reason,
+	}
+	for _, cb := range callbacks {
+		cb(event)
+	}
+}
+
+func (q *BaseActionQueue) LastInterrupt() string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.lastInterrupt
+}
+
+// RecordInterrupts records every dropped action into timeline as
+// InterruptedActionEvent; encounter supplies round and turn (nil = none)
+func (q *BaseActionQueue) RecordInterrupts(timeline Timeline, encounter Encounter) {
+	q.OnActionFailed(func(event ActionFailedEvent) {
+		timeline.Record(InterruptedActionEvent(event, encounter))
+	})
+}
+
+func (q *BaseActionQueue) OnActionFailed(callback ActionFailedCallback) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onActionFailed = append(q.onActionFailed, callback)
+}
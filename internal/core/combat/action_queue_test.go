@@ -0,0 +1,115 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionQueue(t *testing.T) {
+	t.Run("advances in queue order", func(t *testing.T) {
+		queue := NewActionQueue("caster")
+		require.NoError(t, queue.Enqueue(&testAction{id: "first"}))
+		require.NoError(t, queue.Enqueue(&testAction{id: "second"}))
+		assert.ErrorIs(t, queue.Enqueue(nil), ErrNilAction)
+
+		next, ok := queue.Peek()
+		require.True(t, ok)
+		assert.Equal(t, "first", next.ID())
+		assert.Equal(t, 2, queue.Size())
+
+		next, ok = queue.Advance()
+		require.True(t, ok)
+		assert.Equal(t, "first", next.ID())
+
+		next, _ = queue.Advance()
+		assert.Equal(t, "second", next.ID())
+
+		_, ok = queue.Advance()
+		assert.False(t, ok)
+		assert.True(t, queue.IsEmpty())
+	})
+
+	t.Run("interrupt clears queued action and records reason", func(t *testing.T) {
+		queue := NewActionQueue("caster")
+		require.NoError(t, queue.Enqueue(&testAction{id: "channel", interruptible: true}))
+		require.NoError(t, queue.Enqueue(&testAction{id: "dodge"}))
+
+		var events []ActionFailedEvent
+		queue.OnActionFailed(func(event ActionFailedEvent) {
+			events = append(events, event)
+		})
+
+		dropped := queue.Interrupt("stunned")
+		require.Len(t, dropped, 1)
+		assert.Equal(t, "channel", dropped[0].ID())
+
+		assert.False(t, queue.Contains("channel"))
+		assert.True(t, queue.Contains("dodge"))
+		assert.Equal(t, "stunned", queue.LastInterrupt())
+
+		require.Len(t, events, 1)
+		assert.Equal(t, EventActionFailed, events[0].Type)
+		assert.Equal(t, "caster", events[0].ParticipantID)
+		assert.Equal(t, "channel", events[0].ActionID)
+		assert.Equal(t, ActionSkill, events[0].ActionType)
+		assert.Equal(t, "stunned", events[0].Reason)
+	})
+
+	t.Run("interrupt is recorded in timeline", func(t *testing.T) {
+		useTimelineClock(t, func() int64 { return 2500 })
+		enc := &resultEncounter{
+			conditionEncounter: &conditionEncounter{testEncounter: &testEncounter{}, round: 4},
+			order:              &turnCounter{turn: 1},
+		}
+		queue := NewActionQueue("caster")
+		require.NoError(t, queue.Enqueue(&resultAction{testAction: &testAction{id: "channel", interruptible: true}}))
+		timeline := NewTimeline()
+		queue.RecordInterrupts(timeline, enc)
+
+		queue.Interrupt("stunned")
+
+		failed := timeline.GetEventsByType(EventActionFailed)
+		require.Len(t, failed, 1)
+		assert.Equal(t, []string{"caster"}, failed[0].ParticipantIDs())
+		assert.Equal(t, 4, failed[0].Round())
+		assert.Equal(t, 1, failed[0].Turn())
+		assert.Equal(t, "channel", failed[0].Data()["action_id"])
+		assert.Equal(t, ActionAttack, failed[0].Data()["action_type"])
+		assert.Equal(t, "stunned", failed[0].Data()["reason"])
+		assert.Len(t, timeline.GetEventsInWindow(2000, 3000), 1)
+	})
+
+	t.Run("interrupt with nothing interruptible keeps last reason", func(t *testing.T) {
+		queue := NewActionQueue("caster")
+		require.NoError(t, queue.Enqueue(&testAction{id: "dodge"}))
+
+		assert.Empty(t, queue.Interrupt("knocked back"))
+		assert.Empty(t, queue.LastInterrupt())
+		assert.Equal(t, 1, queue.Size())
+	})
+
+	t.Run("turn processor resolves queued action first", func(t *testing.T) {
+		ctx := context.Background()
+		caster := &testParticipant{id: "caster", mana: 20}
+		enc := &testEncounter{participants: map[string]Participant{"caster": caster}}
+		proc := NewBaseTurnProcessor()
+
+		caster.actions = []Action{&testAction{id: "attack", priority: 5}}
+		require.NoError(t, proc.Queue("caster").Enqueue(&testAction{id: "channel", cost: ActionCost{Mana: 5}, interruptible: true}))
+
+		var performed []string
+		proc.OnActionPerformed(func(ctx context.Context, p Participant, a Action, r ActionResult, e Encounter) {
+			performed = append(performed, a.ID())
+		})
+
+		require.NoError(t, proc.ProcessTurn(ctx, caster, enc))
+		require.NoError(t, proc.ProcessTurn(ctx, caster, enc))
+
+		assert.Equal(t, []string{"channel", "attack"}, performed)
+		assert.Equal(t, 15.0, caster.mana)
+		assert.Same(t, proc.Queue("caster"), proc.Queue("caster"))
+	})
+}
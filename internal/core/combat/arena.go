@@ -38,6 +38,10 @@ type Arena interface {
 	// RemoveHazard removes hazard from arena
 	RemoveHazard(hazardID string) error
 
+	// TickHazards applies hazard effects to participants inside hazard areas,
+	// removes expired hazards and returns resulting timeline events
+	TickHazards(ctx context.Context, encounter Encounter, deltaMs int64) ([]TimelineEvent, error)
+
 	// Interactives returns interactive objects
 	Interactives() []Interactive
 
@@ -254,6 +258,9 @@ type Hazard interface {
 	// IsExpired returns true if duration ended
 	IsExpired() bool
 
+	// Advance consumes hazard lifetime and returns number of ticks elapsed
+	Advance(deltaMs int64) int
+
 	// Icon returns icon identifier
 	Icon() string
 
@@ -180,6 +180,10 @@ type Participant interface {
 	// MarkDefeated marks participant as defeated
 	MarkDefeated()
 
+	// SetDefeated sets defeated flag without side effects; encounters route
+	// transitions through SetParticipantDefeated
+	SetDefeated(defeated bool)
+
 	// AvailableActions returns possible actions
 	AvailableActions() []Action
 
@@ -306,6 +310,7 @@ type ResultFlag string
 
 const (
 	FlagCritical    ResultFlag = "critical"
+	FlagMissed      ResultFlag = "missed"
 	FlagBlocked     ResultFlag = "blocked"
 	FlagEvaded      ResultFlag = "evaded"
 	FlagCountered   ResultFlag = "countered"
@@ -430,6 +435,7 @@ const (
 	TriggerOnAttacked      TriggerType = "on_attacked"
 	TriggerOnHit           TriggerType = "on_hit"
 	TriggerOnMiss          TriggerType = "on_miss"
+	TriggerOnBlock         TriggerType = "on_block"
 	TriggerOnDamaged       TriggerType = "on_damaged"
 	TriggerOnHealed        TriggerType = "on_healed"
 	TriggerOnAllyAttacked  TriggerType = "on_ally_attacked"
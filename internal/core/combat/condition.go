@@ -0,0 +1,245 @@
+package combat
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// =============================================================================
+// CONDITIONS
+// =============================================================================
+
+var (
+	_ Condition = (*EntitiesDefeatedCondition)(nil)
+	_ Condition = (*SurviveRoundsCondition)(nil)
+	_ Condition = (*ProtectEntityCondition)(nil)
+	_ Condition = (*AndCondition)(nil)
+	_ Condition = (*OrCondition)(nil)
+	_ Condition = (*NotCondition)(nil)
+)
+
+// conditionBase holds identity and role shared by all conditions.
+// Conditions are victory conditions unless marked as defeat.
+type conditionBase struct {
+	id          string
+	description string
+	defeat      bool
+}
+
+func (c *conditionBase) ID() string          { return c.id }
+func (c *conditionBase) Description() string { return c.description }
+func (c *conditionBase) IsVictory() bool     { return !c.defeat }
+func (c *conditionBase) IsDefeat() bool      { return c.defeat }
+
+// SetDescription overrides generated description
+func (c *conditionBase) SetDescription(description string) {
+	c.description = description
+}
+
+// SetDefeat marks condition as loss condition (true) or win condition (false)
+func (c *conditionBase) SetDefeat(defeat bool) {
+	c.defeat = defeat
+}
+
+// --- Entities defeated ---
+
+// EntitiesDefeatedCondition is met when all listed entities are defeated.
+// Without listed entities it targets every enemy participant.
+// Entities no longer in encounter count as defeated.
+type EntitiesDefeatedCondition struct {
+	conditionBase
+
+	entityIDs []string
+}
+
+// NewEntitiesDefeatedCondition creates condition for given entities, or all enemies when none given
+func NewEntitiesDefeatedCondition(id string, entityIDs ...string) *EntitiesDefeatedCondition {
+	description := "Defeat all enemies"
+	if len(entityIDs) > 0 {
+		description = "Defeat " + strings.Join(entityIDs, ", ")
+	}
+	return &EntitiesDefeatedCondition{
+		conditionBase: conditionBase{id: id, description: description},
+		entityIDs:     slices.Clone(entityIDs),
+	}
+}
+
+func (c *EntitiesDefeatedCondition) EntityIDs() []string {
+	return slices.Clone(c.entityIDs)
+}
+
+func (c *EntitiesDefeatedCondition) Type() ConditionType {
+	if len(c.entityIDs) == 0 {
+		return ConditionEliminateAll
+	}
+	return ConditionEliminateTarget
+}
+
+func (c *EntitiesDefeatedCondition) Check(ctx context.Context, encounter Encounter) bool {
+	if len(c.entityIDs) == 0 {
+		for _, p := range encounter.Participants() {
+			if p.Team() == TeamEnemy && !p.IsDefeated() {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, id := range c.entityIDs {
+		if p, ok := encounter.GetParticipant(id); ok && !p.IsDefeated() {
+			return false
+		}
+	}
+	return true
+}
+
+// --- Survive rounds ---
+
+// SurviveRoundsCondition is met once given number of rounds has fully passed
+type SurviveRoundsCondition struct {
+	conditionBase
+
+	rounds int
+}
+
+// NewSurviveRoundsCondition creates condition met when encounter enters round rounds+1
+func NewSurviveRoundsCondition(id string, rounds int) *SurviveRoundsCondition {
+	return &SurviveRoundsCondition{
+		conditionBase: conditionBase{id: id, description: fmt.Sprintf("Survive %d rounds", rounds)},
+		rounds:        rounds,
+	}
+}
+
+func (c *SurviveRoundsCondition) Rounds() int         { return c.rounds }
+func (c *SurviveRoundsCondition) Type() ConditionType { return ConditionSurviveRounds }
+
+func (c *SurviveRoundsCondition) Check(ctx context.Context, encounter Encounter) bool {
+	return encounter.RoundNumber() > c.rounds
+}
+
+// --- Protect entity ---
+
+// ProtectEntityCondition is met while protected entity is in encounter and not defeated.
+// Wrap in NotCondition for "lose if entity falls".
+type ProtectEntityCondition struct {
+	conditionBase
+
+	entityID string
+}
+
+// NewProtectEntityCondition creates condition guarding entity
+func NewProtectEntityCondition(id, entityID string) *ProtectEntityCondition {
+	return &ProtectEntityCondition{
+		conditionBase: conditionBase{id: id, description: "Protect " + entityID},
+		entityID:      entityID,
+	}
+}
+
+func (c *ProtectEntityCondition) EntityID() string    { return c.entityID }
+func (c *ProtectEntityCondition) Type() ConditionType { return ConditionProtect }
+
+func (c *ProtectEntityCondition) Check(ctx context.Context, encounter Encounter) bool {
+	p, ok := encounter.GetParticipant(c.entityID)
+	return ok && !p.IsDefeated()
+}
+
+// --- Combinators ---
+
+// AndCondition is met when all conditions are met. Empty AND is never met.
+type AndCondition struct {
+	conditionBase
+
+	conditions []Condition
+}
+
+// NewAndCondition combines conditions with AND, skipping nil ones
+func NewAndCondition(id string, conditions ...Condition) *AndCondition {
+	conditions = compactConditions(conditions)
+	return &AndCondition{
+		conditionBase: conditionBase{id: id, description: joinDescriptions(conditions, " and ")},
+		conditions:    conditions,
+	}
+}
+
+func (c *AndCondition) Conditions() []Condition { return slices.Clone(c.conditions) }
+func (c *AndCondition) Type() ConditionType     { return ConditionCustom }
+
+func (c *AndCondition) Check(ctx context.Context, encounter Encounter) bool {
+	if len(c.conditions) == 0 {
+		return false
+	}
+	for _, cond := range c.conditions {
+		if !cond.Check(ctx, encounter) {
+			return false
+		}
+	}
+	return true
+}
+
+// OrCondition is met when any condition is met
+type OrCondition struct {
+	conditionBase
+
+	conditions []Condition
+}
+
+// NewOrCondition combines conditions with OR, skipping nil ones
+func NewOrCondition(id string, conditions ...Condition) *OrCondition {
+	conditions = compactConditions(conditions)
+	return &OrCondition{
+		conditionBase: conditionBase{id: id, description: joinDescriptions(conditions, " or ")},
+		conditions:    conditions,
+	}
+}
+
+func (c *OrCondition) Conditions() []Condition { return slices.Clone(c.conditions) }
+func (c *OrCondition) Type() ConditionType     { return ConditionCustom }
+
+func (c *OrCondition) Check(ctx context.Context, encounter Encounter) bool {
+	for _, cond := range c.conditions {
+		if cond.Check(ctx, encounter) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotCondition is met when wrapped condition is not
+type NotCondition struct {
+	conditionBase
+
+	condition Condition
+}
+
+// NewNotCondition negates condition. Nil condition is treated as never met.
+func NewNotCondition(id string, condition Condition) *NotCondition {
+	description := "Not"
+	if condition != nil {
+		description = "Not (" + condition.Description() + ")"
+	}
+	return &NotCondition{
+		conditionBase: conditionBase{id: id, description: description},
+		condition:     condition,
+	}
+}
+
+func (c *NotCondition) Condition() Condition { return c.condition }
+func (c *NotCondition) Type() ConditionType  { return ConditionCustom }
+
+func (c *NotCondition) Check(ctx context.Context, encounter Encounter) bool {
+	return c.condition == nil || !c.condition.Check(ctx, encounter)
+}
+
+func compactConditions(conditions []Condition) []Condition {
+	return slices.DeleteFunc(slices.Clone(conditions), func(c Condition) bool { return c == nil })
+}
+
+func joinDescriptions(conditions []Condition, sep string) string {
+	parts := make([]string, len(conditions))
+	for i, c := range conditions {
+		parts[i] = c.Description()
+	}
+	return strings.Join(parts, sep)
+}
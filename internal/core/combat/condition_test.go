@@ -0,0 +1,142 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type conditionParticipant struct {
+	*testParticipant
+
+	team Team
+}
+
+func (p *conditionParticipant) Team() Team { return p.team }
+
+type conditionEncounter struct {
+	*testEncounter
+
+	round int
+}
+
+func (e *conditionEncounter) RoundNumber() int { return e.round }
+
+func (e *conditionEncounter) Participants() []Participant {
+	result := make([]Participant, 0, len(e.participants))
+	for _, p := range e.participants {
+		result = append(result, p)
+	}
+	return result
+}
+
+func TestConditions(t *testing.T) {
+	ctx := context.Background()
+
+	newScene := func() (*conditionEncounter, map[string]*conditionParticipant) {
+		members := map[string]*conditionParticipant{
+			"hero":    {testParticipant: &testParticipant{id: "hero"}, team: TeamPlayer},
+			"escort":  {testParticipant: &testParticipant{id: "escort"}, team: TeamAlly},
+			"goblin":  {testParticipant: &testParticipant{id: "goblin"}, team: TeamEnemy},
+			"warlord": {testParticipant: &testParticipant{id: "warlord"}, team: TeamEnemy},
+		}
+		participants := make(map[string]Participant, len(members))
+		for id, p := range members {
+			participants[id] = p
+		}
+		return &conditionEncounter{testEncounter: &testEncounter{participants: participants}, round: 1}, members
+	}
+
+	t.Run("entities defeated", func(t *testing.T) {
+		encounter, members := newScene()
+		allEnemies := NewEntitiesDefeatedCondition("all")
+		boss := NewEntitiesDefeatedCondition("boss", "warlord")
+
+		assert.Equal(t, ConditionEliminateAll, allEnemies.Type())
+		assert.Equal(t, ConditionEliminateTarget, boss.Type())
+		assert.False(t, allEnemies.Check(ctx, encounter))
+		assert.False(t, boss.Check(ctx, encounter))
+
+		members["warlord"].defeated = true
+		assert.True(t, boss.Check(ctx, encounter))
+		assert.False(t, allEnemies.Check(ctx, encounter))
+
+		// Removed entities count as defeated
+		delete(encounter.participants, "goblin")
+		assert.True(t, allEnemies.Check(ctx, encounter))
+	})
+
+	t.Run("survive rounds", func(t *testing.T) {
+		encounter, _ := newScene()
+		survive := NewSurviveRoundsCondition("survive", 3)
+
+		assert.Equal(t, "Survive 3 rounds", survive.Description())
+		encounter.round = 3
+		assert.False(t, survive.Check(ctx, encounter))
+		encounter.round = 4
+		assert.True(t, survive.Check(ctx, encounter))
+	})
+
+	t.Run("protect entity", func(t *testing.T) {
+		encounter, members := newScene()
+		protect := NewProtectEntityCondition("protect", "escort")
+
+		assert.True(t, protect.Check(ctx, encounter))
+		members["escort"].defeated = true
+		assert.False(t, protect.Check(ctx, encounter))
+
+		delete(encounter.participants, "escort")
+		assert.False(t, protect.Check(ctx, encounter))
+	})
+
+	t.Run("defeat all enemies or survive rounds", func(t *testing.T) {
+		encounter, members := newScene()
+		victory := NewOrCondition("victory",
+			NewEntitiesDefeatedCondition("all"),
+			NewSurviveRoundsCondition("survive", 10),
+		)
+
+		assert.Equal(t, "Defeat all enemies or Survive 10 rounds", victory.Description())
+		assert.True(t, victory.IsVictory())
+		assert.False(t, victory.Check(ctx, encounter))
+
+		encounter.round = 11
+		assert.True(t, victory.Check(ctx, encounter))
+
+		encounter.round = 2
+		members["goblin"].defeated = true
+		members["warlord"].defeated = true
+		assert.True(t, victory.Check(ctx, encounter))
+	})
+
+	t.Run("and with not", func(t *testing.T) {
+		encounter, members := newScene()
+		victory := NewAndCondition("escort-victory",
+			NewEntitiesDefeatedCondition("boss", "warlord"),
+			NewProtectEntityCondition("protect", "escort"),
+		)
+		defeat := NewNotCondition("escort-lost", NewProtectEntityCondition("protect", "escort"))
+		defeat.SetDefeat(true)
+
+		assert.True(t, defeat.IsDefeat())
+		assert.False(t, victory.Check(ctx, encounter))
+		assert.False(t, defeat.Check(ctx, encounter))
+
+		members["warlord"].defeated = true
+		assert.True(t, victory.Check(ctx, encounter))
+
+		members["escort"].defeated = true
+		assert.False(t, victory.Check(ctx, encounter))
+		assert.True(t, defeat.Check(ctx, encounter))
+	})
+
+	t.Run("empty and nil", func(t *testing.T) {
+		encounter, _ := newScene()
+
+		assert.False(t, NewAndCondition("and").Check(ctx, encounter))
+		assert.False(t, NewOrCondition("or", nil).Check(ctx, encounter))
+		assert.True(t, NewNotCondition("not", nil).Check(ctx, encounter))
+		assert.Empty(t, NewOrCondition("or", nil).Conditions())
+	})
+}
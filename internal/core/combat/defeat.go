@@ -0,0 +1,104 @@
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+// ErrParticipantNotFound is returned when participant is not in encounter
+var ErrParticipantNotFound = errors.New("participant not found")
+
+// =============================================================================
+// DEFEAT TRANSITIONS
+// =============================================================================
+
+// DefeatTransition describes outcome of SetParticipantDefeated
+type DefeatTransition struct {
+	ParticipantID string
+	Defeated      bool
+
+	// Changed is false when participant already was in requested state
+	Changed bool
+
+	// Event is recorded timeline event (nil when unchanged)
+	Event TimelineEvent
+
+	// Victory and defeat conditions evaluated after transition
+	Victory       bool
+	VictoryReason string
+	Defeat        bool
+	DefeatReason  string
+}
+
+// SetParticipantDefeated moves participant into or out of defeated state.
+// Defeat removes participant from turn order, revival re-inserts it ranked by
+// initiative. Transition is recorded on timeline (nil timeline skips recording)
+// and victory and defeat conditions are re-evaluated.
+// Encounter implementations delegate defeat and revival here.
+func SetParticipantDefeated(ctx context.Context, encounter Encounter, timeline Timeline, participantID string, defeated bool) (DefeatTransition, error) {
+	participant, ok := encounter.GetParticipant(participantID)
+	if !ok {
+		return DefeatTransition{}, fmt.Errorf("%w: %s", ErrParticipantNotFound, participantID)
+	}
+
+	result := DefeatTransition{ParticipantID: participantID, Defeated: defeated}
+	if participant.IsDefeated() != defeated {
+		participant.SetDefeated(defeated)
+		result.Changed = true
+
+		if order := encounter.TurnOrder(); order != nil {
+			if defeated {
+				order.Remove(participantID)
+			} else {
+				reinsertByInitiative(order, participant)
+			}
+		}
+
+		result.Event = defeatEvent(participant, encounter, defeated)
+		if timeline != nil {
+			timeline.Record(result.Event)
+		}
+	}
+
+	result.Victory, result.VictoryReason = encounter.CheckVictory(ctx)
+	result.Defeat, result.DefeatReason = encounter.CheckDefeat(ctx)
+	return result, nil
+}
+
+// reinsertByInitiative places participant before first entry with lower
+// initiative; no-op when participant is already in order
+func reinsertByInitiative(order TurnOrder, participant Participant) {
+	entries := order.GetOrder()
+	position := len(entries)
+	for i, entry := range entries {
+		if entry.EntityID() == participant.EntityID() {
+			return
+		}
+		if position == len(entries) && entry.Initiative() < participant.Initiative() {
+			position = i
+		}
+	}
+	order.Insert(participant, position)
+}
+
+func defeatEvent(participant Participant, encounter Encounter, defeated bool) TimelineEvent {
+	cfg := TimelineEventConfig{
+		Type:           EventEntityRevived,
+		Round:          encounter.RoundNumber(),
+		ParticipantIDs: []string{participant.EntityID()},
+		Data:           map[string]any{"team": participant.Team()},
+		Description:    fmt.Sprintf("%s is revived", participant.EntityID()),
+		Severity:       SeverityHigh,
+	}
+	if defeated {
+		cfg.Type = EventEntityDefeated
+		cfg.Description = fmt.Sprintf("%s is defeated", participant.EntityID())
+		cfg.Severity = SeverityCritical
+	}
+	return NewTimelineEvent(cfg)
+}
@@ -0,0 +1,165 @@
+package combat
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defeatParticipant struct {
+	*conditionParticipant
+
+	initiative int
+}
+
+func (p *defeatParticipant) Initiative() int { return p.initiative }
+
+// listTurnOrder keeps plain ordered slice
+type listTurnOrder struct {
+	TurnOrder
+
+	order []Participant
+}
+
+func (o *listTurnOrder) GetOrder() []Participant { return slices.Clone(o.order) }
+
+func (o *listTurnOrder) Insert(participant Participant, position int) {
+	o.order = slices.Insert(o.order, position, participant)
+}
+
+func (o *listTurnOrder) Remove(participantID string) {
+	o.order = slices.DeleteFunc(o.order, func(p Participant) bool { return p.EntityID() == participantID })
+}
+
+func (o *listTurnOrder) ids() []string {
+	ids := make([]string, len(o.order))
+	for i, p := range o.order {
+		ids[i] = p.EntityID()
+	}
+	return ids
+}
+
+type defeatEncounter struct {
+	*conditionEncounter
+
+	order   *listTurnOrder
+	victory []Condition
+	defeat  []Condition
+	checks  int
+}
+
+func (e *defeatEncounter) TurnOrder() TurnOrder { return e.order }
+
+func (e *defeatEncounter) CheckVictory(ctx context.Context) (bool, string) {
+	e.checks++
+	return checkConditions(ctx, e, e.victory)
+}
+
+func (e *defeatEncounter) CheckDefeat(ctx context.Context) (bool, string) {
+	return checkConditions(ctx, e, e.defeat)
+}
+
+func checkConditions(ctx context.Context, e Encounter, conditions []Condition) (bool, string) {
+	for _, c := range conditions {
+		if c.Check(ctx, e) {
+			return true, c.ID()
+		}
+	}
+	return false, ""
+}
+
+func TestSetParticipantDefeated(t *testing.T) {
+	ctx := context.Background()
+
+	newScene := func() (*defeatEncounter, *BaseTimeline) {
+		members := []*defeatParticipant{
+			{conditionParticipant: &conditionParticipant{testParticipant: &testParticipant{id: "hero"}, team: TeamPlayer}, initiative: 20},
+			{conditionParticipant: &conditionParticipant{testParticipant: &testParticipant{id: "goblin"}, team: TeamEnemy}, initiative: 15},
+			{conditionParticipant: &conditionParticipant{testParticipant: &testParticipant{id: "orc"}, team: TeamEnemy}, initiative: 10},
+		}
+		participants := make(map[string]Participant, len(members))
+		order := &listTurnOrder{}
+		for _, p := range members {
+			participants[p.EntityID()] = p
+			order.order = append(order.order, p)
+		}
+		encounter := &defeatEncounter{
+			conditionEncounter: &conditionEncounter{testEncounter: &testEncounter{participants: participants}, round: 2},
+			order:              order,
+			victory:            []Condition{NewEntitiesDefeatedCondition("all_enemies")},
+			defeat:             []Condition{NewEntitiesDefeatedCondition("hero_down", "hero")},
+		}
+		return encounter, NewTimeline()
+	}
+
+	t.Run("defeating last enemy triggers victory", func(t *testing.T) {
+		encounter, timeline := newScene()
+
+		first, err := SetParticipantDefeated(ctx, encounter, timeline, "goblin", true)
+		require.NoError(t, err)
+		assert.True(t, first.Changed)
+		assert.False(t, first.Victory)
+		assert.Equal(t, []string{"hero", "orc"}, encounter.order.ids())
+
+		last, err := SetParticipantDefeated(ctx, encounter, timeline, "orc", true)
+		require.NoError(t, err)
+		assert.True(t, last.Victory)
+		assert.Equal(t, "all_enemies", last.VictoryReason)
+		assert.False(t, last.Defeat)
+		assert.Equal(t, 2, encounter.checks)
+		assert.Equal(t, []string{"hero"}, encounter.order.ids())
+
+		events := timeline.GetEventsByType(EventEntityDefeated)
+		require.Len(t, events, 2)
+		assert.Equal(t, []string{"orc"}, events[1].ParticipantIDs())
+		assert.Equal(t, 2, events[1].Round())
+		assert.Equal(t, SeverityCritical, events[1].Severity())
+	})
+
+	t.Run("revive re-inserts by initiative", func(t *testing.T) {
+		encounter, timeline := newScene()
+		_, err := SetParticipantDefeated(ctx, encounter, timeline, "goblin", true)
+		require.NoError(t, err)
+
+		revived, err := SetParticipantDefeated(ctx, encounter, timeline, "goblin", false)
+		require.NoError(t, err)
+
+		assert.True(t, revived.Changed)
+		assert.False(t, encounter.participants["goblin"].IsDefeated())
+		assert.Equal(t, []string{"hero", "goblin", "orc"}, encounter.order.ids())
+		require.Len(t, timeline.GetEventsByType(EventEntityRevived), 1)
+		assert.Equal(t, revived.Event, timeline.GetEventsByType(EventEntityRevived)[0])
+	})
+
+	t.Run("repeated state is a no-op", func(t *testing.T) {
+		encounter, timeline := newScene()
+
+		result, err := SetParticipantDefeated(ctx, encounter, timeline, "hero", false)
+		require.NoError(t, err)
+
+		assert.False(t, result.Changed)
+		assert.Nil(t, result.Event)
+		assert.Zero(t, timeline.Size())
+		assert.Len(t, encounter.order.ids(), 3)
+	})
+
+	t.Run("hero defeat reports defeat", func(t *testing.T) {
+		encounter, _ := newScene()
+
+		result, err := SetParticipantDefeated(ctx, encounter, nil, "hero", true)
+		require.NoError(t, err)
+
+		assert.True(t, result.Defeat)
+		assert.Equal(t, "hero_down", result.DefeatReason)
+	})
+
+	t.Run("unknown participant", func(t *testing.T) {
+		encounter, timeline := newScene()
+
+		_, err := SetParticipantDefeated(ctx, encounter, timeline, "ghost", true)
+		assert.ErrorIs(t, err, ErrParticipantNotFound)
+	})
+}
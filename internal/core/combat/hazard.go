@@ -0,0 +1,361 @@
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/world/spatial"
+)
+
+// =============================================================================
+// BASE HAZARD
+// =============================================================================
+
+const defaultHazardTickInterval int64 = 1000
+
+var _ Hazard = (*BaseHazard)(nil)
+
+// BaseHazard is area hazard dealing damage every tick interval to entities inside
+type BaseHazard struct {
+	mu sync.RWMutex
+
+	id           string
+	name         string
+	description  string
+	hazardType   HazardType
+	area         spatial.Area
+	damage       float64
+	damageType   DamageType
+	tickInterval int64
+	statusEffect string
+	statusChance float64
+	icon         string
+	visualEffect string
+
+	active    bool
+	duration  int64 // -1 = permanent
+	remaining int64
+	tickAccum int64
+
+	entities map[string]struct{}
+	immune   map[string]struct{}
+}
+
+// HazardConfig holds configuration for BaseHazard
+type HazardConfig struct {
+	ID           string
+	Name         string
+	Description  string
+	Type         HazardType
+	Area         spatial.Area
+	Damage       float64 // Per tick
+	DamageType   DamageType
+	TickInterval int64 // ms between ticks (0 = 1000)
+	StatusEffect string
+	StatusChance float64
+	Duration     int64 // ms (0 or negative = permanent)
+	Icon         string
+	VisualEffect string
+	Immune       []string // Entity IDs unaffected by hazard
+}
+
+// NewHazard creates active hazard from config
+func NewHazard(cfg HazardConfig) *BaseHazard {
+	interval := cfg.TickInterval
+	if interval <= 0 {
+		interval = defaultHazardTickInterval
+	}
+	duration := cfg.Duration
+	if duration <= 0 {
+		duration = -1
+	}
+
+	immune := make(map[string]struct{}, len(cfg.Immune))
+	for _, id := range cfg.Immune {
+		immune[id] = struct{}{}
+	}
+
+	return &BaseHazard{
+		id:           cfg.ID,
+		name:         cfg.Name,
+		description:  cfg.Description,
+		hazardType:   cfg.Type,
+		area:         cfg.Area,
+		damage:       cfg.Damage,
+		damageType:   cfg.DamageType,
+		tickInterval: interval,
+		statusEffect: cfg.StatusEffect,
+		statusChance: cfg.StatusChance,
+		icon:         cfg.Icon,
+		visualEffect: cfg.VisualEffect,
+		active:       true,
+		duration:     duration,
+		remaining:    duration,
+		entities:     make(map[string]struct{}),
+		immune:       immune,
+	}
+}
+
+func (h *BaseHazard) ID() string             { return h.id }
+func (h *BaseHazard) Name() string           { return h.name }
+func (h *BaseHazard) Description() string    { return h.description }
+func (h *BaseHazard) Type() HazardType       { return h.hazardType }
+func (h *BaseHazard) Area() spatial.Area     { return h.area }
+func (h *BaseHazard) DamageType() DamageType { return h.damageType }
+func (h *BaseHazard) TickInterval() int64    { return h.tickInterval }
+func (h *BaseHazard) StatusEffect() string   { return h.statusEffect }
+func (h *BaseHazard) StatusChance() float64  { return h.statusChance }
+func (h *BaseHazard) Icon() string           { return h.icon }
+func (h *BaseHazard) VisualEffect() string   { return h.visualEffect }
+func (h *BaseHazard) Duration() int64        { return h.duration }
+
+func (h *BaseHazard) IsActive() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.active
+}
+
+func (h *BaseHazard) Activate() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.active = true
+}
+
+func (h *BaseHazard) Deactivate() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.active = false
+}
+
+func (h *BaseHazard) Toggle() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.active = !h.active
+}
+
+func (h *BaseHazard) Damage() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.damage
+}
+
+func (h *BaseHazard) SetDamage(damage float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.damage = damage
+}
+
+func (h *BaseHazard) RemainingDuration() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.remaining
+}
+
+func (h *BaseHazard) IsExpired() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.duration > 0 && h.remaining <= 0
+}
+
+// Advance consumes deltaMs of hazard lifetime and returns number of damage
+// ticks elapsed. Time past expiry does not tick; inactive hazards still age.
+func (h *BaseHazard) Advance(deltaMs int64) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if deltaMs <= 0 || (h.duration > 0 && h.remaining <= 0) {
+		return 0
+	}
+
+	elapsed := deltaMs
+	if h.duration > 0 {
+		elapsed = min(elapsed, h.remaining)
+		h.remaining -= elapsed
+	}
+
+	if !h.active {
+		h.tickAccum = 0
+		return 0
+	}
+
+	h.tickAccum += elapsed
+	ticks := h.tickAccum / h.tickInterval
+	h.tickAccum %= h.tickInterval
+	return int(ticks)
+}
+
+func (h *BaseHazard) OnEnter(ctx context.Context, entityID string, encounter Encounter) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entities[entityID] = struct{}{}
+	return nil
+}
+
+// OnTick applies one tick of damage to entity; deltaMs is ignored
+func (h *BaseHazard) OnTick(ctx context.Context, entityID string, deltaMs int64, encounter Encounter) error {
+	participant, ok := encounter.GetParticipant(entityID)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTargetNotFound, entityID)
+	}
+
+	target := participant.Entity()
+	if target == nil {
+		return nil
+	}
+	_, err := target.Damage(ctx, h.Damage(), h.id)
+	return err
+}
+
+func (h *BaseHazard) OnExit(ctx context.Context, entityID string, encounter Encounter) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.entities, entityID)
+	return nil
+}
+
+func (h *BaseHazard) IsImmuneToHazard(entityID string, encounter Encounter) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	_, immune := h.immune[entityID]
+	return immune
+}
+
+func (h *BaseHazard) EntitiesInHazard() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]string, 0, len(h.entities))
+	for id := range h.entities {
+		result = append(result, id)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// =============================================================================
+// HAZARD TICKING
+// =============================================================================
+
+// TickHazards advances every hazard of arena by deltaMs.
+// Participants standing in hazard area take its effect once per elapsed tick,
+// enter/exit hooks follow their positions, and expired hazards are removed.
+// Arena implementations delegate Arena.TickHazards here.
+// Returns timeline events for dealt damage and expired hazards.
+func TickHazards(ctx context.Context, arena Arena, encounter Encounter, deltaMs int64) ([]TimelineEvent, error) {
+	var events []TimelineEvent
+	var errs []error
+
+	for _, hazard := range arena.Hazards() {
+		ticks := hazard.Advance(deltaMs)
+
+		inside, err := syncHazardOccupants(ctx, hazard, encounter)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		for range ticks {
+			for _, participant := range inside {
+				if participant.IsDefeated() {
+					continue
+				}
+				before := participantHealth(participant)
+				if err := hazard.OnTick(ctx, participant.EntityID(), hazard.TickInterval(), encounter); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				dealt := max(before-participantHealth(participant), 0)
+				events = append(events, hazardDamageEvent(hazard, participant.EntityID(), dealt, encounter))
+			}
+		}
+
+		if !hazard.IsExpired() {
+			continue
+		}
+		for _, entityID := range hazard.EntitiesInHazard() {
+			if err := hazard.OnExit(ctx, entityID, encounter); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := arena.RemoveHazard(hazard.ID()); err != nil {
+			errs = append(errs, err)
+		}
+		events = append(events, NewTimelineEvent(TimelineEventConfig{
+			Type:        EventHazardExpired,
+			Round:       encounter.RoundNumber(),
+			Data:        map[string]any{"hazard_id": hazard.ID(), "hazard_type": hazard.Type()},
+			Description: fmt.Sprintf("%s fades", hazard.Name()),
+			Severity:    SeverityLow,
+		}))
+	}
+
+	return events, errors.Join(errs...)
+}
+
+// syncHazardOccupants fires enter/exit hooks for participants whose position
+// changed relative to hazard area and returns affected participants inside
+func syncHazardOccupants(ctx context.Context, hazard Hazard, encounter Encounter) ([]Participant, error) {
+	var errs []error
+	wasInside := make(map[string]bool)
+	for _, id := range hazard.EntitiesInHazard() {
+		wasInside[id] = true
+	}
+
+	var inside []Participant
+	for _, participant := range encounter.Participants() {
+		id := participant.EntityID()
+		in := !participant.IsDefeated() && hazard.Area().Contains(participant.Position())
+
+		switch {
+		case in && !wasInside[id]:
+			if err := hazard.OnEnter(ctx, id, encounter); err != nil {
+				errs = append(errs, err)
+			}
+		case !in && wasInside[id]:
+			if err := hazard.OnExit(ctx, id, encounter); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		delete(wasInside, id)
+
+		if in && !hazard.IsImmuneToHazard(id, encounter) {
+			inside = append(inside, participant)
+		}
+	}
+
+	// Participants that left encounter
+	for id := range wasInside {
+		if err := hazard.OnExit(ctx, id, encounter); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return inside, errors.Join(errs...)
+}
+
+// participantHealth returns current health of participant entity, 0 without one
+func participantHealth(participant Participant) float64 {
+	if e := participant.Entity(); e != nil {
+		return e.Health()
+	}
+	return 0
+}
+
+// hazardDamageEvent records damage hazard tick actually dealt after mitigation
+func hazardDamageEvent(hazard Hazard, entityID string, dealt float64, encounter Encounter) TimelineEvent {
+	return NewTimelineEvent(TimelineEventConfig{
+		Type:           EventDamageDealt,
+		Round:          encounter.RoundNumber(),
+		ParticipantIDs: []string{entityID},
+		Data: map[string]any{
+			"hazard_id":   hazard.ID(),
+			"hazard_type": hazard.Type(),
+			"damage":      dealt,
+			"damage_type": hazard.DamageType(),
+		},
+		Description: fmt.Sprintf("%s takes %g %s damage from %s", entityID, dealt, hazard.DamageType(), hazard.Name()),
+		Severity:    SeverityNormal,
+	})
+}
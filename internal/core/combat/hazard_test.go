@@ -0,0 +1,184 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/entity"
+	"github.com/davidmovas/Depthborn/internal/world/spatial"
+)
+
+type testCombatant struct {
+	entity.Combatant
+
+	health float64
+	armor  float64
+}
+
+func (c *testCombatant) Health() float64 { return c.health }
+
+func (c *testCombatant) Damage(ctx context.Context, amount float64, sourceID string) (float64, error) {
+	dealt := max(amount-c.armor, 0)
+	c.health -= dealt
+	return dealt, nil
+}
+
+type hazardParticipant struct {
+	*testParticipant
+
+	combatant *testCombatant
+}
+
+func (p *hazardParticipant) Entity() entity.Combatant { return p.combatant }
+
+type hazardEncounter struct {
+	*testEncounter
+}
+
+func (e *hazardEncounter) RoundNumber() int { return 1 }
+
+func (e *hazardEncounter) Participants() []Participant {
+	result := make([]Participant, 0, len(e.participants))
+	for _, p := range e.participants {
+		result = append(result, p)
+	}
+	return result
+}
+
+type testArena struct {
+	Arena
+
+	hazards []Hazard
+}
+
+func (a *testArena) Hazards() []Hazard { return append([]Hazard{}, a.hazards...) }
+
+func (a *testArena) RemoveHazard(hazardID string) error {
+	for i, h := range a.hazards {
+		if h.ID() == hazardID {
+			a.hazards = append(a.hazards[:i], a.hazards[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func TestTickHazards(t *testing.T) {
+	ctx := context.Background()
+
+	newScene := func() (*testArena, *hazardEncounter, *hazardParticipant, *hazardParticipant, *BaseHazard) {
+		burning := &hazardParticipant{
+			testParticipant: &testParticipant{id: "hero", pos: spatial.NewPosition(1, 1, 0)},
+			combatant:       &testCombatant{health: 100},
+		}
+		safe := &hazardParticipant{
+			testParticipant: &testParticipant{id: "ally", pos: spatial.NewPosition(5, 5, 0)},
+			combatant:       &testCombatant{health: 100},
+		}
+		fire := NewHazard(HazardConfig{
+			ID:           "fire-1",
+			Name:         "Burning Ground",
+			Type:         HazardFire,
+			Area:         spatial.NewRectangleArea(spatial.NewPosition(0, 0, 0), spatial.NewPosition(2, 2, 0)),
+			Damage:       10,
+			DamageType:   DamageFire,
+			TickInterval: 1000,
+			Duration:     3000,
+		})
+		encounter := &hazardEncounter{&testEncounter{participants: map[string]Participant{
+			"hero": burning,
+			"ally": safe,
+		}}}
+		return &testArena{hazards: []Hazard{fire}}, encounter, burning, safe, fire
+	}
+
+	t.Run("participant in hazard takes damage each tick", func(t *testing.T) {
+		arena, encounter, burning, safe, fire := newScene()
+
+		events, err := TickHazards(ctx, arena, encounter, 1000)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, EventDamageDealt, events[0].Type())
+		assert.Equal(t, []string{"hero"}, events[0].ParticipantIDs())
+		assert.Equal(t, "fire-1", events[0].Data()["hazard_id"])
+		assert.Equal(t, 90.0, burning.combatant.health)
+		assert.Equal(t, 100.0, safe.combatant.health)
+		assert.Equal(t, []string{"hero"}, fire.EntitiesInHazard())
+
+		// Half a tick does nothing, the rest completes it
+		events, err = TickHazards(ctx, arena, encounter, 500)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		_, err = TickHazards(ctx, arena, encounter, 500)
+		require.NoError(t, err)
+		assert.Equal(t, 80.0, burning.combatant.health)
+	})
+
+	t.Run("event records mitigated damage", func(t *testing.T) {
+		arena, encounter, burning, _, _ := newScene()
+		burning.combatant.armor = 4
+
+		events, err := TickHazards(ctx, arena, encounter, 1000)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, 6.0, events[0].Data()["damage"])
+		assert.Equal(t, 94.0, burning.combatant.health)
+	})
+
+	t.Run("no damage after expiry", func(t *testing.T) {
+		arena, encounter, burning, _, fire := newScene()
+
+		// Runs past 3s duration: only 3 ticks land
+		events, err := TickHazards(ctx, arena, encounter, 5000)
+		require.NoError(t, err)
+		assert.Equal(t, 70.0, burning.combatant.health)
+		require.Len(t, events, 4)
+		assert.Equal(t, EventHazardExpired, events[3].Type())
+		assert.True(t, fire.IsExpired())
+		assert.Empty(t, arena.Hazards())
+		assert.Empty(t, fire.EntitiesInHazard())
+
+		_, err = TickHazards(ctx, arena, encounter, 2000)
+		require.NoError(t, err)
+		assert.Equal(t, 70.0, burning.combatant.health)
+	})
+
+	t.Run("leaving area stops damage", func(t *testing.T) {
+		arena, encounter, burning, _, fire := newScene()
+
+		_, err := TickHazards(ctx, arena, encounter, 1000)
+		require.NoError(t, err)
+
+		burning.pos = spatial.NewPosition(4, 4, 0)
+		_, err = TickHazards(ctx, arena, encounter, 1000)
+		require.NoError(t, err)
+		assert.Equal(t, 90.0, burning.combatant.health)
+		assert.Empty(t, fire.EntitiesInHazard())
+	})
+
+	t.Run("immune and inactive", func(t *testing.T) {
+		arena, encounter, burning, _, _ := newScene()
+		immuneFire := NewHazard(HazardConfig{
+			ID:     "fire-2",
+			Area:   spatial.NewRectangleArea(spatial.NewPosition(0, 0, 0), spatial.NewPosition(2, 2, 0)),
+			Damage: 50,
+			Immune: []string{"hero"},
+		})
+		dormant := NewHazard(HazardConfig{
+			ID:     "fire-3",
+			Area:   spatial.NewRectangleArea(spatial.NewPosition(0, 0, 0), spatial.NewPosition(2, 2, 0)),
+			Damage: 50,
+		})
+		dormant.Deactivate()
+		arena.hazards = []Hazard{immuneFire, dormant}
+
+		events, err := TickHazards(ctx, arena, encounter, 2000)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Equal(t, 100.0, burning.combatant.health)
+		assert.Equal(t, int64(-1), dormant.RemainingDuration())
+	})
+}
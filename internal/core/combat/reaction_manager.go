@@ -0,0 +1,247 @@
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrReactionExists   = errors.New("reaction already registered")
+	ErrReactionNotFound = errors.New("reaction not found")
+	ErrInvalidReaction  = errors.New("invalid reaction")
+)
+
+// =============================================================================
+// REACTION MANAGER
+// =============================================================================
+
+// ReactionManager tracks participant reactions and decides which respond to events
+type ReactionManager interface {
+	// Register adds reaction under trigger with guard options
+	Register(reaction Reaction, opts ReactionOptions) error
+
+	// Unregister removes reaction
+	Unregister(reactionID string) error
+
+	// CheckReactions returns reactions eligible for event in priority order.
+	// Returned reactions are marked triggered: guards and cooldowns start immediately.
+	CheckReactions(ctx context.Context, event ReactionEvent, encounter Encounter) []Reaction
+
+	// AdvanceTurn resets once-per-turn guards and ticks cooldowns by one turn
+	AdvanceTurn()
+
+	// Cooldown returns turns left before reaction can trigger again
+	Cooldown(reactionID string) int
+
+	// OnReactionTriggered registers callback for triggered reactions
+	OnReactionTriggered(callback ReactionCallback)
+}
+
+// ReactionEvent describes occurrence that may provoke reactions
+type ReactionEvent struct {
+	Trigger  TriggerType
+	SourceID string // Participant causing event (attacker, caster)
+	TargetID string // Participant affected by event
+	ActionID string
+	Data     map[string]any
+}
+
+// ReactionCondition decides if reaction answers event.
+// Nil condition means reaction answers only events targeting its owner.
+type ReactionCondition func(ctx context.Context, reaction Reaction, event ReactionEvent, encounter Encounter) bool
+
+// ReactionOptions configures how reaction is triggered
+type ReactionOptions struct {
+	Trigger     TriggerType
+	Condition   ReactionCondition
+	Cooldown    int  // Turns before reaction can trigger again (0 = none)
+	OncePerTurn bool // Reaction triggers at most once between AdvanceTurn calls
+}
+
+// ReactionCallback is invoked for every triggered reaction
+type ReactionCallback func(ctx context.Context, reaction Reaction, event ReactionEvent)
+
+var _ ReactionManager = (*BaseReactionManager)(nil)
+
+// BaseReactionManager implements ReactionManager
+type BaseReactionManager struct {
+	mu sync.RWMutex
+
+	entries   map[string]*reactionEntry
+	byTrigger map[TriggerType][]string
+
+	onTriggered []ReactionCallback
+}
+
+type reactionEntry struct {
+	reaction   Reaction
+	opts       ReactionOptions
+	cooldown   int
+	usedInTurn bool
+}
+
+// NewReactionManager creates empty reaction manager
+func NewReactionManager() *BaseReactionManager {
+	return &BaseReactionManager{
+		entries:   make(map[string]*reactionEntry),
+		byTrigger: make(map[TriggerType][]string),
+	}
+}
+
+func (m *BaseReactionManager) Register(reaction Reaction, opts ReactionOptions) error {
+	if reaction == nil || reaction.ID() == "" {
+		return fmt.Errorf("%w: reaction ID required", ErrInvalidReaction)
+	}
+	if opts.Trigger == "" {
+		return fmt.Errorf("%w: %s has no trigger", ErrInvalidReaction, reaction.ID())
+	}
+	if opts.Cooldown < 0 {
+		return fmt.Errorf("%w: %s has negative cooldown", ErrInvalidReaction, reaction.ID())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.entries[reaction.ID()]; exists {
+		return fmt.Errorf("%w: %s", ErrReactionExists, reaction.ID())
+	}
+
+	m.entries[reaction.ID()] = &reactionEntry{
+		reaction: reaction,
+		opts:     opts,
+	}
+	m.byTrigger[opts.Trigger] = append(m.byTrigger[opts.Trigger], reaction.ID())
+	return nil
+}
+
+func (m *BaseReactionManager) Unregister(reactionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[reactionID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrReactionNotFound, reactionID)
+	}
+
+	ids := m.byTrigger[entry.opts.Trigger]
+	for i, id := range ids {
+		if id == reactionID {
+			m.byTrigger[entry.opts.Trigger] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	delete(m.entries, reactionID)
+	return nil
+}
+
+func (m *BaseReactionManager) CheckReactions(ctx context.Context, event ReactionEvent, encounter Encounter) []Reaction {
+	m.mu.RLock()
+	var candidates []*reactionEntry
+	for _, id := range m.byTrigger[event.Trigger] {
+		entry := m.entries[id]
+		if isReadyLocked(entry) {
+			candidates = append(candidates, entry)
+		}
+	}
+	m.mu.RUnlock()
+
+	var eligible []*reactionEntry
+	for _, entry := range candidates {
+		if meetsCondition(ctx, entry, event, encounter) {
+			eligible = append(eligible, entry)
+		}
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].reaction.Priority() > eligible[j].reaction.Priority()
+	})
+
+	m.mu.Lock()
+	result := make([]Reaction, 0, len(eligible))
+	for _, entry := range eligible {
+		if m.entries[entry.reaction.ID()] != entry || !isReadyLocked(entry) {
+			continue
+		}
+		entry.cooldown = entry.opts.Cooldown
+		entry.usedInTurn = true
+		entry.reaction.DecrementUses()
+		result = append(result, entry.reaction)
+	}
+
+	callbacks := append([]ReactionCallback{}, m.onTriggered...)
+	m.mu.Unlock()
+
+	for _, reaction := range result {
+		for _, cb := range callbacks {
+			cb(ctx, reaction, event)
+		}
+	}
+	return result
+}
+
+// isReadyLocked checks cooldown, once-per-turn and remaining uses of entry
+func isReadyLocked(entry *reactionEntry) bool {
+	if entry.cooldown > 0 {
+		return false
+	}
+	if entry.opts.OncePerTurn && entry.usedInTurn {
+		return false
+	}
+	return !entry.reaction.IsExpended()
+}
+
+// meetsCondition evaluates owner state, condition and CanTrigger of entry.
+// Must be called without manager lock held.
+func meetsCondition(ctx context.Context, entry *reactionEntry, event ReactionEvent, encounter Encounter) bool {
+	reaction := entry.reaction
+	if encounter != nil {
+		if owner, ok := encounter.GetParticipant(reaction.OwnerID()); ok && owner.IsDefeated() {
+			return false
+		}
+	}
+
+	if entry.opts.Condition != nil {
+		if !entry.opts.Condition(ctx, reaction, event, encounter) {
+			return false
+		}
+	} else if event.TargetID != reaction.OwnerID() {
+		return false
+	}
+
+	return reaction.CanTrigger(ctx, encounter)
+}
+
+func (m *BaseReactionManager) AdvanceTurn() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, entry := range m.entries {
+		entry.usedInTurn = false
+		if entry.cooldown > 0 {
+			entry.cooldown--
+		}
+	}
+}
+
+func (m *BaseReactionManager) Cooldown(reactionID string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if entry, exists := m.entries[reactionID]; exists {
+		return entry.cooldown
+	}
+	return 0
+}
+
+func (m *BaseReactionManager) OnReactionTriggered(callback ReactionCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onTriggered = append(m.onTriggered, callback)
+}
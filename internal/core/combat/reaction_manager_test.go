@@ -0,0 +1,135 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReaction struct {
+	Reaction
+
+	id       string
+	owner    string
+	priority int
+	uses     int
+}
+
+func (r *testReaction) ID() string                                               { return r.id }
+func (r *testReaction) OwnerID() string                                          { return r.owner }
+func (r *testReaction) Priority() int                                            { return r.priority }
+func (r *testReaction) CanTrigger(ctx context.Context, encounter Encounter) bool { return true }
+func (r *testReaction) IsExpended() bool                                         { return r.uses == 0 }
+
+func (r *testReaction) DecrementUses() {
+	if r.uses > 0 {
+		r.uses--
+	}
+}
+
+func TestBaseReactionManager(t *testing.T) {
+	ctx := context.Background()
+
+	hit := ReactionEvent{Trigger: TriggerOnHit, SourceID: "goblin", TargetID: "hero"}
+
+	t.Run("riposte fires on hit and waits for cooldown", func(t *testing.T) {
+		m := NewReactionManager()
+		riposte := &testReaction{id: "riposte", owner: "hero", uses: -1}
+		require.NoError(t, m.Register(riposte, ReactionOptions{Trigger: TriggerOnHit, Cooldown: 2}))
+
+		triggered := m.CheckReactions(ctx, hit, nil)
+		require.Len(t, triggered, 1)
+		assert.Equal(t, "riposte", triggered[0].ID())
+		assert.Equal(t, 2, m.Cooldown("riposte"))
+
+		assert.Empty(t, m.CheckReactions(ctx, hit, nil))
+
+		m.AdvanceTurn()
+		assert.Empty(t, m.CheckReactions(ctx, hit, nil))
+
+		m.AdvanceTurn()
+		assert.Len(t, m.CheckReactions(ctx, hit, nil), 1)
+	})
+
+	t.Run("ignores other triggers and targets", func(t *testing.T) {
+		m := NewReactionManager()
+		require.NoError(t, m.Register(&testReaction{id: "riposte", owner: "hero", uses: -1}, ReactionOptions{Trigger: TriggerOnHit}))
+
+		assert.Empty(t, m.CheckReactions(ctx, ReactionEvent{Trigger: TriggerOnBlock, TargetID: "hero"}, nil))
+		assert.Empty(t, m.CheckReactions(ctx, ReactionEvent{Trigger: TriggerOnHit, TargetID: "ally"}, nil))
+	})
+
+	t.Run("once per turn guard", func(t *testing.T) {
+		m := NewReactionManager()
+		require.NoError(t, m.Register(&testReaction{id: "parry", owner: "hero", uses: -1}, ReactionOptions{Trigger: TriggerOnHit, OncePerTurn: true}))
+
+		assert.Len(t, m.CheckReactions(ctx, hit, nil), 1)
+		assert.Empty(t, m.CheckReactions(ctx, hit, nil))
+
+		m.AdvanceTurn()
+		assert.Len(t, m.CheckReactions(ctx, hit, nil), 1)
+	})
+
+	t.Run("priority order and custom condition", func(t *testing.T) {
+		m := NewReactionManager()
+		guardAlly := func(ctx context.Context, r Reaction, ev ReactionEvent, enc Encounter) bool {
+			return ev.TargetID == "hero"
+		}
+
+		require.NoError(t, m.Register(&testReaction{id: "counter", owner: "hero", priority: 1, uses: -1}, ReactionOptions{Trigger: TriggerOnHit}))
+		require.NoError(t, m.Register(&testReaction{id: "intercept", owner: "paladin", priority: 5, uses: -1}, ReactionOptions{Trigger: TriggerOnHit, Condition: guardAlly}))
+
+		var fired []string
+		m.OnReactionTriggered(func(ctx context.Context, r Reaction, ev ReactionEvent) {
+			fired = append(fired, r.ID())
+		})
+
+		triggered := m.CheckReactions(ctx, hit, nil)
+		require.Len(t, triggered, 2)
+		assert.Equal(t, "intercept", triggered[0].ID())
+		assert.Equal(t, "counter", triggered[1].ID())
+		assert.Equal(t, []string{"intercept", "counter"}, fired)
+	})
+
+	t.Run("condition may call back into manager", func(t *testing.T) {
+		m := NewReactionManager()
+		var nested []Reaction
+		reentered := false
+		condition := func(ctx context.Context, r Reaction, ev ReactionEvent, enc Encounter) bool {
+			if !reentered {
+				reentered = true
+				nested = m.CheckReactions(ctx, ev, enc)
+			}
+			return m.Cooldown(r.ID()) == 0
+		}
+		require.NoError(t, m.Register(&testReaction{id: "parry", owner: "hero", uses: -1}, ReactionOptions{Trigger: TriggerOnHit, OncePerTurn: true, Condition: condition}))
+
+		// Nested check consumed the once-per-turn parry before outer check marked it
+		assert.Empty(t, m.CheckReactions(ctx, hit, nil))
+		require.Len(t, nested, 1)
+		assert.Equal(t, "parry", nested[0].ID())
+	})
+
+	t.Run("expended reactions are skipped", func(t *testing.T) {
+		m := NewReactionManager()
+		require.NoError(t, m.Register(&testReaction{id: "last_stand", owner: "hero", uses: 1}, ReactionOptions{Trigger: TriggerOnHit}))
+
+		assert.Len(t, m.CheckReactions(ctx, hit, nil), 1)
+		assert.Empty(t, m.CheckReactions(ctx, hit, nil))
+	})
+
+	t.Run("registration errors", func(t *testing.T) {
+		m := NewReactionManager()
+		r := &testReaction{id: "riposte", owner: "hero", uses: -1}
+
+		assert.ErrorIs(t, m.Register(r, ReactionOptions{}), ErrInvalidReaction)
+		require.NoError(t, m.Register(r, ReactionOptions{Trigger: TriggerOnHit}))
+		assert.ErrorIs(t, m.Register(r, ReactionOptions{Trigger: TriggerOnHit}), ErrReactionExists)
+
+		require.NoError(t, m.Unregister("riposte"))
+		assert.ErrorIs(t, m.Unregister("riposte"), ErrReactionNotFound)
+		assert.Empty(t, m.CheckReactions(ctx, hit, nil))
+	})
+}
@@ -259,6 +259,12 @@ type Timeline interface {
 	// GetEventsByTurn returns events from specific turn
 	GetEventsByTurn(round, turn int) []TimelineEvent
 
+	// GetEventsBySeverity returns events with severity at least min
+	GetEventsBySeverity(min EventSeverity) []TimelineEvent
+
+	// GetEventsInWindow returns events with timestamp in [startMs, endMs]
+	GetEventsInWindow(startMs, endMs int64) []TimelineEvent
+
 	// GetRecentEvents returns N most recent events
 	GetRecentEvents(count int) []TimelineEvent
 
@@ -328,6 +334,7 @@ const (
 	EventBlocked           EventType = "blocked"
 	EventEvaded            EventType = "evaded"
 	EventCountered         EventType = "countered"
+	EventHazardExpired     EventType = "hazard_expired"
 )
 
 // EventSeverity indicates event importance
@@ -0,0 +1,331 @@
+package combat
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/davidmovas/Depthborn/pkg/identifier"
+	"github.com/davidmovas/Depthborn/pkg/persist"
+)
+
+// TypeTimelineEvent is persist type name of BaseTimelineEvent
+const TypeTimelineEvent = "combat.timeline_event"
+
+// TimelineClock returns current time in milliseconds. Events created without
+// timestamp are stamped with it; replace for replays and tests.
+var TimelineClock = func() int64 { return time.Now().UnixMilli() }
+
+func init() {
+	if err := persist.RegisterType(TypeTimelineEvent, func() any { return &BaseTimelineEvent{} }); err != nil {
+		panic(err)
+	}
+}
+
+// =============================================================================
+// TIMELINE EVENT
+// =============================================================================
+
+var _ TimelineEvent = (*BaseTimelineEvent)(nil)
+
+// BaseTimelineEvent is immutable TimelineEvent
+type BaseTimelineEvent struct {
+	id             string
+	eventType      EventType
+	timestamp      int64
+	round          int
+	turn           int
+	participantIDs []string
+	data           map[string]any
+	description    string
+	severity       EventSeverity
+}
+
+// TimelineEventConfig holds configuration for BaseTimelineEvent
+type TimelineEventConfig struct {
+	ID             string // Generated when empty
+	Type           EventType
+	Timestamp      int64 // TimelineClock when zero
+	Round          int
+	Turn           int
+	ParticipantIDs []string
+	Data           map[string]any
+	Description    string
+	Severity       EventSeverity
+}
+
+// NewTimelineEvent creates timeline event from config
+func NewTimelineEvent(cfg TimelineEventConfig) *BaseTimelineEvent {
+	id := cfg.ID
+	if id == "" {
+		id = identifier.New()
+	}
+	timestamp := cfg.Timestamp
+	if timestamp == 0 {
+		timestamp = TimelineClock()
+	}
+	return &BaseTimelineEvent{
+		id:             id,
+		eventType:      cfg.Type,
+		timestamp:      timestamp,
+		round:          cfg.Round,
+		turn:           cfg.Turn,
+		participantIDs: slices.Clone(cfg.ParticipantIDs),
+		data:           maps.Clone(cfg.Data),
+		description:    cfg.Description,
+		severity:       cfg.Severity,
+	}
+}
+
+func (e *BaseTimelineEvent) ID() string               { return e.id }
+func (e *BaseTimelineEvent) Type() EventType          { return e.eventType }
+func (e *BaseTimelineEvent) Timestamp() int64         { return e.timestamp }
+func (e *BaseTimelineEvent) Round() int               { return e.round }
+func (e *BaseTimelineEvent) Turn() int                { return e.turn }
+func (e *BaseTimelineEvent) Description() string      { return e.description }
+func (e *BaseTimelineEvent) Severity() EventSeverity  { return e.severity }
+func (e *BaseTimelineEvent) ParticipantIDs() []string { return slices.Clone(e.participantIDs) }
+func (e *BaseTimelineEvent) Data() map[string]any     { return maps.Clone(e.data) }
+
+// =============================================================================
+// TIMELINE
+// =============================================================================
+
+var _ Timeline = (*BaseTimeline)(nil)
+
+// BaseTimeline is in-memory Timeline keeping events in record order
+type BaseTimeline struct {
+	mu sync.RWMutex
+
+	events []TimelineEvent
+}
+
+// NewTimeline creates empty timeline
+func NewTimeline() *BaseTimeline {
+	return &BaseTimeline{events: make([]TimelineEvent, 0)}
+}
+
+func (t *BaseTimeline) Record(event TimelineEvent) {
+	if event == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *BaseTimeline) GetEvents() []TimelineEvent {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return slices.Clone(t.events)
+}
+
+func (t *BaseTimeline) GetEventsByType(eventType EventType) []TimelineEvent {
+	return t.filter(func(e TimelineEvent) bool { return e.Type() == eventType })
+}
+
+func (t *BaseTimeline) GetEventsByParticipant(participantID string) []TimelineEvent {
+	return t.filter(func(e TimelineEvent) bool { return slices.Contains(e.ParticipantIDs(), participantID) })
+}
+
+func (t *BaseTimeline) GetEventsByRound(round int) []TimelineEvent {
+	return t.filter(func(e TimelineEvent) bool { return e.Round() == round })
+}
+
+func (t *BaseTimeline) GetEventsByTurn(round, turn int) []TimelineEvent {
+	return t.filter(func(e TimelineEvent) bool { return e.Round() == round && e.Turn() == turn })
+}
+
+// GetEventsBySeverity returns events with severity at least min
+func (t *BaseTimeline) GetEventsBySeverity(min EventSeverity) []TimelineEvent {
+	return t.filter(func(e TimelineEvent) bool { return e.Severity() >= min })
+}
+
+// GetEventsInWindow returns events with timestamp in [startMs, endMs]
+func (t *BaseTimeline) GetEventsInWindow(startMs, endMs int64) []TimelineEvent {
+	return t.filter(func(e TimelineEvent) bool {
+		return e.Timestamp() >= startMs && e.Timestamp() <= endMs
+	})
+}
+
+func (t *BaseTimeline) GetRecentEvents(count int) []TimelineEvent {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if count <= 0 {
+		return []TimelineEvent{}
+	}
+	start := max(len(t.events)-count, 0)
+	return slices.Clone(t.events[start:])
+}
+
+func (t *BaseTimeline) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = make([]TimelineEvent, 0)
+}
+
+// Export returns events with time span, round/turn totals and event-count statistics.
+// Damage and healing totals are read from "damage" and "healing" event data.
+func (t *BaseTimeline) Export() TimelineData {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	data := TimelineData{Events: slices.Clone(t.events)}
+	turns := make(map[[2]int]struct{})
+	for i, e := range t.events {
+		if i == 0 || e.Timestamp() < data.StartTime {
+			data.StartTime = e.Timestamp()
+		}
+		data.EndTime = max(data.EndTime, e.Timestamp())
+		data.TotalRounds = max(data.TotalRounds, e.Round())
+		if e.Turn() > 0 {
+			turns[[2]int{e.Round(), e.Turn()}] = struct{}{}
+		}
+
+		stats := &data.Statistics
+		switch e.Type() {
+		case EventDamageDealt:
+			if v, ok := e.Data()["damage"].(float64); ok {
+				stats.TotalDamage += v
+			}
+		case EventHealingDone:
+			if v, ok := e.Data()["healing"].(float64); ok {
+				stats.TotalHealing += v
+			}
+		case EventActionPerformed:
+			stats.TotalActions++
+		case EventCriticalHit:
+			stats.CriticalHits++
+		case EventMissed:
+			stats.Misses++
+		case EventStatusApplied:
+			stats.StatusesApplied++
+		case EventEntityDefeated:
+			stats.Deaths++
+		case EventEntityRevived:
+			stats.Revivals++
+		}
+	}
+	data.TotalTurns = len(turns)
+	return data
+}
+
+func (t *BaseTimeline) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.events)
+}
+
+func (t *BaseTimeline) filter(match func(TimelineEvent) bool) []TimelineEvent {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	result := make([]TimelineEvent, 0)
+	for _, e := range t.events {
+		if match(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// =============================================================================
+// SERIALIZATION
+// =============================================================================
+
+// TimelineEventState holds serializable timeline event
+type TimelineEventState struct {
+	ID             string         `msgpack:"id"`
+	Type           string         `msgpack:"type"`
+	Timestamp      int64          `msgpack:"timestamp"`
+	Round          int            `msgpack:"round"`
+	Turn           int            `msgpack:"turn"`
+	ParticipantIDs []string       `msgpack:"participant_ids,omitempty"`
+	Data           map[string]any `msgpack:"data,omitempty"`
+	Description    string         `msgpack:"description"`
+	Severity       int            `msgpack:"severity"`
+}
+
+// MarshalBinary implements persist.Marshaler
+func (e *BaseTimelineEvent) MarshalBinary() ([]byte, error) {
+	return persist.DefaultCodec().Encode(TimelineEventState{
+		ID:             e.id,
+		Type:           string(e.eventType),
+		Timestamp:      e.timestamp,
+		Round:          e.round,
+		Turn:           e.turn,
+		ParticipantIDs: e.participantIDs,
+		Data:           e.data,
+		Description:    e.description,
+		Severity:       int(e.severity),
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (e *BaseTimelineEvent) UnmarshalBinary(data []byte) error {
+	var state TimelineEventState
+	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+		return err
+	}
+	e.id = state.ID
+	e.eventType = EventType(state.Type)
+	e.timestamp = state.Timestamp
+	e.round = state.Round
+	e.turn = state.Turn
+	e.participantIDs = state.ParticipantIDs
+	e.data = state.Data
+	e.description = state.Description
+	e.severity = EventSeverity(state.Severity)
+	return nil
+}
+
+// timelineDataState is TimelineData with type-tagged events
+type timelineDataState struct {
+	StartTime        int64                            `msgpack:"start_time"`
+	EndTime          int64                            `msgpack:"end_time"`
+	TotalRounds      int                              `msgpack:"total_rounds"`
+	TotalTurns       int                              `msgpack:"total_turns"`
+	Events           []persist.TypedValue             `msgpack:"events,omitempty"`
+	Statistics       Statistics                       `msgpack:"statistics"`
+	ParticipantStats map[string]ParticipantStatistics `msgpack:"participant_stats,omitempty"`
+}
+
+// MarshalBinary implements persist.Marshaler.
+// Events must be of types registered with persist.
+func (d TimelineData) MarshalBinary() ([]byte, error) {
+	events, err := persist.WrapSlice(persist.DefaultTypeRegistry(), d.Events)
+	if err != nil {
+		return nil, err
+	}
+	return persist.DefaultCodec().Encode(timelineDataState{
+		StartTime:        d.StartTime,
+		EndTime:          d.EndTime,
+		TotalRounds:      d.TotalRounds,
+		TotalTurns:       d.TotalTurns,
+		Events:           events,
+		Statistics:       d.Statistics,
+		ParticipantStats: d.ParticipantStats,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (d *TimelineData) UnmarshalBinary(data []byte) error {
+	var state timelineDataState
+	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+		return err
+	}
+	events, err := persist.UnwrapSlice[TimelineEvent](persist.DefaultTypeRegistry(), state.Events)
+	if err != nil {
+		return err
+	}
+	*d = TimelineData{
+		StartTime:        state.StartTime,
+		EndTime:          state.EndTime,
+		TotalRounds:      state.TotalRounds,
+		TotalTurns:       state.TotalTurns,
+		Events:           events,
+		Statistics:       state.Statistics,
+		ParticipantStats: state.ParticipantStats,
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineDataSerialization(t *testing.T) {
+	data := TimelineData{
+		StartTime:   100,
+		EndTime:     5100,
+		TotalRounds: 2,
+		TotalTurns:  4,
+		Events: []TimelineEvent{
+			NewTimelineEvent(TimelineEventConfig{
+				Type:           EventCombatStart,
+				Round:          1,
+				ParticipantIDs: []string{"hero", "goblin"},
+				Severity:       SeverityHigh,
+			}),
+			NewTimelineEvent(TimelineEventConfig{
+				Type:           EventDamageDealt,
+				Timestamp:      1200,
+				Round:          1,
+				Turn:           2,
+				ParticipantIDs: []string{"goblin"},
+				Data:           map[string]any{"damage": 12.5, "damage_type": "fire"},
+				Description:    "goblin burns",
+				Severity:       SeverityNormal,
+			}),
+		},
+		Statistics:       Statistics{TotalDamage: 12.5, TotalActions: 4},
+		ParticipantStats: map[string]ParticipantStatistics{"hero": {ParticipantID: "hero", DamageDealt: 12.5}},
+	}
+
+	raw, err := data.MarshalBinary()
+	require.NoError(t, err)
+
+	var restored TimelineData
+	require.NoError(t, restored.UnmarshalBinary(raw))
+
+	assert.Equal(t, data, restored)
+	require.Len(t, restored.Events, 2)
+	assert.IsType(t, &BaseTimelineEvent{}, restored.Events[1])
+	assert.Equal(t, 12.5, restored.Events[1].Data()["damage"])
+}
+
+// useTimelineClock replaces TimelineClock for duration of test
+func useTimelineClock(t *testing.T, clock func() int64) {
+	previous := TimelineClock
+	TimelineClock = clock
+	t.Cleanup(func() { TimelineClock = previous })
+}
+
+func TestBaseTimelineFiltering(t *testing.T) {
+	useTimelineClock(t, func() int64 { return 0 }) // start event keeps zero timestamp
+	timeline := NewTimeline()
+	record := func(id string, eventType EventType, timestamp int64, severity EventSeverity) {
+		timeline.Record(NewTimelineEvent(TimelineEventConfig{
+			ID:        id,
+			Type:      eventType,
+			Timestamp: timestamp,
+			Round:     1,
+			Turn:      1,
+			Severity:  severity,
+		}))
+	}
+	record("start", EventCombatStart, 0, SeverityHigh)
+	record("hit", EventDamageDealt, 1500, SeverityNormal)
+	record("crit", EventCriticalHit, 3000, SeverityCritical)
+	record("status", EventStatusApplied, 4000, SeverityLow)
+	record("kill", EventEntityDefeated, 6000, SeverityCritical)
+
+	ids := func(events []TimelineEvent) []string {
+		result := make([]string, len(events))
+		for i, e := range events {
+			result[i] = e.ID()
+		}
+		return result
+	}
+
+	t.Run("by severity", func(t *testing.T) {
+		assert.Equal(t, []string{"crit", "kill"}, ids(timeline.GetEventsBySeverity(SeverityCritical)))
+		assert.Equal(t, []string{"start", "crit", "kill"}, ids(timeline.GetEventsBySeverity(SeverityHigh)))
+		assert.Len(t, timeline.GetEventsBySeverity(SeverityLow), 5)
+	})
+
+	t.Run("unstamped events take clock time", func(t *testing.T) {
+		now := int64(2500)
+		useTimelineClock(t, func() int64 { return now })
+
+		clocked := NewTimeline()
+		clocked.Record(NewTimelineEvent(TimelineEventConfig{ID: "tick", Type: EventDamageDealt}))
+		now = 9000
+		clocked.Record(NewTimelineEvent(TimelineEventConfig{ID: "late", Type: EventDamageDealt}))
+		clocked.Record(NewTimelineEvent(TimelineEventConfig{ID: "preset", Type: EventDamageDealt, Timestamp: 2600}))
+
+		assert.Equal(t, []string{"tick", "preset"}, ids(clocked.GetEventsInWindow(2000, 3000)))
+		assert.Equal(t, int64(9000), clocked.GetEvents()[1].Timestamp())
+	})
+
+	t.Run("in window", func(t *testing.T) {
+		assert.Equal(t, []string{"crit", "status", "kill"}, ids(timeline.GetEventsInWindow(3000, 6000)))
+		assert.Equal(t, []string{"start", "hit"}, ids(timeline.GetEventsInWindow(0, 1500)))
+		assert.Empty(t, timeline.GetEventsInWindow(7000, 9000))
+		assert.Empty(t, timeline.GetEventsInWindow(5000, 1000))
+	})
+
+	t.Run("critical events in last 5 seconds", func(t *testing.T) {
+		var critical []string
+		for _, e := range timeline.GetEventsInWindow(1000, 6000) {
+			if e.Severity() >= SeverityCritical {
+				critical = append(critical, e.ID())
+			}
+		}
+		assert.Equal(t, []string{"crit", "kill"}, critical)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		data := timeline.Export()
+		assert.Equal(t, int64(0), data.StartTime)
+		assert.Equal(t, int64(6000), data.EndTime)
+		assert.Equal(t, 1, data.TotalRounds)
+		assert.Equal(t, 1, data.Statistics.CriticalHits)
+		assert.Equal(t, 1, data.Statistics.Deaths)
+		assert.Len(t, data.Events, timeline.Size())
+		assert.Equal(t, []string{"status", "kill"}, ids(timeline.GetRecentEvents(2)))
+
+		timeline.Clear()
+		assert.Zero(t, timeline.Size())
+	})
+}
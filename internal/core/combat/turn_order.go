@@ -0,0 +1,254 @@
+package combat
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+	"sync"
+)
+
+// =============================================================================
+// TIE BREAKERS
+// =============================================================================
+
+// TieBreaker compares participants with equal initiative. Negative result
+// puts a first, positive puts b first, zero leaves decision to next breaker.
+type TieBreaker func(a, b Participant) int
+
+// TieBreakByStat puts participant with higher looked up stat first
+func TieBreakByStat(stat func(participant Participant) float64) TieBreaker {
+	return func(a, b Participant) int {
+		return cmp.Compare(stat(b), stat(a))
+	}
+}
+
+// TieBreakRandom flips coin derived from seed and entity IDs, so same seed
+// always yields same order
+func TieBreakRandom(seed int64) TieBreaker {
+	return func(a, b Participant) int {
+		return cmp.Compare(seededRoll(seed, a.EntityID()), seededRoll(seed, b.EntityID()))
+	}
+}
+
+func seededRoll(seed int64, entityID string) uint64 {
+	h := fnv.New64a()
+	_ = binary.Write(h, binary.LittleEndian, seed)
+	_, _ = h.Write([]byte(entityID))
+	return h.Sum64()
+}
+
+// =============================================================================
+// BASE TURN ORDER
+// =============================================================================
+
+var _ TurnOrder = (*BaseTurnOrder)(nil)
+
+// BaseTurnOrder sorts participants by initiative, highest first. Ties go
+// through tie breakers in order, then entity ID.
+type BaseTurnOrder struct {
+	mu sync.RWMutex
+
+	tieBreakers []TieBreaker
+
+	order []Participant
+	index int // active entry, -1 before first Next of round
+	round int
+}
+
+// NewBaseTurnOrder creates turn order with tie breakers evaluated in order
+func NewBaseTurnOrder(tieBreakers ...TieBreaker) *BaseTurnOrder {
+	return &BaseTurnOrder{
+		tieBreakers: slices.Clone(tieBreakers),
+		index:       -1,
+		round:       1,
+	}
+}
+
+// Calculate orders non-defeated participants and restarts round
+func (o *BaseTurnOrder) Calculate(ctx context.Context, participants []Participant) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	order := make([]Participant, 0, len(participants))
+	for _, participant := range participants {
+		if participant != nil && !participant.IsDefeated() {
+			order = append(order, participant)
+		}
+	}
+	slices.SortStableFunc(order, o.compare)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.order = order
+	o.index = -1
+	return nil
+}
+
+func (o *BaseTurnOrder) compare(a, b Participant) int {
+	if result := cmp.Compare(b.Initiative(), a.Initiative()); result != 0 {
+		return result
+	}
+	for _, breaker := range o.tieBreakers {
+		if result := breaker(a, b); result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(a.EntityID(), b.EntityID())
+}
+
+func (o *BaseTurnOrder) Next() (Participant, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.index+1 >= len(o.order) {
+		return nil, false
+	}
+	o.index++
+	return o.order[o.index], true
+}
+
+func (o *BaseTurnOrder) Current() (Participant, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.index < 0 || o.index >= len(o.order) {
+		return nil, false
+	}
+	return o.order[o.index], true
+}
+
+func (o *BaseTurnOrder) Peek() (Participant, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.index+1 >= len(o.order) {
+		return nil, false
+	}
+	return o.order[o.index+1], true
+}
+
+func (o *BaseTurnOrder) GetOrder() []Participant {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return slices.Clone(o.order)
+}
+
+// Insert places participant at position (clamped), replacing earlier entry
+// of same participant; active participant stays active
+func (o *BaseTurnOrder) Insert(participant Participant, position int) {
+	if participant == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.removeLocked(participant.EntityID())
+	position = max(0, min(position, len(o.order)))
+	o.order = slices.Insert(o.order, position, participant)
+	if position <= o.index {
+		o.index++
+	}
+}
+
+func (o *BaseTurnOrder) Remove(participantID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.removeLocked(participantID)
+}
+
+func (o *BaseTurnOrder) removeLocked(participantID string) {
+	i := o.indexOfLocked(participantID)
+	if i < 0 {
+		return
+	}
+	o.order = slices.Delete(o.order, i, i+1)
+	if i <= o.index {
+		o.index--
+	}
+}
+
+// Delay moves participant later; delaying active participant hands turn
+// to whoever follows it
+func (o *BaseTurnOrder) Delay(participantID string, positions int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if i := o.indexOfLocked(participantID); i >= 0 && positions > 0 {
+		o.moveLocked(i, min(i+positions, len(o.order)-1))
+	}
+}
+
+// Advance moves participant earlier; participants still waiting this round
+// cannot move past active one
+func (o *BaseTurnOrder) Advance(participantID string, positions int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	i := o.indexOfLocked(participantID)
+	if i < 0 || positions <= 0 {
+		return
+	}
+	target := max(i-positions, 0)
+	if i > o.index {
+		target = max(target, o.index+1)
+	}
+	o.moveLocked(i, target)
+}
+
+func (o *BaseTurnOrder) moveLocked(from, to int) {
+	if from == to {
+		return
+	}
+
+	participant := o.order[from]
+	o.order = slices.Insert(slices.Delete(o.order, from, from+1), to, participant)
+
+	switch {
+	case from <= o.index && to >= o.index:
+		o.index--
+	case from > o.index && to <= o.index:
+		o.index++
+	}
+}
+
+func (o *BaseTurnOrder) indexOfLocked(participantID string) int {
+	return slices.IndexFunc(o.order, func(p Participant) bool {
+		return p.EntityID() == participantID
+	})
+}
+
+// Reset recalculates order keeping round number
+func (o *BaseTurnOrder) Reset(ctx context.Context, participants []Participant) {
+	_ = o.Calculate(ctx, participants)
+}
+
+func (o *BaseTurnOrder) RoundNumber() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.round
+}
+
+// IncrementRound starts next round from top of order
+func (o *BaseTurnOrder) IncrementRound() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.round++
+	o.index = -1
+}
+
+func (o *BaseTurnOrder) IsNewRound() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.index < 0
+}
+
+// TurnNumber returns 1-based turn within round, 0 before first Next
+func (o *BaseTurnOrder) TurnNumber() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.index + 1
+}
@@ -0,0 +1,103 @@
+package combat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func orderIDs(participants []Participant) []string {
+	ids := make([]string, 0, len(participants))
+	for _, p := range participants {
+		ids = append(ids, p.EntityID())
+	}
+	return ids
+}
+
+func TestBaseTurnOrder(t *testing.T) {
+	ctx := context.Background()
+
+	newParticipant := func(id string, initiative int) *defeatParticipant {
+		return &defeatParticipant{
+			conditionParticipant: &conditionParticipant{testParticipant: &testParticipant{id: id}},
+			initiative:           initiative,
+		}
+	}
+	alpha, zulu, orc := newParticipant("alpha", 10), newParticipant("zulu", 10), newParticipant("orc", 15)
+	participants := []Participant{alpha, zulu, orc}
+
+	speed := map[string]float64{"alpha": 3, "zulu": 7}
+	bySpeed := TieBreakByStat(func(p Participant) float64 { return speed[p.EntityID()] })
+
+	t.Run("ties resolve by ID by default", func(t *testing.T) {
+		order := NewBaseTurnOrder()
+		require.NoError(t, order.Calculate(ctx, participants))
+
+		assert.Equal(t, []string{"orc", "alpha", "zulu"}, orderIDs(order.GetOrder()))
+	})
+
+	t.Run("stat breaker reorders tied participants", func(t *testing.T) {
+		order := NewBaseTurnOrder(bySpeed, TieBreakRandom(42))
+		require.NoError(t, order.Calculate(ctx, participants))
+
+		assert.Equal(t, []string{"orc", "zulu", "alpha"}, orderIDs(order.GetOrder()))
+	})
+
+	t.Run("seeded random is deterministic", func(t *testing.T) {
+		equalSpeed := TieBreakByStat(func(Participant) float64 { return 5 })
+
+		first := NewBaseTurnOrder(equalSpeed, TieBreakRandom(7))
+		second := NewBaseTurnOrder(equalSpeed, TieBreakRandom(7))
+		require.NoError(t, first.Calculate(ctx, participants))
+		require.NoError(t, second.Calculate(ctx, []Participant{zulu, orc, alpha}))
+
+		assert.Equal(t, orderIDs(first.GetOrder()), orderIDs(second.GetOrder()))
+
+		expected := []string{"orc", "alpha", "zulu"}
+		if seededRoll(7, "zulu") < seededRoll(7, "alpha") {
+			expected = []string{"orc", "zulu", "alpha"}
+		}
+		assert.Equal(t, expected, orderIDs(first.GetOrder()))
+	})
+
+	t.Run("defeated participants are skipped", func(t *testing.T) {
+		fallen := newParticipant("fallen", 30)
+		fallen.defeated = true
+
+		order := NewBaseTurnOrder()
+		require.NoError(t, order.Calculate(ctx, append([]Participant{fallen}, participants...)))
+		assert.Equal(t, []string{"orc", "alpha", "zulu"}, orderIDs(order.GetOrder()))
+	})
+
+	t.Run("turn progression and rounds", func(t *testing.T) {
+		order := NewBaseTurnOrder(bySpeed)
+		require.NoError(t, order.Calculate(ctx, participants))
+		assert.True(t, order.IsNewRound())
+
+		current, ok := order.Next()
+		require.True(t, ok)
+		assert.Equal(t, "orc", current.EntityID())
+
+		order.Delay("orc", 1)
+		next, ok := order.Next()
+		require.True(t, ok)
+		assert.Equal(t, "zulu", next.EntityID())
+		assert.Equal(t, 1, order.TurnNumber())
+
+		order.Remove("zulu")
+		peeked, ok := order.Peek()
+		require.True(t, ok)
+		assert.Equal(t, "orc", peeked.EntityID())
+
+		_, _ = order.Next()
+		_, _ = order.Next()
+		_, ok = order.Next()
+		assert.False(t, ok)
+
+		order.IncrementRound()
+		assert.Equal(t, 2, order.RoundNumber())
+		assert.Equal(t, 0, order.TurnNumber())
+	})
+}
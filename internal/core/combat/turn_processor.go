@@ -0,0 +1,405 @@
+package combat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrCannotAct            = errors.New("participant cannot act")
+	ErrNoAvailableActions   = errors.New("no available actions")
+	ErrActionOnCooldown     = errors.New("action is on cooldown")
+	ErrInsufficientMana     = errors.New("insufficient mana")
+	ErrInsufficientStamina  = errors.New("insufficient stamina")
+	ErrInsufficientHealth   = errors.New("insufficient health")
+	ErrTargetNotFound       = errors.New("target not found")
+	ErrTargetOutOfRange     = errors.New("target out of range")
+	ErrNoLineOfSight        = errors.New("no line of sight to target")
+	ErrParticipantNotActing = errors.New("participant is not the action actor")
+)
+
+// =============================================================================
+// RESOURCES
+// =============================================================================
+
+// ResourceHolder is implemented by participants that track spendable resources.
+// Participants without it cannot pay mana or stamina costs.
+type ResourceHolder interface {
+	// Mana returns current mana
+	Mana() float64
+
+	// SetMana updates current mana
+	SetMana(value float64)
+
+	// Stamina returns current stamina
+	Stamina() float64
+
+	// SetStamina updates current stamina
+	SetStamina(value float64)
+}
+
+// cooldownAction is satisfied by actions with cooldowns (e.g. SkillAction)
+type cooldownAction interface {
+	Cooldown() int64
+	SetCooldown(ms int64)
+	IsOnCooldown() bool
+}
+
+// =============================================================================
+// BASE TURN PROCESSOR
+// =============================================================================
+
+var _ TurnProcessor = (*BaseTurnProcessor)(nil)
+
+// BaseTurnProcessor implements TurnProcessor.
+// Validates actions against resources, cooldowns, range and line of sight
+// and pays their costs before execution.
+type BaseTurnProcessor struct {
+	mu sync.RWMutex
+
+	ai AI
+
+	// queues holds pending actions per participant, drained before AI selection
+	queues map[string]*BaseActionQueue
+
+	onTurnStart       []TurnEventCallback
+	onTurnEnd         []TurnEventCallback
+	onActionPerformed []ActionEventCallback
+}
+
+// TurnProcessorConfig holds configuration for BaseTurnProcessor
+type TurnProcessorConfig struct {
+	// AI selects actions in SelectAction (nil = highest priority available action)
+	AI AI
+}
+
+// NewBaseTurnProcessor creates turn processor with default config
+func NewBaseTurnProcessor() *BaseTurnProcessor {
+	return NewBaseTurnProcessorWithConfig(TurnProcessorConfig{})
+}
+
+// NewBaseTurnProcessorWithConfig creates turn processor from config
+func NewBaseTurnProcessorWithConfig(cfg TurnProcessorConfig) *BaseTurnProcessor {
+	return &BaseTurnProcessor{
+		ai:     cfg.AI,
+		queues: make(map[string]*BaseActionQueue),
+	}
+}
+
+// --- Turn Flow ---
+
+func (p *BaseTurnProcessor) BeginTurn(ctx context.Context, participant Participant, encounter Encounter) error {
+	p.mu.RLock()
+	callbacks := append([]TurnEventCallback{}, p.onTurnStart...)
+	p.mu.RUnlock()
+
+	for _, cb := range callbacks {
+		cb(ctx, participant, encounter)
+	}
+	return nil
+}
+
+// ProcessTurn selects, validates, pays for and executes a single action.
+// Costs are refunded when execution fails.
+// Queued action of participant takes precedence over selection; queued
+// action failing validation is dropped and reported as EventActionFailed.
+func (p *BaseTurnProcessor) ProcessTurn(ctx context.Context, participant Participant, encounter Encounter) error {
+	if !p.CanAct(participant, encounter) {
+		return ErrCannotAct
+	}
+
+	queue := p.Queue(participant.EntityID())
+	action, queued := queue.Peek()
+	if !queued {
+		var err error
+		if action, err = p.SelectAction(ctx, participant, encounter); err != nil {
+			return err
+		}
+	}
+
+	if err := p.ValidateTurn(ctx, participant, action, encounter); err != nil {
+		if queued {
+			queue.Reject(action, err.Error())
+		}
+		return err
+	}
+	if queued {
+		queue.Advance()
+	}
+
+	if err := p.ApplyTurnCosts(ctx, participant, action, encounter); err != nil {
+		return err
+	}
+
+	result, err := action.Execute(ctx, encounter)
+	if err != nil {
+		refundTurnCosts(participant, action)
+		return fmt.Errorf("failed to execute action %s: %w", action.ID(), err)
+	}
+
+	p.mu.RLock()
+	callbacks := append([]ActionEventCallback{}, p.onActionPerformed...)
+	p.mu.RUnlock()
+
+	for _, cb := range callbacks {
+		cb(ctx, participant, action, result, encounter)
+	}
+	return nil
+}
+
+func (p *BaseTurnProcessor) EndTurn(ctx context.Context, participant Participant, encounter Encounter) error {
+	participant.SetHasActed(true)
+
+	p.mu.RLock()
+	callbacks := append([]TurnEventCallback{}, p.onTurnEnd...)
+	p.mu.RUnlock()
+
+	for _, cb := range callbacks {
+		cb(ctx, participant, encounter)
+	}
+	return nil
+}
+
+// Queue returns action queue of participant, creating it on first use
+func (p *BaseTurnProcessor) Queue(participantID string) *BaseActionQueue {
+	p.mu.RLock()
+	queue, ok := p.queues[participantID]
+	p.mu.RUnlock()
+	if ok {
+		return queue
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if queue, ok = p.queues[participantID]; !ok {
+		queue = NewActionQueue(participantID)
+		p.queues[participantID] = queue
+	}
+	return queue
+}
+
+// --- Action Selection ---
+
+// CanAct returns false for defeated participants and entities under
+// controlling effects (stun, freeze, etc.)
+func (p *BaseTurnProcessor) CanAct(participant Participant, encounter Encounter) bool {
+	if participant == nil || participant.IsDefeated() {
+		return false
+	}
+
+	if e := participant.Entity(); e != nil && !e.CanAct() {
+		return false
+	}
+	return true
+}
+
+// GetAvailableActions returns actions participant can afford and that are off cooldown
+func (p *BaseTurnProcessor) GetAvailableActions(participant Participant, encounter Encounter) []Action {
+	if !p.CanAct(participant, encounter) {
+		return nil
+	}
+
+	var result []Action
+	for _, action := range participant.AvailableActions() {
+		if isOnCooldown(action) {
+			continue
+		}
+		if checkCost(participant, action.Cost()) != nil {
+			continue
+		}
+		result = append(result, action)
+	}
+	return result
+}
+
+func (p *BaseTurnProcessor) SelectAction(ctx context.Context, participant Participant, encounter Encounter) (Action, error) {
+	p.mu.RLock()
+	ai := p.ai
+	p.mu.RUnlock()
+
+	if ai != nil {
+		return ai.SelectAction(ctx, participant, encounter)
+	}
+
+	available := p.GetAvailableActions(participant, encounter)
+	if len(available) == 0 {
+		return nil, ErrNoAvailableActions
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].Priority() > available[j].Priority()
+	})
+	return available[0], nil
+}
+
+// --- Validation & Costs ---
+
+// ValidateTurn checks actor state, cooldown, resources, range and line of sight
+func (p *BaseTurnProcessor) ValidateTurn(ctx context.Context, participant Participant, action Action, encounter Encounter) error {
+	if !p.CanAct(participant, encounter) {
+		return ErrCannotAct
+	}
+
+	if actorID := action.ActorID(); actorID != "" && actorID != participant.EntityID() {
+		return fmt.Errorf("%w: %s", ErrParticipantNotActing, participant.EntityID())
+	}
+
+	if isOnCooldown(action) {
+		return fmt.Errorf("%w: %s", ErrActionOnCooldown, action.ID())
+	}
+
+	if err := checkCost(participant, action.Cost()); err != nil {
+		return err
+	}
+
+	if err := checkTargets(participant, action, encounter); err != nil {
+		return err
+	}
+
+	return action.Validate(ctx, encounter)
+}
+
+// ApplyTurnCosts deducts health, mana and stamina and starts action cooldown
+func (p *BaseTurnProcessor) ApplyTurnCosts(ctx context.Context, participant Participant, action Action, encounter Encounter) error {
+	cost := action.Cost()
+	if err := checkCost(participant, cost); err != nil {
+		return err
+	}
+
+	if cost.Mana > 0 || cost.Stamina > 0 {
+		holder := participant.(ResourceHolder)
+		holder.SetMana(holder.Mana() - cost.Mana)
+		holder.SetStamina(holder.Stamina() - cost.Stamina)
+	}
+
+	if cost.Health > 0 {
+		e := participant.Entity()
+		e.SetHealth(e.Health() - cost.Health)
+	}
+
+	if cd, ok := action.(cooldownAction); ok && cd.Cooldown() > 0 {
+		cd.SetCooldown(cd.Cooldown())
+	}
+
+	return nil
+}
+
+// refundTurnCosts reverts ApplyTurnCosts for action that failed to execute.
+// Validation guarantees action was off cooldown, so cooldown is cleared.
+func refundTurnCosts(participant Participant, action Action) {
+	cost := action.Cost()
+	if cost.Mana > 0 || cost.Stamina > 0 {
+		holder := participant.(ResourceHolder)
+		holder.SetMana(holder.Mana() + cost.Mana)
+		holder.SetStamina(holder.Stamina() + cost.Stamina)
+	}
+
+	if cost.Health > 0 {
+		e := participant.Entity()
+		e.SetHealth(e.Health() + cost.Health)
+	}
+
+	if cd, ok := action.(cooldownAction); ok && cd.Cooldown() > 0 {
+		cd.SetCooldown(0)
+	}
+}
+
+func isOnCooldown(action Action) bool {
+	cd, ok := action.(cooldownAction)
+	return ok && cd.IsOnCooldown()
+}
+
+// checkCost verifies participant can pay cost.
+// Health costs may not reduce health to zero.
+func checkCost(participant Participant, cost ActionCost) error {
+	if cost.Mana > 0 || cost.Stamina > 0 {
+		holder, ok := participant.(ResourceHolder)
+		if !ok {
+			if cost.Mana > 0 {
+				return ErrInsufficientMana
+			}
+			return ErrInsufficientStamina
+		}
+		if holder.Mana() < cost.Mana {
+			return fmt.Errorf("%w (have: %.1f, need: %.1f)", ErrInsufficientMana, holder.Mana(), cost.Mana)
+		}
+		if holder.Stamina() < cost.Stamina {
+			return fmt.Errorf("%w (have: %.1f, need: %.1f)", ErrInsufficientStamina, holder.Stamina(), cost.Stamina)
+		}
+	}
+
+	if cost.Health > 0 {
+		e := participant.Entity()
+		if e == nil || e.Health() <= cost.Health {
+			return ErrInsufficientHealth
+		}
+	}
+
+	return nil
+}
+
+// checkTargets verifies every target exists, is within range and visible
+func checkTargets(participant Participant, action Action, encounter Encounter) error {
+	from := participant.Position()
+
+	for _, targetID := range action.TargetIDs() {
+		if targetID == participant.EntityID() {
+			continue
+		}
+
+		target, ok := encounter.GetParticipant(targetID)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrTargetNotFound, targetID)
+		}
+
+		to := target.Position()
+		if r := action.Range(); r > 0 && from.DistanceTo(to) > r {
+			return fmt.Errorf("%w: %s (distance %.1f, range %.1f)", ErrTargetOutOfRange, targetID, from.DistanceTo(to), r)
+		}
+
+		if action.RequiresLineOfSight() {
+			if arena := encounter.Arena(); arena != nil && !arena.CanSee(from, to) {
+				return fmt.Errorf("%w: %s", ErrNoLineOfSight, targetID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// --- Callbacks ---
+
+func (p *BaseTurnProcessor) OnTurnStart(callback TurnEventCallback) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onTurnStart = append(p.onTurnStart, callback)
+}
+
+func (p *BaseTurnProcessor) OnTurnEnd(callback TurnEventCallback) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onTurnEnd = append(p.onTurnEnd, callback)
+}
+
+func (p *BaseTurnProcessor) OnActionPerformed(callback ActionEventCallback) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onActionPerformed = append(p.onActionPerformed, callback)
+}
+
+// RecordActionResults records every performed action and its outcome on
+// timeline (see ActionResultEvents)
+func (p *BaseTurnProcessor) RecordActionResults(timeline Timeline) {
+	p.OnActionPerformed(func(ctx context.Context, participant Participant, action Action, result ActionResult, encounter Encounter) {
+		for _, event := range ActionResultEvents(participant, action, result, encounter) {
+			timeline.Record(event)
+		}
+	})
+}
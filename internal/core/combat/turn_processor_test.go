@@ -0,0 +1,233 @@
+package combat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/entity"
+	"github.com/davidmovas/Depthborn/internal/world/spatial"
+)
+
+// testParticipant overrides only what the processor uses
+type testParticipant struct {
+	Participant
+
+	id       string
+	pos      spatial.Position
+	defeated bool
+	acted    bool
+	mana     float64
+	stamina  float64
+	actions  []Action
+}
+
+func (p *testParticipant) EntityID() string           { return p.id }
+func (p *testParticipant) Entity() entity.Combatant   { return nil }
+func (p *testParticipant) Position() spatial.Position { return p.pos }
+func (p *testParticipant) IsDefeated() bool           { return p.defeated }
+func (p *testParticipant) SetDefeated(defeated bool)  { p.defeated = defeated }
+func (p *testParticipant) SetHasActed(acted bool)     { p.acted = acted }
+func (p *testParticipant) AvailableActions() []Action { return p.actions }
+func (p *testParticipant) Mana() float64              { return p.mana }
+func (p *testParticipant) SetMana(value float64)      { p.mana = value }
+func (p *testParticipant) Stamina() float64           { return p.stamina }
+func (p *testParticipant) SetStamina(value float64)   { p.stamina = value }
+
+type testEncounter struct {
+	Encounter
+
+	participants map[string]Participant
+}
+
+func (e *testEncounter) Arena() Arena { return nil }
+
+func (e *testEncounter) GetParticipant(entityID string) (Participant, bool) {
+	p, ok := e.participants[entityID]
+	return p, ok
+}
+
+type testAction struct {
+	Action
+
+	id       string
+	cost     ActionCost
+	targets  []string
+	rangeVal float64
+	priority int
+	cooldown int64
+	remain   int64
+
+	interruptible bool
+	execErr       error
+}
+
+func (a *testAction) ID() string                                              { return a.id }
+func (a *testAction) Type() ActionType                                        { return ActionSkill }
+func (a *testAction) ActorID() string                                         { return "" }
+func (a *testAction) Cost() ActionCost                                        { return a.cost }
+func (a *testAction) TargetIDs() []string                                     { return a.targets }
+func (a *testAction) Range() float64                                          { return a.rangeVal }
+func (a *testAction) RequiresLineOfSight() bool                               { return false }
+func (a *testAction) Priority() int                                           { return a.priority }
+func (a *testAction) Validate(ctx context.Context, encounter Encounter) error { return nil }
+func (a *testAction) Cooldown() int64                                         { return a.cooldown }
+func (a *testAction) SetCooldown(ms int64)                                    { a.remain = ms }
+func (a *testAction) IsOnCooldown() bool                                      { return a.remain > 0 }
+func (a *testAction) CanBeInterrupted() bool                                  { return a.interruptible }
+
+func (a *testAction) Execute(ctx context.Context, encounter Encounter) (ActionResult, error) {
+	if a.execErr != nil {
+		return ActionResult{}, a.execErr
+	}
+	return ActionResult{Success: true}, nil
+}
+
+func TestBaseTurnProcessor(t *testing.T) {
+	setup := func() (*testParticipant, *testParticipant, *testEncounter) {
+		caster := &testParticipant{id: "caster", mana: 20, stamina: 10}
+		target := &testParticipant{id: "target", pos: spatial.Position{X: 3}}
+		enc := &testEncounter{participants: map[string]Participant{
+			"caster": caster,
+			"target": target,
+		}}
+		return caster, target, enc
+	}
+
+	t.Run("rejects action with insufficient mana", func(t *testing.T) {
+		ctx := context.Background()
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		fireball := &testAction{id: "fireball", cost: ActionCost{Mana: 30}, targets: []string{"target"}, rangeVal: 5}
+
+		err := proc.ValidateTurn(ctx, caster, fireball, enc)
+		assert.ErrorIs(t, err, ErrInsufficientMana)
+
+		caster.actions = []Action{fireball}
+		assert.Empty(t, proc.GetAvailableActions(caster, enc))
+		assert.Equal(t, 20.0, caster.mana)
+	})
+
+	t.Run("accepts action and applies costs", func(t *testing.T) {
+		ctx := context.Background()
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		bolt := &testAction{id: "bolt", cost: ActionCost{Mana: 15, Stamina: 4}, targets: []string{"target"}, rangeVal: 5, cooldown: 2000}
+		caster.actions = []Action{bolt}
+
+		var performed []string
+		proc.OnActionPerformed(func(ctx context.Context, p Participant, a Action, r ActionResult, e Encounter) {
+			performed = append(performed, a.ID())
+		})
+
+		require.NoError(t, proc.ProcessTurn(ctx, caster, enc))
+
+		assert.Equal(t, 5.0, caster.mana)
+		assert.Equal(t, 6.0, caster.stamina)
+		assert.True(t, bolt.IsOnCooldown())
+		assert.Equal(t, []string{"bolt"}, performed)
+
+		err := proc.ValidateTurn(ctx, caster, bolt, enc)
+		assert.ErrorIs(t, err, ErrActionOnCooldown)
+	})
+
+	t.Run("refunds costs when execution fails", func(t *testing.T) {
+		ctx := context.Background()
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		fizzle := errors.New("fizzled")
+		bolt := &testAction{id: "bolt", cost: ActionCost{Mana: 15, Stamina: 4}, targets: []string{"target"}, rangeVal: 5, cooldown: 2000, execErr: fizzle}
+		caster.actions = []Action{bolt}
+
+		var performed []string
+		proc.OnActionPerformed(func(ctx context.Context, p Participant, a Action, r ActionResult, e Encounter) {
+			performed = append(performed, a.ID())
+		})
+
+		err := proc.ProcessTurn(ctx, caster, enc)
+		assert.ErrorIs(t, err, fizzle)
+		assert.Equal(t, 20.0, caster.mana)
+		assert.Equal(t, 10.0, caster.stamina)
+		assert.False(t, bolt.IsOnCooldown())
+		assert.Empty(t, performed)
+	})
+
+	t.Run("queued action failing validation is reported", func(t *testing.T) {
+		ctx := context.Background()
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		fireball := &testAction{id: "fireball", cost: ActionCost{Mana: 30}, targets: []string{"target"}, rangeVal: 5}
+		queue := proc.Queue("caster")
+		require.NoError(t, queue.Enqueue(fireball))
+
+		var failed []ActionFailedEvent
+		queue.OnActionFailed(func(event ActionFailedEvent) {
+			failed = append(failed, event)
+		})
+
+		err := proc.ProcessTurn(ctx, caster, enc)
+		assert.ErrorIs(t, err, ErrInsufficientMana)
+		assert.True(t, queue.IsEmpty())
+		require.Len(t, failed, 1)
+		assert.Equal(t, EventActionFailed, failed[0].Type)
+		assert.Equal(t, "fireball", failed[0].ActionID)
+		assert.Equal(t, err.Error(), failed[0].Reason)
+		assert.Equal(t, 20.0, caster.mana)
+	})
+
+	t.Run("rejects target out of range", func(t *testing.T) {
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		stab := &testAction{id: "stab", targets: []string{"target"}, rangeVal: 1.5}
+		err := proc.ValidateTurn(context.Background(), caster, stab, enc)
+		assert.ErrorIs(t, err, ErrTargetOutOfRange)
+	})
+
+	t.Run("defeated participant cannot act", func(t *testing.T) {
+		caster, _, enc := setup()
+		caster.defeated = true
+		proc := NewBaseTurnProcessor()
+
+		assert.False(t, proc.CanAct(caster, enc))
+		assert.ErrorIs(t, proc.ProcessTurn(context.Background(), caster, enc), ErrCannotAct)
+	})
+
+	t.Run("selects highest priority action", func(t *testing.T) {
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		caster.actions = []Action{
+			&testAction{id: "low", priority: 1},
+			&testAction{id: "high", priority: 5},
+			&testAction{id: "unaffordable", priority: 9, cost: ActionCost{Mana: 100}},
+		}
+
+		action, err := proc.SelectAction(context.Background(), caster, enc)
+		require.NoError(t, err)
+		assert.Equal(t, "high", action.ID())
+	})
+
+	t.Run("turn callbacks fire", func(t *testing.T) {
+		ctx := context.Background()
+		caster, _, enc := setup()
+		proc := NewBaseTurnProcessor()
+
+		var events []string
+		proc.OnTurnStart(func(ctx context.Context, p Participant, e Encounter) { events = append(events, "start") })
+		proc.OnTurnEnd(func(ctx context.Context, p Participant, e Encounter) { events = append(events, "end") })
+
+		require.NoError(t, proc.BeginTurn(ctx, caster, enc))
+		require.NoError(t, proc.EndTurn(ctx, caster, enc))
+
+		assert.Equal(t, []string{"start", "end"}, events)
+		assert.True(t, caster.acted)
+	})
+}
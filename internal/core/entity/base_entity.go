@@ -146,6 +146,20 @@ func (e *BaseEntity) StatusEffects() status.Manager {
 	return e.statuses
 }
 
+// ApplyStatus applies status effect of type statusID for duration in milliseconds
+func (e *BaseEntity) ApplyStatus(ctx context.Context, statusID string, durationMs int64) error {
+	if e.statuses == nil {
+		return fmt.Errorf("entity %s has no status manager", e.ID())
+	}
+
+	return e.statuses.Apply(ctx, status.NewEffect(status.EffectConfig{
+		EffectType: statusID,
+		Name:       statusID,
+		Duration:   durationMs,
+		TargetID:   e.ID(),
+	}))
+}
+
 func (e *BaseEntity) Transform() spatial.Transform {
 	return e.transform
 }
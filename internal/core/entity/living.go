@@ -3,6 +3,7 @@ package entity
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math"
 
 	"github.com/davidmovas/Depthborn/internal/core/attribute"
@@ -11,11 +12,15 @@ import (
 
 var _ Living = (*BaseLiving)(nil)
 
+// ResourceHealth names health in RestoreResource
+const ResourceHealth = "health"
+
 type BaseLiving struct {
 	*BaseEntity
 
 	health    float64
 	maxHealth float64
+	resources map[string]float64 // non-health pools (mana, stamina, ...)
 }
 
 type LivingConfig struct {
@@ -157,6 +162,31 @@ func (l *BaseLiving) Heal(ctx context.Context, amount float64, sourceID string)
 	return actualHealing, nil
 }
 
+// RestoreResource adds amount to resource. Health is capped at MaxHealth and
+// never restored on dead entity; other resources accumulate in own pool.
+func (l *BaseLiving) RestoreResource(resource string, amount float64) {
+	if resource == ResourceHealth {
+		if l.IsAlive() {
+			l.SetHealth(math.Min(l.health+amount, l.MaxHealth()))
+		}
+		return
+	}
+
+	if l.resources == nil {
+		l.resources = make(map[string]float64)
+	}
+	l.resources[resource] = math.Max(l.resources[resource]+amount, 0)
+	l.Touch()
+}
+
+// Resource returns current amount of resource
+func (l *BaseLiving) Resource(resource string) float64 {
+	if resource == ResourceHealth {
+		return l.health
+	}
+	return l.resources[resource]
+}
+
 func (l *BaseLiving) HealthPercent() float64 {
 	maxHP := l.MaxHealth()
 	if maxHP <= 0 {
@@ -173,6 +203,9 @@ func (l *BaseLiving) SerializeState() (map[string]any, error) {
 
 	state["health"] = l.health
 	state["max_health"] = l.maxHealth
+	if len(l.resources) > 0 {
+		state["resources"] = maps.Clone(l.resources)
+	}
 
 	return state, nil
 }
@@ -190,6 +223,18 @@ func (l *BaseLiving) DeserializeState(state map[string]any) error {
 		l.maxHealth = maxHealth
 	}
 
+	switch resources := state["resources"].(type) {
+	case map[string]float64:
+		l.resources = maps.Clone(resources)
+	case map[string]any:
+		l.resources = make(map[string]float64, len(resources))
+		for name, value := range resources {
+			if amount, ok := value.(float64); ok {
+				l.resources[name] = amount
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -220,6 +265,7 @@ func (l *BaseLiving) Clone() any {
 		BaseEntity: baseClone,
 		health:     l.health,
 		maxHealth:  l.maxHealth,
+		resources:  maps.Clone(l.resources),
 	}
 
 	return clone
@@ -228,8 +274,9 @@ func (l *BaseLiving) Clone() any {
 // LivingState holds the complete serializable state of a BaseLiving.
 type LivingState struct {
 	EntityState
-	Health    float64 `msgpack:"health"`
-	MaxHealth float64 `msgpack:"max_health"`
+	Health    float64            `msgpack:"health"`
+	MaxHealth float64            `msgpack:"max_health"`
+	Resources map[string]float64 `msgpack:"resources,omitempty"`
 }
 
 // MarshalBinary implements persist.Marshaler for BaseLiving.
@@ -250,6 +297,7 @@ func (l *BaseLiving) MarshalBinary() ([]byte, error) {
 		EntityState: es,
 		Health:      l.health,
 		MaxHealth:   l.maxHealth,
+		Resources:   l.resources,
 	}
 
 	return persist.DefaultCodec().Encode(ls)
@@ -281,6 +329,7 @@ func (l *BaseLiving) UnmarshalBinary(data []byte) error {
 	// Restore living-specific fields
 	l.health = ls.Health
 	l.maxHealth = ls.MaxHealth
+	l.resources = ls.Resources
 
 	return nil
 }
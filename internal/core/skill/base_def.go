@@ -2,6 +2,9 @@ package skill
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
 	"sync"
 
 	"github.com/davidmovas/Depthborn/internal/core/types"
@@ -9,6 +12,12 @@ import (
 
 var _ Def = (*BaseDef)(nil)
 
+// ErrInvalidDef is returned when a skill definition fails validation
+var ErrInvalidDef = errors.New("invalid skill definition")
+
+// defaultTargeting is used for skills that don't declare targeting
+var defaultTargeting = &BaseTargetRule{targetType: TargetSelf}
+
 // BaseDef implements the Def interface.
 // Represents an immutable skill template loaded from YAML.
 type BaseDef struct {
@@ -79,10 +88,6 @@ func NewBaseDef(config DefConfig) *BaseDef {
 		metadata:     config.Metadata,
 	}
 
-	if def.targeting == nil {
-		def.targeting = &BaseTargetRule{targetType: TargetSelf}
-	}
-
 	if def.metadata == nil {
 		def.metadata = make(map[string]any)
 	}
@@ -141,6 +146,46 @@ func (d *BaseDef) SetLevelData(level int, data *BaseLevelData) {
 	d.levelData[level] = data
 }
 
+// CostProgression returns cost of resource at each level from 1 to MaxLevel.
+// Levels without data or without cost for resource are zero.
+func (d *BaseDef) CostProgression(resource ResourceType) []float64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make([]float64, max(d.maxLevel, 0))
+	for i := range result {
+		result[i] = d.levelCostLocked(i+1, resource)
+	}
+	return result
+}
+
+// TotalCostToLevel sums cost of resource over levels 1..level (capped at MaxLevel)
+func (d *BaseDef) TotalCostToLevel(resource ResourceType, level int) float64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	total := 0.0
+	for l := 1; l <= min(level, d.maxLevel); l++ {
+		total += d.levelCostLocked(l, resource)
+	}
+	return total
+}
+
+func (d *BaseDef) levelCostLocked(level int, resource ResourceType) float64 {
+	data, ok := d.levelData[level]
+	if !ok {
+		return 0
+	}
+
+	total := 0.0
+	for _, cost := range data.costs {
+		if cost.Resource == resource {
+			total += cost.Amount
+		}
+	}
+	return total
+}
+
 func (d *BaseDef) BaseCooldown() int64 {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -162,6 +207,10 @@ func (d *BaseDef) ChargeRecovery() int64 {
 func (d *BaseDef) Targeting() TargetRule {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
+
+	if d.targeting == nil {
+		return defaultTargeting
+	}
 	return d.targeting
 }
 
@@ -176,6 +225,22 @@ func (d *BaseDef) Effects() []EffectDef {
 	return result
 }
 
+func (d *BaseDef) EffectsAtLevel(level int) []EffectDef {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var overrides []EffectValue
+	if data, ok := d.levelData[level]; ok {
+		overrides = data.effects
+	}
+
+	result := make([]EffectDef, len(d.effects))
+	for i, e := range d.effects {
+		result[i] = e.withOverride(overrides)
+	}
+	return result
+}
+
 func (d *BaseDef) Requirements() Requirements {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -199,6 +264,77 @@ func (d *BaseDef) Metadata() map[string]any {
 	return result
 }
 
+// Validate checks definition consistency.
+// Level data, when present, must cover every level from 1 to MaxLevel. If any level
+// defines resource costs, all levels must. Active skills with effects must declare targeting.
+func (d *BaseDef) Validate() error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.id == "" {
+		return fmt.Errorf("%w: missing ID", ErrInvalidDef)
+	}
+
+	if d.maxLevel < 0 {
+		return fmt.Errorf("%w: negative max level %d", ErrInvalidDef, d.maxLevel)
+	}
+
+	effectIDs := make(map[string]bool, len(d.effects))
+	for _, effect := range d.effects {
+		if !isKnownEffectType(effect.effectType) {
+			return fmt.Errorf("%w: effect %s has unknown type %q", ErrInvalidDef, effect.id, effect.effectType)
+		}
+		effectIDs[effect.id] = true
+	}
+
+	if d.skillType == TypeActive && len(d.effects) > 0 && d.targeting == nil {
+		return fmt.Errorf("%w: active skill has no targeting", ErrInvalidDef)
+	}
+
+	if len(d.levelData) == 0 {
+		return nil
+	}
+
+	if len(d.levelData) != d.maxLevel {
+		return fmt.Errorf("%w: %d levels defined but max level is %d", ErrInvalidDef, len(d.levelData), d.maxLevel)
+	}
+
+	costsRequired := false
+	for _, data := range d.levelData {
+		if len(data.costs) > 0 {
+			costsRequired = true
+			break
+		}
+	}
+
+	for level := 1; level <= d.maxLevel; level++ {
+		data, ok := d.levelData[level]
+		if !ok {
+			return fmt.Errorf("%w: missing data for level %d", ErrInvalidDef, level)
+		}
+		if costsRequired && len(data.costs) == 0 {
+			return fmt.Errorf("%w: level %d has no resource costs", ErrInvalidDef, level)
+		}
+		for _, ev := range data.effects {
+			if !effectIDs[ev.EffectID] {
+				return fmt.Errorf("%w: level %d references unknown effect %s", ErrInvalidDef, level, ev.EffectID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isKnownEffectType(t EffectType) bool {
+	switch t {
+	case EffectDamage, EffectHeal, EffectStatus, EffectBuff, EffectDebuff, EffectSummon,
+		EffectTeleport, EffectKnockback, EffectPull, EffectModifySkill, EffectResource, EffectDispel:
+		return true
+	default:
+		return false
+	}
+}
+
 // =============================================================================
 // BASE LEVEL DATA
 // =============================================================================
@@ -409,6 +545,38 @@ func (e *BaseEffectDef) Delay() int64             { return e.delay }
 func (e *BaseEffectDef) Duration() int64          { return e.duration }
 func (e *BaseEffectDef) Metadata() map[string]any { return e.metadata }
 
+// withOverride returns copy with chance/duration from matching level value,
+// or e itself when nothing is overridden
+func (e *BaseEffectDef) withOverride(values []EffectValue) *BaseEffectDef {
+	for _, v := range values {
+		if v.EffectID != e.id || (v.Chance == 0 && v.Duration == 0) {
+			continue
+		}
+		leveled := *e
+		if v.Chance != 0 {
+			leveled.chance = v.Chance
+		}
+		if v.Duration != 0 {
+			leveled.duration = v.Duration
+		}
+		return &leveled
+	}
+	return e
+}
+
+// Rolls decides whether effect applies, true with probability Chance().
+// Zero chance means effect is not a proc and always applies (same as 1.0);
+// nil rng falls back to global source.
+func (e *BaseEffectDef) Rolls(rng *rand.Rand) bool {
+	if e.chance <= 0 || e.chance >= 1 {
+		return true
+	}
+	if rng == nil {
+		return rand.Float64() < e.chance
+	}
+	return rng.Float64() < e.chance
+}
+
 // =============================================================================
 // BASE REQUIREMENTS
 // =============================================================================
@@ -3,6 +3,7 @@ package skill
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/davidmovas/Depthborn/pkg/identifier"
@@ -38,6 +39,7 @@ type BaseInstance struct {
 
 	// Cooldown state
 	cooldownRemaining int64 // Remaining cooldown in ms
+	cooldownTotal     int64 // Full duration of current cooldown in ms
 
 	// Charge state
 	charges        int   // Current available charges
@@ -45,6 +47,11 @@ type BaseInstance struct {
 
 	// Modifiers affecting this skill
 	modifiers []SkillModifier
+
+	// Event callbacks
+	onUsed          []func()
+	onCooldownReady []func()
+	onChargeGained  []func(charges int)
 }
 
 // InstanceConfig holds configuration for creating BaseInstance
@@ -201,6 +208,7 @@ func (i *BaseInstance) SetCooldown(ms int64) {
 		ms = 0
 	}
 	i.cooldownRemaining = ms
+	i.cooldownTotal = ms
 }
 
 func (i *BaseInstance) IsOnCooldown() bool {
@@ -209,6 +217,34 @@ func (i *BaseInstance) IsOnCooldown() bool {
 	return i.cooldownRemaining > 0
 }
 
+// CooldownSeconds returns remaining cooldown in seconds
+func (i *BaseInstance) CooldownSeconds() float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return float64(i.cooldownRemaining) / 1000
+}
+
+// CooldownPercent returns remaining/total cooldown [0.0 - 1.0], 0 when ready
+func (i *BaseInstance) CooldownPercent() float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if i.cooldownRemaining <= 0 {
+		return 0
+	}
+	// Total is unknown for states saved before it was tracked
+	total := max(i.cooldownTotal, i.cooldownRemaining)
+	return float64(i.cooldownRemaining) / float64(total)
+}
+
+// CooldownText formats remaining cooldown as "2.3s", empty when ready
+func (i *BaseInstance) CooldownText() string {
+	if !i.IsOnCooldown() {
+		return ""
+	}
+	return fmt.Sprintf("%.1fs", i.CooldownSeconds())
+}
+
 func (i *BaseInstance) Charges() int {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
@@ -264,18 +300,34 @@ func (i *BaseInstance) ChargeRecoveryProgress() float64 {
 	return float64(i.chargeRecovery) / float64(i.def.ChargeRecovery())
 }
 
+// ChargeText formats charges as "2/3 charges", empty for skills without charges
+func (i *BaseInstance) ChargeText() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	maxCharges := i.maxChargesLocked()
+	if maxCharges <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d charges", i.charges, maxCharges)
+}
+
 func (i *BaseInstance) Update(deltaMs int64) {
 	i.mu.Lock()
-	defer i.mu.Unlock()
 
 	// Update cooldown
+	cooldownReady := false
 	if i.cooldownRemaining > 0 {
 		i.cooldownRemaining -= deltaMs
-		if i.cooldownRemaining < 0 {
+		if i.cooldownRemaining <= 0 {
 			i.cooldownRemaining = 0
+			i.cooldownTotal = 0
+			cooldownReady = true
 		}
 	}
 
+	var gained []int
+
 	// Update charge recovery
 	if i.def != nil && i.def.BaseCharges() > 0 {
 		maxCharges := i.maxChargesLocked()
@@ -288,6 +340,7 @@ func (i *BaseInstance) Update(deltaMs int64) {
 			for i.chargeRecovery >= recoveryTime && i.charges < maxCharges {
 				i.charges++
 				i.chargeRecovery -= recoveryTime
+				gained = append(gained, i.charges)
 			}
 
 			// Cap recovery progress
@@ -296,6 +349,52 @@ func (i *BaseInstance) Update(deltaMs int64) {
 			}
 		}
 	}
+
+	// Copy callbacks to invoke without holding lock
+	var readyCallbacks []func()
+	if cooldownReady {
+		readyCallbacks = append(readyCallbacks, i.onCooldownReady...)
+	}
+	var chargeCallbacks []func(charges int)
+	if len(gained) > 0 {
+		chargeCallbacks = append(chargeCallbacks, i.onChargeGained...)
+	}
+	i.mu.Unlock()
+
+	for _, cb := range readyCallbacks {
+		cb()
+	}
+	for _, charges := range gained {
+		for _, cb := range chargeCallbacks {
+			cb(charges)
+		}
+	}
+}
+
+// ResetRuntime returns the instance to a fully ready state: cooldown cleared,
+// charges refilled to max and charge recovery progress discarded.
+// Intended for combat end; it is not regeneration, so no ready or
+// charge-gained callbacks fire. Out-of-combat recovery should keep using Update.
+func (i *BaseInstance) ResetRuntime() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.cooldownRemaining = 0
+	i.cooldownTotal = 0
+	i.chargeRecovery = 0
+	if i.def != nil && i.def.BaseCharges() > 0 {
+		i.charges = i.maxChargesLocked()
+	}
+}
+
+// ResetAll resets runtime state of every given instance, skipping nils.
+// Loadout implementations delegate their ResetAll here.
+func ResetAll(instances ...Instance) {
+	for _, inst := range instances {
+		if inst != nil {
+			inst.ResetRuntime()
+		}
+	}
 }
 
 func (i *BaseInstance) CanUse(ctx context.Context, casterID string) bool {
@@ -327,8 +426,20 @@ func (i *BaseInstance) CanUse(ctx context.Context, casterID string) bool {
 
 func (i *BaseInstance) Use(ctx context.Context, casterID string, params ActivationParams) (Result, error) {
 	i.mu.Lock()
-	defer i.mu.Unlock()
+	result, err := i.useLocked(ctx, casterID, params)
+	var callbacks []func()
+	if err == nil && result.Success {
+		callbacks = append(callbacks, i.onUsed...)
+	}
+	i.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb()
+	}
+	return result, err
+}
 
+func (i *BaseInstance) useLocked(ctx context.Context, casterID string, params ActivationParams) (Result, error) {
 	if i.def == nil {
 		return Result{Success: false, Message: "skill definition not loaded"}, nil
 	}
@@ -353,6 +464,7 @@ func (i *BaseInstance) Use(ctx context.Context, casterID string, params Activati
 	// Apply cooldown (only if not using charges, or using last charge)
 	if !usesCharges || i.charges == 0 {
 		i.cooldownRemaining = cooldown
+		i.cooldownTotal = cooldown
 	}
 
 	// TODO: Actually execute skill effects
@@ -446,6 +558,32 @@ func (i *BaseInstance) ClearModifiers() {
 	i.modifiers = make([]SkillModifier, 0)
 }
 
+// =============================================================================
+// EVENTS
+// =============================================================================
+
+// OnUsed registers callback invoked after every successful Use
+func (i *BaseInstance) OnUsed(callback func()) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.onUsed = append(i.onUsed, callback)
+}
+
+// OnCooldownReady registers callback invoked once when Update brings cooldown to zero
+func (i *BaseInstance) OnCooldownReady(callback func()) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.onCooldownReady = append(i.onCooldownReady, callback)
+}
+
+// OnChargeGained registers callback invoked for each charge recovered in Update.
+// It receives charge count after recovery.
+func (i *BaseInstance) OnChargeGained(callback func(charges int)) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.onChargeGained = append(i.onChargeGained, callback)
+}
+
 // =============================================================================
 // SERIALIZATION
 // =============================================================================
@@ -456,6 +594,7 @@ type InstanceState struct {
 	Level             int    `msgpack:"level"`
 	IsActive          bool   `msgpack:"is_active"`
 	CooldownRemaining int64  `msgpack:"cooldown"`
+	CooldownTotal     int64  `msgpack:"cooldown_total"`
 	Charges           int    `msgpack:"charges"`
 	ChargeRecovery    int64  `msgpack:"charge_recovery"`
 }
@@ -470,6 +609,7 @@ func (i *BaseInstance) GetState() InstanceState {
 		Level:             i.level,
 		IsActive:          i.isActive,
 		CooldownRemaining: i.cooldownRemaining,
+		CooldownTotal:     i.cooldownTotal,
 		Charges:           i.charges,
 		ChargeRecovery:    i.chargeRecovery,
 	}
@@ -484,6 +624,7 @@ func (i *BaseInstance) RestoreState(state InstanceState) {
 	i.level = state.Level
 	i.isActive = state.IsActive
 	i.cooldownRemaining = state.CooldownRemaining
+	i.cooldownTotal = state.CooldownTotal
 	i.charges = state.Charges
 	i.chargeRecovery = state.ChargeRecovery
 }
@@ -3,7 +3,14 @@ package skill
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
 )
 
 // =============================================================================
@@ -19,6 +26,13 @@ var (
 	ErrNodeExcluded         = errors.New("node excluded by another allocation")
 	ErrNodeRequired         = errors.New("node is required by other allocations")
 	ErrInsufficientCurrency = errors.New("insufficient currency for respec")
+	ErrPlanActive           = errors.New("allocation plan already active")
+	ErrNoPlan               = errors.New("no active allocation plan")
+	ErrInvalidTree          = errors.New("invalid tree definition")
+	ErrBranchCapReached     = errors.New("branch point cap reached")
+	ErrLevelTooLow          = errors.New("character level too low")
+	ErrTreeStateNotFound    = errors.New("tree state not found")
+	ErrTreeStateExists      = errors.New("tree state already in set")
 )
 
 // =============================================================================
@@ -167,6 +181,46 @@ func (t *BaseTree) PathExists(fromNodeID, toNodeID string) bool {
 	return false
 }
 
+// FindNodesByName returns nodes whose name contains query (case-insensitive), sorted by ID
+func (t *BaseTree) FindNodesByName(query string) []Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	query = strings.ToLower(query)
+	result := make([]Node, 0)
+	for _, n := range t.nodes {
+		if strings.Contains(strings.ToLower(n.Name()), query) {
+			result = append(result, n)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result
+}
+
+// NodesInBranch returns nodes listed in branch NodeIDs (YAML order when loaded)
+func (t *BaseTree) NodesInBranch(branchID string) []Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, branch := range t.branches {
+		if branch.ID != branchID {
+			continue
+		}
+
+		result := make([]Node, 0, len(branch.NodeIDs))
+		for _, id := range branch.NodeIDs {
+			if n, ok := t.nodes[id]; ok {
+				result = append(result, n)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
 // AddNode adds a node to the tree
 func (t *BaseTree) AddNode(node *BaseNode) {
 	t.mu.Lock()
@@ -182,6 +236,71 @@ func (t *BaseTree) SetStartNodes(nodeIDs []string) {
 	copy(t.startNodes, nodeIDs)
 }
 
+// NormalizeConnections mirrors every connection so adjacency is bidirectional.
+// Normalization is the contract: definitions may list each edge on one side
+// only and loaders call this after parsing. Connections to unknown nodes are
+// left as-is and reported by Validate. Returns number of edges mirrored.
+func (t *BaseTree) NormalizeConnections() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	added := 0
+	for _, id := range t.sortedNodeIDsLocked() {
+		for _, connID := range t.nodes[id].Connections() {
+			other, ok := t.nodes[connID]
+			if !ok || connID == id {
+				continue
+			}
+			if other.addConnection(id) {
+				added++
+			}
+		}
+	}
+	return added
+}
+
+// Validate checks tree references. Dangling start nodes and connections are
+// errors; one-sided connections are warnings since NormalizeConnections fixes them.
+func (t *BaseTree) Validate() (warnings []string, err error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var problems []string
+
+	for _, id := range t.startNodes {
+		if _, ok := t.nodes[id]; !ok {
+			problems = append(problems, fmt.Sprintf("start node %s not found", id))
+		}
+	}
+
+	for _, id := range t.sortedNodeIDsLocked() {
+		for _, connID := range t.nodes[id].Connections() {
+			other, ok := t.nodes[connID]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("node %s connects to unknown node %s", id, connID))
+				continue
+			}
+			if !other.hasConnection(id) {
+				warnings = append(warnings, fmt.Sprintf("connection %s -> %s is not mirrored", id, connID))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return warnings, fmt.Errorf("%w: %s", ErrInvalidTree, strings.Join(problems, "; "))
+	}
+	return warnings, nil
+}
+
+func (t *BaseTree) sortedNodeIDsLocked() []string {
+	ids := make([]string, 0, len(t.nodes))
+	for id := range t.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // =============================================================================
 // BASE NODE
 // =============================================================================
@@ -202,7 +321,10 @@ type BaseNode struct {
 	levelCost    int
 	requirements []string
 	exclusions   []string
+	exclGroup    string
+	reqLevel     int
 	connections  []string
+	connCosts    map[string]int
 	effects      []NodeEffect
 	levelEffects map[int][]NodeEffect
 	skillID      string
@@ -227,6 +349,16 @@ type NodeConfig struct {
 	SkillID      string
 	PosX, PosY   float64
 	Icon         string
+
+	// ExclusionGroup - at most one node of the group can be allocated
+	ExclusionGroup string
+
+	// RequiredLevel - minimum character level to allocate (0 = none)
+	RequiredLevel int
+
+	// ConnectionCosts - path search cost to move to specific neighbor,
+	// overriding neighbor's node cost for that edge
+	ConnectionCosts map[string]int
 }
 
 // NewBaseNode creates a new tree node
@@ -242,7 +374,10 @@ func NewBaseNode(config NodeConfig) *BaseNode {
 		levelCost:    config.LevelCost,
 		requirements: config.Requirements,
 		exclusions:   config.Exclusions,
+		exclGroup:    config.ExclusionGroup,
+		reqLevel:     config.RequiredLevel,
 		connections:  config.Connections,
+		connCosts:    maps.Clone(config.ConnectionCosts),
 		effects:      config.Effects,
 		levelEffects: make(map[int][]NodeEffect),
 		skillID:      config.SkillID,
@@ -316,6 +451,44 @@ func (n *BaseNode) Exclusions() []string {
 	return result
 }
 
+func (n *BaseNode) ExclusionGroup() string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.exclGroup
+}
+
+func (n *BaseNode) RequiredLevel() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.reqLevel
+}
+
+func (n *BaseNode) hasConnection(nodeID string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for _, id := range n.connections {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// addConnection appends connection if missing, returns true when added
+func (n *BaseNode) addConnection(nodeID string) bool {
+	if n.hasConnection(nodeID) {
+		return false
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	connections := make([]string, len(n.connections), len(n.connections)+1)
+	copy(connections, n.connections)
+	n.connections = append(connections, nodeID)
+	return true
+}
+
 func (n *BaseNode) Connections() []string {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -324,6 +497,13 @@ func (n *BaseNode) Connections() []string {
 	return result
 }
 
+func (n *BaseNode) ConnectionCost(neighborID string) (int, bool) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	cost, ok := n.connCosts[neighborID]
+	return cost, ok
+}
+
 func (n *BaseNode) Effects() []NodeEffect {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -348,6 +528,34 @@ func (n *BaseNode) EffectsAtLevel(level int) []NodeEffect {
 	return result
 }
 
+// RenderAtLevel combines name, type, cost and effect descriptions at level.
+// Leveled nodes also show level progress and cost of the next level.
+func (n *BaseNode) RenderAtLevel(level int) string {
+	effects := n.EffectsAtLevel(level)
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)", n.name, n.nodeType)
+	if n.maxLevel > 1 {
+		fmt.Fprintf(&b, " - Level %d/%d", level, n.maxLevel)
+	}
+
+	fmt.Fprintf(&b, "\nCost: %d", n.cost)
+	if n.maxLevel > 1 && level < n.maxLevel {
+		fmt.Fprintf(&b, " (next level: %d)", n.levelCost)
+	}
+
+	for _, effect := range effects {
+		if desc := effect.Description(); desc != "" {
+			b.WriteString("\n")
+			b.WriteString(desc)
+		}
+	}
+	return b.String()
+}
+
 func (n *BaseNode) SkillID() string {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -386,13 +594,32 @@ type BaseTreeState struct {
 	treeID          string
 	tree            Tree           // Reference to tree definition
 	allocated       map[string]int // nodeID -> level (1 = allocated, >1 = leveled)
+	paidCosts       map[string]int // nodeID -> points paid for base allocation
 	availablePoints int
 	spentPoints     int
 
+	// Committed state captured by BeginPlan (nil when not planning)
+	plan *treePlan
+
+	// Aggregated effects of allocated nodes, rebuilt lazily after changes
+	effectsCache []NodeEffect
+	effectsDirty bool
+
+	// Maximum points spendable per branch (branchID -> cap)
+	branchCaps map[string]int
+
+	// Owner level checked against node RequiredLevel
+	characterLevel int
+
+	// Adjusts node cost by nodes already allocated in its branch (nil = flat)
+	costScaling CostScaling
+
 	// Respec cost configuration
 	baseCostPerNode  int64
 	costPerNodeLevel int64
 	resetCostBase    int64
+
+	callbacks treeCallbacks
 }
 
 // TreeStateConfig holds configuration for tree state
@@ -402,6 +629,29 @@ type TreeStateConfig struct {
 	BaseCostPerNode  int64
 	CostPerNodeLevel int64
 	ResetCostBase    int64
+
+	// CostScaling adjusts allocation cost by nodes allocated in branch (nil = flat)
+	CostScaling CostScaling
+}
+
+// CostScaling returns effective allocation cost of node with baseCost given
+// number of nodes already allocated in its branch
+type CostScaling func(baseCost, nodesInBranch int) int
+
+// FlatCostScaling charges base cost regardless of branch investment
+func FlatCostScaling(baseCost, _ int) int {
+	return baseCost
+}
+
+// DiscountEvery lowers cost by discount for every n nodes already allocated
+// in branch, never below minCost. Negative discount makes nodes costlier instead.
+func DiscountEvery(n, discount, minCost int) CostScaling {
+	return func(baseCost, nodesInBranch int) int {
+		if n <= 0 {
+			return baseCost
+		}
+		return max(baseCost-(nodesInBranch/n)*discount, minCost)
+	}
 }
 
 // NewBaseTreeState creates a new tree state
@@ -410,11 +660,15 @@ func NewBaseTreeState(config TreeStateConfig) *BaseTreeState {
 		treeID:           config.TreeID,
 		tree:             config.Tree,
 		allocated:        make(map[string]int),
+		paidCosts:        make(map[string]int),
+		effectsDirty:     true,
+		branchCaps:       make(map[string]int),
 		availablePoints:  0,
 		spentPoints:      0,
 		baseCostPerNode:  config.BaseCostPerNode,
 		costPerNodeLevel: config.CostPerNodeLevel,
 		resetCostBase:    config.ResetCostBase,
+		costScaling:      config.CostScaling,
 	}
 }
 
@@ -425,11 +679,23 @@ func (s *BaseTreeState) TreeID() string {
 }
 
 func (s *BaseTreeState) AllocateNode(ctx context.Context, nodeID string) error {
+	_ = ctx
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	before := s.pointsLocked()
+	err := s.allocateNodeLocked(nodeID)
+	cbs, after := s.callbacks.clone(), s.pointsLocked()
+	s.mu.Unlock()
 
-	_ = ctx
+	if err != nil {
+		return err
+	}
+	cbs.allocated(nodeID)
+	cbs.pointsChanged(before, after)
+	return nil
+}
 
+func (s *BaseTreeState) allocateNodeLocked(nodeID string) error {
 	// Check if node exists
 	node, ok := s.tree.GetNode(nodeID)
 	if !ok {
@@ -442,7 +708,7 @@ func (s *BaseTreeState) AllocateNode(ctx context.Context, nodeID string) error {
 	}
 
 	// Check points
-	cost := node.Cost()
+	cost := s.effectiveCostLocked(node)
 	if s.availablePoints < cost {
 		return ErrInsufficientPoints
 	}
@@ -463,26 +729,170 @@ func (s *BaseTreeState) AllocateNode(ctx context.Context, nodeID string) error {
 	}
 
 	// Check exclusions
-	for _, exclID := range node.Exclusions() {
-		if s.allocated[exclID] > 0 {
-			return ErrNodeExcluded
-		}
+	if s.isExcludedLocked(node) {
+		return ErrNodeExcluded
+	}
+
+	if required := node.RequiredLevel(); s.characterLevel < required {
+		return fmt.Errorf("%w: %s needs level %d, have %d", ErrLevelTooLow, nodeID, required, s.characterLevel)
+	}
+
+	if err := s.checkBranchCapLocked(node, cost); err != nil {
+		return err
 	}
 
 	// Allocate
 	s.allocated[nodeID] = 1
+	s.paidCosts[nodeID] = cost
+	s.effectsDirty = true
 	s.availablePoints -= cost
 	s.spentPoints += cost
 
 	return nil
 }
 
-func (s *BaseTreeState) DeallocateNode(ctx context.Context, nodeID string) error {
+// AllocateBatch allocates nodes as a single unit, e.g. when importing a build.
+// Nodes are ordered so each one follows a requirement it depends on, total
+// cost is checked up front, and any failure rolls every allocation back.
+func (s *BaseTreeState) AllocateBatch(ctx context.Context, nodeIDs []string) error {
+	_ = ctx
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	before := s.pointsLocked()
+	ordered, err := s.allocateBatchLocked(nodeIDs)
+	cbs, after := s.callbacks.clone(), s.pointsLocked()
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	for _, nodeID := range ordered {
+		cbs.allocated(nodeID)
+	}
+	cbs.pointsChanged(before, after)
+	return nil
+}
+
+func (s *BaseTreeState) allocateBatchLocked(nodeIDs []string) ([]string, error) {
+	ordered, err := s.orderBatchLocked(nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if total, _ := s.pathCostLocked(ordered); total > s.availablePoints {
+		return nil, fmt.Errorf("%w: batch needs %d, have %d", ErrInsufficientPoints, total, s.availablePoints)
+	}
+
+	allocated, paid := copyAllocations(s.allocated), copyAllocations(s.paidCosts)
+	available, spent := s.availablePoints, s.spentPoints
+	for _, nodeID := range ordered {
+		if err = s.allocateNodeLocked(nodeID); err != nil {
+			s.allocated, s.paidCosts = allocated, paid
+			s.availablePoints, s.spentPoints = available, spent
+			s.effectsDirty = true
+			return nil, fmt.Errorf("batch allocation of %s: %w", nodeID, err)
+		}
+	}
+	return ordered, nil
+}
+
+// orderBatchLocked sorts batch topologically: node is placed once it has no
+// requirements or one of them is allocated or placed earlier. Input order
+// breaks ties.
+func (s *BaseTreeState) orderBatchLocked(nodeIDs []string) ([]string, error) {
+	seen := make(map[string]bool, len(nodeIDs))
+	pending := make([]Node, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		node, ok := s.tree.GetNode(nodeID)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+		}
+		if seen[nodeID] || s.allocated[nodeID] > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNodeAlreadyAlloc, nodeID)
+		}
+		seen[nodeID] = true
+		pending = append(pending, node)
+	}
+
+	placed := make(map[string]bool, len(pending))
+	ordered := make([]string, 0, len(pending))
+	for len(pending) > 0 {
+		remaining := make([]Node, 0, len(pending))
+		for _, node := range pending {
+			if s.batchReadyLocked(node, placed) {
+				placed[node.ID()] = true
+				ordered = append(ordered, node.ID())
+			} else {
+				remaining = append(remaining, node)
+			}
+		}
+		if len(remaining) == len(pending) {
+			ids := make([]string, len(remaining))
+			for i, node := range remaining {
+				ids[i] = node.ID()
+			}
+			return nil, fmt.Errorf("%w: %s", ErrRequirementsNotMet, strings.Join(ids, ", "))
+		}
+		pending = remaining
+	}
+	return ordered, nil
+}
 
+func (s *BaseTreeState) batchReadyLocked(node Node, placed map[string]bool) bool {
+	reqs := node.Requirements()
+	if len(reqs) == 0 {
+		return true
+	}
+	for _, reqID := range reqs {
+		if placed[reqID] || s.allocated[reqID] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedLocked reports whether node conflicts with allocation through
+// explicit exclusions or shared exclusion group
+func (s *BaseTreeState) isExcludedLocked(node Node) bool {
+	for _, exclID := range node.Exclusions() {
+		if s.allocated[exclID] > 0 {
+			return true
+		}
+	}
+
+	group := node.ExclusionGroup()
+	if group == "" {
+		return false
+	}
+	for allocID, level := range s.allocated {
+		if level == 0 || allocID == node.ID() {
+			continue
+		}
+		if other, ok := s.tree.GetNode(allocID); ok && other.ExclusionGroup() == group {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *BaseTreeState) DeallocateNode(ctx context.Context, nodeID string) error {
 	_ = ctx
 
+	s.mu.Lock()
+	before := s.pointsLocked()
+	err := s.deallocateNodeLocked(nodeID)
+	cbs, after := s.callbacks.clone(), s.pointsLocked()
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	cbs.deallocated(nodeID)
+	cbs.pointsChanged(before, after)
+	return nil
+}
+
+func (s *BaseTreeState) deallocateNodeLocked(nodeID string) error {
 	// Check if allocated
 	level, ok := s.allocated[nodeID]
 	if !ok || level == 0 {
@@ -512,20 +922,93 @@ func (s *BaseTreeState) DeallocateNode(ctx context.Context, nodeID string) error
 		return ErrNodeNotFound
 	}
 
-	// Calculate refund (base cost + level costs)
-	refund := node.Cost()
-	if level > 1 {
-		refund += (level - 1) * node.LevelCost()
-	}
+	// Calculate refund (paid cost + level costs)
+	refund := s.nodeRefundLocked(node, level)
 
 	// Deallocate
 	delete(s.allocated, nodeID)
+	delete(s.paidCosts, nodeID)
+	s.effectsDirty = true
 	s.availablePoints += refund
 	s.spentPoints -= refund
 
 	return nil
 }
 
+// nodeRefundLocked returns points spent on node allocated at level
+func (s *BaseTreeState) nodeRefundLocked(node Node, level int) int {
+	refund := s.paidCostLocked(node)
+	if level > 1 {
+		refund += (level - 1) * node.LevelCost()
+	}
+	return refund
+}
+
+// DeallocationImpact previews removing allocated node: points refunded and
+// dependent nodes left without any allocated requirement that would have to go too.
+// Cascade is ordered so deallocating it front to back, then nodeID, always succeeds.
+// Refund covers nodeID and cascade. Returns ok=false if node is not allocated.
+func (s *BaseTreeState) DeallocationImpact(nodeID string) (refund int, cascadeIDs []string, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.allocated[nodeID] == 0 {
+		return 0, nil, false
+	}
+	node, found := s.tree.GetNode(nodeID)
+	if !found {
+		return 0, nil, false
+	}
+
+	removed := map[string]bool{nodeID: true}
+	refund = s.nodeRefundLocked(node, s.allocated[nodeID])
+
+	allocated := make([]string, 0, len(s.allocated))
+	for id, level := range s.allocated {
+		if level > 0 {
+			allocated = append(allocated, id)
+		}
+	}
+	sort.Strings(allocated)
+
+	// Collect orphans round by round until nothing else loses support
+	cascadeIDs = make([]string, 0)
+	for {
+		var orphans []string
+		for _, id := range allocated {
+			if removed[id] {
+				continue
+			}
+			dep, found := s.tree.GetNode(id)
+			if !found || len(dep.Requirements()) == 0 {
+				continue
+			}
+			supported := false
+			for _, reqID := range dep.Requirements() {
+				if s.allocated[reqID] > 0 && !removed[reqID] {
+					supported = true
+					break
+				}
+			}
+			if !supported {
+				orphans = append(orphans, id)
+				refund += s.nodeRefundLocked(dep, s.allocated[id])
+			}
+		}
+		if len(orphans) == 0 {
+			break
+		}
+		for _, id := range orphans {
+			removed[id] = true
+		}
+		cascadeIDs = append(cascadeIDs, orphans...)
+	}
+
+	// Deepest dependents first
+	slices.Reverse(cascadeIDs)
+	return refund, cascadeIDs, true
+}
+
 func (s *BaseTreeState) hasAlternativeRequirement(nodeID, excludeReqID string) bool {
 	node, ok := s.tree.GetNode(nodeID)
 	if !ok {
@@ -598,29 +1081,43 @@ func (s *BaseTreeState) canDeallocateWithExclusions(nodeID string, excluding []s
 }
 
 func (s *BaseTreeState) ResetAll(ctx context.Context) error {
+	_ = ctx
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	before := s.pointsLocked()
+	removed := make([]string, 0, len(s.allocated))
+	for nodeID, level := range s.allocated {
+		if level > 0 {
+			removed = append(removed, nodeID)
+		}
+	}
+	sort.Strings(removed)
+	s.resetAllLocked()
+	cbs, after := s.callbacks.clone(), s.pointsLocked()
+	s.mu.Unlock()
 
-	_ = ctx
+	for _, nodeID := range removed {
+		cbs.deallocated(nodeID)
+	}
+	cbs.pointsChanged(before, after)
+	return nil
+}
 
+func (s *BaseTreeState) resetAllLocked() {
 	// Calculate total refund
 	totalRefund := 0
 	for nodeID, level := range s.allocated {
 		if node, ok := s.tree.GetNode(nodeID); ok {
-			refund := node.Cost()
-			if level > 1 {
-				refund += (level - 1) * node.LevelCost()
-			}
-			totalRefund += refund
+			totalRefund += s.nodeRefundLocked(node, level)
 		}
 	}
 
 	// Clear allocations
 	s.allocated = make(map[string]int)
+	s.paidCosts = make(map[string]int)
+	s.effectsDirty = true
 	s.availablePoints += totalRefund
 	s.spentPoints = 0
-
-	return nil
 }
 
 func (s *BaseTreeState) IsAllocated(nodeID string) bool {
@@ -647,11 +1144,23 @@ func (s *BaseTreeState) GetAllocatedLevel(nodeID string) int {
 }
 
 func (s *BaseTreeState) LevelUpNode(ctx context.Context, nodeID string) error {
+	_ = ctx
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	before := s.pointsLocked()
+	err := s.levelUpNodeLocked(nodeID)
+	cbs, after, level := s.callbacks.clone(), s.pointsLocked(), s.allocated[nodeID]
+	s.mu.Unlock()
 
-	_ = ctx
+	if err != nil {
+		return err
+	}
+	cbs.leveled(nodeID, level)
+	cbs.pointsChanged(before, after)
+	return nil
+}
 
+func (s *BaseTreeState) levelUpNodeLocked(nodeID string) error {
 	// Check if allocated
 	level, ok := s.allocated[nodeID]
 	if !ok || level == 0 {
@@ -674,14 +1183,197 @@ func (s *BaseTreeState) LevelUpNode(ctx context.Context, nodeID string) error {
 		return ErrInsufficientPoints
 	}
 
+	if err := s.checkBranchCapLocked(node, cost); err != nil {
+		return err
+	}
+
 	// Level up
 	s.allocated[nodeID] = level + 1
+	s.effectsDirty = true
 	s.availablePoints -= cost
 	s.spentPoints += cost
 
 	return nil
 }
 
+// TreeProgress summarizes allocations against tree definition
+type TreeProgress struct {
+	AllocatedNodes int
+	TotalNodes     int
+	SpentPoints    int
+
+	// ByType counts allocated nodes per node type
+	ByType map[NodeType]int
+
+	// Branches maps branch ID to share of its nodes allocated (0-100)
+	Branches map[string]float64
+}
+
+// Progress returns allocation summary for UI ("23/150 nodes, 3 keystones")
+func (s *BaseTreeState) Progress() TreeProgress {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	progress := TreeProgress{
+		SpentPoints: s.spentPoints,
+		ByType:      make(map[NodeType]int),
+		Branches:    make(map[string]float64),
+	}
+
+	branchTotal := make(map[string]int)
+	branchAllocated := make(map[string]int)
+	for _, node := range s.tree.GetNodes() {
+		progress.TotalNodes++
+		_, allocated := s.allocated[node.ID()]
+		if allocated {
+			progress.AllocatedNodes++
+			progress.ByType[node.Type()]++
+		}
+
+		if branch := node.Branch(); branch != "" {
+			branchTotal[branch]++
+			if allocated {
+				branchAllocated[branch]++
+			}
+		}
+	}
+
+	for branch, total := range branchTotal {
+		progress.Branches[branch] = float64(branchAllocated[branch]) / float64(total) * 100
+	}
+	return progress
+}
+
+// SetCharacterLevel updates owner level used by node level requirements
+func (s *BaseTreeState) SetCharacterLevel(level int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.characterLevel = level
+}
+
+func (s *BaseTreeState) CharacterLevel() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.characterLevel
+}
+
+// SetBranchCap limits points that can be spent on nodes of branch.
+// Non-positive max removes the cap.
+func (s *BaseTreeState) SetBranchCap(branchID string, max int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if max <= 0 {
+		delete(s.branchCaps, branchID)
+		return
+	}
+	s.branchCaps[branchID] = max
+}
+
+// BranchCap returns cap of branch and whether one is set
+func (s *BaseTreeState) BranchCap(branchID string) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit, ok := s.branchCaps[branchID]
+	return limit, ok
+}
+
+// SpentInBranch returns points spent on allocated nodes of branch
+func (s *BaseTreeState) SpentInBranch(branchID string) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.spentInBranchLocked(branchID)
+}
+
+func (s *BaseTreeState) spentInBranchLocked(branchID string) int {
+	spent := 0
+	for nodeID, level := range s.allocated {
+		node, ok := s.tree.GetNode(nodeID)
+		if !ok || node.Branch() != branchID {
+			continue
+		}
+		spent += s.nodeRefundLocked(node, level)
+	}
+	return spent
+}
+
+// nodesInBranchLocked counts allocated nodes of branch
+func (s *BaseTreeState) nodesInBranchLocked(branchID string) int {
+	count := 0
+	for nodeID := range s.allocated {
+		if node, ok := s.tree.GetNode(nodeID); ok && node.Branch() == branchID {
+			count++
+		}
+	}
+	return count
+}
+
+// EffectiveCost returns points allocating node would cost now, after
+// cost scaling by nodes allocated in its branch
+func (s *BaseTreeState) EffectiveCost(nodeID string) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node, ok := s.tree.GetNode(nodeID)
+	if !ok {
+		return 0, false
+	}
+	return s.effectiveCostLocked(node), true
+}
+
+func (s *BaseTreeState) effectiveCostLocked(node Node) int {
+	return s.scaledCostLocked(node, s.nodesInBranchLocked(node.Branch()))
+}
+
+func (s *BaseTreeState) scaledCostLocked(node Node, nodesInBranch int) int {
+	if s.costScaling == nil {
+		return node.Cost()
+	}
+	return max(s.costScaling(node.Cost(), nodesInBranch), 0)
+}
+
+// paidCostLocked returns points paid for node base allocation; states
+// restored without paid costs fall back to node cost
+func (s *BaseTreeState) paidCostLocked(node Node) int {
+	if cost, ok := s.paidCosts[node.ID()]; ok {
+		return cost
+	}
+	return node.Cost()
+}
+
+// pathCostLocked sums scaled costs of allocating nodes in order, each
+// one counting toward its branch for the next, and returns per-branch totals
+func (s *BaseTreeState) pathCostLocked(nodeIDs []string) (total int, byBranch map[string]int) {
+	byBranch = make(map[string]int)
+	placed := make(map[string]int)
+	for _, nodeID := range nodeIDs {
+		node, ok := s.tree.GetNode(nodeID)
+		if !ok {
+			continue
+		}
+		branch := node.Branch()
+		cost := s.scaledCostLocked(node, s.nodesInBranchLocked(branch)+placed[branch])
+		placed[branch]++
+		byBranch[branch] += cost
+		total += cost
+	}
+	return total, byBranch
+}
+
+// checkBranchCapLocked verifies spending cost on node keeps its branch within cap
+func (s *BaseTreeState) checkBranchCapLocked(node Node, cost int) error {
+	limit, ok := s.branchCaps[node.Branch()]
+	if !ok {
+		return nil
+	}
+
+	if spent := s.spentInBranchLocked(node.Branch()); spent+cost > limit {
+		return fmt.Errorf("%w: %s has %d/%d points", ErrBranchCapReached, node.Branch(), spent, limit)
+	}
+	return nil
+}
+
 func (s *BaseTreeState) CanAllocate(nodeID string) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -697,7 +1389,8 @@ func (s *BaseTreeState) CanAllocate(nodeID string) bool {
 	}
 
 	// Enough points?
-	if s.availablePoints < node.Cost() {
+	cost := s.effectiveCostLocked(node)
+	if s.availablePoints < cost {
 		return false
 	}
 
@@ -717,13 +1410,16 @@ func (s *BaseTreeState) CanAllocate(nodeID string) bool {
 	}
 
 	// Exclusions check
-	for _, exclID := range node.Exclusions() {
-		if s.allocated[exclID] > 0 {
-			return false
-		}
+	if s.isExcludedLocked(node) {
+		return false
 	}
 
-	return true
+	// Level gate
+	if s.characterLevel < node.RequiredLevel() {
+		return false
+	}
+
+	return s.checkBranchCapLocked(node, cost) == nil
 }
 
 func (s *BaseTreeState) CanDeallocate(nodeID string) bool {
@@ -765,6 +1461,125 @@ func (s *BaseTreeState) hasAlternativeRequirementLocked(nodeID, excludeReqID str
 	return false
 }
 
+// PreviewPathTo finds cheapest chain of unallocated nodes that makes target
+// allocatable from current allocations, in allocation order and ending with target.
+// Nodes blocked by exclusions or level gate are skipped. Edge weights set via
+// ConnectionCosts steer route choice only; totalCost is allocation cost of path.
+// Affordable reports whether available points and branch caps cover whole path.
+// State is not mutated.
+// Unreachable target returns nil path; allocated target returns empty path.
+func (s *BaseTreeState) PreviewPathTo(targetNodeID string) (path []string, totalCost int, affordable bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	path, totalCost, ok := s.cheapestPathLocked(targetNodeID)
+	if !ok {
+		return nil, 0, false
+	}
+	return path, totalCost, s.pathAffordableLocked(path, totalCost)
+}
+
+// cheapestPathLocked runs Dijkstra over requirement edges where entering
+// node costs edge weight from ConnectionCost, falling back to its allocation
+// cost, and allocated nodes are free sources. Returned cost is allocation cost
+// of path nodes regardless of edge weights.
+func (s *BaseTreeState) cheapestPathLocked(targetNodeID string) ([]string, int, bool) {
+	if _, ok := s.tree.GetNode(targetNodeID); !ok {
+		return nil, 0, false
+	}
+	if s.allocated[targetNodeID] > 0 {
+		return []string{}, 0, true
+	}
+
+	nodes := s.tree.GetNodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	// Requirement -> nodes unlocked by it
+	unlocks := make(map[string][]Node)
+	dist := make(map[string]int)
+	prev := make(map[string]string)
+	for _, node := range nodes {
+		for _, reqID := range node.Requirements() {
+			unlocks[reqID] = append(unlocks[reqID], node)
+		}
+		if s.allocated[node.ID()] > 0 {
+			dist[node.ID()] = 0
+		} else if len(node.Requirements()) == 0 && s.canPathThroughLocked(node) {
+			dist[node.ID()] = node.Cost()
+		}
+	}
+
+	done := make(map[string]bool)
+	for {
+		current, best := "", 0
+		for _, node := range nodes {
+			id := node.ID()
+			if d, ok := dist[id]; ok && !done[id] && (current == "" || d < best) {
+				current, best = id, d
+			}
+		}
+		if current == "" {
+			return nil, 0, false
+		}
+		if current == targetNodeID {
+			break
+		}
+		done[current] = true
+
+		for _, next := range unlocks[current] {
+			id := next.ID()
+			if done[id] || s.allocated[id] > 0 || !s.canPathThroughLocked(next) {
+				continue
+			}
+			cost := best + s.edgeCostLocked(current, next)
+			if d, ok := dist[id]; !ok || cost < d {
+				dist[id] = cost
+				prev[id] = current
+			}
+		}
+	}
+
+	path := make([]string, 0)
+	for id := targetNodeID; id != "" && s.allocated[id] == 0; id = prev[id] {
+		path = append(path, id)
+	}
+	slices.Reverse(path)
+
+	totalCost, _ := s.pathCostLocked(path)
+	return path, totalCost, true
+}
+
+// edgeCostLocked returns search weight of moving from node to next
+func (s *BaseTreeState) edgeCostLocked(fromID string, next Node) int {
+	if from, ok := s.tree.GetNode(fromID); ok {
+		if cost, ok := from.ConnectionCost(next.ID()); ok {
+			return cost
+		}
+	}
+	return next.Cost()
+}
+
+// canPathThroughLocked reports whether unallocated node could be allocated
+// regardless of points and requirements
+func (s *BaseTreeState) canPathThroughLocked(node Node) bool {
+	return !s.isExcludedLocked(node) && s.characterLevel >= node.RequiredLevel()
+}
+
+// pathAffordableLocked checks path cost against available points and branch caps
+func (s *BaseTreeState) pathAffordableLocked(path []string, totalCost int) bool {
+	if totalCost > s.availablePoints {
+		return false
+	}
+
+	_, branchCost := s.pathCostLocked(path)
+	for branchID, cost := range branchCost {
+		if limit, ok := s.branchCaps[branchID]; ok && s.spentInBranchLocked(branchID)+cost > limit {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *BaseTreeState) AvailablePoints() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -779,8 +1594,17 @@ func (s *BaseTreeState) SpentPoints() int {
 
 func (s *BaseTreeState) AddPoints(amount int) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	before := s.pointsLocked()
 	s.availablePoints += amount
+
+	// Granted points are real, keep them if the plan is discarded
+	if s.plan != nil {
+		s.plan.availablePoints += amount
+	}
+	cbs, after := s.callbacks.clone(), s.pointsLocked()
+	s.mu.Unlock()
+
+	cbs.pointsChanged(before, after)
 }
 
 func (s *BaseTreeState) RespecCost(nodeIDs []string) int64 {
@@ -815,10 +1639,56 @@ func (s *BaseTreeState) ResetCost() int64 {
 	return cost
 }
 
+// GetActiveEffects returns effects of all allocated nodes.
+// The aggregated list is cached until allocations change; callers get a copy.
 func (s *BaseTreeState) GetActiveEffects() []NodeEffect {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	if !s.effectsDirty {
+		effects := append([]NodeEffect(nil), s.effectsCache...)
+		s.mu.RUnlock()
+		return effects
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another caller may have rebuilt cache while waiting for write lock
+	if s.effectsDirty {
+		s.effectsCache = s.collectEffectsLocked()
+		s.effectsDirty = false
+	}
+	return append([]NodeEffect(nil), s.effectsCache...)
+}
+
+// AggregatedAttributeEffects sums attribute effects of allocated nodes
+// at their current levels, keyed by attribute and modifier type
+func (s *BaseTreeState) AggregatedAttributeEffects() map[attribute.Type]map[attribute.ModifierType]float64 {
+	return sumAttributeEffects(s.GetActiveEffects())
+}
 
+func sumAttributeEffects(effects []NodeEffect) map[attribute.Type]map[attribute.ModifierType]float64 {
+	result := make(map[attribute.Type]map[attribute.ModifierType]float64)
+	for _, effect := range effects {
+		attr, ok := effect.(interface {
+			Attribute() attribute.Type
+			ModType() attribute.ModifierType
+		})
+		if !ok || effect.Type() != EffectTypeAttribute {
+			continue
+		}
+
+		byMod, exists := result[attr.Attribute()]
+		if !exists {
+			byMod = make(map[attribute.ModifierType]float64)
+			result[attr.Attribute()] = byMod
+		}
+		byMod[attr.ModType()] += effect.Value()
+	}
+	return result
+}
+
+func (s *BaseTreeState) collectEffectsLocked() []NodeEffect {
 	var effects []NodeEffect
 	for nodeID, level := range s.allocated {
 		if node, ok := s.tree.GetNode(nodeID); ok {
@@ -829,6 +1699,17 @@ func (s *BaseTreeState) GetActiveEffects() []NodeEffect {
 	return effects
 }
 
+// NodeTooltip renders node at its allocated level, or level 1 preview if unallocated
+func (s *BaseTreeState) NodeTooltip(nodeID string) (string, bool) {
+	node, ok := s.tree.GetNode(nodeID)
+	if !ok {
+		return "", false
+	}
+
+	level := max(s.GetAllocatedLevel(nodeID), 1)
+	return node.RenderAtLevel(level), true
+}
+
 func (s *BaseTreeState) ApplyEffects(ctx context.Context, entityID string) error {
 	effects := s.GetActiveEffects()
 	for _, effect := range effects {
@@ -849,6 +1730,211 @@ func (s *BaseTreeState) RemoveEffects(ctx context.Context, entityID string) erro
 	return nil
 }
 
+// =============================================================================
+// PLANNING
+// =============================================================================
+
+// treePlan holds committed allocations while a plan is being edited
+type treePlan struct {
+	allocated       map[string]int
+	paidCosts       map[string]int
+	availablePoints int
+	spentPoints     int
+}
+
+// BeginPlan starts planning mode. Subsequent allocations mutate a scratch layer
+// budgeted by the current available points until CommitPlan or DiscardPlan.
+func (s *BaseTreeState) BeginPlan() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.plan != nil {
+		return ErrPlanActive
+	}
+
+	s.plan = &treePlan{
+		allocated:       copyAllocations(s.allocated),
+		paidCosts:       copyAllocations(s.paidCosts),
+		availablePoints: s.availablePoints,
+		spentPoints:     s.spentPoints,
+	}
+	return nil
+}
+
+// IsPlanning returns true while a plan is active
+func (s *BaseTreeState) IsPlanning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.plan != nil
+}
+
+// PlanCost returns points the plan would spend (negative if it refunds)
+func (s *BaseTreeState) PlanCost() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.plan == nil {
+		return 0
+	}
+	return s.spentPoints - s.plan.spentPoints
+}
+
+// CommitPlan keeps planned allocations and leaves planning mode
+func (s *BaseTreeState) CommitPlan() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.plan == nil {
+		return ErrNoPlan
+	}
+
+	s.plan = nil
+	return nil
+}
+
+// DiscardPlan restores allocations captured by BeginPlan.
+// Node callbacks fire for every node the rollback changes.
+func (s *BaseTreeState) DiscardPlan() error {
+	s.mu.Lock()
+	if s.plan == nil {
+		s.mu.Unlock()
+		return ErrNoPlan
+	}
+
+	before := s.pointsLocked()
+	var deallocated, allocated []string
+	for nodeID := range s.allocated {
+		if _, ok := s.plan.allocated[nodeID]; !ok {
+			deallocated = append(deallocated, nodeID)
+		}
+	}
+	leveled := make(map[string]int)
+	for nodeID, level := range s.plan.allocated {
+		current, ok := s.allocated[nodeID]
+		if !ok {
+			allocated = append(allocated, nodeID)
+		}
+		if level != current && (ok || level > 1) {
+			leveled[nodeID] = level
+		}
+	}
+	slices.Sort(deallocated)
+	slices.Sort(allocated)
+
+	s.allocated = s.plan.allocated
+	s.paidCosts = s.plan.paidCosts
+	s.effectsDirty = true
+	s.availablePoints = s.plan.availablePoints
+	s.spentPoints = s.plan.spentPoints
+	s.plan = nil
+	cbs, after := s.callbacks.clone(), s.pointsLocked()
+	s.mu.Unlock()
+
+	for _, nodeID := range deallocated {
+		cbs.deallocated(nodeID)
+	}
+	for _, nodeID := range allocated {
+		cbs.allocated(nodeID)
+	}
+	for _, nodeID := range slices.Sorted(maps.Keys(leveled)) {
+		cbs.leveled(nodeID, leveled[nodeID])
+	}
+	cbs.pointsChanged(before, after)
+	return nil
+}
+
+// --- Observers ---
+
+// treePoints is available/spent pair compared to detect point changes
+type treePoints struct {
+	available int
+	spent     int
+}
+
+func (s *BaseTreeState) pointsLocked() treePoints {
+	return treePoints{available: s.availablePoints, spent: s.spentPoints}
+}
+
+// treeCallbacks holds observers, copied under lock and invoked after unlock
+type treeCallbacks struct {
+	onAllocated     []func(nodeID string)
+	onDeallocated   []func(nodeID string)
+	onLeveled       []func(nodeID string, level int)
+	onPointsChanged []func(available, spent int)
+}
+
+func (c treeCallbacks) clone() treeCallbacks {
+	return treeCallbacks{
+		onAllocated:     slices.Clone(c.onAllocated),
+		onDeallocated:   slices.Clone(c.onDeallocated),
+		onLeveled:       slices.Clone(c.onLeveled),
+		onPointsChanged: slices.Clone(c.onPointsChanged),
+	}
+}
+
+func (c treeCallbacks) allocated(nodeID string) {
+	for _, cb := range c.onAllocated {
+		cb(nodeID)
+	}
+}
+
+func (c treeCallbacks) deallocated(nodeID string) {
+	for _, cb := range c.onDeallocated {
+		cb(nodeID)
+	}
+}
+
+func (c treeCallbacks) leveled(nodeID string, level int) {
+	for _, cb := range c.onLeveled {
+		cb(nodeID, level)
+	}
+}
+
+func (c treeCallbacks) pointsChanged(before, after treePoints) {
+	if before == after {
+		return
+	}
+	for _, cb := range c.onPointsChanged {
+		cb(after.available, after.spent)
+	}
+}
+
+// OnNodeAllocated registers callback invoked after node is allocated
+func (s *BaseTreeState) OnNodeAllocated(callback func(nodeID string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks.onAllocated = append(s.callbacks.onAllocated, callback)
+}
+
+// OnNodeDeallocated registers callback invoked after node is deallocated (including ResetAll)
+func (s *BaseTreeState) OnNodeDeallocated(callback func(nodeID string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks.onDeallocated = append(s.callbacks.onDeallocated, callback)
+}
+
+// OnNodeLeveled registers callback invoked with new level after LevelUpNode
+func (s *BaseTreeState) OnNodeLeveled(callback func(nodeID string, level int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks.onLeveled = append(s.callbacks.onLeveled, callback)
+}
+
+// OnPointsChanged registers callback invoked whenever available or spent points change
+func (s *BaseTreeState) OnPointsChanged(callback func(available, spent int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks.onPointsChanged = append(s.callbacks.onPointsChanged, callback)
+}
+
+func copyAllocations(src map[string]int) map[string]int {
+	dst := make(map[string]int, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
 // =============================================================================
 // SERIALIZATION
 // =============================================================================
@@ -859,23 +1945,33 @@ type TreeStateData struct {
 	Allocated       map[string]int `msgpack:"allocated"`
 	AvailablePoints int            `msgpack:"available_points"`
 	SpentPoints     int            `msgpack:"spent_points"`
+
+	// PaidCosts holds scaled cost paid per node so refunds match it
+	PaidCosts map[string]int `msgpack:"paid_costs,omitempty"`
 }
 
-// GetData returns serializable data
+// GetData returns serializable data.
+// Uncommitted plan changes are never persisted.
 func (s *BaseTreeState) GetData() TreeStateData {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	allocated := make(map[string]int, len(s.allocated))
-	for k, v := range s.allocated {
-		allocated[k] = v
+	if s.plan != nil {
+		return TreeStateData{
+			TreeID:          s.treeID,
+			Allocated:       copyAllocations(s.plan.allocated),
+			AvailablePoints: s.plan.availablePoints,
+			SpentPoints:     s.plan.spentPoints,
+			PaidCosts:       copyAllocations(s.plan.paidCosts),
+		}
 	}
 
 	return TreeStateData{
 		TreeID:          s.treeID,
-		Allocated:       allocated,
+		Allocated:       copyAllocations(s.allocated),
 		AvailablePoints: s.availablePoints,
 		SpentPoints:     s.spentPoints,
+		PaidCosts:       copyAllocations(s.paidCosts),
 	}
 }
 
@@ -889,6 +1985,9 @@ func (s *BaseTreeState) RestoreData(data TreeStateData) {
 	for k, v := range data.Allocated {
 		s.allocated[k] = v
 	}
+	s.paidCosts = copyAllocations(data.PaidCosts)
+	s.effectsDirty = true
 	s.availablePoints = data.AvailablePoints
 	s.spentPoints = data.SpentPoints
+	s.plan = nil
 }
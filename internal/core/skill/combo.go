@@ -0,0 +1,202 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	ErrInvalidCombo = errors.New("invalid combo definition")
+	ErrComboExists  = errors.New("combo already registered")
+)
+
+// =============================================================================
+// COMBO DEFINITION
+// =============================================================================
+
+// ComboDef describes ordered sequence of skill tags that forms a combo
+type ComboDef struct {
+	ID   string
+	Name string
+
+	// Steps lists tag each consecutive skill must carry
+	Steps []string
+
+	// WindowMs is maximum time from first to last step
+	WindowMs int64
+
+	// Rewards are granted when combo completes
+	Rewards []ComboReward
+}
+
+// ComboReward is attribute modifier granted by completed combo
+type ComboReward struct {
+	Attribute attribute.Type
+	Modifier  attribute.Modifier
+}
+
+// ComboEvent reports completed combo
+type ComboEvent struct {
+	Combo       ComboDef
+	StartedMs   int64
+	CompletedMs int64
+}
+
+// ComboCallback is invoked for every completed combo
+type ComboCallback func(event ComboEvent)
+
+// =============================================================================
+// COMBO TRACKER
+// =============================================================================
+
+// ComboTracker follows skill usage and detects registered combos.
+// Steps must be consecutive: a skill that doesn't carry the next
+// expected tag breaks the sequence.
+type ComboTracker struct {
+	mu sync.RWMutex
+
+	combos   map[string]ComboDef
+	order    []string
+	progress map[string]comboProgress
+
+	onCombo []ComboCallback
+}
+
+type comboProgress struct {
+	next    int
+	started int64
+}
+
+// NewComboTracker creates tracker without combos
+func NewComboTracker() *ComboTracker {
+	return &ComboTracker{
+		combos:   make(map[string]ComboDef),
+		progress: make(map[string]comboProgress),
+	}
+}
+
+// Register adds combo definition
+func (t *ComboTracker) Register(def ComboDef) error {
+	if def.ID == "" {
+		return fmt.Errorf("%w: ID is required", ErrInvalidCombo)
+	}
+	if len(def.Steps) < 2 {
+		return fmt.Errorf("%w: %s needs at least 2 steps", ErrInvalidCombo, def.ID)
+	}
+	if def.WindowMs <= 0 {
+		return fmt.Errorf("%w: %s needs positive window", ErrInvalidCombo, def.ID)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.combos[def.ID]; exists {
+		return fmt.Errorf("%w: %s", ErrComboExists, def.ID)
+	}
+
+	def.Steps = slices.Clone(def.Steps)
+	def.Rewards = slices.Clone(def.Rewards)
+	t.combos[def.ID] = def
+	t.order = append(t.order, def.ID)
+	return nil
+}
+
+// Get returns combo by ID
+func (t *ComboTracker) Get(id string) (ComboDef, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	def, ok := t.combos[id]
+	return def, ok
+}
+
+// Combos returns registered combos in registration order
+func (t *ComboTracker) Combos() []ComboDef {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]ComboDef, 0, len(t.order))
+	for _, id := range t.order {
+		result = append(result, t.combos[id])
+	}
+	return result
+}
+
+// Record registers skill use and returns combos it completed
+func (t *ComboTracker) Record(skillTags []string, timestampMs int64) []ComboEvent {
+	t.mu.Lock()
+
+	var events []ComboEvent
+	for _, id := range t.order {
+		def := t.combos[id]
+		p := t.progress[id]
+
+		// Sequence timed out: whatever is in progress is lost
+		if p.next > 0 && timestampMs-p.started > def.WindowMs {
+			p = comboProgress{}
+		}
+
+		switch {
+		case slices.Contains(skillTags, def.Steps[p.next]):
+			if p.next == 0 {
+				p.started = timestampMs
+			}
+			p.next++
+		case p.next > 0 && slices.Contains(skillTags, def.Steps[0]):
+			// Broken sequence may still start over with this skill
+			p = comboProgress{next: 1, started: timestampMs}
+		default:
+			p = comboProgress{}
+		}
+
+		if p.next == len(def.Steps) {
+			events = append(events, ComboEvent{
+				Combo:       def,
+				StartedMs:   p.started,
+				CompletedMs: timestampMs,
+			})
+			p = comboProgress{}
+		}
+
+		t.progress[id] = p
+	}
+
+	callbacks := append([]ComboCallback{}, t.onCombo...)
+	t.mu.Unlock()
+
+	for _, event := range events {
+		for _, cb := range callbacks {
+			cb(event)
+		}
+	}
+	return events
+}
+
+// Progress returns how many steps of combo are currently matched
+func (t *ComboTracker) Progress(id string) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.progress[id].next
+}
+
+// Reset clears progress of all combos
+func (t *ComboTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress = make(map[string]comboProgress)
+}
+
+// OnCombo registers callback for completed combos
+func (t *ComboTracker) OnCombo(callback ComboCallback) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onCombo = append(t.onCombo, callback)
+}
@@ -0,0 +1,89 @@
+package skill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+)
+
+func TestComboTracker(t *testing.T) {
+	elementalChain := ComboDef{
+		ID:       "elemental_chain",
+		Name:     "Elemental Chain",
+		Steps:    []string{TagFire, TagCold, TagLightning},
+		WindowMs: 3000,
+		Rewards: []ComboReward{
+			{Attribute: attribute.AttrMagicalDamage, Modifier: attribute.NewModifier("elemental_chain", attribute.ModMore, 30, "combo")},
+		},
+	}
+
+	newTracker := func(t *testing.T) *ComboTracker {
+		tracker := NewComboTracker()
+		require.NoError(t, tracker.Register(elementalChain))
+		return tracker
+	}
+
+	t.Run("комбо из трёх шагов в окне", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		var fired []string
+		tracker.OnCombo(func(event ComboEvent) {
+			fired = append(fired, event.Combo.ID)
+		})
+
+		require.Empty(t, tracker.Record([]string{TagFire, TagSpell}, 1000))
+		require.Empty(t, tracker.Record([]string{TagCold, TagSpell}, 2000))
+		require.Equal(t, 2, tracker.Progress("elemental_chain"))
+
+		events := tracker.Record([]string{TagLightning, TagAoE}, 3500)
+		require.Len(t, events, 1)
+		require.Equal(t, int64(1000), events[0].StartedMs)
+		require.Equal(t, int64(3500), events[0].CompletedMs)
+		require.Len(t, events[0].Combo.Rewards, 1)
+		require.Equal(t, []string{"elemental_chain"}, fired)
+		require.Equal(t, 0, tracker.Progress("elemental_chain"))
+	})
+
+	t.Run("опоздавший шаг", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		tracker.Record([]string{TagFire}, 0)
+		tracker.Record([]string{TagCold}, 1500)
+		require.Empty(t, tracker.Record([]string{TagLightning}, 3001))
+		require.Equal(t, 0, tracker.Progress("elemental_chain"))
+	})
+
+	t.Run("неверный порядок", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		tracker.Record([]string{TagFire}, 0)
+		require.Empty(t, tracker.Record([]string{TagLightning}, 100))
+		require.Empty(t, tracker.Record([]string{TagCold}, 200))
+		require.Equal(t, 0, tracker.Progress("elemental_chain"))
+	})
+
+	t.Run("прерванная цепочка начинается заново", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		tracker.Record([]string{TagFire}, 0)
+		tracker.Record([]string{TagFire}, 500)
+		tracker.Record([]string{TagCold}, 1000)
+		events := tracker.Record([]string{TagLightning}, 3400)
+		require.Len(t, events, 1)
+		require.Equal(t, int64(500), events[0].StartedMs)
+	})
+
+	t.Run("регистрация", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		require.ErrorIs(t, tracker.Register(elementalChain), ErrComboExists)
+		require.ErrorIs(t, tracker.Register(ComboDef{ID: "short", Steps: []string{TagFire}, WindowMs: 100}), ErrInvalidCombo)
+		require.ErrorIs(t, tracker.Register(ComboDef{ID: "no_window", Steps: []string{TagFire, TagCold}}), ErrInvalidCombo)
+
+		combos := tracker.Combos()
+		require.Len(t, combos, 1)
+		require.Equal(t, "Elemental Chain", combos[0].Name)
+	})
+}
@@ -1,9 +1,12 @@
 package skill
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -47,6 +50,13 @@ type Registry interface {
 
 	// LoadFromDirectory loads all YAML files from directory
 	LoadFromDirectory(dir string) error
+
+	// ReloadFromDirectory re-reads directory: adds new skills, replaces changed
+	// ones and drops skills whose files disappeared
+	ReloadFromDirectory(dir string) (added, updated, removed int, err error)
+
+	// ToYAML serializes all definitions in the format LoadFromYAML reads
+	ToYAML() ([]byte, error)
 }
 
 // =============================================================================
@@ -59,12 +69,22 @@ var _ Registry = (*BaseRegistry)(nil)
 type BaseRegistry struct {
 	mu     sync.RWMutex
 	skills map[string]Def
+
+	// sources tracks skills loaded from files, for reloads
+	sources map[string]skillSource
+}
+
+// skillSource remembers file and spec a skill was loaded from
+type skillSource struct {
+	path string
+	spec SkillYAML
 }
 
 // NewBaseRegistry creates a new skill registry
 func NewBaseRegistry() *BaseRegistry {
 	return &BaseRegistry{
-		skills: make(map[string]Def),
+		skills:  make(map[string]Def),
+		sources: make(map[string]skillSource),
 	}
 }
 
@@ -76,6 +96,12 @@ func (r *BaseRegistry) Register(def Def) error {
 		return fmt.Errorf("skill %s already registered", def.ID())
 	}
 
+	if v, ok := def.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("skill %s: %w", def.ID(), err)
+		}
+	}
+
 	r.skills[def.ID()] = def
 	return nil
 }
@@ -167,48 +193,48 @@ type SkillFile struct {
 type SkillYAML struct {
 	ID           string            `yaml:"id"`
 	Name         string            `yaml:"name"`
-	Description  string            `yaml:"description"`
+	Description  string            `yaml:"description,omitempty"`
 	Type         string            `yaml:"type"`
-	Tags         []string          `yaml:"tags"`
-	Icon         string            `yaml:"icon"`
+	Tags         []string          `yaml:"tags,omitempty"`
+	Icon         string            `yaml:"icon,omitempty"`
 	MaxLevel     int               `yaml:"max_level"`
-	Cooldown     int64             `yaml:"cooldown"`
-	Charges      int               `yaml:"charges"`
-	ChargeCD     int64             `yaml:"charge_recovery"`
-	Targeting    *TargetingYAML    `yaml:"targeting"`
-	Effects      []EffectYAML      `yaml:"effects"`
-	Levels       []LevelYAML       `yaml:"levels"`
-	Requirements *RequirementsYAML `yaml:"requirements"`
-	Metadata     map[string]any    `yaml:"metadata"`
+	Cooldown     int64             `yaml:"cooldown,omitempty"`
+	Charges      int               `yaml:"charges,omitempty"`
+	ChargeCD     int64             `yaml:"charge_recovery,omitempty"`
+	Targeting    *TargetingYAML    `yaml:"targeting,omitempty"`
+	Effects      []EffectYAML      `yaml:"effects,omitempty"`
+	Levels       []LevelYAML       `yaml:"levels,omitempty"`
+	Requirements *RequirementsYAML `yaml:"requirements,omitempty"`
+	Metadata     map[string]any    `yaml:"metadata,omitempty"`
 }
 
 // TargetingYAML represents targeting in YAML
 type TargetingYAML struct {
 	Type        string  `yaml:"type"`
-	AreaType    string  `yaml:"area_type"`
-	Range       float64 `yaml:"range"`
-	AreaRadius  float64 `yaml:"area_radius"`
-	MaxTargets  int     `yaml:"max_targets"`
-	MinTargets  int     `yaml:"min_targets"`
-	CanSelf     bool    `yaml:"can_self"`
-	CanAllies   bool    `yaml:"can_allies"`
-	CanEnemies  bool    `yaml:"can_enemies"`
-	RequiresLOS bool    `yaml:"requires_los"`
-	ChainCount  int     `yaml:"chain_count"`
-	ChainFallof float64 `yaml:"chain_falloff"`
+	AreaType    string  `yaml:"area_type,omitempty"`
+	Range       float64 `yaml:"range,omitempty"`
+	AreaRadius  float64 `yaml:"area_radius,omitempty"`
+	MaxTargets  int     `yaml:"max_targets,omitempty"`
+	MinTargets  int     `yaml:"min_targets,omitempty"`
+	CanSelf     bool    `yaml:"can_self,omitempty"`
+	CanAllies   bool    `yaml:"can_allies,omitempty"`
+	CanEnemies  bool    `yaml:"can_enemies,omitempty"`
+	RequiresLOS bool    `yaml:"requires_los,omitempty"`
+	ChainCount  int     `yaml:"chain_count,omitempty"`
+	ChainFallof float64 `yaml:"chain_falloff,omitempty"`
 }
 
 // EffectYAML represents effect in YAML
 type EffectYAML struct {
 	ID         string         `yaml:"id"`
 	Type       string         `yaml:"type"`
-	DamageType string         `yaml:"damage_type"`
-	StatusID   string         `yaml:"status_id"`
-	Scaling    []ScalingYAML  `yaml:"scaling"`
-	Chance     float64        `yaml:"chance"`
-	Delay      int64          `yaml:"delay"`
-	Duration   int64          `yaml:"duration"`
-	Metadata   map[string]any `yaml:"metadata"`
+	DamageType string         `yaml:"damage_type,omitempty"`
+	StatusID   string         `yaml:"status_id,omitempty"`
+	Scaling    []ScalingYAML  `yaml:"scaling,omitempty"`
+	Chance     float64        `yaml:"chance,omitempty"`
+	Delay      int64          `yaml:"delay,omitempty"`
+	Duration   int64          `yaml:"duration,omitempty"`
+	Metadata   map[string]any `yaml:"metadata,omitempty"`
 }
 
 // ScalingYAML represents scaling rule in YAML
@@ -220,12 +246,12 @@ type ScalingYAML struct {
 // LevelYAML represents level data in YAML
 type LevelYAML struct {
 	Level       int               `yaml:"level"`
-	Costs       []ResourceYAML    `yaml:"costs"`
-	Effects     []EffectValueYAML `yaml:"effects"`
-	Cooldown    int64             `yaml:"cooldown"`
-	Charges     int               `yaml:"charges"`
-	Description string            `yaml:"description"`
-	Metadata    map[string]any    `yaml:"metadata"`
+	Costs       []ResourceYAML    `yaml:"costs,omitempty"`
+	Effects     []EffectValueYAML `yaml:"effects,omitempty"`
+	Cooldown    int64             `yaml:"cooldown,omitempty"`
+	Charges     int               `yaml:"charges,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Metadata    map[string]any    `yaml:"metadata,omitempty"`
 }
 
 // ResourceYAML represents resource cost in YAML
@@ -238,19 +264,26 @@ type ResourceYAML struct {
 // EffectValueYAML represents effect values in YAML
 type EffectValueYAML struct {
 	EffectID string         `yaml:"effect_id"`
-	Values   map[string]any `yaml:"values"`
+	Values   map[string]any `yaml:"values,omitempty"`
+	Chance   float64        `yaml:"chance,omitempty"`
+	Duration int64          `yaml:"duration,omitempty"`
 }
 
 // RequirementsYAML represents requirements in YAML
 type RequirementsYAML struct {
-	CharacterLevel int                `yaml:"character_level"`
-	Attributes     map[string]float64 `yaml:"attributes"`
+	CharacterLevel int                `yaml:"character_level,omitempty"`
+	Attributes     map[string]float64 `yaml:"attributes,omitempty"`
 	Skills         map[string]int     `yaml:"skills"`
-	Nodes          []string           `yaml:"nodes"`
-	Items          []string           `yaml:"items"`
+	Nodes          []string           `yaml:"nodes,omitempty"`
+	Items          []string           `yaml:"items,omitempty"`
 }
 
 func (r *BaseRegistry) LoadFromYAML(data []byte) error {
+	return r.loadYAML(data, "")
+}
+
+// loadYAML registers skills from data, remembering path as their source when set
+func (r *BaseRegistry) loadYAML(data []byte, path string) error {
 	var file SkillFile
 	if err := yaml.Unmarshal(data, &file); err != nil {
 		return fmt.Errorf("failed to parse YAML: %w", err)
@@ -265,6 +298,12 @@ func (r *BaseRegistry) LoadFromYAML(data []byte) error {
 		if err := r.Register(def); err != nil {
 			return err
 		}
+
+		if path != "" {
+			r.mu.Lock()
+			r.sources[def.ID()] = skillSource{path: path, spec: skillYAML}
+			r.mu.Unlock()
+		}
 	}
 
 	return nil
@@ -276,7 +315,7 @@ func (r *BaseRegistry) LoadFromFile(path string) error {
 		return fmt.Errorf("failed to read file %s: %w", path, err)
 	}
 
-	return r.LoadFromYAML(data)
+	return r.loadYAML(data, path)
 }
 
 func (r *BaseRegistry) LoadFromDirectory(dir string) error {
@@ -304,6 +343,102 @@ func (r *BaseRegistry) LoadFromDirectory(dir string) error {
 	return nil
 }
 
+// ReloadFromDirectory re-reads YAML files of dir.
+// Changed skills are replaced by ID, so instances created earlier keep their
+// old definition. Skills are removed only when their file is gone; a file that
+// fails to load keeps its previously loaded skills. Errors are collected and
+// returned together after all valid files are applied.
+func (r *BaseRegistry) ReloadFromDirectory(dir string) (added, updated, removed int, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	type loaded struct {
+		def    *BaseDef
+		source skillSource
+	}
+
+	fresh := make(map[string]loaded)
+	failed := make(map[string]bool)
+	var errs []error
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			errs = append(errs, fmt.Errorf("failed to read file %s: %w", path, readErr))
+			failed[path] = true
+			continue
+		}
+
+		var file SkillFile
+		if parseErr := yaml.Unmarshal(data, &file); parseErr != nil {
+			errs = append(errs, fmt.Errorf("failed to load %s: failed to parse YAML: %w", path, parseErr))
+			failed[path] = true
+			continue
+		}
+
+		for _, skillYAML := range file.Skills {
+			def, defErr := parseSkillYAML(skillYAML)
+			if defErr == nil {
+				defErr = def.Validate()
+			}
+			if defErr == nil {
+				if prev, dup := fresh[def.ID()]; dup {
+					defErr = fmt.Errorf("duplicate of skill in %s", prev.source.path)
+				}
+			}
+			if defErr != nil {
+				errs = append(errs, fmt.Errorf("failed to load %s: skill %s: %w", path, skillYAML.ID, defErr))
+				failed[path] = true
+				continue
+			}
+
+			fresh[def.ID()] = loaded{def: def, source: skillSource{path: path, spec: skillYAML}}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, l := range fresh {
+		if _, exists := r.skills[id]; exists {
+			if prev, tracked := r.sources[id]; tracked && reflect.DeepEqual(prev.spec, l.source.spec) {
+				r.sources[id] = l.source
+				continue
+			}
+			updated++
+		} else {
+			added++
+		}
+
+		r.skills[id] = l.def
+		r.sources[id] = l.source
+	}
+
+	cleanDir := filepath.Clean(dir)
+	for id, source := range r.sources {
+		if filepath.Dir(source.path) != cleanDir || failed[source.path] {
+			continue
+		}
+		if _, ok := fresh[id]; ok {
+			continue
+		}
+
+		delete(r.skills, id)
+		delete(r.sources, id)
+		removed++
+	}
+
+	return added, updated, removed, errors.Join(errs...)
+}
+
 // =============================================================================
 // YAML PARSING
 // =============================================================================
@@ -311,12 +446,10 @@ func (r *BaseRegistry) LoadFromDirectory(dir string) error {
 func parseSkillYAML(y SkillYAML) (*BaseDef, error) {
 	skillType := parseSkillType(y.Type)
 
-	// Parse targeting
+	// Parse targeting (nil falls back to self-targeting)
 	var targeting *BaseTargetRule
 	if y.Targeting != nil {
 		targeting = parseTargetingYAML(y.Targeting)
-	} else {
-		targeting = &BaseTargetRule{targetType: TargetSelf}
 	}
 
 	// Parse effects
@@ -478,8 +611,11 @@ func parseEffectType(s string) EffectType {
 		return EffectResource
 	case "dispel":
 		return EffectDispel
-	default:
+	case "":
 		return EffectDamage
+	default:
+		// Kept as-is so validation can reject it
+		return EffectType(s)
 	}
 }
 
@@ -498,6 +634,8 @@ func parseLevelYAML(y LevelYAML) *BaseLevelData {
 		effects = append(effects, EffectValue{
 			EffectID: ey.EffectID,
 			Values:   ey.Values,
+			Chance:   ey.Chance,
+			Duration: ey.Duration,
 		})
 	}
 
@@ -556,6 +694,145 @@ func parseRequirementsYAML(y *RequirementsYAML) *BaseRequirements {
 	})
 }
 
+// =============================================================================
+// YAML EXPORT
+// =============================================================================
+
+// ToYAML serializes all registered definitions, ordered by ID, into the
+// skills file format so edited skills can be saved and loaded back
+func (r *BaseRegistry) ToYAML() ([]byte, error) {
+	defs := r.GetAll()
+	sort.Slice(defs, func(i, j int) bool { return defs[i].ID() < defs[j].ID() })
+
+	file := SkillFile{Version: "1.0", Skills: make([]SkillYAML, 0, len(defs))}
+	for _, def := range defs {
+		file.Skills = append(file.Skills, skillToYAML(def))
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode skills: %w", err)
+	}
+	return data, nil
+}
+
+func sortedTags(tags []string) []string {
+	sort.Strings(tags)
+	return tags
+}
+
+func skillToYAML(def Def) SkillYAML {
+	y := SkillYAML{
+		ID:          def.ID(),
+		Name:        def.Name(),
+		Description: def.Description(),
+		Type:        string(def.Type()),
+		Tags:        sortedTags(def.Tags().All()),
+		Icon:        def.Icon(),
+		MaxLevel:    def.MaxLevel(),
+		Cooldown:    def.BaseCooldown(),
+		Charges:     def.BaseCharges(),
+		ChargeCD:    def.ChargeRecovery(),
+		Metadata:    def.Metadata(),
+	}
+
+	// Implicit self-targeting stays implicit
+	if targeting := def.Targeting(); targeting != nil && targeting != TargetRule(defaultTargeting) {
+		y.Targeting = targetingToYAML(targeting)
+	}
+
+	for _, effect := range def.Effects() {
+		y.Effects = append(y.Effects, effectToYAML(effect))
+	}
+
+	for level := 1; level <= def.MaxLevel(); level++ {
+		if data := def.LevelData(level); data != nil {
+			y.Levels = append(y.Levels, levelToYAML(data))
+		}
+	}
+
+	if req, ok := def.Requirements().(*BaseRequirements); ok && req != nil {
+		y.Requirements = requirementsToYAML(req)
+	}
+
+	return y
+}
+
+func targetingToYAML(t TargetRule) *TargetingYAML {
+	return &TargetingYAML{
+		Type:        string(t.Type()),
+		AreaType:    string(t.AreaType()),
+		Range:       t.Range(),
+		AreaRadius:  t.AreaRadius(),
+		MaxTargets:  t.MaxTargets(),
+		MinTargets:  t.MinTargets(),
+		CanSelf:     t.CanTargetSelf(),
+		CanAllies:   t.CanTargetAllies(),
+		CanEnemies:  t.CanTargetEnemies(),
+		RequiresLOS: t.RequiresLineOfSight(),
+		ChainCount:  t.ChainCount(),
+		ChainFallof: t.ChainFalloff(),
+	}
+}
+
+func effectToYAML(e EffectDef) EffectYAML {
+	scaling := make([]ScalingYAML, 0, len(e.Scaling()))
+	for _, rule := range e.Scaling() {
+		scaling = append(scaling, ScalingYAML{Attribute: rule.Attribute, Multiplier: rule.Multiplier})
+	}
+
+	return EffectYAML{
+		ID:         e.ID(),
+		Type:       string(e.Type()),
+		DamageType: e.DamageType(),
+		StatusID:   e.StatusID(),
+		Scaling:    scaling,
+		Chance:     e.Chance(),
+		Delay:      e.Delay(),
+		Duration:   e.Duration(),
+		Metadata:   e.Metadata(),
+	}
+}
+
+func levelToYAML(l LevelData) LevelYAML {
+	y := LevelYAML{
+		Level:       l.Level(),
+		Cooldown:    l.Cooldown(),
+		Charges:     l.Charges(),
+		Description: l.Description(),
+		Metadata:    l.Metadata(),
+	}
+
+	for _, cost := range l.ResourceCosts() {
+		y.Costs = append(y.Costs, ResourceYAML{
+			Resource: string(cost.Resource),
+			Type:     string(cost.Type),
+			Amount:   cost.Amount,
+		})
+	}
+
+	for _, value := range l.Effects() {
+		y.Effects = append(y.Effects, EffectValueYAML{
+			EffectID: value.EffectID,
+			Values:   value.Values,
+			Chance:   value.Chance,
+			Duration: value.Duration,
+		})
+	}
+
+	return y
+}
+
+func requirementsToYAML(r *BaseRequirements) *RequirementsYAML {
+	return &RequirementsYAML{
+		CharacterLevel: r.CharacterLevel(),
+		Attributes:     r.Attributes(),
+		Skills:         r.Skills(),
+		Nodes:          r.Nodes(),
+		Items:          r.Items(),
+	}
+}
+
 // =============================================================================
 // GLOBAL REGISTRY
 // =============================================================================
@@ -2,6 +2,7 @@ package skill
 
 import (
 	"context"
+	"math/rand/v2"
 
 	"github.com/davidmovas/Depthborn/internal/core/types"
 )
@@ -65,6 +66,10 @@ type Def interface {
 	// Effects returns effect templates that this skill applies
 	Effects() []EffectDef
 
+	// EffectsAtLevel returns effect templates with level overrides applied.
+	// Combat should use this rather than Effects.
+	EffectsAtLevel(level int) []EffectDef
+
 	// Requirements returns requirements to learn/use this skill
 	Requirements() Requirements
 
@@ -104,6 +109,8 @@ type LevelData interface {
 type EffectValue struct {
 	EffectID string         // References EffectDef.ID()
 	Values   map[string]any // Effect-specific values (damage, healing, duration, etc.)
+	Chance   float64        // Overrides EffectDef.Chance (0 = use base)
+	Duration int64          // Overrides EffectDef.Duration in ms (0 = use base)
 }
 
 // =============================================================================
@@ -158,6 +165,9 @@ type Instance interface {
 	// Update processes cooldown/charge recovery for elapsed time
 	Update(deltaMs int64)
 
+	// ResetRuntime clears cooldown and refills charges without firing callbacks
+	ResetRuntime()
+
 	// CanUse checks if skill can be used (has charges/not on cooldown, resources available)
 	CanUse(ctx context.Context, casterID string) bool
 
@@ -317,6 +327,9 @@ type EffectDef interface {
 	// Chance returns probability of effect applying [0.0, 1.0] (1.0 = always)
 	Chance() float64
 
+	// Rolls decides whether effect applies this time based on Chance
+	Rolls(rng *rand.Rand) bool
+
 	// Delay returns delay before effect activates in ms
 	Delay() int64
 
@@ -2,6 +2,9 @@ package skill
 
 import (
 	"context"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -75,6 +78,44 @@ func TestBaseDef(t *testing.T) {
 		level3 := def.LevelData(3)
 		require.Nil(t, level3)
 	})
+
+	t.Run("прогрессия стоимости", func(t *testing.T) {
+		def := NewBaseDef(DefConfig{
+			ID:       "fireball",
+			Name:     "Fireball",
+			MaxLevel: 4,
+		})
+
+		def.SetLevelData(1, NewBaseLevelData(LevelDataConfig{
+			Level: 1,
+			Costs: []ResourceCost{{Resource: ResourceMana, Type: CostFlat, Amount: 10}},
+		}))
+		def.SetLevelData(2, NewBaseLevelData(LevelDataConfig{
+			Level: 2,
+			Costs: []ResourceCost{
+				{Resource: ResourceMana, Type: CostFlat, Amount: 14},
+				{Resource: ResourceHealth, Type: CostFlat, Amount: 5},
+			},
+		}))
+		def.SetLevelData(4, NewBaseLevelData(LevelDataConfig{
+			Level: 4,
+			Costs: []ResourceCost{{Resource: ResourceMana, Type: CostFlat, Amount: 25}},
+		}))
+
+		t.Run("уровни без данных заполняются нулями", func(t *testing.T) {
+			require.Equal(t, []float64{10, 14, 0, 25}, def.CostProgression(ResourceMana))
+			require.Equal(t, []float64{0, 5, 0, 0}, def.CostProgression(ResourceHealth))
+			require.Equal(t, []float64{0, 0, 0, 0}, def.CostProgression(ResourceRage))
+		})
+
+		t.Run("накопленная стоимость", func(t *testing.T) {
+			require.Equal(t, float64(0), def.TotalCostToLevel(ResourceMana, 0))
+			require.Equal(t, float64(10), def.TotalCostToLevel(ResourceMana, 1))
+			require.Equal(t, float64(24), def.TotalCostToLevel(ResourceMana, 3))
+			require.Equal(t, float64(49), def.TotalCostToLevel(ResourceMana, 4))
+			require.Equal(t, float64(49), def.TotalCostToLevel(ResourceMana, 10))
+		})
+	})
 }
 
 func TestBaseInstance(t *testing.T) {
@@ -132,6 +173,9 @@ func TestBaseInstance(t *testing.T) {
 
 		t.Run("начальное состояние", func(t *testing.T) {
 			require.False(t, inst.IsOnCooldown())
+			require.Zero(t, inst.CooldownPercent())
+			require.Empty(t, inst.CooldownText())
+			require.Empty(t, inst.ChargeText())
 		})
 
 		t.Run("после активации", func(t *testing.T) {
@@ -145,9 +189,32 @@ func TestBaseInstance(t *testing.T) {
 			require.Equal(t, int64(3000), inst.Cooldown())
 		})
 
+		t.Run("отображение в середине", func(t *testing.T) {
+			inst.Update(700)
+			require.InDelta(t, 2.3, inst.CooldownSeconds(), 1e-9)
+			require.InDelta(t, 0.46, inst.CooldownPercent(), 1e-9)
+			require.Equal(t, "2.3s", inst.CooldownText())
+		})
+
 		t.Run("полное восстановление", func(t *testing.T) {
-			inst.Update(3000)
+			inst.Update(2300)
 			require.False(t, inst.IsOnCooldown())
+			require.Zero(t, inst.CooldownPercent())
+			require.Zero(t, inst.CooldownSeconds())
+		})
+
+		t.Run("процент после активации", func(t *testing.T) {
+			ctx := context.Background()
+			_, err := inst.Use(ctx, "player1", ActivationParams{})
+			require.NoError(t, err)
+			require.Equal(t, 1.0, inst.CooldownPercent())
+
+			inst.Update(1250)
+			require.InDelta(t, 0.75, inst.CooldownPercent(), 1e-9)
+
+			restored := NewBaseInstance(InstanceConfig{Def: def, StartLevel: 1})
+			restored.RestoreState(inst.GetState())
+			require.InDelta(t, 0.75, restored.CooldownPercent(), 1e-9)
 		})
 	})
 
@@ -167,6 +234,7 @@ func TestBaseInstance(t *testing.T) {
 		t.Run("начальное количество", func(t *testing.T) {
 			require.Equal(t, 3, inst.Charges())
 			require.Equal(t, 3, inst.MaxCharges())
+			require.Equal(t, "3/3 charges", inst.ChargeText())
 		})
 
 		t.Run("использование зарядов", func(t *testing.T) {
@@ -177,17 +245,65 @@ func TestBaseInstance(t *testing.T) {
 			inst.UseCharge()
 			require.Equal(t, 0, inst.Charges())
 			require.False(t, inst.UseCharge())
+			require.Equal(t, "0/3 charges", inst.ChargeText())
 		})
 
 		t.Run("восстановление зарядов", func(t *testing.T) {
 			inst.Update(2000)
 			require.Equal(t, 1, inst.Charges())
+			require.Equal(t, "1/3 charges", inst.ChargeText())
 
 			inst.Update(4000)
 			require.Equal(t, 3, inst.Charges())
 		})
 	})
 
+	t.Run("сброс после боя", func(t *testing.T) {
+		def := NewBaseDef(DefConfig{
+			ID:             "blink",
+			Name:           "Blink",
+			BaseCooldown:   3000,
+			BaseCharges:    2,
+			ChargeRecovery: 1500,
+		})
+		inst := NewBaseInstance(InstanceConfig{Def: def, StartLevel: 1})
+
+		var ready int
+		var gained []int
+		inst.OnCooldownReady(func() { ready++ })
+		inst.OnChargeGained(func(charges int) { gained = append(gained, charges) })
+
+		inst.SetCooldown(3000)
+		inst.UseCharge()
+		inst.UseCharge()
+		inst.Update(500)
+		require.True(t, inst.IsOnCooldown())
+		require.Equal(t, 0, inst.Charges())
+		require.Greater(t, inst.ChargeRecoveryProgress(), 0.0)
+
+		inst.ResetRuntime()
+
+		require.False(t, inst.IsOnCooldown())
+		require.Equal(t, 2, inst.Charges())
+		require.Equal(t, 1.0, inst.ChargeRecoveryProgress())
+		require.True(t, inst.CanUse(context.Background(), "player1"))
+		require.Zero(t, ready)
+		require.Empty(t, gained)
+
+		t.Run("массовый сброс", func(t *testing.T) {
+			other := NewBaseInstance(InstanceConfig{Def: def, StartLevel: 1})
+			inst.SetCooldown(1000)
+			other.SetCooldown(2000)
+			other.UseCharge()
+
+			ResetAll(inst, nil, other)
+
+			require.False(t, inst.IsOnCooldown())
+			require.False(t, other.IsOnCooldown())
+			require.Equal(t, 2, other.Charges())
+		})
+	})
+
 	t.Run("активация скилла", func(t *testing.T) {
 		def := NewBaseDef(DefConfig{
 			ID:           "usable_skill",
@@ -219,6 +335,74 @@ func TestBaseInstance(t *testing.T) {
 		require.True(t, inst.IsOnCooldown())
 		require.False(t, inst.CanUse(ctx, "player1"))
 	})
+
+	t.Run("события", func(t *testing.T) {
+		ctx := context.Background()
+
+		t.Run("использование", func(t *testing.T) {
+			inst := NewBaseInstance(InstanceConfig{
+				Def: NewBaseDef(DefConfig{ID: "strike", Name: "Strike", BaseCooldown: 1000}),
+			})
+
+			used := 0
+			inst.OnUsed(func() { used++ })
+
+			_, err := inst.Use(ctx, "player1", ActivationParams{})
+			require.NoError(t, err)
+			require.Equal(t, 1, used)
+
+			_, err = inst.Use(ctx, "player1", ActivationParams{})
+			require.ErrorIs(t, err, ErrOnCooldown)
+			require.Equal(t, 1, used)
+		})
+
+		t.Run("готовность срабатывает один раз", func(t *testing.T) {
+			inst := NewBaseInstance(InstanceConfig{
+				Def: NewBaseDef(DefConfig{ID: "strike", Name: "Strike", BaseCooldown: 1000}),
+			})
+
+			ready := 0
+			inst.OnCooldownReady(func() { ready++ })
+
+			inst.Update(500)
+			require.Equal(t, 0, ready)
+
+			_, err := inst.Use(ctx, "player1", ActivationParams{})
+			require.NoError(t, err)
+
+			inst.Update(600)
+			require.Equal(t, 0, ready)
+
+			inst.Update(400)
+			require.Equal(t, 1, ready)
+
+			inst.Update(1000)
+			inst.Update(1000)
+			require.Equal(t, 1, ready)
+		})
+
+		t.Run("восстановление заряда", func(t *testing.T) {
+			inst := NewBaseInstance(InstanceConfig{
+				Def: NewBaseDef(DefConfig{ID: "dash", Name: "Dash", BaseCharges: 3, ChargeRecovery: 1000}),
+			})
+
+			var gained []int
+			inst.OnChargeGained(func(charges int) { gained = append(gained, charges) })
+
+			inst.UseCharge()
+			inst.UseCharge()
+			inst.UseCharge()
+
+			inst.Update(1000)
+			require.Equal(t, []int{1}, gained)
+
+			inst.Update(5000)
+			require.Equal(t, []int{1, 2, 3}, gained)
+
+			inst.Update(1000)
+			require.Equal(t, []int{1, 2, 3}, gained)
+		})
+	})
 }
 
 func TestBaseTargetRule(t *testing.T) {
@@ -304,6 +488,26 @@ func TestBaseEffectDef(t *testing.T) {
 		require.Equal(t, 0.25, effect.Chance())
 		require.Equal(t, int64(5000), effect.Duration())
 	})
+
+	t.Run("chance rolling", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(42, 7))
+
+		burn := NewBaseEffectDef(EffectDefConfig{ID: "apply_burn", Type: EffectStatus, Chance: 0.25})
+		const rolls = 20000
+		hits := 0
+		for range rolls {
+			if burn.Rolls(rng) {
+				hits++
+			}
+		}
+		require.InDelta(t, 0.25, float64(hits)/rolls, 0.02)
+
+		always := NewBaseEffectDef(EffectDefConfig{ID: "hit", Type: EffectDamage})
+		for range 100 {
+			require.True(t, always.Rolls(rng))
+		}
+		require.True(t, (&BaseEffectDef{}).Rolls(nil))
+	})
 }
 
 func TestTags(t *testing.T) {
@@ -493,6 +697,223 @@ skills:
 		require.Len(t, costs, 1)
 		require.Equal(t, float64(10), costs[0].Amount)
 	})
+
+	t.Run("эффекты по уровням", func(t *testing.T) {
+		registry := NewBaseRegistry()
+
+		yaml := `
+version: "1.0"
+skills:
+  - id: ignite
+    name: "Ignite"
+    type: active
+    max_level: 3
+    targeting:
+      type: single
+      can_enemies: true
+    effects:
+      - id: hit
+        type: damage
+        damage_type: fire
+      - id: apply_burn
+        type: status
+        status_id: burning
+        chance: 0.2
+        duration: 3000
+    levels:
+      - level: 1
+        costs:
+          - resource: mana
+            amount: 10
+      - level: 2
+        costs:
+          - resource: mana
+            amount: 12
+        effects:
+          - effect_id: apply_burn
+            chance: 0.35
+      - level: 3
+        costs:
+          - resource: mana
+            amount: 14
+        effects:
+          - effect_id: apply_burn
+            chance: 0.5
+            duration: 5000
+`
+		require.NoError(t, registry.LoadFromYAML([]byte(yaml)))
+		def, ok := registry.Get("ignite")
+		require.True(t, ok)
+
+		burnAt := func(level int) EffectDef {
+			for _, e := range def.EffectsAtLevel(level) {
+				if e.ID() == "apply_burn" {
+					return e
+				}
+			}
+			t.Fatalf("apply_burn missing at level %d", level)
+			return nil
+		}
+
+		require.Equal(t, 0.2, burnAt(1).Chance())
+		require.Equal(t, int64(3000), burnAt(1).Duration())
+		require.Equal(t, 0.35, burnAt(2).Chance())
+		require.Equal(t, int64(3000), burnAt(2).Duration())
+		require.Equal(t, 0.5, burnAt(3).Chance())
+		require.Equal(t, int64(5000), burnAt(3).Duration())
+
+		// Base templates stay untouched
+		require.Equal(t, 0.2, def.Effects()[1].Chance())
+		require.Len(t, def.EffectsAtLevel(3), 2)
+	})
+
+	t.Run("валидация", func(t *testing.T) {
+		t.Run("пропущенный уровень", func(t *testing.T) {
+			registry := NewBaseRegistry()
+
+			yaml := `
+skills:
+  - id: gapped
+    name: "Gapped"
+    type: passive
+    max_level: 3
+    levels:
+      - level: 1
+      - level: 3
+`
+			err := registry.LoadFromYAML([]byte(yaml))
+			require.ErrorIs(t, err, ErrInvalidDef)
+			require.Contains(t, err.Error(), "gapped")
+			require.False(t, registry.Has("gapped"))
+		})
+
+		t.Run("max_level не совпадает с уровнями", func(t *testing.T) {
+			def := NewBaseDef(DefConfig{ID: "short", Type: TypePassive, MaxLevel: 3})
+			def.SetLevelData(1, NewBaseLevelData(LevelDataConfig{Level: 1}))
+			def.SetLevelData(2, NewBaseLevelData(LevelDataConfig{Level: 2}))
+
+			require.ErrorIs(t, def.Validate(), ErrInvalidDef)
+		})
+
+		t.Run("активный навык без таргетинга", func(t *testing.T) {
+			registry := NewBaseRegistry()
+
+			yaml := `
+skills:
+  - id: untargeted
+    name: "Untargeted"
+    type: active
+    effects:
+      - id: hit
+        type: damage
+`
+			err := registry.LoadFromYAML([]byte(yaml))
+			require.ErrorIs(t, err, ErrInvalidDef)
+			require.Contains(t, err.Error(), "targeting")
+		})
+
+		t.Run("неизвестный тип эффекта", func(t *testing.T) {
+			registry := NewBaseRegistry()
+
+			yaml := `
+skills:
+  - id: weird
+    name: "Weird"
+    type: passive
+    effects:
+      - id: odd
+        type: transmute
+`
+			require.ErrorIs(t, registry.LoadFromYAML([]byte(yaml)), ErrInvalidDef)
+		})
+
+		t.Run("уровень без стоимости", func(t *testing.T) {
+			def := NewBaseDef(DefConfig{ID: "costly", Type: TypePassive, MaxLevel: 2})
+			def.SetLevelData(1, NewBaseLevelData(LevelDataConfig{
+				Level: 1,
+				Costs: []ResourceCost{{Resource: ResourceMana, Type: CostFlat, Amount: 5}},
+			}))
+			def.SetLevelData(2, NewBaseLevelData(LevelDataConfig{Level: 2}))
+
+			require.ErrorIs(t, def.Validate(), ErrInvalidDef)
+		})
+
+		t.Run("ошибка директории содержит файл и навык", func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "broken.yaml")
+			content := `
+skills:
+  - id: broken_skill
+    name: "Broken"
+    type: passive
+    max_level: 2
+    levels:
+      - level: 2
+`
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+			err := NewBaseRegistry().LoadFromDirectory(dir)
+			require.ErrorIs(t, err, ErrInvalidDef)
+			require.Contains(t, err.Error(), path)
+			require.Contains(t, err.Error(), "broken_skill")
+		})
+	})
+
+	t.Run("горячая перезагрузка", func(t *testing.T) {
+		dir := t.TempDir()
+		write := func(name, content string) {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+		passive := func(id, name string) string {
+			return "skills:\n  - id: " + id + "\n    name: \"" + name + "\"\n    type: passive\n"
+		}
+
+		write("fire.yaml", passive("ember", "Ember"))
+		write("cold.yaml", passive("frost", "Frost"))
+
+		registry := NewBaseRegistry()
+		require.NoError(t, registry.LoadFromDirectory(dir))
+		require.Equal(t, 2, registry.Count())
+
+		oldInstance, err := registry.CreateInstance("ember", 1)
+		require.NoError(t, err)
+
+		t.Run("без изменений", func(t *testing.T) {
+			added, updated, removed, err := registry.ReloadFromDirectory(dir)
+			require.NoError(t, err)
+			require.Equal(t, [3]int{0, 0, 0}, [3]int{added, updated, removed})
+		})
+
+		t.Run("добавление, изменение и удаление", func(t *testing.T) {
+			write("fire.yaml", passive("ember", "Greater Ember"))
+			write("storm.yaml", passive("spark", "Spark"))
+			require.NoError(t, os.Remove(filepath.Join(dir, "cold.yaml")))
+
+			added, updated, removed, err := registry.ReloadFromDirectory(dir)
+			require.NoError(t, err)
+			require.Equal(t, [3]int{1, 1, 1}, [3]int{added, updated, removed})
+
+			require.False(t, registry.Has("frost"))
+			require.True(t, registry.Has("spark"))
+
+			def, _ := registry.Get("ember")
+			require.Equal(t, "Greater Ember", def.Name())
+			require.Equal(t, "Ember", oldInstance.Def().Name())
+		})
+
+		t.Run("сломанный файл не ломает реестр", func(t *testing.T) {
+			write("storm.yaml", "skills: [")
+			write("earth.yaml", passive("stone", "Stone"))
+
+			added, _, removed, err := registry.ReloadFromDirectory(dir)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "storm.yaml")
+			require.Equal(t, 1, added)
+			require.Equal(t, 0, removed)
+			require.True(t, registry.Has("spark"))
+			require.True(t, registry.Has("stone"))
+		})
+	})
 }
 
 func TestLoadRealYAMLFiles(t *testing.T) {
@@ -522,6 +943,40 @@ func TestLoadRealYAMLFiles(t *testing.T) {
 		require.Equal(t, TypePassive, toughness.Type())
 	})
 
+	t.Run("export round-trip", func(t *testing.T) {
+		data, err := registry.ToYAML()
+		require.NoError(t, err)
+
+		reloaded := NewBaseRegistry()
+		require.NoError(t, reloaded.LoadFromYAML(data))
+		require.Equal(t, registry.Count(), reloaded.Count())
+
+		for _, original := range registry.GetAll() {
+			restored, ok := reloaded.Get(original.ID())
+			require.True(t, ok, original.ID())
+
+			require.Equal(t, original.Type(), restored.Type(), original.ID())
+			require.ElementsMatch(t, original.Tags().All(), restored.Tags().All(), original.ID())
+			require.Equal(t, original.MaxLevel(), restored.MaxLevel(), original.ID())
+			require.Equal(t, original.Targeting().Type(), restored.Targeting().Type(), original.ID())
+			require.Len(t, restored.Effects(), len(original.Effects()), original.ID())
+
+			for level := 1; level <= original.MaxLevel(); level++ {
+				want, got := original.LevelData(level), restored.LevelData(level)
+				if want == nil {
+					require.Nil(t, got, "%s level %d", original.ID(), level)
+					continue
+				}
+				require.NotNil(t, got, "%s level %d", original.ID(), level)
+				require.Equal(t, want.ResourceCosts(), got.ResourceCosts(), "%s level %d", original.ID(), level)
+			}
+		}
+
+		again, err := reloaded.ToYAML()
+		require.NoError(t, err)
+		require.Equal(t, string(data), string(again))
+	})
+
 	t.Run("keystone passives", func(t *testing.T) {
 		bloodMagic, ok := registry.Get("blood_magic")
 		require.True(t, ok, "expected 'blood_magic' skill to be loaded")
@@ -60,6 +60,9 @@ type TreeState interface {
 	// AllocateNode unlocks a node (spends points)
 	AllocateNode(ctx context.Context, nodeID string) error
 
+	// AllocateBatch allocates all nodes in dependency order or none of them
+	AllocateBatch(ctx context.Context, nodeIDs []string) error
+
 	// DeallocateNode locks a node (refunds points, costs currency)
 	DeallocateNode(ctx context.Context, nodeID string) error
 
@@ -106,6 +109,9 @@ type TreeState interface {
 	// GetActiveEffects returns all effects from allocated nodes
 	GetActiveEffects() []NodeEffect
 
+	// AggregatedAttributeEffects sums attribute effects per attribute and modifier type
+	AggregatedAttributeEffects() map[attribute.Type]map[attribute.ModifierType]float64
+
 	// ApplyEffects applies all allocated node effects to entity
 	ApplyEffects(ctx context.Context, entityID string) error
 
@@ -161,15 +167,29 @@ type Node interface {
 	// If any of these is allocated, this node cannot be allocated
 	Exclusions() []string
 
+	// ExclusionGroup returns group name shared by mutually exclusive nodes
+	// Only one node of the group can be allocated ("" = no group)
+	ExclusionGroup() string
+
+	// RequiredLevel returns minimum character level to allocate (0 = none)
+	RequiredLevel() int
+
 	// Connections returns adjacent node IDs (for pathing)
 	Connections() []string
 
+	// ConnectionCost returns path search cost of moving from this node to
+	// neighbor; ok is false when no edge weight is set (node cost applies)
+	ConnectionCost(neighborID string) (cost int, ok bool)
+
 	// Effects returns effects granted by this node
 	Effects() []NodeEffect
 
 	// EffectsAtLevel returns effects for specific level
 	EffectsAtLevel(level int) []NodeEffect
 
+	// RenderAtLevel returns human-readable node summary at level (for tooltips)
+	RenderAtLevel(level int) string
+
 	// SkillID returns skill granted (for NodeSkill type)
 	SkillID() string
 
@@ -302,6 +322,9 @@ type Loadout interface {
 	// Update processes all skill cooldowns/charges
 	Update(deltaMs int64)
 
+	// ResetAll makes every equipped skill fully ready (e.g. on combat end)
+	ResetAll()
+
 	// CanUseSlot checks if skill in slot can be used
 	CanUseSlot(ctx context.Context, slot int, casterID string) bool
 
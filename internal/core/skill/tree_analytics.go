@@ -0,0 +1,162 @@
+package skill
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// =============================================================================
+// TREE ANALYTICS (Allocation heatmap for balancing)
+// =============================================================================
+
+// TreeAnalytics aggregates serialized tree states of many builds into
+// per-node and per-branch allocation statistics
+type TreeAnalytics struct {
+	mu sync.RWMutex
+
+	tree   Tree
+	builds int
+
+	nodeCounts  map[string]int // nodeID -> builds allocating node
+	nodeLevels  map[string]int // nodeID -> summed allocated levels
+	branchNodes map[string]int // branchID -> allocated nodes across builds
+	branchUsers map[string]int // branchID -> builds with any node in branch
+}
+
+// NodeAllocationStats describes how often node is taken across builds
+type NodeAllocationStats struct {
+	NodeID       string
+	Count        int
+	AverageLevel float64 // over builds allocating node
+	Share        float64 // Count / builds [0.0 - 1.0]
+}
+
+// BranchPopularity describes investment in branch across builds
+type BranchPopularity struct {
+	BranchID    string
+	Allocations int     // allocated nodes summed over builds
+	Builds      int     // builds with at least one node in branch
+	Share       float64 // Builds / total builds [0.0 - 1.0]
+}
+
+// NewTreeAnalytics creates empty analytics for tree
+func NewTreeAnalytics(tree Tree) *TreeAnalytics {
+	return &TreeAnalytics{
+		tree:        tree,
+		nodeCounts:  make(map[string]int),
+		nodeLevels:  make(map[string]int),
+		branchNodes: make(map[string]int),
+		branchUsers: make(map[string]int),
+	}
+}
+
+// Add ingests build snapshot. Snapshots of other trees are rejected;
+// nodes unknown to tree are counted but have no branch.
+func (a *TreeAnalytics) Add(data TreeStateData) error {
+	if data.TreeID != a.tree.ID() {
+		return fmt.Errorf("%w: snapshot of %q added to analytics of %q", ErrInvalidTree, data.TreeID, a.tree.ID())
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.builds++
+	touched := make(map[string]bool)
+	for nodeID, level := range data.Allocated {
+		if level <= 0 {
+			continue
+		}
+		a.nodeCounts[nodeID]++
+		a.nodeLevels[nodeID] += level
+
+		if node, ok := a.tree.GetNode(nodeID); ok && node.Branch() != "" {
+			a.branchNodes[node.Branch()]++
+			touched[node.Branch()] = true
+		}
+	}
+	for branchID := range touched {
+		a.branchUsers[branchID]++
+	}
+	return nil
+}
+
+// AddAll ingests snapshots, stopping at first rejected one
+func (a *TreeAnalytics) AddAll(snapshots ...TreeStateData) error {
+	for _, data := range snapshots {
+		if err := a.Add(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Builds returns number of ingested snapshots
+func (a *TreeAnalytics) Builds() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.builds
+}
+
+// NodeStats returns stats of node; ok=false if no build allocated it
+func (a *TreeAnalytics) NodeStats(nodeID string) (NodeAllocationStats, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.nodeCounts[nodeID] == 0 {
+		return NodeAllocationStats{}, false
+	}
+	return a.nodeStatsLocked(nodeID), true
+}
+
+// Heatmap returns stats of every allocated node, most allocated first, ties by ID
+func (a *TreeAnalytics) Heatmap() []NodeAllocationStats {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make([]NodeAllocationStats, 0, len(a.nodeCounts))
+	for nodeID := range a.nodeCounts {
+		result = append(result, a.nodeStatsLocked(nodeID))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].NodeID < result[j].NodeID
+	})
+	return result
+}
+
+func (a *TreeAnalytics) nodeStatsLocked(nodeID string) NodeAllocationStats {
+	count := a.nodeCounts[nodeID]
+	return NodeAllocationStats{
+		NodeID:       nodeID,
+		Count:        count,
+		AverageLevel: float64(a.nodeLevels[nodeID]) / float64(count),
+		Share:        float64(count) / float64(a.builds),
+	}
+}
+
+// BranchPopularity returns branches invested in by any build, most
+// allocations first, ties by ID
+func (a *TreeAnalytics) BranchPopularity() []BranchPopularity {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make([]BranchPopularity, 0, len(a.branchNodes))
+	for branchID, allocations := range a.branchNodes {
+		result = append(result, BranchPopularity{
+			BranchID:    branchID,
+			Allocations: allocations,
+			Builds:      a.branchUsers[branchID],
+			Share:       float64(a.branchUsers[branchID]) / float64(a.builds),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Allocations != result[j].Allocations {
+			return result[i].Allocations > result[j].Allocations
+		}
+		return result[i].BranchID < result[j].BranchID
+	})
+	return result
+}
@@ -0,0 +1,180 @@
+package skill
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// =============================================================================
+// NODE EFFECT METADATA ACCESSORS
+// =============================================================================
+
+// EffectMetaInt returns metadata value as int.
+// Accepts any integer type, whole floats and numeric strings (YAML decodes numbers inconsistently).
+func EffectMetaInt(effect NodeEffect, key string) (int, bool) {
+	if effect == nil {
+		return 0, false
+	}
+	return metaInt(effect.Metadata(), key)
+}
+
+// EffectMetaFloat returns metadata value as float64.
+// Accepts any integer or float type and numeric strings.
+func EffectMetaFloat(effect NodeEffect, key string) (float64, bool) {
+	if effect == nil {
+		return 0, false
+	}
+	return metaFloat(effect.Metadata(), key)
+}
+
+// EffectMetaString returns metadata value as string.
+// Non-string scalars are not converted.
+func EffectMetaString(effect NodeEffect, key string) (string, bool) {
+	if effect == nil {
+		return "", false
+	}
+	return metaString(effect.Metadata(), key)
+}
+
+// EffectMetaStringSlice returns metadata value as []string.
+// Accepts []string and []any (as produced by YAML) when all elements are strings.
+func EffectMetaStringSlice(effect NodeEffect, key string) ([]string, bool) {
+	if effect == nil {
+		return nil, false
+	}
+	return metaStringSlice(effect.Metadata(), key)
+}
+
+func metaInt(meta map[string]any, key string) (int, bool) {
+	raw, ok := meta[key]
+	if !ok {
+		return 0, false
+	}
+
+	switch v := raw.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float32:
+		return floatToInt(float64(v))
+	case float64:
+		return floatToInt(v)
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false
+		}
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func floatToInt(f float64) (int, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func metaFloat(meta map[string]any, key string) (float64, bool) {
+	raw, ok := meta[key]
+	if !ok {
+		return 0, false
+	}
+
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+func metaString(meta map[string]any, key string) (string, bool) {
+	raw, ok := meta[key]
+	if !ok {
+		return "", false
+	}
+
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case fmt.Stringer:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
+
+func metaStringSlice(meta map[string]any, key string) ([]string, bool) {
+	raw, ok := meta[key]
+	if !ok {
+		return nil, false
+	}
+
+	switch v := raw.(type) {
+	case []string:
+		result := make([]string, len(v))
+		copy(result, v)
+		return result, true
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return nil, false
+			}
+			result = append(result, s)
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/pkg/persist"
 	"gopkg.in/yaml.v3"
 )
 
@@ -183,10 +184,16 @@ type NodeYAML struct {
 	Position PositionYAML `yaml:"position"`
 
 	// Graph connections
-	Connections  []string `yaml:"connections"`  // Adjacent nodes (bidirectional pathing)
+	Connections  []string `yaml:"connections"`  // Adjacent nodes (bidirectional, mirrored on load)
 	Requirements []string `yaml:"requirements"` // Must have at least ONE allocated
 	Exclusions   []string `yaml:"exclusions"`   // Cannot allocate if ANY is allocated
 
+	ExclusionGroup string `yaml:"exclusion_group"`     // Only one node per group can be allocated
+	RequiredLevel  int    `yaml:"min_character_level"` // Character level needed to allocate
+
+	// Path search cost to move to neighbor, overrides neighbor's cost for that edge
+	ConnectionCosts map[string]int `yaml:"connection_costs"`
+
 	// Effects granted when allocated
 	Effects []NodeEffectYAML `yaml:"effects"`
 
@@ -328,15 +335,18 @@ func parseTreeYAML(y TreeYAML) (*BaseTree, error) {
 		tree.AddNode(node)
 	}
 
+	// Connections are bidirectional
+	tree.NormalizeConnections()
+
 	// Set start nodes
 	tree.SetStartNodes(y.StartNodes)
 
-	// Update branches with node IDs
+	// Update branches with node IDs (in YAML order)
 	for i := range tree.branches {
 		branch := &tree.branches[i]
-		for _, node := range tree.nodes {
-			if node.Branch() == branch.ID {
-				branch.NodeIDs = append(branch.NodeIDs, node.ID())
+		for _, nodeYAML := range y.Nodes {
+			if nodeYAML.Branch == branch.ID {
+				branch.NodeIDs = append(branch.NodeIDs, nodeYAML.ID)
 			}
 		}
 	}
@@ -368,6 +378,10 @@ func parseNodeYAML(y NodeYAML) (*BaseNode, error) {
 		PosX:         y.Position.X,
 		PosY:         y.Position.Y,
 		Icon:         y.Icon,
+
+		ExclusionGroup:  y.ExclusionGroup,
+		RequiredLevel:   y.RequiredLevel,
+		ConnectionCosts: y.ConnectionCosts,
 	})
 
 	// Parse level-specific effects
@@ -426,9 +440,17 @@ func parseNodeEffect(y NodeEffectYAML) (NodeEffect, error) {
 		}, nil
 
 	case "grant_skill":
+		skillID := y.SkillID
+		if skillID == "" {
+			skillID, _ = metaString(y.Metadata, "skill_id")
+		}
+		startLevel := y.StartLevel
+		if startLevel == 0 {
+			startLevel, _ = metaInt(y.Metadata, "start_level")
+		}
 		return &BaseGrantSkillEffect{
-			skillID:     y.SkillID,
-			startLevel:  y.StartLevel,
+			skillID:     skillID,
+			startLevel:  startLevel,
 			description: y.Description,
 		}, nil
 
@@ -442,9 +464,13 @@ func parseNodeEffect(y NodeEffectYAML) (NodeEffect, error) {
 		}, nil
 
 	case "skill_mod":
+		targetTags := y.TargetSkillTags
+		if len(targetTags) == 0 {
+			targetTags, _ = metaStringSlice(y.Metadata, "target_skill_tags")
+		}
 		return &BaseSkillModEffect{
 			targetSkillID:   y.TargetSkillID,
-			targetSkillTags: y.TargetSkillTags,
+			targetSkillTags: targetTags,
 			description:     y.Description,
 			metadata:        y.Metadata,
 		}, nil
@@ -768,6 +794,290 @@ func (e *BaseSpecialEffect) Remove(ctx context.Context, entityID string) error {
 	return nil
 }
 
+// =============================================================================
+// NODE EFFECT SERIALIZATION
+// =============================================================================
+
+// Persist type names of node effect implementations
+const (
+	TypeAttributeEffect  = "skill.effect.attribute"
+	TypeGrantSkillEffect = "skill.effect.grant_skill"
+	TypePassiveEffect    = "skill.effect.passive"
+	TypeSkillModEffect   = "skill.effect.skill_mod"
+	TypeSpecialEffect    = "skill.effect.special"
+)
+
+func init() {
+	factories := map[string]func() any{
+		TypeAttributeEffect:  func() any { return &BaseAttributeEffect{} },
+		TypeGrantSkillEffect: func() any { return &BaseGrantSkillEffect{} },
+		TypePassiveEffect:    func() any { return &BasePassiveEffect{} },
+		TypeSkillModEffect:   func() any { return &BaseSkillModEffect{} },
+		TypeSpecialEffect:    func() any { return &BaseSpecialEffect{} },
+	}
+	for name, factory := range factories {
+		if err := persist.RegisterType(name, factory); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// NodeEffectState holds serializable fields of base node effects.
+// Each implementation uses the subset it needs.
+type NodeEffectState struct {
+	EffectType   string         `msgpack:"effect_type,omitempty"`
+	Attribute    string         `msgpack:"attribute,omitempty"`
+	ModType      string         `msgpack:"mod_type,omitempty"`
+	Value        float64        `msgpack:"value,omitempty"`
+	SkillID      string         `msgpack:"skill_id,omitempty"`
+	StartLevel   int            `msgpack:"start_level,omitempty"`
+	TriggerType  string         `msgpack:"trigger_type,omitempty"`
+	TriggerValue float64        `msgpack:"trigger_value,omitempty"`
+	EffectID     string         `msgpack:"effect_id,omitempty"`
+	TargetTags   []string       `msgpack:"target_tags,omitempty"`
+	Description  string         `msgpack:"description,omitempty"`
+	Metadata     map[string]any `msgpack:"metadata,omitempty"`
+}
+
+func decodeNodeEffectState(data []byte) (NodeEffectState, error) {
+	var state NodeEffectState
+	err := persist.DefaultCodec().Decode(data, &state)
+	return state, err
+}
+
+// MarshalBinary implements persist.Marshaler
+func (e *BaseAttributeEffect) MarshalBinary() ([]byte, error) {
+	return persist.DefaultCodec().Encode(NodeEffectState{
+		Attribute:   string(e.attribute),
+		ModType:     string(e.modType),
+		Value:       e.value,
+		Description: e.description,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (e *BaseAttributeEffect) UnmarshalBinary(data []byte) error {
+	state, err := decodeNodeEffectState(data)
+	if err != nil {
+		return err
+	}
+	e.attribute = attribute.Type(state.Attribute)
+	e.modType = attribute.ModifierType(state.ModType)
+	e.value = state.Value
+	e.description = state.Description
+	return nil
+}
+
+// MarshalBinary implements persist.Marshaler
+func (e *BaseGrantSkillEffect) MarshalBinary() ([]byte, error) {
+	return persist.DefaultCodec().Encode(NodeEffectState{
+		SkillID:     e.skillID,
+		StartLevel:  e.startLevel,
+		Description: e.description,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (e *BaseGrantSkillEffect) UnmarshalBinary(data []byte) error {
+	state, err := decodeNodeEffectState(data)
+	if err != nil {
+		return err
+	}
+	e.skillID = state.SkillID
+	e.startLevel = state.StartLevel
+	e.description = state.Description
+	return nil
+}
+
+// MarshalBinary implements persist.Marshaler
+func (e *BasePassiveEffect) MarshalBinary() ([]byte, error) {
+	return persist.DefaultCodec().Encode(NodeEffectState{
+		TriggerType:  string(e.triggerType),
+		TriggerValue: e.triggerValue,
+		EffectID:     e.effectID,
+		Description:  e.description,
+		Metadata:     e.metadata,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (e *BasePassiveEffect) UnmarshalBinary(data []byte) error {
+	state, err := decodeNodeEffectState(data)
+	if err != nil {
+		return err
+	}
+	e.triggerType = TriggerType(state.TriggerType)
+	e.triggerValue = state.TriggerValue
+	e.effectID = state.EffectID
+	e.description = state.Description
+	e.metadata = state.Metadata
+	return nil
+}
+
+// MarshalBinary implements persist.Marshaler
+func (e *BaseSkillModEffect) MarshalBinary() ([]byte, error) {
+	return persist.DefaultCodec().Encode(NodeEffectState{
+		SkillID:     e.targetSkillID,
+		TargetTags:  e.targetSkillTags,
+		Description: e.description,
+		Metadata:    e.metadata,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (e *BaseSkillModEffect) UnmarshalBinary(data []byte) error {
+	state, err := decodeNodeEffectState(data)
+	if err != nil {
+		return err
+	}
+	e.targetSkillID = state.SkillID
+	e.targetSkillTags = state.TargetTags
+	e.description = state.Description
+	e.metadata = state.Metadata
+	return nil
+}
+
+// MarshalBinary implements persist.Marshaler
+func (e *BaseSpecialEffect) MarshalBinary() ([]byte, error) {
+	return persist.DefaultCodec().Encode(NodeEffectState{
+		EffectType:  string(e.effectType),
+		Description: e.description,
+		Metadata:    e.metadata,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (e *BaseSpecialEffect) UnmarshalBinary(data []byte) error {
+	state, err := decodeNodeEffectState(data)
+	if err != nil {
+		return err
+	}
+	e.effectType = NodeEffectType(state.EffectType)
+	e.description = state.Description
+	e.metadata = state.Metadata
+	return nil
+}
+
+// NodeState holds serializable node; effects are type-tagged so concrete
+// effect types survive round trip
+type NodeState struct {
+	ID              string                       `msgpack:"id"`
+	Name            string                       `msgpack:"name"`
+	Description     string                       `msgpack:"description,omitempty"`
+	Type            string                       `msgpack:"type"`
+	Branch          string                       `msgpack:"branch,omitempty"`
+	Cost            int                          `msgpack:"cost"`
+	MaxLevel        int                          `msgpack:"max_level"`
+	LevelCost       int                          `msgpack:"level_cost,omitempty"`
+	Requirements    []string                     `msgpack:"requirements,omitempty"`
+	Exclusions      []string                     `msgpack:"exclusions,omitempty"`
+	ExclusionGroup  string                       `msgpack:"exclusion_group,omitempty"`
+	RequiredLevel   int                          `msgpack:"required_level,omitempty"`
+	Connections     []string                     `msgpack:"connections,omitempty"`
+	ConnectionCosts map[string]int               `msgpack:"connection_costs,omitempty"`
+	Effects         []persist.TypedValue         `msgpack:"effects,omitempty"`
+	LevelEffects    map[int][]persist.TypedValue `msgpack:"level_effects,omitempty"`
+	SkillID         string                       `msgpack:"skill_id,omitempty"`
+	PosX            float64                      `msgpack:"pos_x"`
+	PosY            float64                      `msgpack:"pos_y"`
+	Icon            string                       `msgpack:"icon,omitempty"`
+}
+
+// MarshalBinary implements persist.Marshaler.
+// Effects must be of types registered with persist.
+func (n *BaseNode) MarshalBinary() ([]byte, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	registry := persist.DefaultTypeRegistry()
+	effects, err := persist.WrapSlice(registry, n.effects)
+	if err != nil {
+		return nil, fmt.Errorf("node %s effects: %w", n.id, err)
+	}
+
+	var levelEffects map[int][]persist.TypedValue
+	if len(n.levelEffects) > 0 {
+		levelEffects = make(map[int][]persist.TypedValue, len(n.levelEffects))
+		for level, list := range n.levelEffects {
+			wrapped, err := persist.WrapSlice(registry, list)
+			if err != nil {
+				return nil, fmt.Errorf("node %s level %d effects: %w", n.id, level, err)
+			}
+			levelEffects[level] = wrapped
+		}
+	}
+
+	return persist.DefaultCodec().Encode(NodeState{
+		ID:              n.id,
+		Name:            n.name,
+		Description:     n.description,
+		Type:            string(n.nodeType),
+		Branch:          n.branch,
+		Cost:            n.cost,
+		MaxLevel:        n.maxLevel,
+		LevelCost:       n.levelCost,
+		Requirements:    n.requirements,
+		Exclusions:      n.exclusions,
+		ExclusionGroup:  n.exclGroup,
+		RequiredLevel:   n.reqLevel,
+		Connections:     n.connections,
+		ConnectionCosts: n.connCosts,
+		Effects:         effects,
+		LevelEffects:    levelEffects,
+		SkillID:         n.skillID,
+		PosX:            n.posX,
+		PosY:            n.posY,
+		Icon:            n.icon,
+	})
+}
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (n *BaseNode) UnmarshalBinary(data []byte) error {
+	var state NodeState
+	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+		return err
+	}
+
+	registry := persist.DefaultTypeRegistry()
+	effects, err := persist.UnwrapSlice[NodeEffect](registry, state.Effects)
+	if err != nil {
+		return fmt.Errorf("node %s effects: %w", state.ID, err)
+	}
+	levelEffects := make(map[int][]NodeEffect, len(state.LevelEffects))
+	for level, list := range state.LevelEffects {
+		unwrapped, err := persist.UnwrapSlice[NodeEffect](registry, list)
+		if err != nil {
+			return fmt.Errorf("node %s level %d effects: %w", state.ID, level, err)
+		}
+		levelEffects[level] = unwrapped
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.id = state.ID
+	n.name = state.Name
+	n.description = state.Description
+	n.nodeType = NodeType(state.Type)
+	n.branch = state.Branch
+	n.cost = state.Cost
+	n.maxLevel = state.MaxLevel
+	n.levelCost = state.LevelCost
+	n.requirements = state.Requirements
+	n.exclusions = state.Exclusions
+	n.exclGroup = state.ExclusionGroup
+	n.reqLevel = state.RequiredLevel
+	n.connections = state.Connections
+	n.connCosts = state.ConnectionCosts
+	n.effects = effects
+	n.levelEffects = levelEffects
+	n.skillID = state.SkillID
+	n.posX = state.PosX
+	n.posY = state.PosY
+	n.icon = state.Icon
+	return nil
+}
+
 // =============================================================================
 // GLOBAL TREE REGISTRY
 // =============================================================================
@@ -0,0 +1,231 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/pkg/persist"
+)
+
+// =============================================================================
+// TREE STATE SET (Character's trees)
+// =============================================================================
+
+// TreeStateSet holds all tree states of one character (e.g. class + ascendancy).
+// Operations route to tree state by tree ID; effects aggregate across trees.
+type TreeStateSet struct {
+	mu sync.RWMutex
+
+	states map[string]*BaseTreeState
+	order  []string // Tree IDs in insertion order
+}
+
+// NewTreeStateSet creates set holding given states
+func NewTreeStateSet(states ...*BaseTreeState) (*TreeStateSet, error) {
+	set := &TreeStateSet{states: make(map[string]*BaseTreeState)}
+	for _, state := range states {
+		if err := set.Add(state); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Add puts tree state into set; one state per tree ID
+func (ts *TreeStateSet) Add(state *BaseTreeState) error {
+	if state == nil {
+		return fmt.Errorf("cannot add nil tree state")
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	treeID := state.TreeID()
+	if _, exists := ts.states[treeID]; exists {
+		return fmt.Errorf("%w: %s", ErrTreeStateExists, treeID)
+	}
+	ts.states[treeID] = state
+	ts.order = append(ts.order, treeID)
+	return nil
+}
+
+// Remove drops tree state from set
+func (ts *TreeStateSet) Remove(treeID string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if _, exists := ts.states[treeID]; !exists {
+		return false
+	}
+	delete(ts.states, treeID)
+	ts.order = slices.DeleteFunc(ts.order, func(id string) bool { return id == treeID })
+	return true
+}
+
+func (ts *TreeStateSet) Get(treeID string) (*BaseTreeState, bool) {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	state, ok := ts.states[treeID]
+	return state, ok
+}
+
+// TreeIDs returns tree IDs in insertion order
+func (ts *TreeStateSet) TreeIDs() []string {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+	return slices.Clone(ts.order)
+}
+
+// States returns tree states in insertion order
+func (ts *TreeStateSet) States() []*BaseTreeState {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	result := make([]*BaseTreeState, len(ts.order))
+	for i, id := range ts.order {
+		result[i] = ts.states[id]
+	}
+	return result
+}
+
+func (ts *TreeStateSet) state(treeID string) (*BaseTreeState, error) {
+	state, ok := ts.Get(treeID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTreeStateNotFound, treeID)
+	}
+	return state, nil
+}
+
+// --- Routed operations ---
+
+// AddPointsToTree grants points usable only in given tree
+func (ts *TreeStateSet) AddPointsToTree(treeID string, amount int) error {
+	state, err := ts.state(treeID)
+	if err != nil {
+		return err
+	}
+	state.AddPoints(amount)
+	return nil
+}
+
+// AllocateNode allocates node in tree with given ID
+func (ts *TreeStateSet) AllocateNode(ctx context.Context, treeID, nodeID string) error {
+	state, err := ts.state(treeID)
+	if err != nil {
+		return err
+	}
+	return state.AllocateNode(ctx, nodeID)
+}
+
+// DeallocateNode deallocates node in tree with given ID
+func (ts *TreeStateSet) DeallocateNode(ctx context.Context, treeID, nodeID string) error {
+	state, err := ts.state(treeID)
+	if err != nil {
+		return err
+	}
+	return state.DeallocateNode(ctx, nodeID)
+}
+
+// SetCharacterLevel updates owner level of every tree state
+func (ts *TreeStateSet) SetCharacterLevel(level int) {
+	for _, state := range ts.States() {
+		state.SetCharacterLevel(level)
+	}
+}
+
+// AvailablePoints returns unspent points summed over all trees
+func (ts *TreeStateSet) AvailablePoints() int {
+	total := 0
+	for _, state := range ts.States() {
+		total += state.AvailablePoints()
+	}
+	return total
+}
+
+// SpentPoints returns spent points summed over all trees
+func (ts *TreeStateSet) SpentPoints() int {
+	total := 0
+	for _, state := range ts.States() {
+		total += state.SpentPoints()
+	}
+	return total
+}
+
+// --- Aggregation ---
+
+// AggregatedEffects returns active effects of all trees, in tree insertion order
+func (ts *TreeStateSet) AggregatedEffects() []NodeEffect {
+	var effects []NodeEffect
+	for _, state := range ts.States() {
+		effects = append(effects, state.GetActiveEffects()...)
+	}
+	return effects
+}
+
+// AggregatedAttributeEffects sums attribute effects of all trees,
+// keyed by attribute and modifier type
+func (ts *TreeStateSet) AggregatedAttributeEffects() map[attribute.Type]map[attribute.ModifierType]float64 {
+	return sumAttributeEffects(ts.AggregatedEffects())
+}
+
+// --- Serialization ---
+
+// TreeStateSetSchema names tree set data for persist.DefaultMigrator migrations
+const TreeStateSetSchema = "skill.tree_state_set"
+
+// TreeStateSetData holds serializable data of all trees in set
+type TreeStateSetData struct {
+	Trees []TreeStateData `msgpack:"trees"`
+}
+
+// GetData returns serializable data of every tree in insertion order
+func (ts *TreeStateSet) GetData() TreeStateSetData {
+	states := ts.States()
+	data := TreeStateSetData{Trees: make([]TreeStateData, len(states))}
+	for i, state := range states {
+		data.Trees[i] = state.GetData()
+	}
+	return data
+}
+
+// RestoreData restores trees from serialized data.
+// Trees missing from set are created with create (e.g. registry.CreateState);
+// with nil create they are reported as ErrTreeStateNotFound.
+func (ts *TreeStateSet) RestoreData(data TreeStateSetData, create func(treeID string) (*BaseTreeState, error)) error {
+	for _, treeData := range data.Trees {
+		state, ok := ts.Get(treeData.TreeID)
+		if !ok {
+			if create == nil {
+				return fmt.Errorf("%w: %s", ErrTreeStateNotFound, treeData.TreeID)
+			}
+			created, err := create(treeData.TreeID)
+			if err != nil {
+				return err
+			}
+			if err := ts.Add(created); err != nil {
+				return err
+			}
+			state = created
+		}
+		state.RestoreData(treeData)
+	}
+	return nil
+}
+
+// Encode serializes set data tagged with TreeStateSetSchema version
+func (ts *TreeStateSet) Encode() ([]byte, error) {
+	return persist.DefaultMigrator().Encode(TreeStateSetSchema, ts.GetData())
+}
+
+// Decode upgrades data written by Encode through registered migrations and
+// restores trees like RestoreData
+func (ts *TreeStateSet) Decode(data []byte, create func(treeID string) (*BaseTreeState, error)) error {
+	var setData TreeStateSetData
+	if err := persist.DefaultMigrator().DecodeMigrated(data, &setData); err != nil {
+		return err
+	}
+	return ts.RestoreData(setData, create)
+}
@@ -2,11 +2,15 @@ package skill
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
 // =============================================================================
@@ -607,6 +611,161 @@ func TestBaseTreeState(t *testing.T) {
 		})
 	})
 
+	t.Run("batch allocation", func(t *testing.T) {
+		ctx := context.Background()
+		newState := func(points int) *BaseTreeState {
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "test_tree", Tree: createTestTree()})
+			state.AddPoints(points)
+			return state
+		}
+
+		t.Run("out-of-order batch is sorted", func(t *testing.T) {
+			state := newState(10)
+			var order []string
+			state.OnNodeAllocated(func(nodeID string) { order = append(order, nodeID) })
+
+			err := state.AllocateBatch(ctx, []string{"keystone_1", "node_c", "node_b", "start"})
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"start", "node_b", "node_c", "keystone_1"}, order)
+			require.Equal(t, 6, state.AvailablePoints())
+			require.Equal(t, 4, state.SpentPoints())
+		})
+
+		t.Run("cost-exceeding batch changes nothing", func(t *testing.T) {
+			state := newState(3)
+			require.NoError(t, state.AllocateNode(ctx, "start"))
+
+			err := state.AllocateBatch(ctx, []string{"node_a", "node_c", "keystone_1"})
+			require.ErrorIs(t, err, ErrInsufficientPoints)
+
+			require.Equal(t, []string{"start"}, state.GetAllocatedNodes())
+			require.Equal(t, 3, state.AvailablePoints())
+		})
+
+		t.Run("mid-batch failure rolls back", func(t *testing.T) {
+			state := newState(10)
+			var points int
+			state.OnPointsChanged(func(available, spent int) { points++ })
+
+			err := state.AllocateBatch(ctx, []string{"start", "node_a", "node_c", "keystone_1", "keystone_2"})
+			require.ErrorIs(t, err, ErrNodeExcluded)
+
+			require.Empty(t, state.GetAllocatedNodes())
+			require.Equal(t, 10, state.AvailablePoints())
+			require.Zero(t, state.SpentPoints())
+			require.Zero(t, points)
+		})
+
+		t.Run("unreachable node", func(t *testing.T) {
+			state := newState(10)
+
+			err := state.AllocateBatch(ctx, []string{"start", "node_c"})
+			require.ErrorIs(t, err, ErrRequirementsNotMet)
+			require.ErrorContains(t, err, "node_c")
+			require.Empty(t, state.GetAllocatedNodes())
+		})
+	})
+
+	t.Run("cost scaling", func(t *testing.T) {
+		ctx := context.Background()
+		newState := func(scaling CostScaling, points int) *BaseTreeState {
+			tree := NewBaseTree(TreeConfig{ID: "scaled_tree", Name: "Scaled Tree"})
+			for _, cfg := range []NodeConfig{
+				{ID: "root", Branch: "might", Cost: 3},
+				{ID: "step_1", Branch: "might", Cost: 3, Requirements: []string{"root"}},
+				{ID: "step_2", Branch: "might", Cost: 3, Requirements: []string{"step_1"}},
+				{ID: "other", Branch: "wits", Cost: 3, Requirements: []string{"root"}},
+			} {
+				cfg.Name, cfg.Type = cfg.ID, NodePath
+				tree.AddNode(NewBaseNode(cfg))
+			}
+			tree.SetStartNodes([]string{"root"})
+
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "scaled_tree", Tree: tree, CostScaling: scaling})
+			state.AddPoints(points)
+			return state
+		}
+
+		t.Run("flat scaling keeps base cost", func(t *testing.T) {
+			state := newState(FlatCostScaling, 10)
+			require.NoError(t, state.AllocateBatch(ctx, []string{"root", "step_1"}))
+			require.Equal(t, 6, state.SpentPoints())
+		})
+
+		t.Run("discount grows with branch investment", func(t *testing.T) {
+			state := newState(DiscountEvery(1, 1, 1), 10)
+
+			require.NoError(t, state.AllocateNode(ctx, "root"))
+			cost, ok := state.EffectiveCost("step_1")
+			require.True(t, ok)
+			require.Equal(t, 2, cost)
+
+			require.NoError(t, state.AllocateNode(ctx, "step_1"))
+			require.NoError(t, state.AllocateNode(ctx, "step_2"))
+			require.Equal(t, 6, state.SpentPoints())
+			require.Equal(t, 6, state.SpentInBranch("might"))
+
+			// Other branch starts without discount
+			cost, _ = state.EffectiveCost("other")
+			require.Equal(t, 3, cost)
+		})
+
+		t.Run("discount counts allocated nodes not points", func(t *testing.T) {
+			state := newState(DiscountEvery(2, 1, 1), 10)
+			require.NoError(t, state.AllocateBatch(ctx, []string{"root", "step_1"}))
+			require.Equal(t, 6, state.SpentInBranch("might"))
+
+			// Two nodes allocated earn one discount, six points would earn three
+			cost, ok := state.EffectiveCost("step_2")
+			require.True(t, ok)
+			require.Equal(t, 2, cost)
+		})
+
+		t.Run("refund equals scaled cost paid", func(t *testing.T) {
+			state := newState(DiscountEvery(1, 1, 1), 10)
+			require.NoError(t, state.AllocateBatch(ctx, []string{"step_2", "step_1", "root"}))
+			require.Equal(t, 4, state.AvailablePoints())
+
+			require.NoError(t, state.DeallocateNode(ctx, "step_2"))
+			require.Equal(t, 5, state.AvailablePoints())
+
+			refund, _, ok := state.DeallocationImpact("step_1")
+			require.True(t, ok)
+			require.Equal(t, 2, refund)
+			require.NoError(t, state.DeallocateNode(ctx, "step_1"))
+			require.Equal(t, 7, state.AvailablePoints())
+
+			require.NoError(t, state.ResetAll(ctx))
+			require.Equal(t, 10, state.AvailablePoints())
+			require.Zero(t, state.SpentPoints())
+		})
+
+		t.Run("paid costs survive save and plan discard", func(t *testing.T) {
+			state := newState(DiscountEvery(1, 1, 1), 10)
+			require.NoError(t, state.AllocateBatch(ctx, []string{"root", "step_1", "step_2"}))
+
+			restored := newState(FlatCostScaling, 0)
+			restored.RestoreData(state.GetData())
+			require.NoError(t, restored.DeallocateNode(ctx, "step_2"))
+			require.Equal(t, 5, restored.AvailablePoints())
+
+			require.NoError(t, state.BeginPlan())
+			require.NoError(t, state.DeallocateNode(ctx, "step_2"))
+			require.NoError(t, state.DiscardPlan())
+			require.NoError(t, state.DeallocateNode(ctx, "step_2"))
+			require.Equal(t, 5, state.AvailablePoints())
+		})
+
+		t.Run("batch budget uses scaled costs", func(t *testing.T) {
+			state := newState(DiscountEvery(1, 1, 1), 5)
+
+			err := state.AllocateBatch(ctx, []string{"root", "step_1", "step_2"})
+			require.ErrorIs(t, err, ErrInsufficientPoints)
+			require.ErrorContains(t, err, "needs 6")
+		})
+	})
+
 	t.Run("mutual exclusions", func(t *testing.T) {
 		tree := createTestTree()
 		state := NewBaseTreeState(TreeStateConfig{
@@ -637,6 +796,121 @@ func TestBaseTreeState(t *testing.T) {
 		})
 	})
 
+	t.Run("exclusion group", func(t *testing.T) {
+		yamlData := []byte(`
+version: "1.0"
+tree:
+  id: keystone_tree
+  name: "Keystone Tree"
+  start_nodes: [start]
+  nodes:
+    - id: start
+      name: "Start"
+      type: path
+      cost: 0
+      connections: [ks_a, ks_b, ks_c]
+    - id: ks_a
+      name: "Keystone A"
+      type: keystone
+      cost: 1
+      requirements: [start]
+      exclusion_group: keystones
+    - id: ks_b
+      name: "Keystone B"
+      type: keystone
+      cost: 1
+      requirements: [start]
+      exclusion_group: keystones
+    - id: ks_c
+      name: "Keystone C"
+      type: keystone
+      cost: 1
+      requirements: [start]
+      exclusion_group: keystones
+`)
+		registry := NewBaseTreeRegistry()
+		require.NoError(t, registry.LoadFromYAML(yamlData))
+
+		tree, ok := registry.Get("keystone_tree")
+		require.True(t, ok)
+		node, ok := tree.GetNode("ks_b")
+		require.True(t, ok)
+		require.Equal(t, "keystones", node.ExclusionGroup())
+
+		state, err := registry.CreateState("keystone_tree")
+		require.NoError(t, err)
+		state.AddPoints(10)
+		ctx := context.Background()
+		require.NoError(t, state.AllocateNode(ctx, "start"))
+
+		t.Run("only one keystone of group can be allocated", func(t *testing.T) {
+			require.True(t, state.CanAllocate("ks_b"))
+			require.NoError(t, state.AllocateNode(ctx, "ks_b"))
+
+			require.Equal(t, ErrNodeExcluded, state.AllocateNode(ctx, "ks_a"))
+			require.Equal(t, ErrNodeExcluded, state.AllocateNode(ctx, "ks_c"))
+			require.False(t, state.CanAllocate("ks_a"))
+			require.False(t, state.CanAllocate("ks_c"))
+		})
+
+		t.Run("deallocating frees the group", func(t *testing.T) {
+			require.NoError(t, state.DeallocateNode(ctx, "ks_b"))
+			require.NoError(t, state.AllocateNode(ctx, "ks_c"))
+			require.False(t, state.CanAllocate("ks_b"))
+		})
+	})
+
+	t.Run("required character level", func(t *testing.T) {
+		yamlData := []byte(`
+version: "1.0"
+tree:
+  id: gated_tree
+  name: "Gated Tree"
+  start_nodes: [start]
+  nodes:
+    - id: start
+      name: "Start"
+      type: path
+      cost: 0
+      connections: [endgame]
+    - id: endgame
+      name: "Endgame Keystone"
+      type: keystone
+      cost: 1
+      requirements: [start]
+      min_character_level: 50
+`)
+		registry := NewBaseTreeRegistry()
+		require.NoError(t, registry.LoadFromYAML(yamlData))
+
+		tree, ok := registry.Get("gated_tree")
+		require.True(t, ok)
+		node, ok := tree.GetNode("endgame")
+		require.True(t, ok)
+		require.Equal(t, 50, node.RequiredLevel())
+
+		state, err := registry.CreateState("gated_tree")
+		require.NoError(t, err)
+		state.AddPoints(5)
+		ctx := context.Background()
+		require.NoError(t, state.AllocateNode(ctx, "start"))
+
+		t.Run("rejected below required level", func(t *testing.T) {
+			state.SetCharacterLevel(30)
+			require.False(t, state.CanAllocate("endgame"))
+			require.ErrorIs(t, state.AllocateNode(ctx, "endgame"), ErrLevelTooLow)
+			require.False(t, state.IsAllocated("endgame"))
+			require.Equal(t, 5, state.AvailablePoints())
+		})
+
+		t.Run("allowed at required level", func(t *testing.T) {
+			state.SetCharacterLevel(50)
+			require.Equal(t, 50, state.CharacterLevel())
+			require.True(t, state.CanAllocate("endgame"))
+			require.NoError(t, state.AllocateNode(ctx, "endgame"))
+		})
+	})
+
 	t.Run("deallocation", func(t *testing.T) {
 		tree := createTestTree()
 		state := NewBaseTreeState(TreeStateConfig{
@@ -792,166 +1066,1019 @@ func TestBaseTreeState(t *testing.T) {
 		require.True(t, state2.IsAllocated("mastery"))
 		require.Equal(t, 2, state2.GetAllocatedLevel("mastery"))
 	})
-}
 
-// =============================================================================
-// CREATE STATE FROM REGISTRY
-// =============================================================================
+	t.Run("planning", func(t *testing.T) {
+		ctx := context.Background()
+		newState := func() *BaseTreeState {
+			state := NewBaseTreeState(TreeStateConfig{
+				TreeID: "test_tree",
+				Tree:   createTestTree(),
+			})
+			state.AddPoints(5)
+			require.NoError(t, state.AllocateNode(ctx, "start"))
+			require.NoError(t, state.AllocateNode(ctx, "node_a"))
+			return state
+		}
 
-func TestRegistryCreateState(t *testing.T) {
-	yamlData := []byte(`
-version: "1.0"
-tree:
-  id: state_test_tree
-  name: "State Test Tree"
-  start_nodes:
-    - start
-  nodes:
-    - id: start
-      name: "Start"
-      type: path
-      cost: 0
-      position: { x: 0, y: 0 }
-`)
-	registry := NewBaseTreeRegistry()
-	_ = registry.LoadFromYAML(yamlData)
+		t.Run("discard restores exact allocations", func(t *testing.T) {
+			state := newState()
+			before := state.GetData()
 
-	t.Run("successful state creation", func(t *testing.T) {
-		state, err := registry.CreateState("state_test_tree")
-		require.NoError(t, err)
-		require.NotNil(t, state)
-		require.Equal(t, "state_test_tree", state.TreeID())
-	})
+			require.NoError(t, state.BeginPlan())
+			require.ErrorIs(t, state.BeginPlan(), ErrPlanActive)
 
-	t.Run("error for non-existing tree", func(t *testing.T) {
-		_, err := registry.CreateState("nonexistent")
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "not found")
-	})
-}
+			require.NoError(t, state.AllocateNode(ctx, "node_c"))
+			require.NoError(t, state.AllocateNode(ctx, "keystone_1"))
+			require.NoError(t, state.DeallocateNode(ctx, "keystone_1"))
+			require.NoError(t, state.AllocateNode(ctx, "mastery"))
+			require.Equal(t, 3, state.PlanCost())
 
-// =============================================================================
-// INTEGRATION: LOAD REAL YAML FILES
-// =============================================================================
+			// Virtual budget is the 4 points available at BeginPlan
+			require.NoError(t, state.LevelUpNode(ctx, "mastery"))
+			require.ErrorIs(t, state.AllocateNode(ctx, "node_b"), ErrInsufficientPoints)
 
-func TestLoadRealTreeYAMLFiles(t *testing.T) {
-	// Get path to data/trees relative to test file
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		t.Skip("failed to determine test file path")
-	}
+			// Uncommitted plan is not persisted
+			require.Equal(t, before, state.GetData())
 
-	// internal/core/skill -> ../../data/trees
-	treesDir := filepath.Join(filepath.Dir(filename), "..", "..", "..", "data", "trees")
+			require.NoError(t, state.DiscardPlan())
+			require.False(t, state.IsPlanning())
+			require.Equal(t, before, state.GetData())
+			require.Equal(t, 0, state.PlanCost())
+			require.ErrorIs(t, state.DiscardPlan(), ErrNoPlan)
+		})
 
-	registry := NewBaseTreeRegistry()
-	err := registry.LoadFromDirectory(treesDir)
-	if err != nil {
-		t.Skipf("failed to load trees: %v", err)
-	}
+		t.Run("discard fires node callbacks for rollback", func(t *testing.T) {
+			state := newState()
+			require.NoError(t, state.AllocateNode(ctx, "mastery"))
 
-	t.Run("trees loaded", func(t *testing.T) {
-		count := registry.Count()
-		require.Greater(t, count, 0, "should have at least one tree")
-		t.Logf("Trees loaded: %d", count)
-	})
+			var events []string
+			state.OnNodeAllocated(func(nodeID string) { events = append(events, "allocated:"+nodeID) })
+			state.OnNodeDeallocated(func(nodeID string) { events = append(events, "deallocated:"+nodeID) })
+			state.OnNodeLeveled(func(nodeID string, level int) {
+				events = append(events, fmt.Sprintf("leveled:%s:%d", nodeID, level))
+			})
 
-	t.Run("main_tree loaded correctly", func(t *testing.T) {
-		tree, ok := registry.Get("main_tree")
-		if !ok {
-			t.Skip("main_tree not found")
-		}
+			require.NoError(t, state.BeginPlan())
+			require.NoError(t, state.LevelUpNode(ctx, "mastery"))
+			require.NoError(t, state.DeallocateNode(ctx, "node_a"))
+			require.NoError(t, state.AllocateNode(ctx, "node_b"))
+			events = nil
 
-		t.Run("basic properties", func(t *testing.T) {
-			require.Equal(t, "main_tree", tree.ID())
-			require.Equal(t, "Skill Tree", tree.Name())
+			require.NoError(t, state.DiscardPlan())
+			require.Equal(t, []string{"deallocated:node_b", "allocated:node_a", "leveled:mastery:1"}, events)
 		})
 
-		t.Run("branches", func(t *testing.T) {
-			branches := tree.GetBranches()
-			require.GreaterOrEqual(t, len(branches), 3, "should have at least 3 branches")
+		t.Run("committed plan matches direct allocation", func(t *testing.T) {
+			planned := newState()
+			require.NoError(t, planned.BeginPlan())
+			require.NoError(t, planned.AllocateNode(ctx, "node_c"))
+			require.NoError(t, planned.AllocateNode(ctx, "keystone_2"))
+			require.NoError(t, planned.CommitPlan())
+			require.ErrorIs(t, planned.CommitPlan(), ErrNoPlan)
 
-			branchIDs := make(map[string]bool)
-			for _, b := range branches {
-				branchIDs[b.ID] = true
-			}
-			require.True(t, branchIDs["combat"], "should have combat branch")
-			require.True(t, branchIDs["defense"], "should have defense branch")
-			require.True(t, branchIDs["magic"], "should have magic branch")
+			direct := newState()
+			require.NoError(t, direct.AllocateNode(ctx, "node_c"))
+			require.NoError(t, direct.AllocateNode(ctx, "keystone_2"))
+
+			require.Equal(t, direct.GetData(), planned.GetData())
 		})
 
-		t.Run("start nodes", func(t *testing.T) {
-			startNodes := tree.GetStartNodes()
-			require.GreaterOrEqual(t, len(startNodes), 1, "should have at least one start node")
+		t.Run("points granted while planning survive discard", func(t *testing.T) {
+			state := newState()
+			require.NoError(t, state.BeginPlan())
+			require.NoError(t, state.AllocateNode(ctx, "node_b"))
+			state.AddPoints(2)
+			require.NoError(t, state.DiscardPlan())
+
+			require.Equal(t, 6, state.AvailablePoints())
+			require.False(t, state.IsAllocated("node_b"))
 		})
+	})
 
-		t.Run("keystones", func(t *testing.T) {
-			nodes := tree.GetNodes()
-			keystoneCount := 0
-			for _, node := range nodes {
-				if node.Type() == NodeKeystone {
-					keystoneCount++
-				}
+	t.Run("branch caps", func(t *testing.T) {
+		ctx := context.Background()
+		newState := func() *BaseTreeState {
+			tree := NewBaseTree(TreeConfig{ID: "branch_tree", Name: "Branch Tree"})
+			tree.AddNode(NewBaseNode(NodeConfig{ID: "start", Type: NodePath}))
+			for _, id := range []string{"fire_1", "fire_2", "fire_3"} {
+				tree.AddNode(NewBaseNode(NodeConfig{
+					ID:           id,
+					Type:         NodePath,
+					Branch:       "fire",
+					Cost:         1,
+					Requirements: []string{"start"},
+				}))
 			}
-			require.Greater(t, keystoneCount, 0, "should have at least one keystone")
-			t.Logf("Keystones found: %d", keystoneCount)
-		})
+			tree.AddNode(NewBaseNode(NodeConfig{
+				ID:           "fire_mastery",
+				Type:         NodeMastery,
+				Branch:       "fire",
+				Cost:         1,
+				MaxLevel:     3,
+				LevelCost:    2,
+				Requirements: []string{"start"},
+			}))
+			tree.AddNode(NewBaseNode(NodeConfig{
+				ID:           "cold_1",
+				Type:         NodePath,
+				Branch:       "cold",
+				Cost:         1,
+				Requirements: []string{"start"},
+			}))
+			tree.SetStartNodes([]string{"start"})
+
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "branch_tree", Tree: tree})
+			state.AddPoints(20)
+			require.NoError(t, state.AllocateNode(ctx, "start"))
+			return state
+		}
 
-		t.Run("leveled nodes", func(t *testing.T) {
-			// Check fire_mastery
-			node, ok := tree.GetNode("fire_mastery")
-			if ok {
-				require.Equal(t, 3, node.MaxLevel())
-				require.Equal(t, 1, node.LevelCost())
+		t.Run("spent points are counted per branch", func(t *testing.T) {
+			state := newState()
+			require.NoError(t, state.AllocateNode(ctx, "fire_1"))
+			require.NoError(t, state.AllocateNode(ctx, "fire_mastery"))
+			require.NoError(t, state.LevelUpNode(ctx, "fire_mastery"))
+			require.NoError(t, state.AllocateNode(ctx, "cold_1"))
 
-				// Check effects per level
-				for level := 1; level <= 3; level++ {
-					effects := node.EffectsAtLevel(level)
-					require.NotEmpty(t, effects, "should have effects at level %d", level)
-				}
-			}
+			require.Equal(t, 4, state.SpentInBranch("fire"))
+			require.Equal(t, 1, state.SpentInBranch("cold"))
+			require.Equal(t, 0, state.SpentInBranch("lightning"))
 		})
 
-		t.Run("graph is connected", func(t *testing.T) {
-			// Check that we can reach from start_combat to blood_magic
-			startNodes := tree.GetStartNodes()
-			if len(startNodes) > 0 {
-				startID := startNodes[0].ID()
-				// Check that there's a path from start
-				adjacent := tree.GetAdjacentNodes(startID)
-				require.NotEmpty(t, adjacent, "start node should have connections")
-			}
-		})
-	})
-}
+		t.Run("allocation stops at cap", func(t *testing.T) {
+			state := newState()
+			state.SetBranchCap("fire", 2)
 
-// =============================================================================
-// NODE EFFECTS APPLY/REMOVE
-// =============================================================================
+			require.NoError(t, state.AllocateNode(ctx, "fire_1"))
+			require.NoError(t, state.AllocateNode(ctx, "fire_2"))
+			require.False(t, state.CanAllocate("fire_3"))
+			require.ErrorIs(t, state.AllocateNode(ctx, "fire_3"), ErrBranchCapReached)
+			require.Equal(t, 2, state.SpentInBranch("fire"))
 
-func TestNodeEffectsApplyRemove(t *testing.T) {
-	ctx := context.Background()
-	entityID := "test_entity"
+			// Other branches are unaffected
+			require.NoError(t, state.AllocateNode(ctx, "cold_1"))
 
-	t.Run("attribute effect", func(t *testing.T) {
-		effect := &BaseAttributeEffect{
-			attribute:   "strength",
-			modType:     "flat",
-			value:       10,
-			description: "+10 Strength",
-		}
+			// Freeing points in branch makes room again
+			require.NoError(t, state.DeallocateNode(ctx, "fire_2"))
+			require.NoError(t, state.AllocateNode(ctx, "fire_3"))
+		})
 
-		err := effect.Apply(ctx, entityID)
-		require.NoError(t, err) // TODO пока просто заглушка
+		t.Run("level up respects cap", func(t *testing.T) {
+			state := newState()
+			state.SetBranchCap("fire", 4)
 
-		err = effect.Remove(ctx, entityID)
-		require.NoError(t, err)
+			require.NoError(t, state.AllocateNode(ctx, "fire_mastery"))
+			require.NoError(t, state.LevelUpNode(ctx, "fire_mastery"))
+			require.ErrorIs(t, state.LevelUpNode(ctx, "fire_mastery"), ErrBranchCapReached)
+			require.Equal(t, 2, state.GetAllocatedLevel("fire_mastery"))
+
+			state.SetBranchCap("fire", 0)
+			_, ok := state.BranchCap("fire")
+			require.False(t, ok)
+			require.NoError(t, state.LevelUpNode(ctx, "fire_mastery"))
+		})
 	})
 
-	t.Run("grant_skill effect", func(t *testing.T) {
-		effect := &BaseGrantSkillEffect{
-			skillID:     "fireball",
-			startLevel:  1,
+	t.Run("active effects cache", func(t *testing.T) {
+		ctx := context.Background()
+		tree := NewBaseTree(TreeConfig{ID: "effects_tree", Name: "Effects Tree"})
+		tree.AddNode(NewBaseNode(NodeConfig{
+			ID:          "start",
+			Type:        NodePath,
+			Connections: []string{"might"},
+			Effects:     []NodeEffect{&BaseAttributeEffect{attribute: attribute.AttrStrength, value: 5}},
+		}))
+		might := NewBaseNode(NodeConfig{
+			ID:           "might",
+			Type:         NodeMastery,
+			Cost:         1,
+			MaxLevel:     2,
+			LevelCost:    1,
+			Requirements: []string{"start"},
+			Effects:      []NodeEffect{&BaseAttributeEffect{attribute: attribute.AttrStrength, value: 10}},
+		})
+		might.SetLevelEffects(2, []NodeEffect{
+			&BaseAttributeEffect{attribute: attribute.AttrStrength, value: 10},
+			&BaseAttributeEffect{attribute: attribute.AttrVitality, value: 10},
+		})
+		tree.AddNode(might)
+		tree.SetStartNodes([]string{"start"})
+
+		state := NewBaseTreeState(TreeStateConfig{TreeID: "effects_tree", Tree: tree})
+		state.AddPoints(5)
+		require.Empty(t, state.GetActiveEffects())
+
+		require.NoError(t, state.AllocateNode(ctx, "start"))
+		require.Len(t, state.GetActiveEffects(), 1)
+
+		require.NoError(t, state.AllocateNode(ctx, "might"))
+		require.Len(t, state.GetActiveEffects(), 2)
+
+		require.NoError(t, state.LevelUpNode(ctx, "might"))
+		require.Len(t, state.GetActiveEffects(), 3)
+
+		t.Run("returned slice is a copy", func(t *testing.T) {
+			effects := state.GetActiveEffects()
+			effects[0] = nil
+			require.NotNil(t, state.GetActiveEffects()[0])
+		})
+
+		require.NoError(t, state.DeallocateNode(ctx, "might"))
+		require.Len(t, state.GetActiveEffects(), 1)
+
+		data := state.GetData()
+		require.NoError(t, state.ResetAll(ctx))
+		require.Empty(t, state.GetActiveEffects())
+
+		state.RestoreData(data)
+		require.Len(t, state.GetActiveEffects(), 1)
+	})
+
+	t.Run("aggregated attribute effects", func(t *testing.T) {
+		ctx := context.Background()
+		strFlat := func(v float64) NodeEffect {
+			return &BaseAttributeEffect{attribute: attribute.AttrStrength, modType: attribute.ModFlat, value: v}
+		}
+		strInc := func(v float64) NodeEffect {
+			return &BaseAttributeEffect{attribute: attribute.AttrStrength, modType: attribute.ModIncreased, value: v}
+		}
+
+		tree := NewBaseTree(TreeConfig{ID: "might_tree", Name: "Might Tree"})
+		tree.AddNode(NewBaseNode(NodeConfig{
+			ID:          "start",
+			Type:        NodePath,
+			Connections: []string{"str_1", "str_2", "str_pct", "mastery"},
+			Effects:     []NodeEffect{strFlat(5)},
+		}))
+		for _, id := range []string{"str_1", "str_2"} {
+			tree.AddNode(NewBaseNode(NodeConfig{ID: id, Type: NodePath, Cost: 1, Requirements: []string{"start"}, Effects: []NodeEffect{strFlat(10)}}))
+		}
+		tree.AddNode(NewBaseNode(NodeConfig{
+			ID:           "str_pct",
+			Type:         NodeNotable,
+			Cost:         1,
+			Requirements: []string{"start"},
+			Effects:      []NodeEffect{strInc(0.1), &BaseGrantSkillEffect{skillID: "cleave", startLevel: 1}},
+		}))
+		mastery := NewBaseNode(NodeConfig{
+			ID:           "mastery",
+			Type:         NodeMastery,
+			Cost:         1,
+			MaxLevel:     2,
+			LevelCost:    1,
+			Requirements: []string{"start"},
+			Effects:      []NodeEffect{strInc(0.05)},
+		})
+		mastery.SetLevelEffects(2, []NodeEffect{
+			strInc(0.15),
+			&BaseAttributeEffect{attribute: attribute.AttrVitality, modType: attribute.ModFlat, value: 20},
+		})
+		tree.AddNode(mastery)
+		tree.SetStartNodes([]string{"start"})
+
+		state := NewBaseTreeState(TreeStateConfig{TreeID: "might_tree", Tree: tree})
+		state.AddPoints(10)
+		require.Empty(t, state.AggregatedAttributeEffects())
+
+		for _, id := range []string{"start", "str_1", "str_2", "str_pct", "mastery"} {
+			require.NoError(t, state.AllocateNode(ctx, id))
+		}
+		totals := state.AggregatedAttributeEffects()
+		require.Len(t, totals, 1)
+		require.InDelta(t, 25, totals[attribute.AttrStrength][attribute.ModFlat], 1e-9)
+		require.InDelta(t, 0.15, totals[attribute.AttrStrength][attribute.ModIncreased], 1e-9)
+
+		// Level 2 replaces level 1 effects
+		require.NoError(t, state.LevelUpNode(ctx, "mastery"))
+		totals = state.AggregatedAttributeEffects()
+		require.InDelta(t, 0.25, totals[attribute.AttrStrength][attribute.ModIncreased], 1e-9)
+		require.InDelta(t, 20, totals[attribute.AttrVitality][attribute.ModFlat], 1e-9)
+
+		// Raw list stays unmerged
+		require.Len(t, state.GetActiveEffects(), 7)
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		ctx := context.Background()
+		tree := createTestTree()
+		for _, id := range []string{"combat_1", "combat_2", "combat_3", "combat_4"} {
+			tree.AddNode(NewBaseNode(NodeConfig{
+				ID:           id,
+				Type:         NodePath,
+				Branch:       "combat",
+				Cost:         1,
+				Requirements: []string{"start"},
+			}))
+		}
+
+		state := NewBaseTreeState(TreeStateConfig{TreeID: "test_tree", Tree: tree})
+		state.AddPoints(20)
+
+		progress := state.Progress()
+		require.Equal(t, 0, progress.AllocatedNodes)
+		require.Equal(t, 11, progress.TotalNodes)
+		require.Equal(t, 0.0, progress.Branches["combat"])
+
+		for _, id := range []string{"start", "node_a", "node_c", "keystone_1", "combat_1", "combat_2", "combat_3"} {
+			require.NoError(t, state.AllocateNode(ctx, id))
+		}
+
+		progress = state.Progress()
+		require.Equal(t, 7, progress.AllocatedNodes)
+		require.Equal(t, 11, progress.TotalNodes)
+		require.Equal(t, 7, progress.SpentPoints)
+		require.Equal(t, 1, progress.ByType[NodeKeystone])
+		require.Equal(t, 1, progress.ByType[NodeNotable])
+		require.Equal(t, 5, progress.ByType[NodePath])
+		require.Len(t, progress.Branches, 1)
+		require.Equal(t, 75.0, progress.Branches["combat"])
+	})
+
+	t.Run("node tooltip", func(t *testing.T) {
+		ctx := context.Background()
+		tree := createTestTree()
+		mastery, _ := tree.GetNode("mastery")
+		for level, desc := range []string{"+10% Fire Damage", "+20% Fire Damage", "+35% Fire Damage"} {
+			mastery.(*BaseNode).SetLevelEffects(level+1, []NodeEffect{&BaseAttributeEffect{description: desc}})
+		}
+
+		require.Equal(t, "Mastery (mastery) - Level 2/3\nCost: 1 (next level: 1)\n+20% Fire Damage", mastery.RenderAtLevel(2))
+		require.Equal(t, "Mastery (mastery) - Level 3/3\nCost: 1\n+35% Fire Damage", mastery.RenderAtLevel(3))
+
+		state := NewBaseTreeState(TreeStateConfig{TreeID: "test_tree", Tree: tree})
+		state.AddPoints(10)
+
+		tooltip, ok := state.NodeTooltip("mastery")
+		require.True(t, ok)
+		require.Contains(t, tooltip, "Level 1/3")
+		require.Contains(t, tooltip, "+10% Fire Damage")
+
+		require.NoError(t, state.AllocateNode(ctx, "start"))
+		require.NoError(t, state.AllocateNode(ctx, "mastery"))
+		require.NoError(t, state.LevelUpNode(ctx, "mastery"))
+
+		tooltip, ok = state.NodeTooltip("mastery")
+		require.True(t, ok)
+		require.Contains(t, tooltip, "Level 2/3")
+		require.Contains(t, tooltip, "+20% Fire Damage")
+
+		tooltip, ok = state.NodeTooltip("start")
+		require.True(t, ok)
+		require.Equal(t, "Start (path)\nCost: 0", tooltip)
+
+		_, ok = state.NodeTooltip("missing")
+		require.False(t, ok)
+	})
+
+	t.Run("observers", func(t *testing.T) {
+		ctx := context.Background()
+		state := NewBaseTreeState(TreeStateConfig{TreeID: "test_tree", Tree: createTestTree()})
+
+		var events []string
+		state.OnNodeAllocated(func(nodeID string) {
+			// Fires after mutation, state is readable without deadlock
+			require.True(t, state.IsAllocated(nodeID))
+			events = append(events, "allocated "+nodeID)
+		})
+		state.OnNodeLeveled(func(nodeID string, level int) {
+			require.Equal(t, level, state.GetAllocatedLevel(nodeID))
+			events = append(events, fmt.Sprintf("leveled %s %d", nodeID, level))
+		})
+		state.OnNodeDeallocated(func(nodeID string) {
+			require.False(t, state.IsAllocated(nodeID))
+			events = append(events, "deallocated "+nodeID)
+		})
+		state.OnPointsChanged(func(available, spent int) {
+			events = append(events, fmt.Sprintf("points %d/%d", available, spent))
+		})
+
+		state.AddPoints(10)
+		require.NoError(t, state.AllocateNode(ctx, "start"))
+		require.NoError(t, state.AllocateNode(ctx, "mastery"))
+		require.NoError(t, state.LevelUpNode(ctx, "mastery"))
+		require.Error(t, state.AllocateNode(ctx, "mastery"))
+		require.NoError(t, state.DeallocateNode(ctx, "mastery"))
+
+		require.Equal(t, []string{
+			"points 10/0",
+			"allocated start", // free node, points unchanged
+			"allocated mastery",
+			"points 9/1",
+			"leveled mastery 2",
+			"points 8/2",
+			"deallocated mastery",
+			"points 10/0",
+		}, events)
+	})
+
+	t.Run("DeallocationImpact", func(t *testing.T) {
+		ctx := context.Background()
+		newState := func(t *testing.T, nodeIDs ...string) *BaseTreeState {
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "test_tree", Tree: createTestTree()})
+			state.AddPoints(20)
+			for _, nodeID := range nodeIDs {
+				require.NoError(t, state.AllocateNode(ctx, nodeID))
+			}
+			return state
+		}
+
+		t.Run("leaf has empty cascade", func(t *testing.T) {
+			state := newState(t, "start", "node_a", "node_c", "keystone_1")
+
+			refund, cascade, ok := state.DeallocationImpact("keystone_1")
+			require.True(t, ok)
+			require.Equal(t, 1, refund)
+			require.Empty(t, cascade)
+		})
+
+		t.Run("internal node cascades to dependents", func(t *testing.T) {
+			state := newState(t, "start", "node_a", "node_c", "keystone_1", "mastery")
+			require.NoError(t, state.LevelUpNode(ctx, "mastery"))
+			spent := state.SpentPoints()
+
+			refund, cascade, ok := state.DeallocationImpact("node_a")
+			require.True(t, ok)
+			require.Equal(t, 4, refund) // node_a 1 + node_c 2 + keystone_1 1
+			require.Equal(t, []string{"keystone_1", "node_c"}, cascade)
+			require.Equal(t, spent, state.SpentPoints())
+			require.True(t, state.IsAllocated("node_c"))
+
+			for _, nodeID := range append(cascade, "node_a") {
+				require.NoError(t, state.DeallocateNode(ctx, nodeID))
+			}
+			require.Equal(t, spent-refund, state.SpentPoints())
+
+			refund, cascade, ok = state.DeallocationImpact("start")
+			require.True(t, ok)
+			require.Equal(t, 2, refund) // start 0 + mastery at level 2
+			require.Equal(t, []string{"mastery"}, cascade)
+		})
+
+		t.Run("alternative requirement keeps dependent", func(t *testing.T) {
+			state := newState(t, "start", "node_a", "node_b", "node_c")
+
+			refund, cascade, ok := state.DeallocationImpact("node_a")
+			require.True(t, ok)
+			require.Equal(t, 1, refund)
+			require.Empty(t, cascade)
+		})
+
+		t.Run("unallocated node", func(t *testing.T) {
+			state := newState(t, "start")
+
+			_, cascade, ok := state.DeallocationImpact("node_a")
+			require.False(t, ok)
+			require.Nil(t, cascade)
+		})
+	})
+
+	t.Run("PreviewPathTo", func(t *testing.T) {
+		tree := NewBaseTree(TreeConfig{ID: "path_tree", Name: "Path Tree"})
+		for _, cfg := range []NodeConfig{
+			{ID: "start", Cost: 0},
+			{ID: "cheap_1", Cost: 1, Requirements: []string{"start"}},
+			{ID: "cheap_2", Cost: 1, Requirements: []string{"cheap_1"}},
+			{ID: "pricey", Cost: 5, Requirements: []string{"start"}},
+			{ID: "target", Cost: 2, Requirements: []string{"cheap_2", "pricey"}},
+			{ID: "locked", Cost: 1, Requirements: []string{"start"}, Exclusions: []string{"cheap_1"}},
+			{ID: "beyond", Cost: 1, Requirements: []string{"locked"}},
+		} {
+			tree.AddNode(NewBaseNode(cfg))
+		}
+		ctx := context.Background()
+
+		t.Run("affordable", func(t *testing.T) {
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "path_tree", Tree: tree})
+			state.AddPoints(4)
+
+			path, cost, affordable := state.PreviewPathTo("target")
+			require.Equal(t, []string{"start", "cheap_1", "cheap_2", "target"}, path)
+			require.Equal(t, 4, cost)
+			require.True(t, affordable)
+			require.Empty(t, state.GetAllocatedNodes())
+			require.Equal(t, 4, state.AvailablePoints())
+
+			for _, nodeID := range path {
+				require.NoError(t, state.AllocateNode(ctx, nodeID))
+			}
+			path, cost, affordable = state.PreviewPathTo("target")
+			require.Equal(t, []string{}, path)
+			require.Zero(t, cost)
+			require.True(t, affordable)
+		})
+
+		t.Run("starts from current allocations", func(t *testing.T) {
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "path_tree", Tree: tree})
+			state.AddPoints(2)
+			require.NoError(t, state.AllocateNode(ctx, "start"))
+			require.NoError(t, state.AllocateNode(ctx, "cheap_1"))
+
+			path, cost, affordable := state.PreviewPathTo("target")
+			require.Equal(t, []string{"cheap_2", "target"}, path)
+			require.Equal(t, 3, cost)
+			require.False(t, affordable)
+
+			state.AddPoints(2)
+			_, _, affordable = state.PreviewPathTo("target")
+			require.True(t, affordable)
+		})
+
+		t.Run("unaffordable by branch cap", func(t *testing.T) {
+			capped := NewBaseTree(TreeConfig{ID: "capped"})
+			capped.AddNode(NewBaseNode(NodeConfig{ID: "root", Cost: 1, Branch: "fire"}))
+			capped.AddNode(NewBaseNode(NodeConfig{ID: "leaf", Cost: 2, Branch: "fire", Requirements: []string{"root"}}))
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "capped", Tree: capped})
+			state.AddPoints(10)
+			state.SetBranchCap("fire", 2)
+
+			path, cost, affordable := state.PreviewPathTo("leaf")
+			require.Equal(t, []string{"root", "leaf"}, path)
+			require.Equal(t, 3, cost)
+			require.False(t, affordable)
+		})
+
+		t.Run("unreachable", func(t *testing.T) {
+			state := NewBaseTreeState(TreeStateConfig{TreeID: "path_tree", Tree: tree})
+			state.AddPoints(10)
+			require.NoError(t, state.AllocateNode(ctx, "start"))
+			require.NoError(t, state.AllocateNode(ctx, "cheap_1"))
+
+			path, cost, affordable := state.PreviewPathTo("beyond")
+			require.Nil(t, path)
+			require.Zero(t, cost)
+			require.False(t, affordable)
+
+			path, _, affordable = state.PreviewPathTo("missing")
+			require.Nil(t, path)
+			require.False(t, affordable)
+		})
+
+		t.Run("edge weights change route", func(t *testing.T) {
+			load := func(t *testing.T, startCosts string) Tree {
+				yamlData := []byte(`
+version: "1.0"
+tree:
+  id: edge_tree
+  name: "Edge Tree"
+  start_nodes: [start]
+  nodes:
+    - id: start
+      cost: 0
+      connection_costs: ` + startCosts + `
+    - id: short
+      cost: 1
+      requirements: [start]
+    - id: long_1
+      cost: 2
+      requirements: [start]
+    - id: long_2
+      cost: 2
+      requirements: [long_1]
+    - id: target
+      cost: 1
+      requirements: [short, long_2]
+`)
+				registry := NewBaseTreeRegistry()
+				require.NoError(t, registry.LoadFromYAML(yamlData))
+				tree, ok := registry.Get("edge_tree")
+				require.True(t, ok)
+				return tree
+			}
+			preview := func(tree Tree) ([]string, int) {
+				state := NewBaseTreeState(TreeStateConfig{TreeID: "edge_tree", Tree: tree})
+				state.AddPoints(10)
+				path, cost, _ := state.PreviewPathTo("target")
+				return path, cost
+			}
+
+			path, cost := preview(load(t, "{}"))
+			require.Equal(t, []string{"start", "short", "target"}, path)
+			require.Equal(t, 2, cost)
+
+			weighted := load(t, "{ short: 9 }")
+			start, _ := weighted.GetNode("start")
+			edge, ok := start.ConnectionCost("short")
+			require.True(t, ok)
+			require.Equal(t, 9, edge)
+			_, ok = start.ConnectionCost("long_1")
+			require.False(t, ok)
+
+			// Traversal weight reroutes, reported cost stays allocation cost
+			path, cost = preview(weighted)
+			require.Equal(t, []string{"start", "long_1", "long_2", "target"}, path)
+			require.Equal(t, 5, cost)
+		})
+	})
+}
+
+// =============================================================================
+// TREE STATE SET
+// =============================================================================
+
+func TestTreeStateSet(t *testing.T) {
+	ctx := context.Background()
+	treeYAML := func(id, attr string, value int) []byte {
+		return []byte(fmt.Sprintf(`
+version: "1.0"
+tree:
+  id: %[1]s
+  name: "%[1]s"
+  start_nodes: [start]
+  nodes:
+    - id: start
+      name: "Start"
+      type: path
+      cost: 0
+      connections: [boost]
+    - id: boost
+      name: "Boost"
+      type: notable
+      cost: 1
+      requirements: [start]
+      effects:
+        - type: attribute
+          attribute: %[2]s
+          mod_type: flat
+          value: %[3]d
+`, id, attr, value))
+	}
+
+	registry := NewBaseTreeRegistry()
+	require.NoError(t, registry.LoadFromYAML(treeYAML("class_tree", "strength", 10)))
+	require.NoError(t, registry.LoadFromYAML(treeYAML("ascendancy_tree", "strength", 5)))
+
+	newSet := func(t *testing.T) *TreeStateSet {
+		class, err := registry.CreateState("class_tree")
+		require.NoError(t, err)
+		ascendancy, err := registry.CreateState("ascendancy_tree")
+		require.NoError(t, err)
+		set, err := NewTreeStateSet(class, ascendancy)
+		require.NoError(t, err)
+		return set
+	}
+
+	t.Run("allocation routes by tree ID", func(t *testing.T) {
+		set := newSet(t)
+		require.NoError(t, set.AddPointsToTree("class_tree", 2))
+
+		require.NoError(t, set.AllocateNode(ctx, "class_tree", "start"))
+		require.NoError(t, set.AllocateNode(ctx, "class_tree", "boost"))
+		require.NoError(t, set.AllocateNode(ctx, "ascendancy_tree", "start"))
+		require.ErrorIs(t, set.AllocateNode(ctx, "ascendancy_tree", "boost"), ErrInsufficientPoints)
+		require.ErrorIs(t, set.AllocateNode(ctx, "missing_tree", "start"), ErrTreeStateNotFound)
+		require.ErrorIs(t, set.AddPointsToTree("missing_tree", 1), ErrTreeStateNotFound)
+
+		class, _ := set.Get("class_tree")
+		require.True(t, class.IsAllocated("boost"))
+		require.Equal(t, 1, set.SpentPoints())
+		require.Equal(t, 1, set.AvailablePoints())
+	})
+
+	t.Run("effects aggregate across trees", func(t *testing.T) {
+		set := newSet(t)
+		require.NoError(t, set.AddPointsToTree("class_tree", 1))
+		require.NoError(t, set.AddPointsToTree("ascendancy_tree", 1))
+		for _, treeID := range set.TreeIDs() {
+			require.NoError(t, set.AllocateNode(ctx, treeID, "start"))
+			require.NoError(t, set.AllocateNode(ctx, treeID, "boost"))
+		}
+
+		require.Len(t, set.AggregatedEffects(), 2)
+		require.InDelta(t, 15, set.AggregatedAttributeEffects()[attribute.AttrStrength][attribute.ModFlat], 1e-9)
+	})
+
+	t.Run("duplicate tree rejected", func(t *testing.T) {
+		set := newSet(t)
+		again, err := registry.CreateState("class_tree")
+		require.NoError(t, err)
+		require.ErrorIs(t, set.Add(again), ErrTreeStateExists)
+	})
+
+	t.Run("serialization round-trips all trees", func(t *testing.T) {
+		set := newSet(t)
+		require.NoError(t, set.AddPointsToTree("class_tree", 3))
+		require.NoError(t, set.AddPointsToTree("ascendancy_tree", 1))
+		require.NoError(t, set.AllocateNode(ctx, "class_tree", "start"))
+		require.NoError(t, set.AllocateNode(ctx, "class_tree", "boost"))
+		require.NoError(t, set.AllocateNode(ctx, "ascendancy_tree", "start"))
+
+		encoded, err := persist.DefaultCodec().Encode(set.GetData())
+		require.NoError(t, err)
+		var data TreeStateSetData
+		require.NoError(t, persist.DefaultCodec().Decode(encoded, &data))
+
+		// Missing trees need a factory
+		empty, err := NewTreeStateSet()
+		require.NoError(t, err)
+		require.ErrorIs(t, empty.RestoreData(data, nil), ErrTreeStateNotFound)
+
+		restored, err := NewTreeStateSet()
+		require.NoError(t, err)
+		require.NoError(t, restored.RestoreData(data, registry.CreateState))
+
+		require.Equal(t, []string{"class_tree", "ascendancy_tree"}, restored.TreeIDs())
+		require.Equal(t, set.GetData(), restored.GetData())
+		require.Equal(t, set.AggregatedAttributeEffects(), restored.AggregatedAttributeEffects())
+
+		class, _ := restored.Get("class_tree")
+		require.Equal(t, 2, class.AvailablePoints())
+		ascendancy, _ := restored.Get("ascendancy_tree")
+		require.False(t, ascendancy.IsAllocated("boost"))
+	})
+
+	t.Run("schema-tagged encoding round-trips all trees", func(t *testing.T) {
+		set := newSet(t)
+		require.NoError(t, set.AddPointsToTree("class_tree", 3))
+		require.NoError(t, set.AllocateNode(ctx, "class_tree", "start"))
+
+		encoded, err := set.Encode()
+		require.NoError(t, err)
+
+		restored, err := NewTreeStateSet()
+		require.NoError(t, err)
+		require.NoError(t, restored.Decode(encoded, registry.CreateState))
+		require.Equal(t, set.GetData(), restored.GetData())
+
+		// Untagged data is not a schema-tagged save
+		raw, err := persist.DefaultCodec().Encode(set.GetData())
+		require.NoError(t, err)
+		require.ErrorIs(t, restored.Decode(raw, registry.CreateState), persist.ErrNotVersioned)
+	})
+}
+
+// =============================================================================
+// CREATE STATE FROM REGISTRY
+// =============================================================================
+
+func TestRegistryCreateState(t *testing.T) {
+	yamlData := []byte(`
+version: "1.0"
+tree:
+  id: state_test_tree
+  name: "State Test Tree"
+  start_nodes:
+    - start
+  nodes:
+    - id: start
+      name: "Start"
+      type: path
+      cost: 0
+      position: { x: 0, y: 0 }
+`)
+	registry := NewBaseTreeRegistry()
+	_ = registry.LoadFromYAML(yamlData)
+
+	t.Run("successful state creation", func(t *testing.T) {
+		state, err := registry.CreateState("state_test_tree")
+		require.NoError(t, err)
+		require.NotNil(t, state)
+		require.Equal(t, "state_test_tree", state.TreeID())
+	})
+
+	t.Run("error for non-existing tree", func(t *testing.T) {
+		_, err := registry.CreateState("nonexistent")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+	})
+}
+
+// =============================================================================
+// INTEGRATION: LOAD REAL YAML FILES
+// =============================================================================
+
+func TestLoadRealTreeYAMLFiles(t *testing.T) {
+	// Get path to data/trees relative to test file
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Skip("failed to determine test file path")
+	}
+
+	// internal/core/skill -> ../../data/trees
+	treesDir := filepath.Join(filepath.Dir(filename), "..", "..", "..", "data", "trees")
+
+	registry := NewBaseTreeRegistry()
+	err := registry.LoadFromDirectory(treesDir)
+	if err != nil {
+		t.Skipf("failed to load trees: %v", err)
+	}
+
+	t.Run("trees loaded", func(t *testing.T) {
+		count := registry.Count()
+		require.Greater(t, count, 0, "should have at least one tree")
+		t.Logf("Trees loaded: %d", count)
+	})
+
+	t.Run("main_tree loaded correctly", func(t *testing.T) {
+		tree, ok := registry.Get("main_tree")
+		if !ok {
+			t.Skip("main_tree not found")
+		}
+
+		t.Run("basic properties", func(t *testing.T) {
+			require.Equal(t, "main_tree", tree.ID())
+			require.Equal(t, "Skill Tree", tree.Name())
+		})
+
+		t.Run("branches", func(t *testing.T) {
+			branches := tree.GetBranches()
+			require.GreaterOrEqual(t, len(branches), 3, "should have at least 3 branches")
+
+			branchIDs := make(map[string]bool)
+			for _, b := range branches {
+				branchIDs[b.ID] = true
+			}
+			require.True(t, branchIDs["combat"], "should have combat branch")
+			require.True(t, branchIDs["defense"], "should have defense branch")
+			require.True(t, branchIDs["magic"], "should have magic branch")
+		})
+
+		t.Run("start nodes", func(t *testing.T) {
+			startNodes := tree.GetStartNodes()
+			require.GreaterOrEqual(t, len(startNodes), 1, "should have at least one start node")
+		})
+
+		t.Run("keystones", func(t *testing.T) {
+			nodes := tree.GetNodes()
+			keystoneCount := 0
+			for _, node := range nodes {
+				if node.Type() == NodeKeystone {
+					keystoneCount++
+				}
+			}
+			require.Greater(t, keystoneCount, 0, "should have at least one keystone")
+			t.Logf("Keystones found: %d", keystoneCount)
+		})
+
+		t.Run("leveled nodes", func(t *testing.T) {
+			// Check fire_mastery
+			node, ok := tree.GetNode("fire_mastery")
+			if ok {
+				require.Equal(t, 3, node.MaxLevel())
+				require.Equal(t, 1, node.LevelCost())
+
+				// Check effects per level
+				for level := 1; level <= 3; level++ {
+					effects := node.EffectsAtLevel(level)
+					require.NotEmpty(t, effects, "should have effects at level %d", level)
+				}
+			}
+		})
+
+		t.Run("NodesInBranch matches node branch membership", func(t *testing.T) {
+			expected := make(map[string]int)
+			for _, node := range tree.GetNodes() {
+				expected[node.Branch()]++
+			}
+
+			for _, branch := range tree.GetBranches() {
+				nodes := tree.NodesInBranch(branch.ID)
+				require.Len(t, nodes, expected[branch.ID], "branch %s", branch.ID)
+				for _, node := range nodes {
+					require.Equal(t, branch.ID, node.Branch())
+				}
+			}
+
+			require.NotEmpty(t, tree.NodesInBranch("combat"))
+			require.Nil(t, tree.NodesInBranch("nonexistent"))
+		})
+
+		t.Run("FindNodesByName", func(t *testing.T) {
+			nodes := tree.FindNodesByName("glass CANNON")
+			require.Len(t, nodes, 2)
+			require.Equal(t, "glass_cannon", nodes[0].ID())
+
+			nodes = tree.FindNodesByName("iron")
+			require.Len(t, nodes, 2)
+			for _, node := range nodes {
+				require.Contains(t, node.Name(), "Iron")
+			}
+
+			require.Empty(t, tree.FindNodesByName("no such node"))
+		})
+
+		t.Run("graph is connected", func(t *testing.T) {
+			// Check that we can reach from start_combat to blood_magic
+			startNodes := tree.GetStartNodes()
+			if len(startNodes) > 0 {
+				startID := startNodes[0].ID()
+				// Check that there's a path from start
+				adjacent := tree.GetAdjacentNodes(startID)
+				require.NotEmpty(t, adjacent, "start node should have connections")
+			}
+		})
+	})
+}
+
+// =============================================================================
+// TREE CONNECTIONS
+// =============================================================================
+
+func TestTreeConnections(t *testing.T) {
+	buildTree := func() *BaseTree {
+		tree := NewBaseTree(TreeConfig{ID: "conn_tree", Name: "Connections"})
+		tree.AddNode(NewBaseNode(NodeConfig{ID: "a", Connections: []string{"b"}}))
+		tree.AddNode(NewBaseNode(NodeConfig{ID: "b", Connections: []string{"c"}}))
+		tree.AddNode(NewBaseNode(NodeConfig{ID: "c"}))
+		tree.SetStartNodes([]string{"a"})
+		return tree
+	}
+
+	t.Run("asymmetric connections reported as warnings", func(t *testing.T) {
+		tree := buildTree()
+
+		warnings, err := tree.Validate()
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"connection a -> b is not mirrored",
+			"connection b -> c is not mirrored",
+		}, warnings)
+
+		require.True(t, tree.PathExists("a", "c"))
+		require.False(t, tree.PathExists("c", "a"))
+	})
+
+	t.Run("normalization makes pathing symmetric", func(t *testing.T) {
+		tree := buildTree()
+
+		require.Equal(t, 2, tree.NormalizeConnections())
+		require.Equal(t, 0, tree.NormalizeConnections())
+
+		for _, from := range []string{"a", "b", "c"} {
+			for _, to := range []string{"a", "b", "c"} {
+				require.Equal(t, tree.PathExists(from, to), tree.PathExists(to, from), "%s <-> %s", from, to)
+			}
+		}
+		require.Len(t, tree.GetAdjacentNodes("b"), 2)
+
+		warnings, err := tree.Validate()
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	})
+
+	t.Run("dangling references are errors", func(t *testing.T) {
+		tree := buildTree()
+		tree.AddNode(NewBaseNode(NodeConfig{ID: "d", Connections: []string{"ghost"}}))
+		tree.SetStartNodes([]string{"a", "missing"})
+
+		_, err := tree.Validate()
+		require.ErrorIs(t, err, ErrInvalidTree)
+		require.Contains(t, err.Error(), "ghost")
+		require.Contains(t, err.Error(), "missing")
+	})
+
+	t.Run("loader mirrors connections", func(t *testing.T) {
+		yamlData := []byte(`
+version: "1.0"
+tree:
+  id: one_sided
+  name: "One Sided"
+  start_nodes: [root]
+  nodes:
+    - id: root
+      name: "Root"
+      type: path
+      connections: [leaf]
+    - id: leaf
+      name: "Leaf"
+      type: path
+`)
+		registry := NewBaseTreeRegistry()
+		require.NoError(t, registry.LoadFromYAML(yamlData))
+
+		tree, _ := registry.Get("one_sided")
+		leaf, _ := tree.GetNode("leaf")
+		require.Equal(t, []string{"root"}, leaf.Connections())
+		require.True(t, tree.PathExists("leaf", "root"))
+	})
+}
+
+// =============================================================================
+// NODE EFFECTS APPLY/REMOVE
+// =============================================================================
+
+func TestNodeEffectsApplyRemove(t *testing.T) {
+	ctx := context.Background()
+	entityID := "test_entity"
+
+	t.Run("attribute effect", func(t *testing.T) {
+		effect := &BaseAttributeEffect{
+			attribute:   "strength",
+			modType:     "flat",
+			value:       10,
+			description: "+10 Strength",
+		}
+
+		err := effect.Apply(ctx, entityID)
+		require.NoError(t, err) // TODO пока просто заглушка
+
+		err = effect.Remove(ctx, entityID)
+		require.NoError(t, err)
+	})
+
+	t.Run("grant_skill effect", func(t *testing.T) {
+		effect := &BaseGrantSkillEffect{
+			skillID:     "fireball",
+			startLevel:  1,
 			description: "Grants Fireball",
 		}
 
@@ -977,3 +2104,302 @@ func TestNodeEffectsApplyRemove(t *testing.T) {
 		require.Equal(t, "on_kill", meta["trigger_type"])
 	})
 }
+
+// =============================================================================
+// NODE EFFECT METADATA ACCESSORS
+// =============================================================================
+
+func TestEffectMetaAccessors(t *testing.T) {
+	effect := &BaseSpecialEffect{
+		effectType: EffectTypeSpecial,
+		metadata: map[string]any{
+			"int":          3,
+			"int64":        int64(4),
+			"whole_float":  float64(5),
+			"frac_float":   2.5,
+			"num_string":   "7",
+			"name":         "ember",
+			"yaml_slice":   []any{"fire", "spell"},
+			"mixed_slice":  []any{"fire", 1},
+			"string_slice": []string{"cold"},
+		},
+	}
+
+	t.Run("int coercion", func(t *testing.T) {
+		v, ok := EffectMetaInt(effect, "int")
+		require.True(t, ok)
+		require.Equal(t, 3, v)
+
+		v, ok = EffectMetaInt(effect, "int64")
+		require.True(t, ok)
+		require.Equal(t, 4, v)
+
+		v, ok = EffectMetaInt(effect, "whole_float")
+		require.True(t, ok)
+		require.Equal(t, 5, v)
+
+		v, ok = EffectMetaInt(effect, "num_string")
+		require.True(t, ok)
+		require.Equal(t, 7, v)
+
+		_, ok = EffectMetaInt(effect, "frac_float")
+		require.False(t, ok)
+
+		_, ok = EffectMetaInt(effect, "name")
+		require.False(t, ok)
+
+		_, ok = EffectMetaInt(effect, "missing")
+		require.False(t, ok)
+	})
+
+	t.Run("float coercion", func(t *testing.T) {
+		v, ok := EffectMetaFloat(effect, "frac_float")
+		require.True(t, ok)
+		require.Equal(t, 2.5, v)
+
+		v, ok = EffectMetaFloat(effect, "int")
+		require.True(t, ok)
+		require.Equal(t, float64(3), v)
+
+		v, ok = EffectMetaFloat(effect, "int64")
+		require.True(t, ok)
+		require.Equal(t, float64(4), v)
+
+		v, ok = EffectMetaFloat(effect, "num_string")
+		require.True(t, ok)
+		require.Equal(t, float64(7), v)
+
+		_, ok = EffectMetaFloat(effect, "name")
+		require.False(t, ok)
+	})
+
+	t.Run("string coercion", func(t *testing.T) {
+		v, ok := EffectMetaString(effect, "name")
+		require.True(t, ok)
+		require.Equal(t, "ember", v)
+
+		_, ok = EffectMetaString(effect, "int")
+		require.False(t, ok)
+	})
+
+	t.Run("string slice coercion", func(t *testing.T) {
+		v, ok := EffectMetaStringSlice(effect, "yaml_slice")
+		require.True(t, ok)
+		require.Equal(t, []string{"fire", "spell"}, v)
+
+		v, ok = EffectMetaStringSlice(effect, "string_slice")
+		require.True(t, ok)
+		require.Equal(t, []string{"cold"}, v)
+
+		_, ok = EffectMetaStringSlice(effect, "mixed_slice")
+		require.False(t, ok)
+
+		_, ok = EffectMetaStringSlice(effect, "name")
+		require.False(t, ok)
+	})
+
+	t.Run("nil effect", func(t *testing.T) {
+		_, ok := EffectMetaInt(nil, "int")
+		require.False(t, ok)
+	})
+
+	t.Run("YAML metadata fills effect fields", func(t *testing.T) {
+		yamlData := []byte(`
+version: "1.0"
+tree:
+  id: meta_tree
+  name: "Meta Tree"
+  nodes:
+    - id: start
+      name: "Start"
+      type: path
+      effects:
+        - type: grant_skill
+          metadata:
+            skill_id: frostbolt
+            start_level: 2
+        - type: skill_mod
+          metadata:
+            target_skill_tags: [cold, spell]
+`)
+		registry := NewBaseTreeRegistry()
+		require.NoError(t, registry.LoadFromYAML(yamlData))
+
+		tree, _ := registry.Get("meta_tree")
+		node, _ := tree.GetNode("start")
+		effects := node.Effects()
+		require.Len(t, effects, 2)
+
+		skillID, ok := EffectMetaString(effects[0], "skill_id")
+		require.True(t, ok)
+		require.Equal(t, "frostbolt", skillID)
+
+		level, ok := EffectMetaInt(effects[0], "start_level")
+		require.True(t, ok)
+		require.Equal(t, 2, level)
+
+		tags, ok := EffectMetaStringSlice(effects[1], "target_skill_tags")
+		require.True(t, ok)
+		require.Equal(t, []string{"cold", "spell"}, tags)
+	})
+}
+
+func TestNodeEffectSerialization(t *testing.T) {
+	registry := persist.DefaultTypeRegistry()
+
+	t.Run("mixed effects round-trip through codec", func(t *testing.T) {
+		effects := []NodeEffect{
+			&BaseAttributeEffect{attribute: attribute.AttrStrength, modType: attribute.ModIncreased, value: 0.1, description: "+10% Strength"},
+			&BaseGrantSkillEffect{skillID: "fireball", startLevel: 2, description: "Grants Fireball"},
+			&BasePassiveEffect{triggerType: TriggerOnHit, triggerValue: 0.25, effectID: "ignite", metadata: map[string]any{"element": "fire"}},
+			&BaseSkillModEffect{targetSkillTags: []string{"spell"}, description: "Spells cost less", metadata: map[string]any{"cost": -0.5}},
+			&BaseSpecialEffect{effectType: EffectTypeTrade, description: "Better prices", metadata: map[string]any{"vendor": "all"}},
+		}
+
+		wrapped, err := persist.WrapSlice(registry, effects)
+		require.NoError(t, err)
+		data, err := persist.DefaultCodec().Encode(wrapped)
+		require.NoError(t, err)
+
+		var stored []persist.TypedValue
+		require.NoError(t, persist.DefaultCodec().Decode(data, &stored))
+		restored, err := persist.UnwrapSlice[NodeEffect](registry, stored)
+		require.NoError(t, err)
+
+		require.Equal(t, effects, restored)
+		require.Equal(t, EffectTypeTrade, restored[4].Type())
+	})
+
+	t.Run("node persists effects with type tags", func(t *testing.T) {
+		node := NewBaseNode(NodeConfig{
+			ID:       "ember",
+			Name:     "Ember",
+			Type:     NodeNotable,
+			Branch:   "fire",
+			Cost:     2,
+			MaxLevel: 3,
+			Effects: []NodeEffect{
+				&BaseAttributeEffect{attribute: attribute.AttrStrength, modType: attribute.ModFlat, value: 5},
+				&BaseGrantSkillEffect{skillID: "fireball", startLevel: 1},
+			},
+			Requirements: []string{"root"},
+		})
+		node.SetLevelEffects(2, []NodeEffect{
+			&BasePassiveEffect{triggerType: TriggerOnHit, triggerValue: 0.5, effectID: "ignite"},
+		})
+
+		data, err := node.MarshalBinary()
+		require.NoError(t, err)
+		var state NodeState
+		require.NoError(t, persist.DefaultCodec().Decode(data, &state))
+		require.Equal(t, TypeGrantSkillEffect, state.Effects[1].Type)
+		require.Equal(t, TypePassiveEffect, state.LevelEffects[2][0].Type)
+
+		restored := &BaseNode{}
+		require.NoError(t, restored.UnmarshalBinary(data))
+		require.Equal(t, node.Effects(), restored.Effects())
+		require.Equal(t, node.EffectsAtLevel(2), restored.EffectsAtLevel(2))
+		require.Equal(t, []string{"root"}, restored.Requirements())
+		require.Equal(t, NodeNotable, restored.Type())
+	})
+
+	t.Run("registered names", func(t *testing.T) {
+		name, ok := registry.TypeName(&BasePassiveEffect{})
+		require.True(t, ok)
+		require.Equal(t, TypePassiveEffect, name)
+	})
+}
+
+func BenchmarkGetActiveEffects(b *testing.B) {
+	ctx := context.Background()
+	tree := NewBaseTree(TreeConfig{ID: "bench_tree", Name: "Bench Tree"})
+	tree.AddNode(NewBaseNode(NodeConfig{ID: "start", Type: NodePath}))
+	for i := 0; i < 500; i++ {
+		tree.AddNode(NewBaseNode(NodeConfig{
+			ID:           fmt.Sprintf("node_%d", i),
+			Type:         NodePath,
+			Cost:         1,
+			Requirements: []string{"start"},
+			Effects: []NodeEffect{
+				&BaseAttributeEffect{attribute: attribute.AttrStrength, value: 1},
+				&BaseAttributeEffect{attribute: attribute.AttrVitality, value: 1},
+			},
+		}))
+	}
+	tree.SetStartNodes([]string{"start"})
+
+	state := NewBaseTreeState(TreeStateConfig{TreeID: "bench_tree", Tree: tree})
+	state.AddPoints(500)
+	_ = state.AllocateNode(ctx, "start")
+	for i := 0; i < 500; i++ {
+		_ = state.AllocateNode(ctx, fmt.Sprintf("node_%d", i))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = state.GetActiveEffects()
+	}
+}
+
+func TestTreeAnalytics(t *testing.T) {
+	tree := NewBaseTree(TreeConfig{ID: "heat_tree", Name: "Heat Tree"})
+	for _, cfg := range []NodeConfig{
+		{ID: "root", Branch: "might", Cost: 1},
+		{ID: "step", Branch: "might", Cost: 1, MaxLevel: 3, LevelCost: 1, Requirements: []string{"root"}},
+		{ID: "other", Branch: "wits", Cost: 1, Requirements: []string{"root"}},
+	} {
+		cfg.Name, cfg.Type = cfg.ID, NodePath
+		tree.AddNode(NewBaseNode(cfg))
+	}
+
+	builds := []TreeStateData{
+		{TreeID: "heat_tree", Allocated: map[string]int{"root": 1, "step": 3}},
+		{TreeID: "heat_tree", Allocated: map[string]int{"root": 1, "step": 1, "other": 1}},
+		{TreeID: "heat_tree", Allocated: map[string]int{"root": 1, "other": 1}},
+	}
+
+	t.Run("node counts and average levels", func(t *testing.T) {
+		analytics := NewTreeAnalytics(tree)
+		require.NoError(t, analytics.AddAll(builds...))
+		require.Equal(t, 3, analytics.Builds())
+
+		root, ok := analytics.NodeStats("root")
+		require.True(t, ok)
+		require.Equal(t, NodeAllocationStats{NodeID: "root", Count: 3, AverageLevel: 1, Share: 1}, root)
+
+		step, ok := analytics.NodeStats("step")
+		require.True(t, ok)
+		require.Equal(t, 2, step.Count)
+		require.InDelta(t, 2.0, step.AverageLevel, 1e-9)
+		require.InDelta(t, 2.0/3.0, step.Share, 1e-9)
+
+		heatmap := analytics.Heatmap()
+		require.Len(t, heatmap, 3)
+		require.Equal(t, "root", heatmap[0].NodeID)
+		require.Equal(t, "other", heatmap[1].NodeID)
+		require.Equal(t, "step", heatmap[2].NodeID)
+
+		_, ok = analytics.NodeStats("missing")
+		require.False(t, ok)
+	})
+
+	t.Run("branch popularity", func(t *testing.T) {
+		analytics := NewTreeAnalytics(tree)
+		require.NoError(t, analytics.AddAll(builds...))
+
+		branches := analytics.BranchPopularity()
+		require.Len(t, branches, 2)
+		require.Equal(t, BranchPopularity{BranchID: "might", Allocations: 5, Builds: 3, Share: 1}, branches[0])
+		require.Equal(t, "wits", branches[1].BranchID)
+		require.Equal(t, 2, branches[1].Allocations)
+		require.Equal(t, 2, branches[1].Builds)
+	})
+
+	t.Run("snapshot of other tree rejected", func(t *testing.T) {
+		analytics := NewTreeAnalytics(tree)
+
+		err := analytics.Add(TreeStateData{TreeID: "other_tree", Allocated: map[string]int{"root": 1}})
+		require.ErrorIs(t, err, ErrInvalidTree)
+		require.Zero(t, analytics.Builds())
+	})
+}
@@ -23,6 +23,8 @@ type BaseEffect struct {
 	metadata     map[string]any
 	tickInterval int64
 	lastTick     int64
+	stackPolicy  StackPolicy
+	modifiers    []AttributeModifier
 
 	mu sync.RWMutex
 
@@ -45,6 +47,10 @@ func NewEffect(config EffectConfig) *BaseEffect {
 		config.Metadata = make(map[string]interface{})
 	}
 
+	if config.StackPolicy == "" {
+		config.StackPolicy = StackRefresh
+	}
+
 	return &BaseEffect{
 		id:           id,
 		effectType:   config.EffectType,
@@ -57,6 +63,8 @@ func NewEffect(config EffectConfig) *BaseEffect {
 		metadata:     config.Metadata,
 		tickInterval: config.TickInterval,
 		lastTick:     0,
+		stackPolicy:  config.StackPolicy,
+		modifiers:    append([]AttributeModifier(nil), config.Modifiers...),
 		events:       make(map[EffectEventType]map[string]func(context.Context, EffectEvent) error),
 	}
 }
@@ -125,8 +133,9 @@ func (e *BaseEffect) RemoveStack() bool {
 	}
 }
 
+// IsExpired returns true once timed effect ran out (negative duration = permanent)
 func (e *BaseEffect) IsExpired() bool {
-	return e.Duration() <= 0
+	return e.Duration() == 0
 }
 
 func (e *BaseEffect) SourceID() string {
@@ -226,6 +235,18 @@ func (e *BaseEffect) CanStack(other Effect) bool {
 	return e.effectType == other.Type() && e.sourceID == other.SourceID()
 }
 
+func (e *BaseEffect) StackPolicy() StackPolicy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.stackPolicy
+}
+
+func (e *BaseEffect) Modifiers() []AttributeModifier {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]AttributeModifier(nil), e.modifiers...)
+}
+
 func (e *BaseEffect) Metadata() map[string]any {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
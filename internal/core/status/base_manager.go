@@ -3,7 +3,10 @@ package status
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
 )
 
 var _ Manager = (*BaseManager)(nil)
@@ -11,97 +14,149 @@ var _ Manager = (*BaseManager)(nil)
 type BaseManager struct {
 	effects    map[string]Effect
 	immunities map[string]bool
+	attributes attribute.Manager
+
+	// applied tracks application order for deterministic expiry
+	applied map[string]uint64
+	seq     uint64
+
+	onRemoved []RemovedCallback
 
 	mu sync.RWMutex
 }
 
+// ManagerConfig holds configuration for BaseManager
+type ManagerConfig struct {
+	// Attributes receives effect modifiers while effects are active (nil = modifiers ignored)
+	Attributes attribute.Manager
+}
+
 func NewManager() *BaseManager {
+	return NewManagerWithConfig(ManagerConfig{})
+}
+
+func NewManagerWithConfig(config ManagerConfig) *BaseManager {
 	return &BaseManager{
 		effects:    make(map[string]Effect),
 		immunities: make(map[string]bool),
+		attributes: config.Attributes,
+		applied:    make(map[string]uint64),
 	}
 }
 
 func (m *BaseManager) Apply(ctx context.Context, effect Effect) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	removed, err := m.applyLocked(ctx, effect)
+	m.mu.Unlock()
+
+	m.notifyRemoved(ctx, removed)
+	return err
+}
 
+func (m *BaseManager) applyLocked(ctx context.Context, effect Effect) ([]Effect, error) {
 	effectType := effect.Type()
 
 	// Check immunity
 	if m.immunities[effectType] {
-		return fmt.Errorf("immune to effect type: %s", effectType)
+		return nil, fmt.Errorf("immune to effect type: %s", effectType)
 	}
 
-	// Check if effect can stack with existing
+	var removed []Effect
+
 	existingEffects := m.getByTypeLocked(effectType)
-	for _, existing := range existingEffects {
-		if effect.CanStack(existing) {
-			// Stack with existing effect
-			if existing.AddStack() {
-				if err := existing.OnStack(ctx, effect.TargetID(), existing.Stacks()); err != nil {
-					return fmt.Errorf("failed to stack effect: %w", err)
+
+	if effect.StackPolicy() == StackIndependent {
+		// Each application lives on its own; evict the instance closest to expiry when full
+		var siblings []Effect
+		for _, existing := range existingEffects {
+			if effect.CanStack(existing) && existing.StackPolicy() == StackIndependent {
+				siblings = append(siblings, existing)
+			}
+		}
+
+		if len(siblings) >= effect.MaxStacks() {
+			victim := m.shortestLocked(siblings)
+			if err := m.removeLocked(ctx, victim); err != nil {
+				return nil, fmt.Errorf("failed to replace effect: %w", err)
+			}
+			removed = append(removed, victim)
+		}
+	} else {
+		// Check if effect can stack with existing
+		for _, existing := range existingEffects {
+			if effect.CanStack(existing) {
+				// Stack with existing effect
+				if existing.AddStack() {
+					if err := existing.OnStack(ctx, effect.TargetID(), existing.Stacks()); err != nil {
+						return nil, fmt.Errorf("failed to stack effect: %w", err)
+					}
 				}
 				// Refresh duration if new effect has longer duration
 				if effect.Duration() > existing.Duration() {
 					existing.SetDuration(effect.Duration())
 				}
-				return nil
-			}
-			// Max stacks reached, replace if new effect has longer duration
-			if effect.Duration() > existing.Duration() {
-				existing.SetDuration(effect.Duration())
+				return nil, nil
 			}
-			return nil
 		}
 	}
 
 	// Add new effect
 	m.effects[effect.ID()] = effect
+	m.seq++
+	m.applied[effect.ID()] = m.seq
+	m.attachModifiersLocked(effect)
 
 	// Trigger OnApply
 	if err := effect.OnApply(ctx, effect.TargetID()); err != nil {
+		m.detachModifiersLocked(effect)
 		delete(m.effects, effect.ID())
-		return fmt.Errorf("failed to apply effect: %w", err)
+		delete(m.applied, effect.ID())
+		return removed, fmt.Errorf("failed to apply effect: %w", err)
 	}
 
-	return nil
+	return removed, nil
 }
 
 func (m *BaseManager) Remove(ctx context.Context, effectID string) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	effect, exists := m.effects[effectID]
 	if !exists {
+		m.mu.Unlock()
 		return fmt.Errorf("effect not found: %s", effectID)
 	}
 
-	// Trigger OnRemove
-	if err := effect.OnRemove(ctx, effect.TargetID()); err != nil {
-		return fmt.Errorf("failed to remove effect: %w", err)
+	err := m.removeLocked(ctx, effect)
+	m.mu.Unlock()
+
+	if err != nil {
+		return err
 	}
 
-	delete(m.effects, effectID)
+	m.notifyRemoved(ctx, []Effect{effect})
 	return nil
 }
 
 func (m *BaseManager) RemoveByType(ctx context.Context, effectType string) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
-	var errors []error
+	var (
+		errors  []error
+		removed []Effect
+	)
 
-	for id, effect := range m.effects {
-		if effect.Type() == effectType {
-			if err := effect.OnRemove(ctx, effect.TargetID()); err != nil {
-				errors = append(errors, fmt.Errorf("failed to remove effect %s: %w", id, err))
-				continue
-			}
-			delete(m.effects, id)
+	for _, effect := range m.orderedLocked(m.getByTypeLocked(effectType)) {
+		if err := m.removeLocked(ctx, effect); err != nil {
+			errors = append(errors, fmt.Errorf("failed to remove effect %s: %w", effect.ID(), err))
+			continue
 		}
+		removed = append(removed, effect)
 	}
 
+	m.mu.Unlock()
+
+	m.notifyRemoved(ctx, removed)
+
 	if len(errors) > 0 {
 		return fmt.Errorf("errors removing effects: %v", errors)
 	}
@@ -111,17 +166,23 @@ func (m *BaseManager) RemoveByType(ctx context.Context, effectType string) error
 
 func (m *BaseManager) RemoveAll(ctx context.Context) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	var errors []error
 
-	for id, effect := range m.effects {
+	removed := m.orderedLocked(m.allLocked())
+	for _, effect := range removed {
 		if err := effect.OnRemove(ctx, effect.TargetID()); err != nil {
-			errors = append(errors, fmt.Errorf("failed to remove effect %s: %w", id, err))
+			errors = append(errors, fmt.Errorf("failed to remove effect %s: %w", effect.ID(), err))
 		}
+		m.detachModifiersLocked(effect)
 	}
 
 	m.effects = make(map[string]Effect)
+	m.applied = make(map[string]uint64)
+
+	m.mu.Unlock()
+
+	m.notifyRemoved(ctx, removed)
 
 	if len(errors) > 0 {
 		return fmt.Errorf("errors removing all effects: %v", errors)
@@ -169,7 +230,10 @@ func (m *BaseManager) getByTypeLocked(effectType string) []Effect {
 func (m *BaseManager) GetAll() []Effect {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return m.allLocked()
+}
 
+func (m *BaseManager) allLocked() []Effect {
 	effects := make([]Effect, 0, len(m.effects))
 	for _, effect := range m.effects {
 		effects = append(effects, effect)
@@ -177,35 +241,67 @@ func (m *BaseManager) GetAll() []Effect {
 	return effects
 }
 
+func (m *BaseManager) StackCount(effectType string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	total := 0
+	for _, effect := range m.effects {
+		if effect.Type() == effectType {
+			total += effect.Stacks()
+		}
+	}
+	return total
+}
+
+// Update ticks all effects, counts down timed durations and removes expired effects.
+// Effects expiring in the same update are removed in the order they ran out.
 func (m *BaseManager) Update(ctx context.Context, deltaMs int64) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
-	var expiredIDs []string
-	var errors []error
+	var (
+		expired   []Effect
+		errors    []error
+		remaining = make(map[string]int64)
+	)
 
 	// Process all effects
-	for id, effect := range m.effects {
+	for _, effect := range m.orderedLocked(m.allLocked()) {
 		// Update effect
 		if err := effect.OnTick(ctx, effect.TargetID(), deltaMs); err != nil {
-			errors = append(errors, fmt.Errorf("error updating effect %s: %w", id, err))
+			errors = append(errors, fmt.Errorf("error updating effect %s: %w", effect.ID(), err))
+		}
+
+		// Count down timed effects
+		if d := effect.Duration(); d > 0 {
+			remaining[effect.ID()] = d
+			effect.SetDuration(max(0, d-deltaMs))
 		}
 
 		// Check if expired
 		if effect.IsExpired() {
-			expiredIDs = append(expiredIDs, id)
+			expired = append(expired, effect)
 		}
 	}
 
+	sort.SliceStable(expired, func(i, j int) bool {
+		return remaining[expired[i].ID()] < remaining[expired[j].ID()]
+	})
+
 	// Remove expired effects
-	for _, id := range expiredIDs {
-		effect := m.effects[id]
-		if err := effect.OnRemove(ctx, effect.TargetID()); err != nil {
-			errors = append(errors, fmt.Errorf("error removing expired effect %s: %w", id, err))
+	removed := make([]Effect, 0, len(expired))
+	for _, effect := range expired {
+		if err := m.removeLocked(ctx, effect); err != nil {
+			errors = append(errors, fmt.Errorf("error removing expired effect %s: %w", effect.ID(), err))
+			continue
 		}
-		delete(m.effects, id)
+		removed = append(removed, effect)
 	}
 
+	m.mu.Unlock()
+
+	m.notifyRemoved(ctx, removed)
+
 	if len(errors) > 0 {
 		return fmt.Errorf("errors during update: %v", errors)
 	}
@@ -236,3 +332,95 @@ func (m *BaseManager) RemoveImmunity(effectType string) {
 	defer m.mu.Unlock()
 	delete(m.immunities, effectType)
 }
+
+func (m *BaseManager) OnRemoved(callback RemovedCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onRemoved = append(m.onRemoved, callback)
+}
+
+// removeLocked triggers OnRemove, detaches modifiers and forgets effect
+func (m *BaseManager) removeLocked(ctx context.Context, effect Effect) error {
+	if err := effect.OnRemove(ctx, effect.TargetID()); err != nil {
+		return fmt.Errorf("failed to remove effect: %w", err)
+	}
+
+	m.detachModifiersLocked(effect)
+	delete(m.effects, effect.ID())
+	delete(m.applied, effect.ID())
+	return nil
+}
+
+// orderedLocked sorts effects by application order
+func (m *BaseManager) orderedLocked(effects []Effect) []Effect {
+	sort.SliceStable(effects, func(i, j int) bool {
+		return m.applied[effects[i].ID()] < m.applied[effects[j].ID()]
+	})
+	return effects
+}
+
+// shortestLocked returns effect with least remaining duration (permanent effects last)
+func (m *BaseManager) shortestLocked(effects []Effect) Effect {
+	effects = m.orderedLocked(effects)
+
+	shortest := effects[0]
+	for _, effect := range effects[1:] {
+		d, best := effect.Duration(), shortest.Duration()
+		if best < 0 || (d >= 0 && d < best) {
+			shortest = effect
+		}
+	}
+	return shortest
+}
+
+func (m *BaseManager) attachModifiersLocked(effect Effect) {
+	if m.attributes == nil {
+		return
+	}
+	for _, am := range effect.Modifiers() {
+		m.attributes.AddModifier(am.Attribute, effectModifier{
+			Modifier: am.Modifier,
+			id:       effectModifierID(effect, am),
+		})
+	}
+}
+
+func (m *BaseManager) detachModifiersLocked(effect Effect) {
+	if m.attributes == nil {
+		return
+	}
+	for _, am := range effect.Modifiers() {
+		m.attributes.RemoveModifier(am.Attribute, effectModifierID(effect, am))
+	}
+}
+
+func (m *BaseManager) notifyRemoved(ctx context.Context, removed []Effect) {
+	if len(removed) == 0 {
+		return
+	}
+
+	m.mu.RLock()
+	callbacks := append([]RemovedCallback{}, m.onRemoved...)
+	m.mu.RUnlock()
+
+	for _, effect := range removed {
+		for _, cb := range callbacks {
+			cb(ctx, effect)
+		}
+	}
+}
+
+// effectModifier scopes modifier ID to effect instance so independent
+// stacks sharing one definition do not overwrite each other
+type effectModifier struct {
+	attribute.Modifier
+	id string
+}
+
+func (m effectModifier) ID() string {
+	return m.id
+}
+
+func effectModifierID(effect Effect, am AttributeModifier) string {
+	return effect.ID() + ":" + am.Modifier.ID()
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
 )
 
 var _ Builder = (*EffectBuilder)(nil)
@@ -25,6 +27,8 @@ type EffectConfig struct {
 	TargetID      string
 	Metadata      map[string]any
 	TickInterval  int64
+	StackPolicy   StackPolicy
+	Modifiers     []AttributeModifier
 }
 
 func NewBuilder() *EffectBuilder {
@@ -81,6 +85,19 @@ func (b *EffectBuilder) WithTickInterval(ms int64) Builder {
 	return b
 }
 
+func (b *EffectBuilder) WithStackPolicy(policy StackPolicy) Builder {
+	b.config.StackPolicy = policy
+	return b
+}
+
+func (b *EffectBuilder) WithModifier(attr attribute.Type, modifier attribute.Modifier) Builder {
+	b.config.Modifiers = append(b.config.Modifiers, AttributeModifier{
+		Attribute: attr,
+		Modifier:  modifier,
+	})
+	return b
+}
+
 func (b *EffectBuilder) WithOnEvent(eventType EffectEventType,
 	fn func(ctx context.Context, ev EffectEvent) error,
 ) Builder {
@@ -134,6 +151,9 @@ func (b *EffectBuilder) Build() (Effect, error) {
 	if b.config.InitialStacks < 1 || b.config.InitialStacks > b.config.MaxStacks {
 		return nil, fmt.Errorf("initial stacks must be between 1 and max stacks")
 	}
+	if b.config.StackPolicy != "" && b.config.StackPolicy != StackRefresh && b.config.StackPolicy != StackIndependent {
+		return nil, fmt.Errorf("unknown stack policy: %s", b.config.StackPolicy)
+	}
 
 	// Create effect
 	eff := NewEffect(b.config)
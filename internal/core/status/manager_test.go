@@ -0,0 +1,151 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+)
+
+func buildEffect(t *testing.T, effectType string, durationMs int64, maxStacks int, policy StackPolicy) Effect {
+	t.Helper()
+
+	eff, err := NewBuilder().
+		WithType(effectType).
+		WithTarget("target").
+		WithSource("source").
+		WithDuration(durationMs).
+		WithStacks(1, maxStacks).
+		WithStackPolicy(policy).
+		Build()
+	require.NoError(t, err)
+	return eff
+}
+
+func TestBaseManager(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("refresh on reapply", func(t *testing.T) {
+		m := NewManager()
+		first := buildEffect(t, "burn", 1000, 3, StackRefresh)
+		require.NoError(t, m.Apply(ctx, first))
+
+		require.NoError(t, m.Update(ctx, 600))
+		assert.Equal(t, int64(400), first.Duration())
+
+		require.NoError(t, m.Apply(ctx, buildEffect(t, "burn", 1000, 3, StackRefresh)))
+
+		assert.Equal(t, 1, m.Count())
+		assert.Equal(t, 2, m.StackCount("burn"))
+		assert.Equal(t, int64(1000), first.Duration())
+	})
+
+	t.Run("refresh at max stacks still refreshes duration", func(t *testing.T) {
+		m := NewManager()
+		first := buildEffect(t, "burn", 1000, 1, StackRefresh)
+		require.NoError(t, m.Apply(ctx, first))
+		require.NoError(t, m.Update(ctx, 500))
+
+		require.NoError(t, m.Apply(ctx, buildEffect(t, "burn", 1000, 1, StackRefresh)))
+
+		assert.Equal(t, 1, m.StackCount("burn"))
+		assert.Equal(t, int64(1000), first.Duration())
+	})
+
+	t.Run("independent stacks keep own durations", func(t *testing.T) {
+		m := NewManager()
+		require.NoError(t, m.Apply(ctx, buildEffect(t, "bleed", 1000, 3, StackIndependent)))
+		require.NoError(t, m.Update(ctx, 500))
+		require.NoError(t, m.Apply(ctx, buildEffect(t, "bleed", 1000, 3, StackIndependent)))
+
+		assert.Equal(t, 2, m.Count())
+		assert.Equal(t, 2, m.StackCount("bleed"))
+
+		require.NoError(t, m.Update(ctx, 500))
+		assert.Equal(t, 1, m.StackCount("bleed"))
+		assert.True(t, m.Has("bleed"))
+
+		require.NoError(t, m.Update(ctx, 500))
+		assert.False(t, m.Has("bleed"))
+	})
+
+	t.Run("independent stacks replace shortest when full", func(t *testing.T) {
+		m := NewManager()
+		short := buildEffect(t, "bleed", 300, 2, StackIndependent)
+		long := buildEffect(t, "bleed", 900, 2, StackIndependent)
+		require.NoError(t, m.Apply(ctx, long))
+		require.NoError(t, m.Apply(ctx, short))
+
+		var removed []string
+		m.OnRemoved(func(ctx context.Context, e Effect) {
+			removed = append(removed, e.ID())
+		})
+
+		require.NoError(t, m.Apply(ctx, buildEffect(t, "bleed", 500, 2, StackIndependent)))
+
+		assert.Equal(t, 2, m.StackCount("bleed"))
+		assert.Equal(t, []string{short.ID()}, removed)
+		_, ok := m.Get(long.ID())
+		assert.True(t, ok)
+	})
+
+	t.Run("expiry ordering", func(t *testing.T) {
+		m := NewManager()
+		late := buildEffect(t, "slow", 800, 1, StackRefresh)
+		early := buildEffect(t, "weaken", 200, 1, StackRefresh)
+		mid := buildEffect(t, "blind", 500, 1, StackRefresh)
+		permanent := buildEffect(t, "aura", -1, 1, StackRefresh)
+
+		for _, e := range []Effect{late, early, mid, permanent} {
+			require.NoError(t, m.Apply(ctx, e))
+		}
+
+		var removed []string
+		m.OnRemoved(func(ctx context.Context, e Effect) {
+			removed = append(removed, e.Type())
+		})
+
+		require.NoError(t, m.Update(ctx, 1000))
+
+		assert.Equal(t, []string{"weaken", "blind", "slow"}, removed)
+		assert.True(t, m.Has("aura"))
+		assert.Equal(t, 1, m.Count())
+	})
+
+	t.Run("modifiers applied while active", func(t *testing.T) {
+		attrs := attribute.NewManager()
+		attrs.SetBase(attribute.AttrArmor, 100)
+		m := NewManagerWithConfig(ManagerConfig{Attributes: attrs})
+
+		build := func() Effect {
+			eff, err := NewBuilder().
+				WithType("sunder").
+				WithTarget("target").
+				WithDuration(1000).
+				WithStacks(1, 2).
+				WithStackPolicy(StackIndependent).
+				WithModifier(attribute.AttrArmor, attribute.NewModifier("sunder", attribute.ModFlat, -20, "sunder")).
+				Build()
+			require.NoError(t, err)
+			return eff
+		}
+
+		require.NoError(t, m.Apply(ctx, build()))
+		require.NoError(t, m.Apply(ctx, build()))
+		assert.Equal(t, 60.0, attrs.Get(attribute.AttrArmor))
+
+		require.NoError(t, m.Update(ctx, 1000))
+		assert.Equal(t, 100.0, attrs.Get(attribute.AttrArmor))
+	})
+
+	t.Run("immune effect is rejected", func(t *testing.T) {
+		m := NewManager()
+		m.AddImmunity("stun")
+
+		assert.Error(t, m.Apply(ctx, buildEffect(t, "stun", 1000, 1, StackRefresh)))
+		assert.Equal(t, 0, m.StackCount("stun"))
+	})
+}
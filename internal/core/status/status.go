@@ -3,6 +3,7 @@ package status
 import (
 	"context"
 
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
 	"github.com/davidmovas/Depthborn/internal/core/types"
 )
 
@@ -59,6 +60,12 @@ type Effect interface {
 	// CanStack checks if can stack with another
 	CanStack(other Effect) bool
 
+	// StackPolicy returns how reapplication is handled
+	StackPolicy() StackPolicy
+
+	// Modifiers returns attribute modifiers applied while effect is active
+	Modifiers() []AttributeModifier
+
 	// Metadata returns effect-specific data
 	Metadata() map[string]any
 
@@ -91,6 +98,9 @@ type Manager interface {
 	// GetAll returns all active effects
 	GetAll() []Effect
 
+	// StackCount returns total stacks of effect type across all instances
+	StackCount(effectType string) int
+
 	// Update processes all effects
 	Update(ctx context.Context, deltaMs int64) error
 
@@ -105,8 +115,14 @@ type Manager interface {
 
 	// RemoveImmunity removes immunity
 	RemoveImmunity(effectType string)
+
+	// OnRemoved registers callback invoked after effect is removed or expires
+	OnRemoved(callback RemovedCallback)
 }
 
+// RemovedCallback is invoked after effect leaves manager
+type RemovedCallback func(ctx context.Context, effect Effect)
+
 // Builder creates status effects
 type Builder interface {
 	// WithType sets effect type
@@ -133,6 +149,12 @@ type Builder interface {
 	// WithTickInterval sets tick rate
 	WithTickInterval(ms int64) Builder
 
+	// WithStackPolicy sets reapplication behavior
+	WithStackPolicy(policy StackPolicy) Builder
+
+	// WithModifier adds attribute modifier applied while effect is active
+	WithModifier(attr attribute.Type, modifier attribute.Modifier) Builder
+
 	// WithOnApply sets callback for OnApply event
 	WithOnApply(fn func(ctx context.Context, targetID string) error) Builder
 
@@ -166,6 +188,24 @@ type EffectEvent struct {
 	Type     EffectEventType
 }
 
+// StackPolicy determines how reapplying effect interacts with existing instances
+type StackPolicy string
+
+const (
+	// StackRefresh adds stack to existing instance and refreshes its duration
+	StackRefresh StackPolicy = "refresh"
+
+	// StackIndependent tracks each application as separate instance with own duration.
+	// MaxStacks limits instance count; oldest-expiring instance is replaced when full.
+	StackIndependent StackPolicy = "independent"
+)
+
+// AttributeModifier binds modifier to attribute it affects
+type AttributeModifier struct {
+	Attribute attribute.Type
+	Modifier  attribute.Modifier
+}
+
 // Category groups effect types
 type Category string
 
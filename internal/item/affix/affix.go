@@ -1,6 +1,10 @@
 package affix
 
-import "github.com/davidmovas/Depthborn/internal/core/attribute"
+import (
+	"math/rand/v2"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+)
 
 // Type categorizes affixes
 type Type string
@@ -34,6 +38,10 @@ type Affix interface {
 	// Hidden from player.
 	Rank() int
 
+	// Tier returns player-facing tier (1 = best, 0 = untiered).
+	// Shown in item display and used for tier-band filtering.
+	Tier() int
+
 	// Modifiers returns modifier templates for this affix.
 	// Each template has min/max values that are rolled during generation.
 	Modifiers() []ModifierTemplate
@@ -54,6 +62,9 @@ type Affix interface {
 
 	// HasTag checks if affix has specific tag
 	HasTag(tag string) bool
+
+	// RequiredInfluence returns influence needed to roll this affix ("" = none)
+	RequiredInfluence() string
 }
 
 // ModifierTemplate defines a range for modifier values
@@ -70,7 +81,7 @@ type ModifierTemplate struct {
 	// MaxValue is maximum possible value
 	MaxValue float64
 
-	// Priority for application order
+	// Priority for application order (higher is applied later)
 	Priority int
 }
 
@@ -147,6 +158,12 @@ type Set interface {
 	// GetAll returns all affix instances
 	GetAll() []Instance
 
+	// Ordered returns all affixes in display order: prefixes, then suffixes
+	Ordered() []Instance
+
+	// GetByTypeOrdered returns instances of specified type in add order
+	GetByTypeOrdered(affixType Type) []Instance
+
 	// Count returns total number of affixes
 	Count() int
 
@@ -192,9 +209,18 @@ type Pool interface {
 	// Add adds affix to pool
 	Add(affix Affix)
 
+	// AddAll adds affixes to pool, replacing ones with same ID
+	AddAll(affixes []Affix)
+
 	// Remove removes affix from pool
 	Remove(affixID string)
 
+	// Clear removes all affixes so pool can be reused
+	Clear()
+
+	// Size returns number of affixes in pool
+	Size() int
+
 	// Get retrieves affix by ID
 	Get(affixID string) (Affix, bool)
 
@@ -215,6 +241,9 @@ type Pool interface {
 
 	// Roll randomly selects affix from pool based on weights
 	Roll(ctx RollContext) (Affix, error)
+
+	// RollProbabilities returns per-affix chance of being rolled with ctx
+	RollProbabilities(ctx RollContext) map[string]float64
 }
 
 // RollContext provides context for affix generation
@@ -238,6 +267,17 @@ type RollContext struct {
 
 	// AffixType - filter by type (prefix/suffix/etc)
 	AffixType *Type
+
+	// Influences - item influences; unlock affixes gated behind them
+	Influences []string
+
+	// MinTier/MaxTier - only roll affixes within tier band (0 = unbounded).
+	// Untiered affixes are skipped once either bound is set.
+	MinTier int
+	MaxTier int
+
+	// Rng - random source for rolls; nil uses global source
+	Rng *rand.Rand
 }
 
 // FilterCriteria defines filtering for affix selection
@@ -247,8 +287,11 @@ type FilterCriteria struct {
 	Tags         []string
 	MinRank      int
 	MaxRank      int
+	MinTier      int
+	MaxTier      int
 	MinItemLevel int
 	MaxItemLevel int
+	Influences   []string
 }
 
 // Generator creates affix instances for items
@@ -279,6 +322,9 @@ type GenerateContext struct {
 	// QualityBias affects value distribution [0.0 - 1.0]
 	// 0.0 = bias toward minimum, 0.5 = uniform, 1.0 = bias toward maximum
 	QualityBias float64
+
+	// RequireComplete fails generation if minimum counts can't be reached
+	RequireComplete bool
 }
 
 // Registry manages all available affixes loaded from data files
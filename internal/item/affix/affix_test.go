@@ -1,6 +1,8 @@
 package affix
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +26,11 @@ func createTestAffix(id string, affixType Type, rank int) *BaseAffix {
 		})
 }
 
+// plainInstance exposes only Instance interface of wrapped instance
+type plainInstance struct {
+	Instance
+}
+
 func createTestAffixWithGroup(id string, affixType Type, group string) *BaseAffix {
 	return NewBaseAffix(id, "Test "+id, affixType).
 		WithGroup(group).
@@ -86,6 +93,7 @@ func TestBaseAffix(t *testing.T) {
 			affix := NewBaseAffix("chain-test", "Chain", TypePrefix).
 				WithGroup("test-group").
 				WithRank(80).
+				WithTier(2).
 				WithBaseWeight(150).
 				WithDescription("Description").
 				WithTags([]string{"tag1", "tag2"}).
@@ -94,6 +102,7 @@ func TestBaseAffix(t *testing.T) {
 
 			assert.Equal(t, "test-group", affix.Group())
 			assert.Equal(t, 80, affix.Rank())
+			assert.Equal(t, 2, affix.Tier())
 			assert.Equal(t, 150, affix.BaseWeight())
 			assert.Equal(t, "Description", affix.Description())
 			assert.Equal(t, []string{"tag1", "tag2", "tag3"}, affix.Tags())
@@ -166,6 +175,98 @@ func TestBaseInstance(t *testing.T) {
 		})
 	})
 
+	t.Run("ValidateRolls", func(t *testing.T) {
+		strength := ModifierTemplate{Attribute: attribute.AttrStrength, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10}
+		life := ModifierTemplate{Attribute: attribute.AttrVitality, ModType: attribute.ModFlat, MinValue: 20, MaxValue: 40}
+
+		registry := NewBaseRegistry()
+		require.NoError(t, registry.Register(NewBaseAffix("hacked", "Hacked", TypePrefix).AddModifier(strength).AddModifier(life)))
+
+		t.Run("out-of-range value is clamped", func(t *testing.T) {
+			instance, invalid, err := NewValidatedInstanceFromData(registry, "hacked", []RolledModifier{
+				{Template: strength, Value: 9999},
+				{Template: life, Value: 30},
+			}, RollClamp)
+			require.NoError(t, err)
+
+			require.Len(t, invalid, 1)
+			assert.Equal(t, 0, invalid[0].Index)
+			assert.Equal(t, attribute.AttrStrength, invalid[0].Attribute)
+			assert.Equal(t, 9999.0, invalid[0].Value)
+			assert.Equal(t, 10.0, instance.RolledValues()[0].Value)
+			assert.Equal(t, 30.0, instance.RolledValues()[1].Value)
+		})
+
+		t.Run("out-of-range value is rejected", func(t *testing.T) {
+			values := []RolledModifier{
+				{Template: strength, Value: 5},
+				{Template: life, Value: -3},
+			}
+			instance, invalid, err := NewValidatedInstanceFromData(registry, "hacked", values, RollReject)
+			require.ErrorIs(t, err, ErrInvalidRoll)
+			assert.Nil(t, instance)
+			require.Len(t, invalid, 1)
+			assert.Equal(t, 1, invalid[0].Index)
+			assert.Equal(t, 20.0, invalid[0].Min)
+			assert.Equal(t, 40.0, invalid[0].Max)
+		})
+
+		t.Run("saved templates are not trusted", func(t *testing.T) {
+			widened, retyped := strength, life
+			widened.MaxValue = 1e6
+			retyped.Attribute = attribute.AttrCritChance
+
+			instance, invalid, err := NewValidatedInstanceFromData(registry, "hacked", []RolledModifier{
+				{Template: widened, Value: 1e6},
+				{Template: retyped, Value: 30},
+			}, RollClamp)
+			require.NoError(t, err)
+
+			assert.Len(t, invalid, 2)
+			assert.Equal(t, 10.0, instance.RolledValues()[0].Value)
+			assert.Equal(t, strength, instance.RolledValues()[0].Template)
+			assert.Equal(t, attribute.AttrVitality, instance.RolledValues()[1].Template.Attribute)
+			assert.Equal(t, TypePrefix, instance.Type())
+		})
+
+		t.Run("unknown affix or modifier count is rejected", func(t *testing.T) {
+			_, _, err := NewValidatedInstanceFromData(registry, "forged", []RolledModifier{{Template: strength, Value: 5}}, RollClamp)
+			require.ErrorIs(t, err, ErrUnknownAffix)
+
+			_, _, err = NewValidatedInstanceFromData(registry, "hacked", []RolledModifier{{Template: strength, Value: 5}}, RollClamp)
+			require.ErrorIs(t, err, ErrInvalidRoll)
+		})
+
+		t.Run("valid rolls pass untouched", func(t *testing.T) {
+			instance := NewBaseInstanceFromData("ok", TypePrefix, "", []RolledModifier{{Template: strength, Value: 1}})
+			invalid, err := instance.ValidateRolls(RollReject)
+			require.NoError(t, err)
+			assert.Empty(t, invalid)
+		})
+
+		t.Run("linked affix templates override stored ranges", func(t *testing.T) {
+			affix := createTestAffix("linked", TypePrefix, 50)
+			tmpl := affix.Modifiers()[0]
+			edited := tmpl
+			edited.MaxValue = 1e6
+
+			instance := NewBaseInstanceFromData("linked", TypePrefix, "", []RolledModifier{{Template: edited, Value: 1e6}})
+			instance.SetAffix(affix)
+			invalid, err := instance.ValidateRolls(RollClamp)
+			require.NoError(t, err)
+			require.Len(t, invalid, 1)
+			assert.Equal(t, tmpl.MaxValue, instance.RolledValues()[0].Value)
+		})
+
+		t.Run("NaN clamps to minimum", func(t *testing.T) {
+			instance := NewBaseInstanceFromData("nan", TypePrefix, "", []RolledModifier{{Template: strength, Value: math.NaN()}})
+			invalid, err := instance.ValidateRolls(RollClamp)
+			require.NoError(t, err)
+			assert.Len(t, invalid, 1)
+			assert.Equal(t, 1.0, instance.RolledValues()[0].Value)
+		})
+	})
+
 	t.Run("Reroll", func(t *testing.T) {
 		t.Run("changes values within range", func(t *testing.T) {
 			affix := NewBaseAffix("reroll-test", "Reroll", TypePrefix).
@@ -215,6 +316,36 @@ func TestBaseInstance(t *testing.T) {
 			err := instance.RerollSingle(5)
 			assert.Error(t, err)
 		})
+
+		t.Run("RerollWithFloor never rolls below floor", func(t *testing.T) {
+			affix := NewBaseAffix("floor-test", "Floor", TypePrefix).
+				AddModifier(ModifierTemplate{Attribute: attribute.AttrStrength, ModType: attribute.ModFlat, MinValue: 10, MaxValue: 20}).
+				AddModifier(ModifierTemplate{Attribute: attribute.AttrDexterity, ModType: attribute.ModFlat, MinValue: 100, MaxValue: 300})
+
+			instance := NewBaseInstance(affix, RollModifiers(affix.Modifiers()))
+
+			const floor = 0.7
+			for i := 0; i < 50; i++ {
+				require.NoError(t, instance.RerollWithFloor(floor))
+				for _, rm := range instance.RolledValues() {
+					minValue := rm.Template.MinValue + (rm.Template.MaxValue-rm.Template.MinValue)*floor
+					assert.GreaterOrEqual(t, rm.Value, minValue)
+					assert.LessOrEqual(t, rm.Value, rm.Template.MaxValue)
+				}
+			}
+		})
+
+		t.Run("RerollWithFloor unreachable floor returns error and keeps values", func(t *testing.T) {
+			affix := createTestAffix("floor-max", TypePrefix, 50)
+			values := []RolledModifier{{Template: affix.Modifiers()[0], Value: 12.0}}
+			instance := NewBaseInstance(affix, values)
+
+			err := instance.RerollWithFloor(1.0)
+			assert.Error(t, err)
+			assert.Equal(t, 12.0, instance.RolledValues()[0].Value)
+
+			assert.Error(t, instance.RerollWithFloor(1.5))
+		})
 	})
 
 	t.Run("Quality", func(t *testing.T) {
@@ -317,6 +448,65 @@ func TestBaseSet(t *testing.T) {
 		})
 	})
 
+	t.Run("Display Order", func(t *testing.T) {
+		newMixedSet := func() *BaseSet {
+			set := NewBaseSet()
+			for _, spec := range []struct {
+				id        string
+				affixType Type
+			}{
+				{"suffix-b", TypeSuffix},
+				{"prefix-b", TypePrefix},
+				{"implicit-a", TypeImplicit},
+				{"suffix-a", TypeSuffix},
+				{"prefix-a", TypePrefix},
+			} {
+				affix := createTestAffix(spec.id, spec.affixType, 50)
+				require.NoError(t, set.Add(NewBaseInstance(affix, RollModifiers(affix.Modifiers()))))
+			}
+			return set
+		}
+		ids := func(instances []Instance) []string {
+			result := make([]string, len(instances))
+			for i, instance := range instances {
+				result[i] = instance.AffixID()
+			}
+			return result
+		}
+
+		t.Run("Ordered lists prefixes then suffixes in add order", func(t *testing.T) {
+			set := newMixedSet()
+			expected := []string{"prefix-b", "prefix-a", "suffix-b", "suffix-a", "implicit-a"}
+
+			for range 20 {
+				assert.Equal(t, expected, ids(set.Ordered()))
+			}
+		})
+
+		t.Run("GetByTypeOrdered keeps add order", func(t *testing.T) {
+			set := newMixedSet()
+
+			for range 20 {
+				assert.Equal(t, []string{"suffix-b", "suffix-a"}, ids(set.GetByTypeOrdered(TypeSuffix)))
+			}
+			assert.Empty(t, set.GetByTypeOrdered(TypeEnchant))
+		})
+
+		t.Run("Remove and Clear update order", func(t *testing.T) {
+			set := newMixedSet()
+
+			require.NoError(t, set.Remove("prefix-b"))
+			assert.Equal(t, []string{"prefix-a", "suffix-b", "suffix-a", "implicit-a"}, ids(set.Ordered()))
+
+			affix := createTestAffix("prefix-b", TypePrefix, 50)
+			require.NoError(t, set.Add(NewBaseInstance(affix, RollModifiers(affix.Modifiers()))))
+			assert.Equal(t, []string{"prefix-a", "prefix-b"}, ids(set.GetByTypeOrdered(TypePrefix)))
+
+			set.Clear()
+			assert.Empty(t, set.Ordered())
+		})
+	})
+
 	t.Run("Group Mutual Exclusion", func(t *testing.T) {
 		t.Run("cannot add two affixes from same group", func(t *testing.T) {
 			set := NewBaseSet()
@@ -416,6 +606,33 @@ func TestBaseSet(t *testing.T) {
 			mods := set.AllModifiers()
 			assert.Len(t, mods, 2)
 		})
+
+		t.Run("AllModifiers orders by priority with higher applied later", func(t *testing.T) {
+			set := NewBaseSet()
+
+			prefix := NewBaseAffix("b_prefix", "Prefix", TypePrefix).
+				AddModifier(ModifierTemplate{Attribute: attribute.AttrStrength, ModType: attribute.ModOverride, MinValue: 1, MaxValue: 1, Priority: 10}).
+				AddModifier(ModifierTemplate{Attribute: attribute.AttrStrength, ModType: attribute.ModFlat, MinValue: 2, MaxValue: 2})
+			suffix := NewBaseAffix("a_suffix", "Suffix", TypeSuffix).
+				AddModifier(ModifierTemplate{Attribute: attribute.AttrStrength, ModType: attribute.ModIncreased, MinValue: 3, MaxValue: 3, Priority: 5}).
+				AddModifier(ModifierTemplate{Attribute: attribute.AttrStrength, ModType: attribute.ModFlat, MinValue: 4, MaxValue: 4})
+
+			require.NoError(t, set.Add(NewBaseInstance(prefix, RollModifiers(prefix.Modifiers()))))
+			require.NoError(t, set.Add(NewBaseInstance(suffix, RollModifiers(suffix.Modifiers()))))
+
+			for i := 0; i < 10; i++ {
+				mods := set.AllModifiers()
+				require.Len(t, mods, 4)
+
+				values := make([]float64, len(mods))
+				for j, mod := range mods {
+					values[j] = mod.Value()
+				}
+				// Equal priority keeps affix ID order: a_suffix before b_prefix
+				assert.Equal(t, []float64{4, 2, 3, 1}, values)
+				assert.Equal(t, 10, mods[3].Priority())
+			}
+		})
 	})
 
 	t.Run("Quality and Reroll", func(t *testing.T) {
@@ -432,6 +649,46 @@ func TestBaseSet(t *testing.T) {
 			assert.Equal(t, 0.5, set.TotalQuality())
 		})
 
+		t.Run("RerollAllWithFloor respects floor on every affix", func(t *testing.T) {
+			set := NewBaseSet()
+
+			prefix := createTestAffix("floor-prefix", TypePrefix, 50)
+			suffix := createTestAffix("floor-suffix", TypeSuffix, 50)
+			_ = set.Add(NewBaseInstance(prefix, RollModifiers(prefix.Modifiers())))
+			_ = set.Add(NewBaseInstance(suffix, RollModifiers(suffix.Modifiers())))
+
+			const floor = 0.6
+			for i := 0; i < 20; i++ {
+				require.NoError(t, set.RerollAllWithFloor(floor))
+				for _, instance := range set.GetAll() {
+					for _, rm := range instance.RolledValues() {
+						minValue := rm.Template.MinValue + (rm.Template.MaxValue-rm.Template.MinValue)*floor
+						assert.GreaterOrEqual(t, rm.Value, minValue)
+					}
+				}
+			}
+
+			assert.Error(t, set.RerollAllWithFloor(1.0))
+		})
+
+		t.Run("RerollAllWithFloor leaves set untouched on failure", func(t *testing.T) {
+			set := NewBaseSet()
+
+			prefix := createTestAffix("atomic-prefix", TypePrefix, 50)
+			_ = set.Add(NewBaseInstance(prefix, []RolledModifier{{Template: prefix.Modifiers()[0], Value: 10}}))
+
+			// Wrapped instance hides floored reroll support
+			suffix := createTestAffix("atomic-suffix", TypeSuffix, 50)
+			_ = set.Add(plainInstance{NewBaseInstance(suffix, RollModifiers(suffix.Modifiers()))})
+
+			for i := 0; i < 20; i++ {
+				assert.Error(t, set.RerollAllWithFloor(0.5))
+				instance, ok := set.Get("atomic-prefix")
+				require.True(t, ok)
+				assert.Equal(t, 10.0, instance.RolledValues()[0].Value)
+			}
+		})
+
 		t.Run("RerollAll changes all values", func(t *testing.T) {
 			set := NewBaseSet()
 
@@ -516,6 +773,37 @@ func TestBasePool(t *testing.T) {
 			assert.False(t, exists)
 		})
 
+		t.Run("AddAll ignores duplicate IDs", func(t *testing.T) {
+			pool := NewBasePool()
+			first := createTestAffix("bulk-1", TypePrefix, 50)
+			second := createTestAffix("bulk-2", TypeSuffix, 50)
+
+			pool.AddAll([]Affix{first, second, first})
+			pool.AddAll([]Affix{second})
+
+			assert.Equal(t, 2, pool.Size())
+			_, exists := pool.Get("bulk-1")
+			assert.True(t, exists)
+		})
+
+		t.Run("Clear resets size to zero", func(t *testing.T) {
+			pool := NewBasePool()
+			pool.AddAll([]Affix{
+				createTestAffix("clear-1", TypePrefix, 50),
+				createTestAffix("clear-2", TypeSuffix, 50),
+			})
+			require.Equal(t, 2, pool.Size())
+
+			pool.Clear()
+
+			assert.Equal(t, 0, pool.Size())
+			assert.Empty(t, pool.GetAll())
+
+			// Pool is reusable after clear
+			pool.Add(createTestAffix("clear-3", TypePrefix, 50))
+			assert.Equal(t, 1, pool.Size())
+		})
+
 		t.Run("GetAll returns all affixes", func(t *testing.T) {
 			pool := NewBasePool()
 
@@ -582,6 +870,20 @@ func TestBasePool(t *testing.T) {
 			assert.Len(t, filtered, 1)
 			assert.Equal(t, "f2", filtered[0].ID())
 		})
+
+		t.Run("Filter by tier band", func(t *testing.T) {
+			pool := NewBasePool()
+
+			for tier := 1; tier <= 5; tier++ {
+				pool.Add(NewBaseAffix(fmt.Sprintf("t%d", tier), "Tiered", TypePrefix).WithTier(tier))
+			}
+			pool.Add(NewBaseAffix("untiered", "Untiered", TypePrefix))
+
+			assert.Len(t, pool.Filter(FilterCriteria{}), 6)
+			assert.Len(t, pool.Filter(FilterCriteria{MinTier: 2, MaxTier: 3}), 2)
+			assert.Len(t, pool.Filter(FilterCriteria{MaxTier: 3}), 3)
+			assert.Len(t, pool.Filter(FilterCriteria{MinTier: 4}), 2)
+		})
 	})
 
 	t.Run("Roll", func(t *testing.T) {
@@ -646,6 +948,80 @@ func TestBasePool(t *testing.T) {
 				assert.Equal(t, "low-level", affix.ID())
 			}
 		})
+
+		t.Run("respects influence gate", func(t *testing.T) {
+			pool := NewBasePool()
+
+			pool.Add(createTestAffix("plain", TypePrefix, 50))
+			pool.Add(createTestAffix("shaper", TypePrefix, 50).WithRequiredInfluence("shaper"))
+
+			// Without influence only the plain affix is eligible
+			for i := 0; i < 10; i++ {
+				affix, err := pool.Roll(RollContext{})
+				require.NoError(t, err)
+				assert.Equal(t, "plain", affix.ID())
+			}
+
+			// With influence the gated affix becomes eligible
+			ctx := RollContext{Influences: []string{"shaper"}, ExcludeIDs: []string{"plain"}}
+			affix, err := pool.Roll(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "shaper", affix.ID())
+
+			_, err = pool.Roll(RollContext{Influences: []string{"elder"}, ExcludeIDs: []string{"plain"}})
+			assert.Error(t, err)
+
+			assert.Len(t, pool.Filter(FilterCriteria{}), 1)
+			assert.Len(t, pool.Filter(FilterCriteria{Influences: []string{"shaper"}}), 2)
+		})
+
+		t.Run("respects tier band", func(t *testing.T) {
+			pool := NewBasePool()
+
+			for tier := 1; tier <= 6; tier++ {
+				pool.Add(createTestAffix(fmt.Sprintf("tier-%d", tier), TypePrefix, 50).WithTier(tier))
+			}
+			pool.Add(createTestAffix("untiered", TypePrefix, 50))
+
+			ctx := RollContext{MinTier: 1, MaxTier: 3}
+			for i := 0; i < 50; i++ {
+				affix, err := pool.Roll(ctx)
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, affix.Tier(), 1)
+				assert.LessOrEqual(t, affix.Tier(), 3)
+			}
+
+			_, err := pool.Roll(RollContext{MinTier: 7})
+			assert.Error(t, err)
+		})
+
+		t.Run("RollProbabilities", func(t *testing.T) {
+			pool := NewBasePool()
+			// Rank 1 keeps effective weight equal to base weight
+			pool.Add(createTestAffixWithGroup("life-a", TypePrefix, "life").WithRank(1))
+			pool.Add(createTestAffixWithGroup("life-b", TypePrefix, "life").WithRank(1))
+			pool.Add(createTestAffixWithGroup("mana", TypePrefix, "mana").WithRank(1))
+			pool.Add(createTestAffixWithGroup("armor", TypePrefix, "armor").WithRank(1).WithBaseWeight(200))
+
+			probs := pool.RollProbabilities(RollContext{})
+			require.Len(t, probs, 4)
+
+			total := 0.0
+			for _, p := range probs {
+				total += p
+			}
+			assert.InDelta(t, 1.0, total, 1e-9)
+			assert.InDelta(t, 0.2, probs["mana"], 1e-9)
+			assert.InDelta(t, 0.4, probs["armor"], 1e-9)
+
+			probs = pool.RollProbabilities(RollContext{ExcludeGroups: []string{"life"}})
+			require.Len(t, probs, 2)
+			assert.NotContains(t, probs, "life-a")
+			assert.InDelta(t, 1.0/3, probs["mana"], 1e-9)
+			assert.InDelta(t, 2.0/3, probs["armor"], 1e-9)
+
+			assert.Empty(t, pool.RollProbabilities(RollContext{MinTier: 1}))
+		})
 	})
 }
 
@@ -706,6 +1082,145 @@ func TestBaseGenerator(t *testing.T) {
 			// Should only get 1 due to group exclusion
 			assert.Equal(t, 1, len(instances))
 		})
+
+		t.Run("group exclusion spans prefixes and suffixes", func(t *testing.T) {
+			pool := NewBasePool()
+			pool.Add(createTestAffixWithGroup("fire-prefix", TypePrefix, "fire"))
+			pool.Add(createTestAffixWithGroup("fire-suffix", TypeSuffix, "fire"))
+
+			gen := NewBaseGenerator(pool)
+			ctx := GenerateContext{
+				RollContext: RollContext{ItemType: "sword", ItemLevel: 50},
+				PrefixRange: [2]int{1, 1},
+				SuffixRange: [2]int{1, 1},
+			}
+
+			for i := 0; i < 10; i++ {
+				instances, err := gen.Generate(ctx)
+				require.NoError(t, err)
+				require.Len(t, instances, 1)
+				assert.Equal(t, "fire-prefix", instances[0].AffixID())
+			}
+		})
+
+		t.Run("keeps caller exclusions", func(t *testing.T) {
+			pool := NewBasePool()
+			pool.Add(createTestAffixWithGroup("fire-prefix", TypePrefix, "fire"))
+			pool.Add(createTestAffixWithGroup("fire-suffix", TypeSuffix, "fire"))
+			pool.Add(createTestAffixWithGroup("cold-suffix", TypeSuffix, "cold"))
+
+			instances, err := NewBaseGenerator(pool).Generate(GenerateContext{
+				RollContext: RollContext{ItemType: "sword", ItemLevel: 50, ExcludeGroups: []string{"fire"}},
+				PrefixRange: [2]int{1, 1},
+				SuffixRange: [2]int{1, 1},
+			})
+			require.NoError(t, err)
+			require.Len(t, instances, 1)
+			assert.Equal(t, "cold-suffix", instances[0].AffixID())
+		})
+
+		t.Run("derives ranges from rarity when not set", func(t *testing.T) {
+			pool := NewBasePool()
+			for i := 0; i < 10; i++ {
+				pool.Add(createTestAffix("p-"+string(rune('a'+i)), TypePrefix, 50))
+				pool.Add(createTestAffix("s-"+string(rune('a'+i)), TypeSuffix, 50))
+			}
+			gen := NewBaseGenerator(pool)
+
+			countTypes := func(instances []Instance) (prefixes, suffixes int) {
+				for _, inst := range instances {
+					switch inst.Type() {
+					case TypePrefix:
+						prefixes++
+					case TypeSuffix:
+						suffixes++
+					}
+				}
+				return prefixes, suffixes
+			}
+
+			for i := 0; i < 20; i++ {
+				rare, err := gen.Generate(GenerateContext{
+					RollContext: RollContext{ItemType: "sword", ItemLevel: 50, ItemRarity: 2},
+				})
+				require.NoError(t, err)
+				prefixes, suffixes := countTypes(rare)
+				assert.GreaterOrEqual(t, prefixes, 1)
+				assert.LessOrEqual(t, prefixes, 3)
+				assert.GreaterOrEqual(t, suffixes, 1)
+				assert.LessOrEqual(t, suffixes, 3)
+
+				common, err := gen.Generate(GenerateContext{
+					RollContext: RollContext{ItemType: "sword", ItemLevel: 50, ItemRarity: 0},
+				})
+				require.NoError(t, err)
+				assert.Empty(t, common)
+			}
+
+			mythic, err := gen.Generate(GenerateContext{
+				RollContext: RollContext{ItemType: "sword", ItemLevel: 50, ItemRarity: 5},
+			})
+			require.NoError(t, err)
+			prefixes, suffixes := countTypes(mythic)
+			assert.Equal(t, 3, prefixes)
+			assert.Equal(t, 3, suffixes)
+		})
+	})
+
+	t.Run("RequireComplete", func(t *testing.T) {
+		t.Run("thin pool returns completeness error", func(t *testing.T) {
+			pool := NewBasePool()
+			pool.Add(createTestAffixWithGroup("fire-a", TypePrefix, "fire"))
+			pool.Add(createTestAffixWithGroup("fire-b", TypePrefix, "fire"))
+			pool.Add(createTestAffix("s-a", TypeSuffix, 50))
+
+			gen := NewBaseGenerator(pool)
+			ctx := GenerateContext{
+				RollContext: RollContext{ItemType: "sword", ItemLevel: 50},
+				PrefixRange: [2]int{2, 2},
+				SuffixRange: [2]int{1, 1},
+			}
+
+			instances, err := gen.Generate(ctx)
+			require.NoError(t, err)
+			assert.Len(t, instances, 2)
+
+			ctx.RequireComplete = true
+			instances, err = gen.Generate(ctx)
+			assert.ErrorIs(t, err, ErrIncompleteAffixes)
+			assert.Nil(t, instances)
+		})
+
+		t.Run("sufficient pool succeeds", func(t *testing.T) {
+			pool := NewBasePool()
+			for i := 0; i < 3; i++ {
+				pool.Add(createTestAffix("p-"+string(rune('a'+i)), TypePrefix, 50))
+				pool.Add(createTestAffix("s-"+string(rune('a'+i)), TypeSuffix, 50))
+			}
+
+			instances, err := NewBaseGenerator(pool).Generate(GenerateContext{
+				RollContext:     RollContext{ItemType: "sword", ItemLevel: 50},
+				PrefixRange:     [2]int{3, 3},
+				SuffixRange:     [2]int{3, 3},
+				RequireComplete: true,
+			})
+			require.NoError(t, err)
+			assert.Len(t, instances, 6)
+		})
+	})
+
+	t.Run("AffixRangesForRarity", func(t *testing.T) {
+		prefix, suffix := AffixRangesForRarity(0)
+		assert.Equal(t, [2]int{0, 0}, prefix)
+		assert.Equal(t, [2]int{0, 0}, suffix)
+
+		prefix, suffix = AffixRangesForRarity(1)
+		assert.Equal(t, [2]int{0, 1}, prefix)
+		assert.Equal(t, [2]int{0, 1}, suffix)
+
+		prefix, suffix = AffixRangesForRarity(3)
+		assert.Equal(t, [2]int{1, 3}, prefix)
+		assert.Equal(t, [2]int{1, 3}, suffix)
 	})
 
 	t.Run("CreateInstance", func(t *testing.T) {
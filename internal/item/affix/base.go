@@ -15,11 +15,13 @@ type BaseAffix struct {
 	affixType    Type
 	group        string
 	rank         int
+	tier         int
 	modifiers    []ModifierTemplate
 	requirements Requirements
 	baseWeight   int
 	description  string
 	tags         []string
+	influence    string
 }
 
 // AffixConfig holds configuration for creating BaseAffix
@@ -29,11 +31,14 @@ type AffixConfig struct {
 	Type         Type
 	Group        string
 	Rank         int
+	Tier         int
 	Modifiers    []ModifierTemplate
 	Requirements Requirements
 	BaseWeight   int
 	Description  string
 	Tags         []string
+
+	RequiredInfluence string
 }
 
 // NewBaseAffix creates new affix with default values
@@ -60,11 +65,13 @@ func NewBaseAffixWithConfig(cfg AffixConfig) *BaseAffix {
 		affixType:    cfg.Type,
 		group:        cfg.Group,
 		rank:         cfg.Rank,
+		tier:         max(cfg.Tier, 0),
 		modifiers:    cfg.Modifiers,
 		requirements: cfg.Requirements,
 		baseWeight:   cfg.BaseWeight,
 		description:  cfg.Description,
 		tags:         cfg.Tags,
+		influence:    cfg.RequiredInfluence,
 	}
 
 	if ba.modifiers == nil {
@@ -113,6 +120,12 @@ func (ba *BaseAffix) Rank() int {
 	return ba.rank
 }
 
+func (ba *BaseAffix) Tier() int {
+	ba.mu.RLock()
+	defer ba.mu.RUnlock()
+	return ba.tier
+}
+
 func (ba *BaseAffix) Modifiers() []ModifierTemplate {
 	ba.mu.RLock()
 	defer ba.mu.RUnlock()
@@ -158,6 +171,12 @@ func (ba *BaseAffix) HasTag(tag string) bool {
 	return false
 }
 
+func (ba *BaseAffix) RequiredInfluence() string {
+	ba.mu.RLock()
+	defer ba.mu.RUnlock()
+	return ba.influence
+}
+
 // Builder methods for fluent API
 
 // WithGroup sets mutual exclusion group
@@ -182,6 +201,14 @@ func (ba *BaseAffix) WithRank(rank int) *BaseAffix {
 	return ba
 }
 
+// WithTier sets player-facing tier (1 = best, 0 = untiered)
+func (ba *BaseAffix) WithTier(tier int) *BaseAffix {
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+	ba.tier = max(tier, 0)
+	return ba
+}
+
 // AddModifier adds modifier template
 func (ba *BaseAffix) AddModifier(modifier ModifierTemplate) *BaseAffix {
 	ba.mu.Lock()
@@ -234,6 +261,14 @@ func (ba *BaseAffix) AddTag(tag string) *BaseAffix {
 	return ba
 }
 
+// WithRequiredInfluence gates affix behind item influence
+func (ba *BaseAffix) WithRequiredInfluence(influence string) *BaseAffix {
+	ba.mu.Lock()
+	defer ba.mu.Unlock()
+	ba.influence = influence
+	return ba
+}
+
 // WithTags sets all tags
 func (ba *BaseAffix) WithTags(tags []string) *BaseAffix {
 	ba.mu.Lock()
@@ -1,10 +1,21 @@
 package affix
 
 import (
+	"errors"
 	"fmt"
 	"math/rand/v2"
 )
 
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+// ErrIncompleteAffixes is returned when pool can't satisfy minimum affix counts
+var ErrIncompleteAffixes = errors.New("pool cannot satisfy minimum affix counts")
+
+// completeAttempts limits generation retries when RequireComplete is set
+const completeAttempts = 10
+
 var _ Generator = (*BaseGenerator)(nil)
 
 // BaseGenerator is the default implementation of Generator interface
@@ -19,70 +30,85 @@ func NewBaseGenerator(pool Pool) *BaseGenerator {
 	}
 }
 
+// Generate rolls prefixes and suffixes for item.
+// When both ranges are zero they are derived from ItemRarity.
+// With RequireComplete, generation is retried while minimums aren't met
+// and ErrIncompleteAffixes is returned if the pool can't satisfy them.
 func (bg *BaseGenerator) Generate(ctx GenerateContext) ([]Instance, error) {
-	instances := make([]Instance, 0)
-
-	// Determine number of prefixes and suffixes
-	numPrefixes := randomInRange(ctx.PrefixRange[0], ctx.PrefixRange[1])
-	numSuffixes := randomInRange(ctx.SuffixRange[0], ctx.SuffixRange[1])
-
-	// Track used groups
-	usedGroups := make(map[string]bool)
-	usedIDs := make(map[string]bool)
-
-	// Generate prefixes
-	prefixType := TypePrefix
-	prefixCtx := ctx.RollContext
-	prefixCtx.AffixType = &prefixType
-
-	for i := 0; i < numPrefixes; i++ {
-		// Update exclusions
-		prefixCtx.ExcludeGroups = mapKeys(usedGroups)
-		prefixCtx.ExcludeIDs = mapKeys(usedIDs)
-
-		affix, err := bg.pool.Roll(prefixCtx)
-		if err != nil {
-			// No more eligible prefixes, stop generating
-			break
-		}
+	if ctx.PrefixRange == [2]int{} && ctx.SuffixRange == [2]int{} {
+		ctx.PrefixRange, ctx.SuffixRange = AffixRangesForRarity(ctx.ItemRarity)
+	}
 
-		instance := bg.createInstanceWithBias(affix, ctx.QualityBias)
-		instances = append(instances, instance)
+	if !ctx.RequireComplete {
+		instances, _, _ := bg.generateOnce(ctx)
+		return instances, nil
+	}
 
-		// Track used
-		if affix.Group() != "" {
-			usedGroups[affix.Group()] = true
+	var prefixes, suffixes int
+	for attempt := 0; attempt < completeAttempts; attempt++ {
+		var instances []Instance
+		instances, prefixes, suffixes = bg.generateOnce(ctx)
+		if prefixes >= ctx.PrefixRange[0] && suffixes >= ctx.SuffixRange[0] {
+			return instances, nil
 		}
-		usedIDs[affix.ID()] = true
 	}
 
-	// Generate suffixes
-	suffixType := TypeSuffix
-	suffixCtx := ctx.RollContext
-	suffixCtx.AffixType = &suffixType
+	return nil, fmt.Errorf("%w: got %d/%d prefixes, %d/%d suffixes", ErrIncompleteAffixes,
+		prefixes, ctx.PrefixRange[0], suffixes, ctx.SuffixRange[0])
+}
 
-	for i := 0; i < numSuffixes; i++ {
-		// Update exclusions
-		suffixCtx.ExcludeGroups = mapKeys(usedGroups)
-		suffixCtx.ExcludeIDs = mapKeys(usedIDs)
+// generateOnce performs single generation pass and reports counts it reached.
+// Used groups and IDs accumulate across prefix and suffix passes on top of
+// exclusions from ctx, so no group appears twice regardless of affix type.
+func (bg *BaseGenerator) generateOnce(ctx GenerateContext) ([]Instance, int, int) {
+	instances := make([]Instance, 0)
 
-		affix, err := bg.pool.Roll(suffixCtx)
-		if err != nil {
-			// No more eligible suffixes, stop generating
-			break
-		}
+	// Determine number of prefixes and suffixes
+	numPrefixes := randomInRange(ctx.Rng, ctx.PrefixRange[0], ctx.PrefixRange[1])
+	numSuffixes := randomInRange(ctx.Rng, ctx.SuffixRange[0], ctx.SuffixRange[1])
 
-		instance := bg.createInstanceWithBias(affix, ctx.QualityBias)
-		instances = append(instances, instance)
+	// Track used groups, seeded with caller exclusions
+	usedGroups := make(map[string]bool)
+	usedIDs := make(map[string]bool)
+	for _, group := range ctx.ExcludeGroups {
+		usedGroups[group] = true
+	}
+	for _, id := range ctx.ExcludeIDs {
+		usedIDs[id] = true
+	}
 
-		// Track used
-		if affix.Group() != "" {
-			usedGroups[affix.Group()] = true
+	rollPass := func(affixType Type, count int) int {
+		passCtx := ctx.RollContext
+		passCtx.AffixType = &affixType
+
+		rolled := 0
+		for i := 0; i < count; i++ {
+			// Update exclusions
+			passCtx.ExcludeGroups = mapKeys(usedGroups)
+			passCtx.ExcludeIDs = mapKeys(usedIDs)
+
+			affix, err := bg.pool.Roll(passCtx)
+			if err != nil {
+				// No more eligible affixes of this type, stop generating
+				break
+			}
+
+			instances = append(instances, bg.createInstanceWithBias(affix, ctx.QualityBias, ctx.Rng))
+			rolled++
+
+			// Track used
+			if affix.Group() != "" {
+				usedGroups[affix.Group()] = true
+			}
+			usedIDs[affix.ID()] = true
 		}
-		usedIDs[affix.ID()] = true
+		return rolled
 	}
 
-	return instances, nil
+	prefixes := rollPass(TypePrefix, numPrefixes)
+	suffixes := rollPass(TypeSuffix, numSuffixes)
+
+	return instances, prefixes, suffixes
 }
 
 func (bg *BaseGenerator) AddAffix(set Set, ctx RollContext) (Instance, error) {
@@ -102,7 +128,7 @@ func (bg *BaseGenerator) AddAffix(set Set, ctx RollContext) (Instance, error) {
 		return nil, err
 	}
 
-	instance := bg.CreateInstance(affix)
+	instance := NewBaseInstance(affix, rollModifiers(affix.Modifiers(), ctx.Rng))
 	if err := set.Add(instance); err != nil {
 		return nil, err
 	}
@@ -115,8 +141,8 @@ func (bg *BaseGenerator) CreateInstance(affix Affix) Instance {
 	return NewBaseInstance(affix, values)
 }
 
-func (bg *BaseGenerator) createInstanceWithBias(affix Affix, bias float64) Instance {
-	values := RollModifiersBiased(affix.Modifiers(), bias)
+func (bg *BaseGenerator) createInstanceWithBias(affix Affix, bias float64, rng *rand.Rand) Instance {
+	values := rollModifiersBiased(affix.Modifiers(), bias, rng)
 	return NewBaseInstance(affix, values)
 }
 
@@ -134,16 +160,39 @@ func mapKeys[K comparable, V any](m map[K]V) []K {
 }
 
 // randomInRange returns random int in [min, max] inclusive
-func randomInRange(min, max int) int {
+func randomInRange(rng *rand.Rand, min, max int) int {
 	if min >= max {
 		return min
 	}
-	return min + rand.IntN(max-min+1)
+	return min + randIntN(rng, max-min+1)
+}
+
+// randIntN draws from rng, falling back to global source when nil
+func randIntN(rng *rand.Rand, n int) int {
+	if rng == nil {
+		return rand.IntN(n)
+	}
+	return rng.IntN(n)
+}
+
+// randFloat64 draws from rng, falling back to global source when nil
+func randFloat64(rng *rand.Rand) float64 {
+	if rng == nil {
+		return rand.Float64()
+	}
+	return rng.Float64()
+}
+
+// AffixRangesForRarity returns min/max prefix and suffix counts for rarity,
+// following DefaultLimits
+func AffixRangesForRarity(rarity int) (prefix, suffix [2]int) {
+	limits := DefaultLimits(rarity)
+	return [2]int{limits.MinPrefixes, limits.MaxPrefixes}, [2]int{limits.MinSuffixes, limits.MaxSuffixes}
 }
 
 // GenerateForItem is a convenience function to generate affixes for an item
 func GenerateForItem(pool Pool, itemType string, itemLevel int, slot string, rarity int) ([]Instance, error) {
-	limits := DefaultLimits(rarity)
+	prefixRange, suffixRange := AffixRangesForRarity(rarity)
 	gen := NewBaseGenerator(pool)
 
 	ctx := GenerateContext{
@@ -153,8 +202,8 @@ func GenerateForItem(pool Pool, itemType string, itemLevel int, slot string, rar
 			ItemSlot:   slot,
 			ItemRarity: rarity,
 		},
-		PrefixRange: [2]int{limits.MinPrefixes, limits.MaxPrefixes},
-		SuffixRange: [2]int{limits.MinSuffixes, limits.MaxSuffixes},
+		PrefixRange: prefixRange,
+		SuffixRange: suffixRange,
 		QualityBias: 0.5, // Uniform distribution
 	}
 
@@ -163,7 +212,7 @@ func GenerateForItem(pool Pool, itemType string, itemLevel int, slot string, rar
 
 // GenerateForItemBiased generates with quality bias
 func GenerateForItemBiased(pool Pool, itemType string, itemLevel int, slot string, rarity int, qualityBias float64) ([]Instance, error) {
-	limits := DefaultLimits(rarity)
+	prefixRange, suffixRange := AffixRangesForRarity(rarity)
 	gen := NewBaseGenerator(pool)
 
 	ctx := GenerateContext{
@@ -173,8 +222,8 @@ func GenerateForItemBiased(pool Pool, itemType string, itemLevel int, slot strin
 			ItemSlot:   slot,
 			ItemRarity: rarity,
 		},
-		PrefixRange: [2]int{limits.MinPrefixes, limits.MaxPrefixes},
-		SuffixRange: [2]int{limits.MinSuffixes, limits.MaxSuffixes},
+		PrefixRange: prefixRange,
+		SuffixRange: suffixRange,
 		QualityBias: qualityBias,
 	}
 
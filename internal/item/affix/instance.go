@@ -1,14 +1,23 @@
 package affix
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand/v2"
+	"slices"
 	"sync"
 
 	"github.com/davidmovas/Depthborn/internal/core/attribute"
 	"github.com/davidmovas/Depthborn/pkg/identifier"
 )
 
+// ErrInvalidRoll is returned when rolled value is outside its template range
+var ErrInvalidRoll = errors.New("rolled value outside template range")
+
+// ErrUnknownAffix is returned when saved affix ID is not registered
+var ErrUnknownAffix = errors.New("affix not registered")
+
 var _ Instance = (*BaseInstance)(nil)
 
 // BaseInstance represents a rolled affix on an actual item.
@@ -47,6 +56,32 @@ func NewBaseInstanceFromData(affixID string, affixType Type, group string, value
 	}
 }
 
+// NewValidatedInstanceFromData creates instance from serialized rolls and validates
+// them against affix registered under affixID (nil registry = GlobalRegistry).
+// Type, group and templates come from registry; saved ones are never trusted.
+// With RollReject instance is nil when any value is out of range.
+func NewValidatedInstanceFromData(registry Registry, affixID string, values []RolledModifier, policy RollPolicy) (*BaseInstance, []InvalidRoll, error) {
+	if registry == nil {
+		registry = GlobalRegistry()
+	}
+	affix, ok := registry.Get(affixID)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownAffix, affixID)
+	}
+	if len(values) != len(affix.Modifiers()) {
+		return nil, nil, fmt.Errorf("%w: affix %s has %d modifiers, save has %d",
+			ErrInvalidRoll, affixID, len(affix.Modifiers()), len(values))
+	}
+
+	instance := NewBaseInstanceFromData(affixID, affix.Type(), affix.Group(), slices.Clone(values))
+	instance.affix = affix
+	invalid, err := instance.ValidateRolls(policy)
+	if err != nil {
+		return nil, invalid, err
+	}
+	return instance, invalid, nil
+}
+
 func (bi *BaseInstance) AffixID() string {
 	bi.mu.RLock()
 	defer bi.mu.RUnlock()
@@ -103,7 +138,7 @@ func (bi *BaseInstance) Reroll() {
 	defer bi.mu.Unlock()
 
 	for i := range bi.rolledValues {
-		bi.rolledValues[i].Value = rollValue(
+		bi.rolledValues[i].Value = rollValue(nil,
 			bi.rolledValues[i].Template.MinValue,
 			bi.rolledValues[i].Template.MaxValue,
 		)
@@ -118,13 +153,67 @@ func (bi *BaseInstance) RerollSingle(index int) error {
 		return fmt.Errorf("index out of range: %d", index)
 	}
 
-	bi.rolledValues[index].Value = rollValue(
+	bi.rolledValues[index].Value = rollValue(nil,
 		bi.rolledValues[index].Template.MinValue,
 		bi.rolledValues[index].Template.MaxValue,
 	)
 	return nil
 }
 
+// maxFloorRerollAttempts caps rerolls per modifier when enforcing a quality floor
+const maxFloorRerollAttempts = 1000
+
+// RerollWithFloor re-rolls all values so that each modifier's quality is at least minQuality.
+// Values are only committed if every modifier reaches the floor within the attempt cap.
+func (bi *BaseInstance) RerollWithFloor(minQuality float64) error {
+	if minQuality > 1 {
+		return fmt.Errorf("quality floor %.2f exceeds maximum quality", minQuality)
+	}
+
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	rolled, err := bi.rollWithFloorLocked(minQuality)
+	if err != nil {
+		return err
+	}
+	bi.rolledValues = rolled
+	return nil
+}
+
+// rollWithFloor computes floored rolls without committing them
+func (bi *BaseInstance) rollWithFloor(minQuality float64) ([]RolledModifier, error) {
+	if minQuality > 1 {
+		return nil, fmt.Errorf("quality floor %.2f exceeds maximum quality", minQuality)
+	}
+
+	bi.mu.RLock()
+	defer bi.mu.RUnlock()
+	return bi.rollWithFloorLocked(minQuality)
+}
+
+func (bi *BaseInstance) rollWithFloorLocked(minQuality float64) ([]RolledModifier, error) {
+	rolled := make([]RolledModifier, len(bi.rolledValues))
+	copy(rolled, bi.rolledValues)
+
+	for i := range rolled {
+		value, ok := rollValueWithFloor(rolled[i].Template.MinValue, rolled[i].Template.MaxValue, minQuality)
+		if !ok {
+			return nil, fmt.Errorf("quality floor %.2f unreachable for modifier %d of affix %s after %d attempts",
+				minQuality, i, bi.affixID, maxFloorRerollAttempts)
+		}
+		rolled[i].Value = value
+	}
+	return rolled, nil
+}
+
+// setRolledValues replaces rolled values with precomputed ones
+func (bi *BaseInstance) setRolledValues(values []RolledModifier) {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+	bi.rolledValues = values
+}
+
 func (bi *BaseInstance) Quality() float64 {
 	bi.mu.RLock()
 	defer bi.mu.RUnlock()
@@ -147,9 +236,81 @@ func (bi *BaseInstance) SetAffix(affix Affix) {
 	bi.affix = affix
 }
 
+// RollPolicy controls how ValidateRolls treats out-of-range values
+type RollPolicy int
+
+const (
+	RollClamp  RollPolicy = iota // Clamp value into template range
+	RollReject                   // Leave values untouched and return ErrInvalidRoll
+)
+
+// InvalidRoll describes rolled value outside its template range
+type InvalidRoll struct {
+	Index     int
+	Attribute attribute.Type
+	Value     float64 // Value as stored
+	Min       float64
+	Max       float64
+}
+
+// ValidateRolls checks rolled values against their templates.
+// Templates of linked affix take precedence over stored ones, so edited
+// ranges in save data are not trusted; stored template with other attribute
+// or modifier type counts as invalid. Unless rejected, rolls of linked affix
+// take its templates. NaN counts as invalid and clamps to Min.
+func (bi *BaseInstance) ValidateRolls(policy RollPolicy) ([]InvalidRoll, error) {
+	bi.mu.Lock()
+	defer bi.mu.Unlock()
+
+	var trusted []ModifierTemplate
+	if bi.affix != nil {
+		trusted = bi.affix.Modifiers()
+	}
+
+	var invalid []InvalidRoll
+	for i, rm := range bi.rolledValues {
+		tmpl := rm.Template
+		if i < len(trusted) {
+			tmpl = trusted[i]
+		}
+		lo, hi := min(tmpl.MinValue, tmpl.MaxValue), max(tmpl.MinValue, tmpl.MaxValue)
+		retyped := rm.Template.Attribute != tmpl.Attribute || rm.Template.ModType != tmpl.ModType
+		if rm.Value >= lo && rm.Value <= hi && !retyped {
+			continue
+		}
+		invalid = append(invalid, InvalidRoll{
+			Index:     i,
+			Attribute: tmpl.Attribute,
+			Value:     rm.Value,
+			Min:       lo,
+			Max:       hi,
+		})
+	}
+
+	if len(invalid) > 0 && policy == RollReject {
+		return invalid, fmt.Errorf("%w: %d of %d modifiers on affix %s",
+			ErrInvalidRoll, len(invalid), len(bi.rolledValues), bi.affixID)
+	}
+	for i := range min(len(trusted), len(bi.rolledValues)) {
+		bi.rolledValues[i].Template = trusted[i]
+	}
+	if len(invalid) == 0 {
+		return nil, nil
+	}
+
+	for _, roll := range invalid {
+		value := roll.Value
+		if math.IsNaN(value) {
+			value = roll.Min
+		}
+		bi.rolledValues[roll.Index].Value = min(max(value, roll.Min), roll.Max)
+	}
+	return invalid, nil
+}
+
 // rollValue generates random value between min and max using weighted distribution
 // Values closer to center are more likely (bell curve approximation)
-func rollValue(min, max float64) float64 {
+func rollValue(rng *rand.Rand, min, max float64) float64 {
 	if min >= max {
 		return min
 	}
@@ -159,15 +320,26 @@ func rollValue(min, max float64) float64 {
 	sum := 0.0
 	iterations := 3
 	for i := 0; i < iterations; i++ {
-		sum += rand.Float64()
+		sum += randFloat64(rng)
 	}
 	normalized := sum / float64(iterations)
 
 	return min + (max-min)*normalized
 }
 
+// rollValueWithFloor rolls until value quality reaches minQuality or attempts run out
+func rollValueWithFloor(min, max, minQuality float64) (float64, bool) {
+	for attempt := 0; attempt < maxFloorRerollAttempts; attempt++ {
+		value := rollValue(nil, min, max)
+		if calculateQuality(value, min, max) >= minQuality {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
 // rollValueBiased generates value with bias toward min (0.0) or max (1.0)
-func rollValueBiased(min, max, bias float64) float64 {
+func rollValueBiased(rng *rand.Rand, min, max, bias float64) float64 {
 	if min >= max {
 		return min
 	}
@@ -176,7 +348,7 @@ func rollValueBiased(min, max, bias float64) float64 {
 	// bias = 0.0 -> skew toward min
 	// bias = 0.5 -> uniform
 	// bias = 1.0 -> skew toward max
-	r := rand.Float64()
+	r := randFloat64(rng)
 
 	// Apply power function for bias
 	// bias < 0.5 -> power > 1 -> more weight to lower values
@@ -247,11 +419,15 @@ func pow(x, y float64) float64 {
 
 // RollModifiers generates rolled values from templates using weighted distribution
 func RollModifiers(templates []ModifierTemplate) []RolledModifier {
+	return rollModifiers(templates, nil)
+}
+
+func rollModifiers(templates []ModifierTemplate, rng *rand.Rand) []RolledModifier {
 	result := make([]RolledModifier, len(templates))
 	for i, tmpl := range templates {
 		result[i] = RolledModifier{
 			Template: tmpl,
-			Value:    rollValue(tmpl.MinValue, tmpl.MaxValue),
+			Value:    rollValue(rng, tmpl.MinValue, tmpl.MaxValue),
 		}
 	}
 	return result
@@ -259,11 +435,15 @@ func RollModifiers(templates []ModifierTemplate) []RolledModifier {
 
 // RollModifiersBiased generates rolled values with quality bias
 func RollModifiersBiased(templates []ModifierTemplate, bias float64) []RolledModifier {
+	return rollModifiersBiased(templates, bias, nil)
+}
+
+func rollModifiersBiased(templates []ModifierTemplate, bias float64, rng *rand.Rand) []RolledModifier {
 	result := make([]RolledModifier, len(templates))
 	for i, tmpl := range templates {
 		result[i] = RolledModifier{
 			Template: tmpl,
-			Value:    rollValueBiased(tmpl.MinValue, tmpl.MaxValue, bias),
+			Value:    rollValueBiased(rng, tmpl.MinValue, tmpl.MaxValue, bias),
 		}
 	}
 	return result
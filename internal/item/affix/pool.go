@@ -2,7 +2,7 @@ package affix
 
 import (
 	"fmt"
-	"math/rand/v2"
+	"sort"
 	"sync"
 )
 
@@ -27,12 +27,33 @@ func (bp *BasePool) Add(affix Affix) {
 	bp.affixes[affix.ID()] = affix
 }
 
+// AddAll adds affixes in one pass; affix with already present ID replaces it
+func (bp *BasePool) AddAll(affixes []Affix) {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	for _, affix := range affixes {
+		bp.affixes[affix.ID()] = affix
+	}
+}
+
 func (bp *BasePool) Remove(affixID string) {
 	bp.mu.Lock()
 	defer bp.mu.Unlock()
 	delete(bp.affixes, affixID)
 }
 
+func (bp *BasePool) Clear() {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	clear(bp.affixes)
+}
+
+func (bp *BasePool) Size() int {
+	bp.mu.RLock()
+	defer bp.mu.RUnlock()
+	return len(bp.affixes)
+}
+
 func (bp *BasePool) Get(affixID string) (Affix, bool) {
 	bp.mu.RLock()
 	defer bp.mu.RUnlock()
@@ -139,6 +160,11 @@ func (bp *BasePool) matchesCriteria(affix Affix, criteria FilterCriteria) bool {
 		}
 	}
 
+	// Check influence gate
+	if !hasInfluence(affix, criteria.Influences) {
+		return false
+	}
+
 	// Check rank range
 	if criteria.MinRank > 0 && affix.Rank() < criteria.MinRank {
 		return false
@@ -147,6 +173,11 @@ func (bp *BasePool) matchesCriteria(affix Affix, criteria FilterCriteria) bool {
 		return false
 	}
 
+	// Check tier band
+	if !inTierBand(affix.Tier(), criteria.MinTier, criteria.MaxTier) {
+		return false
+	}
+
 	// Check item level requirements
 	req := affix.Requirements()
 	if req != nil {
@@ -165,27 +196,17 @@ func (bp *BasePool) Roll(ctx RollContext) (Affix, error) {
 	bp.mu.RLock()
 	defer bp.mu.RUnlock()
 
-	eligible := bp.getEligible(ctx)
+	eligible, weights, totalWeight := bp.weightedEligible(ctx)
 	if len(eligible) == 0 {
 		return nil, fmt.Errorf("no eligible affixes found")
 	}
 
-	// Calculate weights with rarity adjustment
-	weights := make([]int, len(eligible))
-	totalWeight := 0
-
-	for i, affix := range eligible {
-		weight := calculateEffectiveWeight(affix, ctx.ItemRarity, ctx.ItemLevel)
-		weights[i] = weight
-		totalWeight += weight
-	}
-
 	if totalWeight <= 0 {
 		return nil, fmt.Errorf("total weight is zero or negative")
 	}
 
 	// Weighted random selection
-	roll := rand.IntN(totalWeight)
+	roll := randIntN(ctx.Rng, totalWeight)
 	currentWeight := 0
 
 	for i, affix := range eligible {
@@ -198,6 +219,42 @@ func (bp *BasePool) Roll(ctx RollContext) (Affix, error) {
 	return eligible[len(eligible)-1], nil
 }
 
+// RollProbabilities returns chance of each eligible affix being picked by Roll
+// with the same context. Values sum to 1.0; map is empty if nothing can roll.
+func (bp *BasePool) RollProbabilities(ctx RollContext) map[string]float64 {
+	bp.mu.RLock()
+	defer bp.mu.RUnlock()
+
+	eligible, weights, totalWeight := bp.weightedEligible(ctx)
+	probabilities := make(map[string]float64, len(eligible))
+	if totalWeight <= 0 {
+		return probabilities
+	}
+
+	for i, affix := range eligible {
+		probabilities[affix.ID()] = float64(weights[i]) / float64(totalWeight)
+	}
+
+	return probabilities
+}
+
+// weightedEligible returns eligible affixes with their effective weights
+func (bp *BasePool) weightedEligible(ctx RollContext) ([]Affix, []int, int) {
+	eligible := bp.getEligible(ctx)
+
+	// Calculate weights with rarity adjustment
+	weights := make([]int, len(eligible))
+	totalWeight := 0
+
+	for i, affix := range eligible {
+		weight := calculateEffectiveWeight(affix, ctx.ItemRarity, ctx.ItemLevel)
+		weights[i] = weight
+		totalWeight += weight
+	}
+
+	return eligible, weights, totalWeight
+}
+
 func (bp *BasePool) getEligible(ctx RollContext) []Affix {
 	eligible := make([]Affix, 0)
 
@@ -207,6 +264,11 @@ func (bp *BasePool) getEligible(ctx RollContext) []Affix {
 		}
 	}
 
+	// Stable order keeps seeded rolls reproducible
+	sort.Slice(eligible, func(i, j int) bool {
+		return eligible[i].ID() < eligible[j].ID()
+	})
+
 	return eligible
 }
 
@@ -244,6 +306,16 @@ func (bp *BasePool) isEligible(affix Affix, ctx RollContext) bool {
 		}
 	}
 
+	// Check influence gate
+	if !hasInfluence(affix, ctx.Influences) {
+		return false
+	}
+
+	// Check tier band
+	if !inTierBand(affix.Tier(), ctx.MinTier, ctx.MaxTier) {
+		return false
+	}
+
 	// Check requirements
 	req := affix.Requirements()
 	if req != nil && !req.Check(ctx.ItemType, ctx.ItemLevel, ctx.ItemSlot) {
@@ -295,6 +367,33 @@ func calculateEffectiveWeight(affix Affix, itemRarity int, itemLevel int) int {
 
 // Helper functions
 
+// hasInfluence reports whether affix is unlocked by given influences.
+// Affixes without a required influence are always unlocked.
+func hasInfluence(affix Affix, influences []string) bool {
+	required := affix.RequiredInfluence()
+	if required == "" {
+		return true
+	}
+	for _, inf := range influences {
+		if inf == required {
+			return true
+		}
+	}
+	return false
+}
+
+// inTierBand reports whether tier lies in [minTier, maxTier] (0 = unbounded).
+// Untiered affixes only pass when band is unbounded.
+func inTierBand(tier, minTier, maxTier int) bool {
+	if minTier <= 0 && maxTier <= 0 {
+		return true
+	}
+	if tier <= 0 {
+		return false
+	}
+	return (minTier <= 0 || tier >= minTier) && (maxTier <= 0 || tier <= maxTier)
+}
+
 func hasAllTags(affixTags []string, required []string) bool {
 	for _, req := range required {
 		found := false
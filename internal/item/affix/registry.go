@@ -86,13 +86,13 @@ func (br *BaseRegistry) GetPool(itemType string, slot string) Pool {
 }
 
 func (br *BaseRegistry) buildPool(itemType string, slot string) Pool {
-	pool := NewBasePool()
+	eligible := make([]Affix, 0, len(br.affixes))
 
 	for _, affix := range br.affixes {
 		req := affix.Requirements()
 		if req == nil {
 			// No requirements = available everywhere
-			pool.Add(affix)
+			eligible = append(eligible, affix)
 			continue
 		}
 
@@ -117,10 +117,12 @@ func (br *BaseRegistry) buildPool(itemType string, slot string) Pool {
 		}
 
 		if typeOK && slotOK {
-			pool.Add(affix)
+			eligible = append(eligible, affix)
 		}
 	}
 
+	pool := NewBasePool()
+	pool.AddAll(eligible)
 	return pool
 }
 
@@ -214,11 +216,14 @@ func (br *BaseRegistry) parseAffixDef(def Def) (Affix, error) {
 		Type:         Type(def.Type),
 		Group:        def.Group,
 		Rank:         def.Rank,
+		Tier:         def.Tier,
 		Modifiers:    modifiers,
 		Requirements: req,
 		BaseWeight:   def.Weight,
 		Description:  def.Description,
 		Tags:         def.Tags,
+
+		RequiredInfluence: def.Influence,
 	})
 
 	return affix, nil
@@ -239,11 +244,13 @@ type Def struct {
 	Type         string          `yaml:"type"` // prefix, suffix, implicit, etc.
 	Group        string          `yaml:"group,omitempty"`
 	Rank         int             `yaml:"rank"`
+	Tier         int             `yaml:"tier,omitempty"`
 	Weight       int             `yaml:"weight"`
 	Description  string          `yaml:"description,omitempty"`
 	Tags         []string        `yaml:"tags,omitempty"`
 	Modifiers    []ModifierDef   `yaml:"modifiers"`
 	Requirements *RequirementDef `yaml:"requirements,omitempty"`
+	Influence    string          `yaml:"influence,omitempty"`
 }
 
 // ModifierDef represents modifier template in YAML
@@ -2,6 +2,8 @@ package affix
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 
 	"github.com/davidmovas/Depthborn/internal/core/attribute"
@@ -42,6 +44,7 @@ type BaseSet struct {
 	mu        sync.RWMutex
 	instances map[string]Instance // affixID -> instance
 	groups    map[string]string   // group -> affixID (for mutual exclusion)
+	order     []string            // affixIDs in add order (for stable display)
 	limits    AffixLimits
 }
 
@@ -77,6 +80,7 @@ func (bs *BaseSet) Add(instance Instance) error {
 	}
 
 	bs.instances[instance.AffixID()] = instance
+	bs.order = append(bs.order, instance.AffixID())
 
 	// Track group for mutual exclusion
 	group := instance.Group()
@@ -103,6 +107,7 @@ func (bs *BaseSet) Remove(affixID string) error {
 	}
 
 	delete(bs.instances, affixID)
+	bs.order = slices.DeleteFunc(bs.order, func(id string) bool { return id == affixID })
 	return nil
 }
 
@@ -138,6 +143,40 @@ func (bs *BaseSet) GetAll() []Instance {
 	return result
 }
 
+// Ordered returns all affixes for display: prefixes first, then suffixes,
+// then any other types; each group keeps add order
+func (bs *BaseSet) Ordered() []Instance {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	result := make([]Instance, 0, len(bs.order))
+	result = bs.appendByTypeLocked(result, TypePrefix)
+	result = bs.appendByTypeLocked(result, TypeSuffix)
+	for _, affixID := range bs.order {
+		if instance := bs.instances[affixID]; instance.Type() != TypePrefix && instance.Type() != TypeSuffix {
+			result = append(result, instance)
+		}
+	}
+	return result
+}
+
+// GetByTypeOrdered returns instances of specified type in add order
+func (bs *BaseSet) GetByTypeOrdered(affixType Type) []Instance {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	return bs.appendByTypeLocked(make([]Instance, 0), affixType)
+}
+
+func (bs *BaseSet) appendByTypeLocked(result []Instance, affixType Type) []Instance {
+	for _, affixID := range bs.order {
+		if instance := bs.instances[affixID]; instance.Type() == affixType {
+			result = append(result, instance)
+		}
+	}
+	return result
+}
+
 func (bs *BaseSet) Count() int {
 	bs.mu.RLock()
 	defer bs.mu.RUnlock()
@@ -260,16 +299,30 @@ func (bs *BaseSet) Clear() {
 
 	bs.instances = make(map[string]Instance)
 	bs.groups = make(map[string]string)
+	bs.order = nil
 }
 
+// AllModifiers returns modifiers of all affixes ordered for application.
+// Lower priority comes first so higher priority is applied later; ties keep
+// affix ID order and the modifier order within each affix.
 func (bs *BaseSet) AllModifiers() []attribute.Modifier {
 	bs.mu.RLock()
 	defer bs.mu.RUnlock()
 
+	affixIDs := make([]string, 0, len(bs.instances))
+	for affixID := range bs.instances {
+		affixIDs = append(affixIDs, affixID)
+	}
+	sort.Strings(affixIDs)
+
 	modifiers := make([]attribute.Modifier, 0)
-	for _, instance := range bs.instances {
-		modifiers = append(modifiers, instance.Modifiers()...)
+	for _, affixID := range affixIDs {
+		modifiers = append(modifiers, bs.instances[affixID].Modifiers()...)
 	}
+
+	sort.SliceStable(modifiers, func(i, j int) bool {
+		return modifiers[i].Priority() < modifiers[j].Priority()
+	})
 	return modifiers
 }
 
@@ -282,6 +335,41 @@ func (bs *BaseSet) RerollAll() {
 	}
 }
 
+// flooredRoller computes floored rolls separately from committing them, so
+// set can reroll all affixes or none
+type flooredRoller interface {
+	rollWithFloor(minQuality float64) ([]RolledModifier, error)
+	setRolledValues(values []RolledModifier)
+}
+
+// RerollAllWithFloor re-rolls all affixes enforcing a minimum quality per modifier.
+// Affixes are rolled in add order and new values are committed only if every
+// affix reaches the floor; on error the set is left untouched.
+func (bs *BaseSet) RerollAllWithFloor(minQuality float64) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	rollers := make([]flooredRoller, 0, len(bs.order))
+	rolls := make([][]RolledModifier, 0, len(bs.order))
+	for _, affixID := range bs.order {
+		roller, ok := bs.instances[affixID].(flooredRoller)
+		if !ok {
+			return fmt.Errorf("affix %s does not support floored reroll", affixID)
+		}
+		rolled, err := roller.rollWithFloor(minQuality)
+		if err != nil {
+			return err
+		}
+		rollers = append(rollers, roller)
+		rolls = append(rolls, rolled)
+	}
+
+	for i, roller := range rollers {
+		roller.setRolledValues(rolls[i])
+	}
+	return nil
+}
+
 func (bs *BaseSet) TotalQuality() float64 {
 	bs.mu.RLock()
 	defer bs.mu.RUnlock()
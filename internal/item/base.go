@@ -277,6 +277,13 @@ func (i *BaseItem) CanStackWith(other Item) bool {
 	return i.stackSize < i.maxStackSize
 }
 
+// StackKey returns type, name and rarity - simple items with these equal stack together
+func (i *BaseItem) StackKey() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return fmt.Sprintf("%s|%s|%d", i.itemType, i.name, i.rarity)
+}
+
 func (i *BaseItem) Value() int64 {
 	i.mu.RLock()
 	defer i.mu.RUnlock()
@@ -359,6 +366,12 @@ func (i *BaseItem) IsTradeable() bool {
 	return i.itemType != TypeQuest
 }
 
+func (i *BaseItem) WeightExempt() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.tags.Has(TagWeightExempt)
+}
+
 // --- Cloneable interface ---
 
 func (i *BaseItem) Clone() any {
@@ -444,12 +457,12 @@ func (i *BaseItem) Marshal() ([]byte, error) {
 		Tags:         i.tags.All(),
 	}
 
-	return persist.DefaultCodec().Encode(state)
+	return encodeState(state)
 }
 
 func (i *BaseItem) Unmarshal(data []byte) error {
 	var state State
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err := decodeState(data, &state); err != nil {
 		return fmt.Errorf("failed to decode item state: %w", err)
 	}
 
@@ -551,7 +564,7 @@ func (i *BaseItem) SerializeState() (map[string]any, error) {
 	}
 
 	var state map[string]any
-	if err = persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err = decodeState(data, &state); err != nil {
 		return nil, err
 	}
 	return state, nil
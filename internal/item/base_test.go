@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
 func TestBaseItem(t *testing.T) {
@@ -345,6 +347,80 @@ func TestBaseItem(t *testing.T) {
 			require.Equal(t, original.Weight(), restored.Weight())
 			require.Equal(t, original.Icon(), restored.Icon())
 		})
+
+		t.Run("SerializeState and DeserializeState roundtrip", func(t *testing.T) {
+			original := NewBaseItemWithConfig(BaseItemConfig{
+				ID:           "state-test",
+				Name:         "Stateful",
+				ItemType:     TypeMaterial,
+				Rarity:       RarityRare,
+				MaxStackSize: 20,
+				Weight:       1.5,
+			})
+			original.AddStack(4)
+
+			state, err := original.SerializeState()
+			require.NoError(t, err)
+			require.Equal(t, "state-test", state["id"])
+
+			restored := &BaseItem{}
+			require.NoError(t, restored.DeserializeState(state))
+			require.Equal(t, original.ID(), restored.ID())
+			require.Equal(t, original.Name(), restored.Name())
+			require.Equal(t, original.Rarity(), restored.Rarity())
+			require.Equal(t, original.StackSize(), restored.StackSize())
+			require.Equal(t, original.Weight(), restored.Weight())
+		})
+
+		t.Run("format versions", func(t *testing.T) {
+			legacyState := State{
+				ID:           "old-1",
+				EntityType:   "item:material",
+				Name:         "Old Ore",
+				ItemType:     string(TypeMaterial),
+				Level:        3,
+				StackSize:    7,
+				MaxStackSize: 20,
+				Tags:         []string{"ore"},
+			}
+			v0, err := persist.DefaultCodec().Encode(legacyState)
+			require.NoError(t, err)
+
+			version, _ := FormatVersionOf(v0)
+			require.Equal(t, byte(0), version)
+
+			v1, err := NewBaseItemWithConfig(BaseItemConfig{
+				ID:           "new-1",
+				Name:         "New Ore",
+				ItemType:     TypeMaterial,
+				MaxStackSize: 20,
+			}).Marshal()
+			require.NoError(t, err)
+
+			version, _ = FormatVersionOf(v1)
+			require.Equal(t, FormatVersion, version)
+
+			fromV0 := &BaseItem{}
+			require.NoError(t, fromV0.Unmarshal(v0))
+			require.Equal(t, "old-1", fromV0.ID())
+			require.Equal(t, "Old Ore", fromV0.Name())
+			require.Equal(t, 7, fromV0.StackSize())
+			require.True(t, fromV0.Tags().Has("ore"))
+
+			fromV1 := &BaseItem{}
+			require.NoError(t, fromV1.Unmarshal(v1))
+			require.Equal(t, "new-1", fromV1.ID())
+			require.Equal(t, "New Ore", fromV1.Name())
+		})
+
+		t.Run("unknown format version", func(t *testing.T) {
+			data, err := NewBaseItem("future", TypeMaterial, "Future").Marshal()
+			require.NoError(t, err)
+			data[1] = FormatVersion + 1
+
+			err = (&BaseItem{}).Unmarshal(data)
+			require.ErrorIs(t, err, ErrUnsupportedFormat)
+		})
 	})
 
 	t.Run("Validation", func(t *testing.T) {
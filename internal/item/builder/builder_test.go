@@ -87,6 +87,11 @@ func TestItemBuilder(t *testing.T) {
 		require.True(t, result.Tags().Has("crafting"))
 	})
 
+	t.Run("WeightExempt marks item", func(t *testing.T) {
+		require.False(t, Quest("Relic").Build().WeightExempt())
+		require.True(t, Quest("Relic").WeightExempt().Build().WeightExempt())
+	})
+
 	t.Run("Build returns valid item", func(t *testing.T) {
 		result := Material("Test Item").Build()
 
@@ -317,6 +322,17 @@ func TestConsumableBuilder(t *testing.T) {
 		require.True(t, result.Tags().Has("healing"))
 	})
 
+	t.Run("Heals adds heal effect", func(t *testing.T) {
+		result := Potion("Healing Potion").
+			Heals(25).
+			Effects(item.NewRestoreResourceEffect("mana", 10)).
+			Build()
+
+		effects := result.Effects()
+		require.Len(t, effects, 2)
+		require.Equal(t, 25.0, effects[0].(*item.HealEffect).Amount())
+	})
+
 	t.Run("Infinite sets charges to -1", func(t *testing.T) {
 		result := Consume("Endless Potion").Infinite().Build()
 
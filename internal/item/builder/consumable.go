@@ -10,6 +10,7 @@ type Consumable struct {
 	maxCooldown int64
 	effect      item.ConsumableEffect
 	effectID    string
+	effects     []item.ConsumableEffect
 	charges     int
 }
 
@@ -110,6 +111,15 @@ func (b *Consumable) Effect(effect item.ConsumableEffect, effectID string) *Cons
 	return b
 }
 
+func (b *Consumable) Effects(effects ...item.ConsumableEffect) *Consumable {
+	b.effects = append(b.effects, effects...)
+	return b
+}
+
+func (b *Consumable) Heals(amount float64) *Consumable {
+	return b.Effects(item.NewHealEffect(amount))
+}
+
 func (b *Consumable) Charges(count int) *Consumable {
 	b.charges = count
 	return b
@@ -126,6 +136,7 @@ func (b *Consumable) Build() *item.BaseConsumable {
 		MaxCooldown:    b.maxCooldown,
 		Effect:         b.effect,
 		EffectID:       b.effectID,
+		Effects:        b.effects,
 		Charges:        b.charges,
 	}
 
@@ -158,6 +158,11 @@ func (b *Equipment) Tags(tags ...string) *Equipment {
 	return b
 }
 
+func (b *Equipment) WeightExempt() *Equipment {
+	b.Item.WeightExempt()
+	return b
+}
+
 // Equipment-specific methods
 func (b *Equipment) Slot(slot item.EquipmentSlot) *Equipment {
 	b.slot = slot
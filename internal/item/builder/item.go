@@ -106,6 +106,11 @@ func (b *Item) Tags(tags ...string) *Item {
 	return b
 }
 
+// WeightExempt excludes item from carry weight
+func (b *Item) WeightExempt() *Item {
+	return b.Tags(item.TagWeightExempt)
+}
+
 func (b *Item) Build() *item.BaseItem {
 	return item.NewBaseItemWithConfig(b.config)
 }
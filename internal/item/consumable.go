@@ -6,7 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/davidmovas/Depthborn/internal/core/entity"
 	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
@@ -21,6 +20,7 @@ type BaseConsumable struct {
 	maxCooldown int64
 	effect      ConsumableEffect
 	effectID    string // For serialization - identifies the effect type
+	effects     []ConsumableEffect
 	lastUsed    int64
 	charges     int // Number of uses before consumed (-1 for infinite until stack depletes)
 	maxCharges  int
@@ -32,6 +32,7 @@ type ConsumableConfig struct {
 	MaxCooldown int64
 	Effect      ConsumableEffect
 	EffectID    string
+	Effects     []ConsumableEffect
 	Charges     int
 }
 
@@ -58,6 +59,7 @@ func NewBaseConsumableWithConfig(cfg ConsumableConfig) *BaseConsumable {
 		cooldown:    0,
 		effect:      cfg.Effect,
 		effectID:    cfg.EffectID,
+		effects:     append([]ConsumableEffect(nil), cfg.Effects...),
 		lastUsed:    0,
 		charges:     cfg.Charges,
 		maxCharges:  cfg.Charges,
@@ -74,20 +76,29 @@ func NewBaseConsumableWithConfig(cfg ConsumableConfig) *BaseConsumable {
 
 // --- Consumable interface implementation ---
 
-func (bc *BaseConsumable) Use(ctx context.Context, user entity.Entity) error {
+func (bc *BaseConsumable) Use(ctx context.Context, target EffectTarget) error {
 	bc.mu.Lock()
 
-	if !bc.canUseInternal(user) {
+	if !bc.canUseInternal(target) {
 		bc.mu.Unlock()
 		return fmt.Errorf("cannot use consumable: on cooldown or no charges")
 	}
 
-	effect := bc.effect
+	if len(bc.effects) > 0 && target == nil {
+		bc.mu.Unlock()
+		return fmt.Errorf("cannot use consumable: no target for effects")
+	}
+
+	effects := make([]ConsumableEffect, 0, len(bc.effects)+1)
+	if bc.effect != nil {
+		effects = append(effects, bc.effect)
+	}
+	effects = append(effects, bc.effects...)
 	bc.mu.Unlock()
 
-	// Apply effect (outside lock to avoid holding lock during potentially long operation)
-	if effect != nil {
-		if err := effect.Apply(ctx, user); err != nil {
+	// Apply effects (outside lock to avoid holding lock during potentially long operation)
+	for _, effect := range effects {
+		if err := effect.Apply(ctx, target); err != nil {
 			return fmt.Errorf("failed to apply consumable effect: %w", err)
 		}
 	}
@@ -118,14 +129,14 @@ func (bc *BaseConsumable) Use(ctx context.Context, user entity.Entity) error {
 	return nil
 }
 
-func (bc *BaseConsumable) CanUse(user entity.Entity) bool {
+func (bc *BaseConsumable) CanUse(target EffectTarget) bool {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
-	return bc.canUseInternal(user)
+	return bc.canUseInternal(target)
 }
 
 // canUseInternal checks if consumable can be used (no lock)
-func (bc *BaseConsumable) canUseInternal(user entity.Entity) bool {
+func (bc *BaseConsumable) canUseInternal(target EffectTarget) bool {
 	// Check cooldown
 	if bc.cooldownInternal() > 0 {
 		return false
@@ -194,6 +205,27 @@ func (bc *BaseConsumable) SetEffect(effect ConsumableEffect, effectID string) {
 	bc.Touch()
 }
 
+// Effects returns effects applied on use in addition to Effect
+func (bc *BaseConsumable) Effects() []ConsumableEffect {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	result := make([]ConsumableEffect, len(bc.effects))
+	copy(result, bc.effects)
+	return result
+}
+
+// AddEffect appends effect applied on use
+func (bc *BaseConsumable) AddEffect(effect ConsumableEffect) {
+	if effect == nil {
+		return
+	}
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.effects = append(bc.effects, effect)
+	bc.Touch()
+}
+
 // --- Additional methods ---
 
 // Charges returns current charges remaining
@@ -224,6 +256,13 @@ func (bc *BaseConsumable) SetCharges(charges int) {
 	bc.Touch()
 }
 
+// StackKey extends base key with effect and charges - partially used consumables don't stack
+func (bc *BaseConsumable) StackKey() string {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return fmt.Sprintf("%s|%s|%d/%d", bc.BaseItem.StackKey(), bc.effectID, bc.charges, bc.maxCharges)
+}
+
 // EffectID returns the effect identifier for serialization
 func (bc *BaseConsumable) EffectID() string {
 	bc.mu.RLock()
@@ -267,6 +306,7 @@ func (bc *BaseConsumable) Clone() any {
 		cooldown:    0, // Reset cooldown for clone
 		effect:      bc.effect,
 		effectID:    bc.effectID,
+		effects:     append([]ConsumableEffect(nil), bc.effects...),
 		lastUsed:    0,             // Reset for clone
 		charges:     bc.maxCharges, // Full charges for clone
 		maxCharges:  bc.maxCharges,
@@ -299,7 +339,7 @@ func (bc *BaseConsumable) Marshal() ([]byte, error) {
 
 	// Decode to State
 	var is State
-	if err := persist.DefaultCodec().Decode(baseData, &is); err != nil {
+	if err := decodeState(baseData, &is); err != nil {
 		return nil, err
 	}
 
@@ -312,12 +352,12 @@ func (bc *BaseConsumable) Marshal() ([]byte, error) {
 		MaxCharges:  bc.maxCharges,
 	}
 
-	return persist.DefaultCodec().Encode(cs)
+	return encodeState(cs)
 }
 
 func (bc *BaseConsumable) Unmarshal(data []byte) error {
 	var cs ConsumableState
-	if err := persist.DefaultCodec().Decode(data, &cs); err != nil {
+	if err := decodeState(data, &cs); err != nil {
 		return fmt.Errorf("failed to decode consumable state: %w", err)
 	}
 
@@ -392,7 +432,7 @@ func (bc *BaseConsumable) SerializeState() (map[string]any, error) {
 	}
 
 	var state map[string]any
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err := decodeState(data, &state); err != nil {
 		return nil, err
 	}
 	return state, nil
@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/davidmovas/Depthborn/internal/core/entity"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,7 +13,7 @@ type mockConsumableEffect struct {
 	applied bool
 }
 
-func (m *mockConsumableEffect) Apply(_ context.Context, _ entity.Entity) error {
+func (m *mockConsumableEffect) Apply(_ context.Context, _ EffectTarget) error {
 	m.applied = true
 	return nil
 }
@@ -27,6 +26,44 @@ func (m *mockConsumableEffect) Duration() int64 {
 	return 0
 }
 
+// mockEffectTarget implements EffectTarget for testing
+type mockEffectTarget struct {
+	health    float64
+	maxHealth float64
+	resources map[string]float64
+	statuses  map[string]int64
+}
+
+func newMockEffectTarget(health, maxHealth float64) *mockEffectTarget {
+	return &mockEffectTarget{
+		health:    health,
+		maxHealth: maxHealth,
+		resources: make(map[string]float64),
+		statuses:  make(map[string]int64),
+	}
+}
+
+func (m *mockEffectTarget) Health() float64 {
+	return m.health
+}
+
+func (m *mockEffectTarget) MaxHealth() float64 {
+	return m.maxHealth
+}
+
+func (m *mockEffectTarget) SetHealth(value float64) {
+	m.health = value
+}
+
+func (m *mockEffectTarget) RestoreResource(resource string, amount float64) {
+	m.resources[resource] += amount
+}
+
+func (m *mockEffectTarget) ApplyStatus(_ context.Context, statusID string, durationMs int64) error {
+	m.statuses[statusID] = durationMs
+	return nil
+}
+
 func TestBaseConsumable(t *testing.T) {
 	t.Run("Creation", func(t *testing.T) {
 		t.Run("NewBaseConsumable creates with defaults", func(t *testing.T) {
@@ -288,6 +325,60 @@ func TestBaseConsumable(t *testing.T) {
 		})
 	})
 
+	t.Run("Effects", func(t *testing.T) {
+		t.Run("healing potion raises health capped at max", func(t *testing.T) {
+			cons := NewBaseConsumableWithConfig(ConsumableConfig{
+				BaseItemConfig: BaseItemConfig{Name: "Healing Potion", MaxStackSize: 5},
+				Effects:        []ConsumableEffect{NewHealEffect(50)},
+				Charges:        -1,
+			})
+			target := newMockEffectTarget(30, 100)
+
+			require.NoError(t, cons.Use(context.Background(), target))
+			require.Equal(t, 80.0, target.Health())
+
+			require.NoError(t, cons.Use(context.Background(), target))
+			require.Equal(t, 100.0, target.Health())
+		})
+
+		t.Run("applies every effect in order", func(t *testing.T) {
+			legacy := &mockConsumableEffect{}
+			cons := NewBaseConsumableWithConfig(ConsumableConfig{
+				BaseItemConfig: BaseItemConfig{Name: "Elixir"},
+				Effect:         legacy,
+				Effects: []ConsumableEffect{
+					NewRestoreResourceEffect("mana", 40),
+				},
+			})
+			cons.AddEffect(NewApplyStatusEffect("regeneration", 5000))
+			target := newMockEffectTarget(10, 10)
+
+			require.NoError(t, cons.Use(context.Background(), target))
+			require.True(t, legacy.applied)
+			require.Equal(t, 40.0, target.resources["mana"])
+			require.Equal(t, int64(5000), target.statuses["regeneration"])
+			require.Len(t, cons.Effects(), 2)
+		})
+
+		t.Run("nil target fails without consuming charge", func(t *testing.T) {
+			cons := NewBaseConsumableWithConfig(ConsumableConfig{
+				BaseItemConfig: BaseItemConfig{Name: "Healing Potion"},
+				Effects:        []ConsumableEffect{NewHealEffect(10)},
+				Charges:        2,
+			})
+
+			require.Error(t, cons.Use(context.Background(), nil))
+			require.Equal(t, 2, cons.Charges())
+		})
+
+		t.Run("built-in effects reject nil target", func(t *testing.T) {
+			ctx := context.Background()
+			require.ErrorIs(t, NewHealEffect(10).Apply(ctx, nil), ErrNoEffectTarget)
+			require.ErrorIs(t, NewRestoreResourceEffect("mana", 5).Apply(ctx, nil), ErrNoEffectTarget)
+			require.ErrorIs(t, NewApplyStatusEffect("regeneration", 100).Apply(ctx, nil), ErrNoEffectTarget)
+		})
+	})
+
 	t.Run("Clone", func(t *testing.T) {
 		t.Run("creates independent copy with reset state", func(t *testing.T) {
 			effect := &mockConsumableEffect{}
@@ -344,6 +435,25 @@ func TestBaseConsumable(t *testing.T) {
 			require.Equal(t, original.Charges(), restored.Charges())
 			require.Equal(t, original.MaxCharges(), restored.MaxCharges())
 		})
+
+		t.Run("SerializeState and DeserializeState roundtrip", func(t *testing.T) {
+			original := NewBaseConsumableWithConfig(ConsumableConfig{
+				BaseItemConfig: BaseItemConfig{ID: "cons-state", Name: "Stateful Potion", ItemType: TypeConsumable},
+				MaxCooldown:    1500,
+				EffectID:       "heal",
+				Charges:        3,
+			})
+
+			state, err := original.SerializeState()
+			require.NoError(t, err)
+
+			restored := &BaseConsumable{}
+			require.NoError(t, restored.DeserializeState(state))
+			require.Equal(t, original.ID(), restored.ID())
+			require.Equal(t, original.EffectID(), restored.EffectID())
+			require.Equal(t, original.Charges(), restored.Charges())
+			require.Equal(t, original.MaxCooldown(), restored.MaxCooldown())
+		})
 	})
 
 	t.Run("Validation", func(t *testing.T) {
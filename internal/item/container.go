@@ -66,6 +66,11 @@ func NewBaseContainerWithConfig(cfg ContainerConfig) *BaseContainer {
 
 // --- Container interface implementation ---
 
+// StackKey includes container ID - containers hold contents and never stack
+func (bc *BaseContainer) StackKey() string {
+	return bc.BaseItem.StackKey() + "|" + bc.ID()
+}
+
 func (bc *BaseContainer) Capacity() int {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
@@ -394,7 +399,7 @@ func (bc *BaseContainer) Marshal() ([]byte, error) {
 
 	// Decode to State
 	var is State
-	if err := persist.DefaultCodec().Decode(baseData, &is); err != nil {
+	if err := decodeState(baseData, &is); err != nil {
 		return nil, err
 	}
 
@@ -418,12 +423,12 @@ func (bc *BaseContainer) Marshal() ([]byte, error) {
 		ContentIDs:   contentIDs,
 	}
 
-	return persist.DefaultCodec().Encode(cs)
+	return encodeState(cs)
 }
 
 func (bc *BaseContainer) Unmarshal(data []byte) error {
 	var cs ContainerState
-	if err := persist.DefaultCodec().Decode(data, &cs); err != nil {
+	if err := decodeState(data, &cs); err != nil {
 		return fmt.Errorf("failed to decode container state: %w", err)
 	}
 
@@ -522,7 +527,7 @@ func (bc *BaseContainer) SerializeState() (map[string]any, error) {
 	}
 
 	var state map[string]any
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err := decodeState(data, &state); err != nil {
 		return nil, err
 	}
 	return state, nil
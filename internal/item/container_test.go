@@ -358,6 +358,23 @@ func TestBaseContainer(t *testing.T) {
 			require.Equal(t, original.MaxWeight(), restored.MaxWeight())
 		})
 
+		t.Run("SerializeState and DeserializeState roundtrip", func(t *testing.T) {
+			original := NewBaseContainerWithConfig(ContainerConfig{
+				BaseItemConfig: BaseItemConfig{ID: "cont-state", Name: "Stateful Bag", ItemType: TypeContainer},
+				Capacity:       8,
+				MaxWeight:      40.0,
+			})
+
+			state, err := original.SerializeState()
+			require.NoError(t, err)
+
+			restored := &BaseContainer{}
+			require.NoError(t, restored.DeserializeState(state))
+			require.Equal(t, original.ID(), restored.ID())
+			require.Equal(t, original.Capacity(), restored.Capacity())
+			require.Equal(t, original.MaxWeight(), restored.MaxWeight())
+		})
+
 		t.Run("ContentIDs returns item IDs", func(t *testing.T) {
 			cont := NewBaseContainer("", "Bag", 10)
 			cont.Add(NewBaseItem("item-1", TypeMaterial, "A"))
@@ -2,11 +2,19 @@ package item
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/davidmovas/Depthborn/internal/core/entity"
 )
 
+// ErrNoEffectTarget is returned when consumable effect is applied without target
+var ErrNoEffectTarget = errors.New("consumable effect has no target")
+
+// Living entities (and characters embedding them) are valid effect targets
+var _ EffectTarget = (*entity.BaseLiving)(nil)
+
 // --- Socket Effects ---
 
 var _ SocketEffect = (*BaseSocketEffect)(nil)
@@ -76,7 +84,7 @@ type BaseConsumableEffect struct {
 	id          string
 	description string
 	duration    int64
-	applyFn     func(ctx context.Context, target entity.Entity) error
+	applyFn     func(ctx context.Context, target EffectTarget) error
 }
 
 // ConsumableEffectConfig holds configuration for creating a BaseConsumableEffect
@@ -84,7 +92,7 @@ type ConsumableEffectConfig struct {
 	ID          string
 	Description string
 	Duration    int64
-	ApplyFn     func(ctx context.Context, target entity.Entity) error
+	ApplyFn     func(ctx context.Context, target EffectTarget) error
 }
 
 // NewBaseConsumableEffect creates a new consumable effect
@@ -105,7 +113,7 @@ func NewBaseConsumableEffectWithConfig(cfg ConsumableEffectConfig) *BaseConsumab
 	}
 }
 
-func (bce *BaseConsumableEffect) Apply(ctx context.Context, target entity.Entity) error {
+func (bce *BaseConsumableEffect) Apply(ctx context.Context, target EffectTarget) error {
 	if bce.applyFn != nil {
 		return bce.applyFn(ctx, target)
 	}
@@ -125,6 +133,114 @@ func (bce *BaseConsumableEffect) ID() string {
 	return bce.id
 }
 
+var _ ConsumableEffect = (*HealEffect)(nil)
+
+// HealEffect restores health, capped at target maximum
+type HealEffect struct {
+	amount float64
+}
+
+// NewHealEffect creates effect restoring amount of health
+func NewHealEffect(amount float64) *HealEffect {
+	return &HealEffect{amount: amount}
+}
+
+func (he *HealEffect) Apply(_ context.Context, target EffectTarget) error {
+	if target == nil {
+		return ErrNoEffectTarget
+	}
+	target.SetHealth(min(target.Health()+he.amount, target.MaxHealth()))
+	return nil
+}
+
+func (he *HealEffect) Description() string {
+	return fmt.Sprintf("Restores %.0f health", he.amount)
+}
+
+func (he *HealEffect) Duration() int64 {
+	return 0
+}
+
+// Amount returns health restored
+func (he *HealEffect) Amount() float64 {
+	return he.amount
+}
+
+var _ ConsumableEffect = (*RestoreResourceEffect)(nil)
+
+// RestoreResourceEffect restores resource such as mana or stamina
+type RestoreResourceEffect struct {
+	resource string
+	amount   float64
+}
+
+// NewRestoreResourceEffect creates effect restoring amount of resource
+func NewRestoreResourceEffect(resource string, amount float64) *RestoreResourceEffect {
+	return &RestoreResourceEffect{resource: resource, amount: amount}
+}
+
+func (re *RestoreResourceEffect) Apply(_ context.Context, target EffectTarget) error {
+	if target == nil {
+		return ErrNoEffectTarget
+	}
+	target.RestoreResource(re.resource, re.amount)
+	return nil
+}
+
+func (re *RestoreResourceEffect) Description() string {
+	return fmt.Sprintf("Restores %.0f %s", re.amount, re.resource)
+}
+
+func (re *RestoreResourceEffect) Duration() int64 {
+	return 0
+}
+
+// Resource returns restored resource name
+func (re *RestoreResourceEffect) Resource() string {
+	return re.resource
+}
+
+// Amount returns amount restored
+func (re *RestoreResourceEffect) Amount() float64 {
+	return re.amount
+}
+
+var _ ConsumableEffect = (*ApplyStatusEffect)(nil)
+
+// ApplyStatusEffect applies status effect to target
+type ApplyStatusEffect struct {
+	statusID string
+	duration int64
+}
+
+// NewApplyStatusEffect creates effect applying status for duration in milliseconds
+func NewApplyStatusEffect(statusID string, duration int64) *ApplyStatusEffect {
+	return &ApplyStatusEffect{statusID: statusID, duration: duration}
+}
+
+func (ae *ApplyStatusEffect) Apply(ctx context.Context, target EffectTarget) error {
+	if target == nil {
+		return ErrNoEffectTarget
+	}
+	return target.ApplyStatus(ctx, ae.statusID, ae.duration)
+}
+
+func (ae *ApplyStatusEffect) Description() string {
+	if ae.duration > 0 {
+		return fmt.Sprintf("Applies %s for %.1fs", ae.statusID, float64(ae.duration)/1000)
+	}
+	return fmt.Sprintf("Applies %s", ae.statusID)
+}
+
+func (ae *ApplyStatusEffect) Duration() int64 {
+	return ae.duration
+}
+
+// StatusID returns applied status identifier
+func (ae *ApplyStatusEffect) StatusID() string {
+	return ae.statusID
+}
+
 // --- Effect Registry ---
 
 // EffectRegistry stores and retrieves effects by ID (thread-safe)
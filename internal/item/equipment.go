@@ -3,6 +3,10 @@ package item
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/davidmovas/Depthborn/internal/core/attribute"
@@ -13,6 +17,10 @@ import (
 
 var _ Equipment = (*BaseEquipment)(nil)
 
+// affixValueBonus is value premium per affix as fraction of base value.
+// Affix quality scales it from 1x (worst roll) to 2x (perfect roll).
+const affixValueBonus = 0.1
+
 // BaseEquipment implements Equipment interface
 type BaseEquipment struct {
 	*BaseItem
@@ -27,6 +35,7 @@ type BaseEquipment struct {
 	socketTypes   []SocketType // Types of allowed sockets
 	affixSet      affix.Set
 	requirements  EquipRequirements
+	scaling       DurabilityScaling
 
 	// Callbacks for equip/unequip events
 	onEquipFn   func(ctx context.Context, entity entity.Entity) error
@@ -41,6 +50,34 @@ type EquipmentConfig struct {
 	SocketCount   int
 	SocketTypes   []SocketType
 	Requirements  EquipRequirements
+	Scaling       DurabilityScaling
+}
+
+// DurabilityScaling makes contributed modifiers weaken as equipment wears down.
+// Effectiveness goes from Floor at zero durability to 1 at full durability.
+type DurabilityScaling struct {
+	Enabled bool
+	// Floor is effectiveness at zero durability [0-1]
+	Floor float64
+	// Curve maps durability ratio [0-1] to progress [0-1]; nil means linear
+	Curve func(ratio float64) float64
+}
+
+// Effectiveness returns modifier multiplier for given durability ratio.
+func (s DurabilityScaling) Effectiveness(ratio float64) float64 {
+	if !s.Enabled {
+		return 1
+	}
+	ratio = clamp01(ratio)
+	if s.Curve != nil {
+		ratio = clamp01(s.Curve(ratio))
+	}
+	floor := clamp01(s.Floor)
+	return floor + (1-floor)*ratio
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
 }
 
 // NewBaseEquipment creates new equipment with minimal configuration
@@ -67,6 +104,7 @@ func NewEquipmentWithConfig(cfg EquipmentConfig) *BaseEquipment {
 		socketTypes:   cfg.SocketTypes,
 		affixSet:      affix.NewBaseSet(),
 		requirements:  cfg.Requirements,
+		scaling:       cfg.Scaling,
 	}
 
 	// Apply defaults
@@ -139,6 +177,107 @@ func (be *BaseEquipment) RemoveAttribute(modID string) bool {
 	return false
 }
 
+// --- Contributed modifiers ---
+
+var _ attribute.Modifier = (*ContributedModifier)(nil)
+
+// ContributedModifier is equipment modifier ready for attribute manager.
+// Source is equipment ID, so RemoveBySource(itemID) strips everything item added.
+type ContributedModifier struct {
+	attribute.Modifier
+
+	id     string
+	attr   attribute.Type
+	source string
+	scale  float64
+}
+
+func (m *ContributedModifier) ID() string                { return m.id }
+func (m *ContributedModifier) Source() string            { return m.source }
+func (m *ContributedModifier) Attribute() attribute.Type { return m.attr }
+
+// Value returns wrapped value scaled by durability. Overrides are never scaled.
+func (m *ContributedModifier) Value() float64 {
+	if m.Type() == attribute.ModOverride {
+		return m.Modifier.Value()
+	}
+	return m.Modifier.Value() * m.scale
+}
+
+// ContributedModifiers returns base attribute and affix modifiers (enchantments
+// included) sourced by equipment ID. Each modifier reports target via Attribute().
+// Base attributes without Attribute() target attribute named by their Source.
+// With durability scaling enabled values reflect durability at call time.
+func (be *BaseEquipment) ContributedModifiers() []attribute.Modifier {
+	be.mu.RLock()
+	defer be.mu.RUnlock()
+
+	contributed := be.contributedModifiersLocked()
+	result := make([]attribute.Modifier, len(contributed))
+	for i, mod := range contributed {
+		result[i] = mod
+	}
+	return result
+}
+
+func (be *BaseEquipment) contributedModifiersLocked() []*ContributedModifier {
+	itemID := be.ID()
+	scale := 1.0
+	if be.maxDurability > 0 {
+		scale = be.scaling.Effectiveness(be.durability / be.maxDurability)
+	}
+	contribute := func(mod attribute.Modifier, attr attribute.Type) *ContributedModifier {
+		return &ContributedModifier{Modifier: mod, id: itemID + ":" + mod.ID(), attr: attr, source: itemID, scale: scale}
+	}
+
+	result := make([]*ContributedModifier, 0, len(be.attributes))
+	for _, mod := range be.attributes {
+		attr := attribute.Type(mod.Source())
+		if targeted, ok := mod.(interface{ Attribute() attribute.Type }); ok {
+			attr = targeted.Attribute()
+		}
+		result = append(result, contribute(mod, attr))
+	}
+
+	if be.affixSet == nil {
+		return result
+	}
+
+	instances := be.affixSet.GetAll()
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].AffixID() < instances[j].AffixID()
+	})
+	affixMods := make([]*ContributedModifier, 0)
+	for _, inst := range instances {
+		rolled := inst.RolledValues()
+		for i, mod := range inst.Modifiers() {
+			if i < len(rolled) {
+				affixMods = append(affixMods, contribute(mod, rolled[i].Template.Attribute))
+			}
+		}
+	}
+	sort.SliceStable(affixMods, func(i, j int) bool {
+		return affixMods[i].Priority() < affixMods[j].Priority()
+	})
+
+	return append(result, affixMods...)
+}
+
+// DurabilityScaling returns durability scaling settings
+func (be *BaseEquipment) DurabilityScaling() DurabilityScaling {
+	be.mu.RLock()
+	defer be.mu.RUnlock()
+	return be.scaling
+}
+
+// SetDurabilityScaling changes durability scaling settings
+func (be *BaseEquipment) SetDurabilityScaling(scaling DurabilityScaling) {
+	be.mu.Lock()
+	defer be.mu.Unlock()
+	be.scaling = scaling
+	be.Touch()
+}
+
 func (be *BaseEquipment) Durability() float64 {
 	be.mu.RLock()
 	defer be.mu.RUnlock()
@@ -313,6 +452,104 @@ func (be *BaseEquipment) Affixes() affix.Set {
 	return be.affixSet
 }
 
+// EffectiveValue returns base value plus premium for each rolled affix.
+// Value stays the base; use this for vendor and trade prices.
+func (be *BaseEquipment) EffectiveValue() int64 {
+	base := be.Value()
+
+	be.mu.RLock()
+	set := be.affixSet
+	be.mu.RUnlock()
+
+	if set == nil {
+		return base
+	}
+
+	multiplier := 1.0
+	for _, inst := range set.GetAll() {
+		multiplier += affixValueBonus * (1 + inst.Quality())
+	}
+	return int64(math.Round(float64(base) * multiplier))
+}
+
+// StackKey extends base key with durability and rolled affixes,
+// so differently rolled equipment never shares a stack
+func (be *BaseEquipment) StackKey() string {
+	be.mu.RLock()
+	defer be.mu.RUnlock()
+	return fmt.Sprintf("%s|%g|%s", be.BaseItem.StackKey(), be.durability, affixSignature(be.affixSet))
+}
+
+// HasUniqueState reports whether item carries rolled affixes or socketed items.
+// Such items are one of a kind and never stack.
+func (be *BaseEquipment) HasUniqueState() bool {
+	be.mu.RLock()
+	defer be.mu.RUnlock()
+
+	if be.affixSet != nil && be.affixSet.Count() > 0 {
+		return true
+	}
+	for _, socket := range be.sockets {
+		if socket != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxStackSize is 1 while item has unique state
+func (be *BaseEquipment) MaxStackSize() int {
+	if be.HasUniqueState() {
+		return 1
+	}
+	return be.BaseItem.MaxStackSize()
+}
+
+// AddStack refuses to grow items with unique state
+func (be *BaseEquipment) AddStack(amount int) bool {
+	if be.HasUniqueState() {
+		return false
+	}
+	return be.BaseItem.AddStack(amount)
+}
+
+// CanStackWith is false whenever either item has unique state
+func (be *BaseEquipment) CanStackWith(other Item) bool {
+	if be.HasUniqueState() {
+		return false
+	}
+	if equip, ok := other.(Equipment); ok && equip.HasUniqueState() {
+		return false
+	}
+	return be.BaseItem.CanStackWith(other)
+}
+
+// affixSignature builds a stable string from affix IDs and rolled values
+func affixSignature(set affix.Set) string {
+	if set == nil {
+		return ""
+	}
+
+	instances := set.GetAll()
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].AffixID() < instances[j].AffixID()
+	})
+
+	var sb strings.Builder
+	for _, inst := range instances {
+		sb.WriteString(inst.AffixID())
+		sb.WriteByte('=')
+		for i, rm := range inst.RolledValues() {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.FormatFloat(rm.Value, 'g', -1, 64))
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
 func (be *BaseEquipment) Requirements() EquipRequirements {
 	be.mu.RLock()
 	defer be.mu.RUnlock()
@@ -351,12 +588,8 @@ func (be *BaseEquipment) OnEquip(ctx context.Context, ent entity.Entity) error {
 	be.mu.RLock()
 	onEquip := be.onEquipFn
 
-	// Collect all modifiers
-	allMods := make([]attribute.Modifier, len(be.attributes))
-	copy(allMods, be.attributes)
-	if be.affixSet != nil {
-		allMods = append(allMods, be.affixSet.AllModifiers()...)
-	}
+	// Collect all modifiers, sourced by item ID
+	allMods := be.contributedModifiersLocked()
 
 	// Collect sockets that have effects
 	socketsWithEffects := make([]Socketable, 0)
@@ -370,7 +603,7 @@ func (be *BaseEquipment) OnEquip(ctx context.Context, ent entity.Entity) error {
 	// Apply attribute modifiers to entity
 	attrMgr := ent.Attributes()
 	for _, mod := range allMods {
-		attrMgr.AddModifier(attribute.Type(mod.Source()), mod)
+		attrMgr.AddModifier(mod.Attribute(), mod)
 	}
 
 	// Apply socket effects (outside lock to avoid deadlock)
@@ -392,13 +625,6 @@ func (be *BaseEquipment) OnUnequip(ctx context.Context, ent entity.Entity) error
 	be.mu.RLock()
 	onUnequip := be.onUnequipFn
 
-	// Collect all modifiers
-	allMods := make([]attribute.Modifier, len(be.attributes))
-	copy(allMods, be.attributes)
-	if be.affixSet != nil {
-		allMods = append(allMods, be.affixSet.AllModifiers()...)
-	}
-
 	// Collect sockets that have effects
 	socketsWithEffects := make([]Socketable, 0)
 	for _, socket := range be.sockets {
@@ -408,11 +634,8 @@ func (be *BaseEquipment) OnUnequip(ctx context.Context, ent entity.Entity) error
 	}
 	be.mu.RUnlock()
 
-	// Remove attribute modifiers from entity
-	attrMgr := ent.Attributes()
-	for _, mod := range allMods {
-		attrMgr.RemoveModifier(attribute.Type(mod.Source()), mod.ID())
-	}
+	// Remove every modifier item contributed
+	ent.Attributes().RemoveBySource(be.ID())
 
 	// Remove socket effects (outside lock to avoid deadlock)
 	for _, socket := range socketsWithEffects {
@@ -473,6 +696,7 @@ func (be *BaseEquipment) Clone() any {
 		socketTypes:   make([]SocketType, len(be.socketTypes)),
 		affixSet:      affix.NewBaseSet(),
 		requirements:  be.requirements, // Requirements typically shared
+		scaling:       be.scaling,
 	}
 
 	copy(clone.attributes, be.attributes)
@@ -508,8 +732,20 @@ type EquipmentState struct {
 	SocketTypes   []string           `msgpack:"socket_types"`
 	SocketIDs     []string           `msgpack:"socket_ids"`
 	AffixIDs      []string           `msgpack:"affix_ids"`
+	Affixes       []AffixState       `msgpack:"affixes,omitempty"`
 	ReqLevel      int                `msgpack:"req_level"`
 	ReqAttrs      map[string]float64 `msgpack:"req_attrs"`
+	// Durability scaling curve is not persisted; restored items scale linearly
+	ScaleByDurability bool    `msgpack:"scale_by_durability,omitempty"`
+	DurabilityFloor   float64 `msgpack:"durability_floor,omitempty"`
+}
+
+// AffixState holds rolled affix instance of serialized equipment
+type AffixState struct {
+	AffixID string                 `msgpack:"affix_id"`
+	Type    string                 `msgpack:"type"`
+	Group   string                 `msgpack:"group"`
+	Rolls   []affix.RolledModifier `msgpack:"rolls"`
 }
 
 func (be *BaseEquipment) Marshal() ([]byte, error) {
@@ -523,7 +759,7 @@ func (be *BaseEquipment) Marshal() ([]byte, error) {
 	}
 
 	var itemState State
-	if err := persist.DefaultCodec().Decode(baseData, &itemState); err != nil {
+	if err := decodeState(baseData, &itemState); err != nil {
 		return nil, err
 	}
 
@@ -541,11 +777,18 @@ func (be *BaseEquipment) Marshal() ([]byte, error) {
 		}
 	}
 
-	// Build affix ID list
+	// Build affix ID list and rolled affixes
 	var affixIDs []string
+	var affixes []AffixState
 	if be.affixSet != nil {
 		for _, a := range be.affixSet.GetAll() {
 			affixIDs = append(affixIDs, a.AffixID())
+			affixes = append(affixes, AffixState{
+				AffixID: a.AffixID(),
+				Type:    string(a.Type()),
+				Group:   a.Group(),
+				Rolls:   a.RolledValues(),
+			})
 		}
 	}
 
@@ -571,16 +814,20 @@ func (be *BaseEquipment) Marshal() ([]byte, error) {
 		SocketTypes:   socketTypes,
 		SocketIDs:     socketIDs,
 		AffixIDs:      affixIDs,
+		Affixes:       affixes,
 		ReqLevel:      reqLevel,
 		ReqAttrs:      reqAttrs,
+
+		ScaleByDurability: be.scaling.Enabled,
+		DurabilityFloor:   be.scaling.Floor,
 	}
 
-	return persist.DefaultCodec().Encode(state)
+	return encodeState(state)
 }
 
 func (be *BaseEquipment) Unmarshal(data []byte) error {
 	var state EquipmentState
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err := decodeState(data, &state); err != nil {
 		return fmt.Errorf("failed to decode equipment state: %w", err)
 	}
 
@@ -603,6 +850,7 @@ func (be *BaseEquipment) Unmarshal(data []byte) error {
 	be.slot = EquipmentSlot(state.Slot)
 	be.durability = state.Durability
 	be.maxDurability = state.MaxDurability
+	be.scaling = DurabilityScaling{Enabled: state.ScaleByDurability, Floor: state.DurabilityFloor}
 
 	// Restore socket types
 	be.socketTypes = make([]SocketType, len(state.SocketTypes))
@@ -613,8 +861,17 @@ func (be *BaseEquipment) Unmarshal(data []byte) error {
 	// Initialize empty sockets (actual items restored separately)
 	be.sockets = make([]Socketable, len(state.SocketIDs))
 
-	// Initialize affix set (actual affixes restored separately)
+	// Restore rolled affixes against registered templates; tampered rolls are clamped
 	be.affixSet = affix.NewBaseSet()
+	for _, a := range state.Affixes {
+		instance, _, err := affix.NewValidatedInstanceFromData(affix.GlobalRegistry(), a.AffixID, a.Rolls, affix.RollClamp)
+		if err != nil {
+			return fmt.Errorf("failed to restore affix %s: %w", a.AffixID, err)
+		}
+		if err := be.affixSet.Add(instance); err != nil {
+			return fmt.Errorf("failed to restore affix %s: %w", a.AffixID, err)
+		}
+	}
 
 	// Restore requirements
 	if state.ReqAttrs != nil {
@@ -665,7 +922,7 @@ func (be *BaseEquipment) SerializeState() (map[string]any, error) {
 	}
 
 	var state map[string]any
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err := decodeState(data, &state); err != nil {
 		return nil, err
 	}
 	return state, nil
@@ -6,6 +6,7 @@ import (
 
 	"github.com/davidmovas/Depthborn/internal/core/attribute"
 	"github.com/davidmovas/Depthborn/internal/core/entity"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
 	"github.com/stretchr/testify/require"
 )
 
@@ -261,6 +262,185 @@ func TestBaseEquipment(t *testing.T) {
 			attrs := equip.Attributes()
 			require.Empty(t, attrs)
 		})
+
+		t.Run("ContributedModifiers are sourced by item ID", func(t *testing.T) {
+			equip := NewBaseEquipment("sword-1", TypeWeaponMelee, "Sword", SlotMainHand)
+			equip.AddAttribute(attribute.NewModifier("base-str", attribute.ModFlat, 10, string(attribute.AttrStrength)))
+
+			sharp := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+				AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+			require.NoError(t, equip.Affixes().Add(affix.NewBaseInstance(sharp, []affix.RolledModifier{{Template: sharp.Modifiers()[0], Value: 7}})))
+			blessed := affix.NewBaseAffix("blessed", "Blessed", affix.TypeEnchant).
+				AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrDexterity, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 5})
+			require.NoError(t, equip.Affixes().Add(affix.NewBaseInstance(blessed, []affix.RolledModifier{{Template: blessed.Modifiers()[0], Value: 3}})))
+
+			mods := equip.ContributedModifiers()
+			require.Len(t, mods, 3)
+
+			targets := make(map[attribute.Type]float64)
+			for _, mod := range mods {
+				require.Equal(t, "sword-1", mod.Source())
+				contributed, ok := mod.(*ContributedModifier)
+				require.True(t, ok)
+				targets[contributed.Attribute()] = mod.Value()
+			}
+			require.Equal(t, map[attribute.Type]float64{
+				attribute.AttrStrength:       10,
+				attribute.AttrPhysicalDamage: 7,
+				attribute.AttrDexterity:      3,
+			}, targets)
+
+			attrs := attribute.NewManager()
+			attrs.SetBase(attribute.AttrStrength, 5)
+			for _, mod := range mods {
+				attrs.AddModifier(mod.(*ContributedModifier).Attribute(), mod)
+			}
+			require.Equal(t, 15.0, attrs.Get(attribute.AttrStrength))
+			require.Equal(t, 7.0, attrs.Get(attribute.AttrPhysicalDamage))
+
+			require.Equal(t, 3, attrs.RemoveBySource("sword-1"))
+			require.Equal(t, 5.0, attrs.Get(attribute.AttrStrength))
+			require.Equal(t, 0.0, attrs.Get(attribute.AttrPhysicalDamage))
+			require.Equal(t, 0.0, attrs.Get(attribute.AttrDexterity))
+		})
+
+		t.Run("ContributedModifiers scale with durability", func(t *testing.T) {
+			newSword := func(scaling DurabilityScaling) *BaseEquipment {
+				equip := NewEquipmentWithConfig(EquipmentConfig{
+					BaseItemConfig: BaseItemConfig{ID: "sword-1", Name: "Sword", ItemType: TypeWeaponMelee},
+					Slot:           SlotMainHand,
+					MaxDurability:  100,
+					Scaling:        scaling,
+				})
+				equip.AddAttribute(attribute.NewModifier("base-str", attribute.ModFlat, 10, string(attribute.AttrStrength)))
+				equip.AddAttribute(attribute.NewModifier("base-cap", attribute.ModOverride, 50, string(attribute.AttrDexterity)))
+				return equip
+			}
+			values := func(equip *BaseEquipment) []float64 {
+				mods := equip.ContributedModifiers()
+				result := make([]float64, len(mods))
+				for i, mod := range mods {
+					result[i] = mod.Value()
+				}
+				return result
+			}
+
+			// Off by default
+			plain := newSword(DurabilityScaling{})
+			plain.SetDurability(50)
+			require.Equal(t, []float64{10, 50}, values(plain))
+
+			linear := newSword(DurabilityScaling{Enabled: true})
+			linear.SetDurability(50)
+			require.Equal(t, []float64{5, 50}, values(linear))
+			linear.SetDurability(0)
+			require.Equal(t, []float64{0, 50}, values(linear))
+			linear.RepairFull()
+			require.Equal(t, []float64{10, 50}, values(linear))
+
+			floored := newSword(DurabilityScaling{Enabled: true, Floor: 0.5})
+			floored.SetDurability(50)
+			require.InDelta(t, 7.5, values(floored)[0], 1e-9)
+			floored.SetDurability(0)
+			require.InDelta(t, 5.0, values(floored)[0], 1e-9)
+
+			curved := newSword(DurabilityScaling{Enabled: true, Curve: func(r float64) float64 { return r * r }})
+			curved.SetDurability(50)
+			require.InDelta(t, 2.5, values(curved)[0], 1e-9)
+			require.True(t, curved.Clone().(*BaseEquipment).DurabilityScaling().Enabled)
+
+			data, err := floored.Marshal()
+			require.NoError(t, err)
+			restored := &BaseEquipment{}
+			require.NoError(t, restored.Unmarshal(data))
+			require.Equal(t, DurabilityScaling{Enabled: true, Floor: 0.5}, restored.DurabilityScaling())
+		})
+	})
+
+	t.Run("StackKey", func(t *testing.T) {
+		newRolled := func(id string, value float64) *BaseEquipment {
+			equip := NewBaseEquipment(id, TypeWeaponMelee, "Iron Sword", SlotMainHand)
+			template := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+				AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+			rolled := []affix.RolledModifier{{Template: template.Modifiers()[0], Value: value}}
+			require.NoError(t, equip.Affixes().Add(affix.NewBaseInstance(template, rolled)))
+			return equip
+		}
+
+		t.Run("same rolls share key", func(t *testing.T) {
+			require.Equal(t, newRolled("a", 5).StackKey(), newRolled("b", 5).StackKey())
+		})
+
+		t.Run("different rolls differ", func(t *testing.T) {
+			require.NotEqual(t, newRolled("a", 5).StackKey(), newRolled("b", 7).StackKey())
+		})
+
+		t.Run("durability is part of key", func(t *testing.T) {
+			worn := newRolled("a", 5)
+			worn.DamageItem(10)
+			require.NotEqual(t, worn.StackKey(), newRolled("b", 5).StackKey())
+		})
+	})
+
+	t.Run("UniqueState", func(t *testing.T) {
+		newPlain := func(id string) *BaseEquipment {
+			return NewEquipmentWithConfig(EquipmentConfig{
+				BaseItemConfig: BaseItemConfig{ID: id, Name: "Arrow", ItemType: TypeWeaponRanged, MaxStackSize: 10},
+				Slot:           SlotOffHand,
+				SocketCount:    1,
+			})
+		}
+
+		t.Run("plain equipment stacks", func(t *testing.T) {
+			a, b := newPlain("a"), newPlain("b")
+			require.False(t, a.HasUniqueState())
+			require.Equal(t, 10, a.MaxStackSize())
+			require.True(t, a.CanStackWith(b))
+		})
+
+		t.Run("socketed item is unique", func(t *testing.T) {
+			a, b := newPlain("a"), newPlain("b")
+			require.NoError(t, a.SetSocket(0, NewBaseSocketable("gem-1", TypeGem, "Ruby", SocketTypeUniversal)))
+
+			require.True(t, a.HasUniqueState())
+			require.Equal(t, 1, a.MaxStackSize())
+			require.False(t, a.AddStack(1))
+			require.False(t, a.CanStackWith(b))
+			require.False(t, b.CanStackWith(a))
+		})
+	})
+
+	t.Run("EffectiveValue", func(t *testing.T) {
+		newSword := func() *BaseEquipment {
+			return NewEquipmentWithConfig(EquipmentConfig{
+				BaseItemConfig: BaseItemConfig{Name: "Iron Sword", ItemType: TypeWeaponMelee, Value: 1000},
+				Slot:           SlotMainHand,
+			})
+		}
+		addAffix := func(equip *BaseEquipment, id string, affixType affix.Type, value float64) {
+			template := affix.NewBaseAffix(id, id, affixType).
+				AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+			rolled := []affix.RolledModifier{{Template: template.Modifiers()[0], Value: value}}
+			require.NoError(t, equip.Affixes().Add(affix.NewBaseInstance(template, rolled)))
+		}
+
+		t.Run("plain item is worth base value", func(t *testing.T) {
+			require.Equal(t, int64(1000), newSword().EffectiveValue())
+		})
+
+		t.Run("affixes add premium scaled by quality", func(t *testing.T) {
+			poor := newSword()
+			addAffix(poor, "sharp", affix.TypePrefix, 1)
+
+			perfect := newSword()
+			addAffix(perfect, "sharp", affix.TypePrefix, 10)
+			addAffix(perfect, "keen", affix.TypePrefix, 10)
+			addAffix(perfect, "of_power", affix.TypeSuffix, 10)
+
+			require.Equal(t, int64(1100), poor.EffectiveValue())
+			require.Equal(t, int64(1600), perfect.EffectiveValue())
+			require.Equal(t, int64(1000), perfect.Value())
+		})
 	})
 
 	t.Run("Clone", func(t *testing.T) {
@@ -318,6 +498,25 @@ func TestBaseEquipment(t *testing.T) {
 			require.Equal(t, original.MaxDurability(), restored.MaxDurability())
 			require.Equal(t, original.SocketCount(), restored.SocketCount())
 		})
+
+		t.Run("SerializeState and DeserializeState roundtrip", func(t *testing.T) {
+			original := NewEquipmentWithConfig(EquipmentConfig{
+				BaseItemConfig: BaseItemConfig{ID: "equip-state", Name: "Stateful Helm", ItemType: TypeArmorHead},
+				Slot:           SlotHead,
+				MaxDurability:  80,
+			})
+			original.DamageItem(30)
+
+			state, err := original.SerializeState()
+			require.NoError(t, err)
+
+			restored := &BaseEquipment{}
+			require.NoError(t, restored.DeserializeState(state))
+			require.Equal(t, original.ID(), restored.ID())
+			require.Equal(t, original.Slot(), restored.Slot())
+			require.Equal(t, original.Durability(), restored.Durability())
+			require.Equal(t, original.MaxDurability(), restored.MaxDurability())
+		})
 	})
 
 	t.Run("Validation", func(t *testing.T) {
@@ -0,0 +1,59 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/davidmovas/Depthborn/pkg/persist"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+// ErrUnsupportedFormat is returned when item blob has unknown format version
+var ErrUnsupportedFormat = errors.New("unsupported item format version")
+
+// =============================================================================
+// FORMAT VERSIONING
+// =============================================================================
+
+const (
+	// formatMagic opens versioned blobs. 0xc1 is never emitted by msgpack,
+	// so blobs without it are legacy (v0) encoded state.
+	formatMagic byte = 0xc1
+
+	// FormatVersion is version written by Marshal
+	FormatVersion byte = 1
+)
+
+// encodeState encodes state prefixed with format header
+func encodeState(state any) ([]byte, error) {
+	data, err := persist.DefaultCodec().Encode(state)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{formatMagic, FormatVersion}, data...), nil
+}
+
+// decodeState decodes state written by encodeState or legacy unversioned blob
+func decodeState(data []byte, state any) error {
+	version, payload := FormatVersionOf(data)
+
+	switch version {
+	case 0, 1:
+		// v1 only added the header; v0 payload layout is identical
+		return persist.DefaultCodec().Decode(payload, state)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedFormat, version)
+	}
+}
+
+// FormatVersionOf reports format version of marshaled item and its payload.
+// Legacy blobs without header are version 0.
+func FormatVersionOf(data []byte) (byte, []byte) {
+	if len(data) < 2 || data[0] != formatMagic {
+		return 0, data
+	}
+	return data[1], data[2:]
+}
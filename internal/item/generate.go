@@ -0,0 +1,76 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+// ErrNotEquippable is returned when generating equipment from non-equippable base
+var ErrNotEquippable = errors.New("item type is not equippable")
+
+// defaultSlots maps equippable item types to slot used for generated items
+var defaultSlots = map[Type]EquipmentSlot{
+	TypeWeaponMelee:     SlotMainHand,
+	TypeWeaponRanged:    SlotTwoHand,
+	TypeWeaponMagic:     SlotMainHand,
+	TypeArmorHead:       SlotHead,
+	TypeArmorChest:      SlotChest,
+	TypeArmorLegs:       SlotLegs,
+	TypeArmorFeet:       SlotFeet,
+	TypeArmorHands:      SlotHands,
+	TypeAccessoryRing:   SlotRing1,
+	TypeAccessoryAmulet: SlotAmulet,
+	TypeAccessoryBelt:   SlotBelt,
+}
+
+// Generate creates equipment from base config at level and rarity and attaches
+// affix set rolled from pool, sized by rarity (see affix.DefaultLimits).
+// Same rng seed, base and pool produce same affixes and rolls; nil rng uses
+// global source, nil pool yields equipment without affixes.
+func Generate(base BaseItemConfig, level, rarity int, pool *affix.BasePool, rng *rand.Rand) (Item, error) {
+	slot, ok := defaultSlots[base.ItemType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotEquippable, base.ItemType)
+	}
+
+	base.Level = level
+	base.Rarity = Rarity(rarity)
+	equip := NewEquipmentWithConfig(EquipmentConfig{
+		BaseItemConfig: base,
+		Slot:           slot,
+		Requirements:   NewSimpleRequirements(level, nil),
+	})
+
+	if pool == nil {
+		return equip, nil
+	}
+
+	instances, err := affix.NewBaseGenerator(pool).Generate(affix.GenerateContext{
+		RollContext: affix.RollContext{
+			ItemType:   string(base.ItemType),
+			ItemLevel:  level,
+			ItemSlot:   string(slot),
+			ItemRarity: rarity,
+			Rng:        rng,
+		},
+		QualityBias: 0.5,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll affixes: %w", err)
+	}
+
+	for _, instance := range instances {
+		if err := equip.Affixes().Add(instance); err != nil {
+			return nil, fmt.Errorf("failed to attach affix %s: %w", instance.AffixID(), err)
+		}
+	}
+
+	return equip, nil
+}
@@ -0,0 +1,106 @@
+package item
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+)
+
+func TestGenerate(t *testing.T) {
+	pool := affix.NewBasePool()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		for _, affixType := range []affix.Type{affix.TypePrefix, affix.TypeSuffix} {
+			pool.Add(affix.NewBaseAffix(string(affixType)+"-"+id, id, affixType).
+				WithGroup(string(affixType) + "-" + id).
+				WithBaseWeight(100).
+				AddModifier(affix.ModifierTemplate{
+					Attribute: attribute.AttrPhysicalDamage,
+					ModType:   attribute.ModFlat,
+					MinValue:  1,
+					MaxValue:  100,
+				}))
+		}
+	}
+	base := BaseItemConfig{Name: "Iron Sword", ItemType: TypeWeaponMelee}
+
+	type rolled struct {
+		ID     string
+		Values []float64
+	}
+	describe := func(itm Item) []rolled {
+		equip := itm.(*BaseEquipment)
+		var result []rolled
+		for _, inst := range equip.Affixes().GetAll() {
+			r := rolled{ID: inst.AffixID()}
+			for _, v := range inst.RolledValues() {
+				r.Values = append(r.Values, v.Value)
+			}
+			result = append(result, r)
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+		return result
+	}
+	generate := func(t *testing.T, rarity int, seed uint64) Item {
+		itm, err := Generate(base, 30, rarity, pool, rand.New(rand.NewPCG(seed, seed)))
+		require.NoError(t, err)
+		return itm
+	}
+
+	t.Run("same seed reproduces item", func(t *testing.T) {
+		for rarity := int(RarityCommon); rarity <= int(RarityMythic); rarity++ {
+			first, second := generate(t, rarity, 42), generate(t, rarity, 42)
+			require.Equal(t, describe(first), describe(second), "rarity %d", rarity)
+		}
+	})
+
+	t.Run("affix count follows rarity", func(t *testing.T) {
+		for rarity := int(RarityCommon); rarity <= int(RarityMythic); rarity++ {
+			limits := affix.DefaultLimits(rarity)
+			for seed := uint64(0); seed < 10; seed++ {
+				itm := generate(t, rarity, seed)
+				equip := itm.(*BaseEquipment)
+				prefixes := len(equip.Affixes().GetByType(affix.TypePrefix))
+				suffixes := len(equip.Affixes().GetByType(affix.TypeSuffix))
+				require.GreaterOrEqual(t, prefixes, limits.MinPrefixes)
+				require.LessOrEqual(t, prefixes, limits.MaxPrefixes)
+				require.GreaterOrEqual(t, suffixes, limits.MinSuffixes)
+				require.LessOrEqual(t, suffixes, limits.MaxSuffixes)
+			}
+		}
+	})
+
+	t.Run("applies level and rarity", func(t *testing.T) {
+		itm := generate(t, int(RarityEpic), 7)
+		require.Equal(t, 30, itm.Level())
+		require.Equal(t, RarityEpic, itm.Rarity())
+		require.Equal(t, SlotMainHand, itm.(*BaseEquipment).Slot())
+		require.Equal(t, 30, itm.(*BaseEquipment).Requirements().Level())
+	})
+
+	t.Run("different seeds vary", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for seed := uint64(0); seed < 10; seed++ {
+			rolls := describe(generate(t, int(RarityMythic), seed))
+			require.Len(t, rolls, 6)
+			seen[fmt.Sprint(rolls)] = true
+		}
+		require.Greater(t, len(seen), 1)
+	})
+
+	t.Run("rejects non-equippable base", func(t *testing.T) {
+		_, err := Generate(BaseItemConfig{Name: "Ore", ItemType: TypeMaterial}, 1, 2, pool, nil)
+		require.ErrorIs(t, err, ErrNotEquippable)
+	})
+
+	t.Run("nil pool yields no affixes", func(t *testing.T) {
+		itm, err := Generate(base, 5, int(RarityLegendary), nil, nil)
+		require.NoError(t, err)
+		require.Zero(t, itm.(*BaseEquipment).Affixes().Count())
+	})
+}
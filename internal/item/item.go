@@ -8,6 +8,7 @@ import (
 	"github.com/davidmovas/Depthborn/internal/core/types"
 	"github.com/davidmovas/Depthborn/internal/infra"
 	"github.com/davidmovas/Depthborn/internal/item/affix"
+	"github.com/davidmovas/Depthborn/pkg/persist"
 )
 
 // Item represents any game item
@@ -57,6 +58,10 @@ type Item interface {
 	// CanStackWith returns true if items can stack together
 	CanStackWith(other Item) bool
 
+	// StackKey returns identity used for stack matching.
+	// Items stack only when their keys are equal.
+	StackKey() string
+
 	// Value returns vendor sell value
 	Value() int64
 
@@ -80,8 +85,14 @@ type Item interface {
 
 	// IsTradeable returns true if item can be traded
 	IsTradeable() bool
+
+	// WeightExempt returns true if item doesn't count toward carry weight
+	WeightExempt() bool
 }
 
+// TagWeightExempt marks items that don't count toward carry weight
+const TagWeightExempt = "weight_exempt"
+
 // Type categorizes items
 type Type string
 
@@ -167,6 +178,12 @@ type Equipment interface {
 	// Affixes returns item affixes
 	Affixes() affix.Set
 
+	// EffectiveValue returns value including premium for rolled affixes
+	EffectiveValue() int64
+
+	// HasUniqueState returns true if item has affixes or socketed items (never stacks)
+	HasUniqueState() bool
+
 	// Requirements returns equip requirements
 	Requirements() EquipRequirements
 
@@ -246,11 +263,11 @@ type SocketEffect interface {
 type Consumable interface {
 	Item
 
-	// Use consumes item and applies effect
-	Use(ctx context.Context, user entity.Entity) error
+	// Use consumes item and applies its effects to target
+	Use(ctx context.Context, target EffectTarget) error
 
-	// CanUse checks if item can be used by entity
-	CanUse(user entity.Entity) bool
+	// CanUse checks if item can be used on target
+	CanUse(target EffectTarget) bool
 
 	// Cooldown returns remaining cooldown in milliseconds
 	Cooldown() int64
@@ -264,8 +281,8 @@ type Consumable interface {
 
 // ConsumableEffect describes what happens when consumable is used
 type ConsumableEffect interface {
-	// Apply applies consumable effect to entity
-	Apply(ctx context.Context, target entity.Entity) error
+	// Apply applies consumable effect to target
+	Apply(ctx context.Context, target EffectTarget) error
 
 	// Description returns effect description
 	Description() string
@@ -274,6 +291,24 @@ type ConsumableEffect interface {
 	Duration() int64
 }
 
+// EffectTarget is what consumable effects act upon
+type EffectTarget interface {
+	// Health returns current health
+	Health() float64
+
+	// MaxHealth returns maximum health
+	MaxHealth() float64
+
+	// SetHealth updates current health
+	SetHealth(value float64)
+
+	// RestoreResource adds amount to resource (mana, stamina, ...)
+	RestoreResource(resource string, amount float64)
+
+	// ApplyStatus applies status effect for duration in milliseconds
+	ApplyStatus(ctx context.Context, statusID string, durationMs int64) error
+}
+
 // Container represents items that hold other items
 type Container interface {
 	Item
@@ -299,3 +334,61 @@ type Container interface {
 	// Weight returns total weight of container and contents
 	Weight() float64
 }
+
+// =============================================================================
+// PERSISTED TYPES
+// =============================================================================
+
+// Registered type names, used as discriminator for self-contained item payloads
+const (
+	PersistBaseItem   = "item.base"
+	PersistEquipment  = "item.equipment"
+	PersistConsumable = "item.consumable"
+	PersistSocketable = "item.socketable"
+	PersistContainer  = "item.container"
+)
+
+func init() {
+	factories := map[string]func() any{
+		PersistBaseItem:   func() any { return &BaseItem{} },
+		PersistEquipment:  func() any { return &BaseEquipment{} },
+		PersistConsumable: func() any { return &BaseConsumable{} },
+		PersistSocketable: func() any { return &BaseSocketable{} },
+		PersistContainer:  func() any { return &BaseContainer{} },
+	}
+	for name, factory := range factories {
+		if err := persist.RegisterType(name, factory); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// MarshalBinary implements persist.Marshaler
+func (i *BaseItem) MarshalBinary() ([]byte, error) { return i.Marshal() }
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (i *BaseItem) UnmarshalBinary(data []byte) error { return i.Unmarshal(data) }
+
+// MarshalBinary implements persist.Marshaler
+func (be *BaseEquipment) MarshalBinary() ([]byte, error) { return be.Marshal() }
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (be *BaseEquipment) UnmarshalBinary(data []byte) error { return be.Unmarshal(data) }
+
+// MarshalBinary implements persist.Marshaler
+func (bc *BaseConsumable) MarshalBinary() ([]byte, error) { return bc.Marshal() }
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (bc *BaseConsumable) UnmarshalBinary(data []byte) error { return bc.Unmarshal(data) }
+
+// MarshalBinary implements persist.Marshaler
+func (bs *BaseSocketable) MarshalBinary() ([]byte, error) { return bs.Marshal() }
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (bs *BaseSocketable) UnmarshalBinary(data []byte) error { return bs.Unmarshal(data) }
+
+// MarshalBinary implements persist.Marshaler
+func (bc *BaseContainer) MarshalBinary() ([]byte, error) { return bc.Marshal() }
+
+// UnmarshalBinary implements persist.Unmarshaler
+func (bc *BaseContainer) UnmarshalBinary(data []byte) error { return bc.Unmarshal(data) }
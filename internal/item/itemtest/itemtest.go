@@ -0,0 +1,29 @@
+// Package itemtest provides item fixtures shared by tests of item containers.
+package itemtest
+
+import (
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/item"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+)
+
+// RolledEquipment creates main hand sword with one "sharp" prefix rolled to
+// value. Its config allows stacks of 10, so containers must rely on stack
+// key to keep rolled copies apart.
+func RolledEquipment(id string, value float64) *item.BaseEquipment {
+	equip := item.NewEquipmentWithConfig(item.EquipmentConfig{
+		BaseItemConfig: item.BaseItemConfig{
+			ID:           id,
+			Name:         "Iron Sword",
+			ItemType:     item.TypeWeaponMelee,
+			Weight:       1.0,
+			MaxStackSize: 10,
+		},
+		Slot: item.SlotMainHand,
+	})
+	template := affix.NewBaseAffix("sharp", "Sharp", affix.TypePrefix).
+		AddModifier(affix.ModifierTemplate{Attribute: attribute.AttrPhysicalDamage, ModType: attribute.ModFlat, MinValue: 1, MaxValue: 10})
+	rolled := []affix.RolledModifier{{Template: template.Modifiers()[0], Value: value}}
+	_ = equip.Affixes().Add(affix.NewBaseInstance(template, rolled))
+	return equip
+}
@@ -102,6 +102,13 @@ func (bs *BaseSocketable) EffectID() string {
 	return bs.effectID
 }
 
+// StackKey extends base key with socket type, tier and effect
+func (bs *BaseSocketable) StackKey() string {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+	return fmt.Sprintf("%s|%s|%d|%s", bs.BaseItem.StackKey(), bs.socketType, bs.tier, bs.effectID)
+}
+
 // Tier returns the power tier
 func (bs *BaseSocketable) Tier() int {
 	bs.mu.RLock()
@@ -279,7 +286,7 @@ func (bs *BaseSocketable) Marshal() ([]byte, error) {
 
 	// Decode to State
 	var is State
-	if err := persist.DefaultCodec().Decode(baseData, &is); err != nil {
+	if err := decodeState(baseData, &is); err != nil {
 		return nil, err
 	}
 
@@ -303,12 +310,12 @@ func (bs *BaseSocketable) Marshal() ([]byte, error) {
 		Modifiers:  modStates,
 	}
 
-	return persist.DefaultCodec().Encode(ss)
+	return encodeState(ss)
 }
 
 func (bs *BaseSocketable) Unmarshal(data []byte) error {
 	var ss SocketableState
-	if err := persist.DefaultCodec().Decode(data, &ss); err != nil {
+	if err := decodeState(data, &ss); err != nil {
 		return fmt.Errorf("failed to decode socketable state: %w", err)
 	}
 
@@ -385,7 +392,7 @@ func (bs *BaseSocketable) SerializeState() (map[string]any, error) {
 	}
 
 	var state map[string]any
-	if err := persist.DefaultCodec().Decode(data, &state); err != nil {
+	if err := decodeState(data, &state); err != nil {
 		return nil, err
 	}
 	return state, nil
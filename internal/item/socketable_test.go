@@ -283,6 +283,25 @@ func TestBaseSocketable(t *testing.T) {
 				require.Equal(t, origMod.Priority(), resMod.Priority())
 			}
 		})
+
+		t.Run("SerializeState and DeserializeState roundtrip", func(t *testing.T) {
+			original := NewBaseSocketableWithConfig(SocketableConfig{
+				BaseItemConfig: BaseItemConfig{ID: "sock-state", Name: "Stateful Rune", ItemType: TypeRune},
+				SocketType:     SocketTypeRune,
+				Tier:           2,
+				Modifiers:      []attribute.Modifier{attribute.NewModifier("dex", attribute.ModFlat, 4, string(attribute.AttrDexterity))},
+			})
+
+			state, err := original.SerializeState()
+			require.NoError(t, err)
+
+			restored := &BaseSocketable{}
+			require.NoError(t, restored.DeserializeState(state))
+			require.Equal(t, original.ID(), restored.ID())
+			require.Equal(t, original.SocketType(), restored.SocketType())
+			require.Equal(t, original.Tier(), restored.Tier())
+			require.Len(t, restored.Modifiers(), 1)
+		})
 	})
 
 	t.Run("Validation", func(t *testing.T) {
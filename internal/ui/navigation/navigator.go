@@ -2,12 +2,15 @@ package navigation
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
 	ErrNoScreen      = errors.New("no screen active")
 	ErrCannotClose   = errors.New("screen cannot be closed")
 	ErrScreenUnknown = errors.New("screen not registered")
+	ErrInvalidRoute  = errors.New("invalid route")
 )
 
 // Navigator manages screen navigation and lifecycle.
@@ -120,6 +123,50 @@ func (n *Navigator) Reset(screenID string, params map[string]any) error {
 	return n.Open(screenID, params)
 }
 
+// Navigate replaces the stack with the screens of a slash-separated route,
+// e.g. "main_menu/character/inventory", so Back unwinds segment by segment.
+// Every segment is validated before the stack is touched; params go to the
+// last screen only.
+func (n *Navigator) Navigate(path string, params map[string]any) error {
+	segments, err := n.parseRoute(path)
+	if err != nil {
+		return err
+	}
+
+	n.Clear()
+	for i, screenID := range segments {
+		var p map[string]any
+		if i == len(segments)-1 {
+			p = params
+		}
+		if err = n.Open(screenID, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseRoute splits route into registered screen IDs.
+func (n *Navigator) parseRoute(path string) ([]string, error) {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty route", ErrInvalidRoute)
+	}
+
+	segments := strings.Split(trimmed, "/")
+	for _, screenID := range segments {
+		if screenID == "" {
+			return nil, fmt.Errorf("%w: empty segment in %q", ErrInvalidRoute, path)
+		}
+		if !n.registry.Has(screenID) {
+			return nil, fmt.Errorf("%w: %q in route %q", ErrScreenUnknown, screenID, path)
+		}
+	}
+
+	return segments, nil
+}
+
 // Clear removes all screens from the stack.
 func (n *Navigator) Clear() {
 	for !n.stack.IsEmpty() {
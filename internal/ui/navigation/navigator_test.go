@@ -0,0 +1,66 @@
+package navigation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type routeScreen struct {
+	*BaseScreen
+
+	params map[string]any
+}
+
+func (s *routeScreen) OnEnter(params map[string]any) { s.params = params }
+
+func TestNavigatorNavigate(t *testing.T) {
+	newNavigator := func() *Navigator {
+		nav := NewNavigator()
+		for _, id := range []string{"main_menu", "character", "inventory"} {
+			nav.Register(id, func() Screen { return &routeScreen{BaseScreen: NewBaseScreen(id)} })
+		}
+		return nav
+	}
+
+	t.Run("multi-segment route", func(t *testing.T) {
+		nav := newNavigator()
+		require.NoError(t, nav.Open("character", nil))
+
+		err := nav.Navigate("main_menu/character/inventory", map[string]any{"tab": 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, nav.StackSize())
+		top := nav.Current().(*routeScreen)
+		assert.Equal(t, "inventory", top.ID())
+		assert.Equal(t, map[string]any{"tab": 2}, top.params)
+
+		require.NoError(t, nav.Back())
+		assert.Equal(t, "character", nav.Current().ID())
+		assert.Nil(t, nav.Current().(*routeScreen).params)
+		require.NoError(t, nav.Back())
+		assert.Equal(t, "main_menu", nav.Current().ID())
+		assert.False(t, nav.CanGoBack())
+	})
+
+	t.Run("unknown segment", func(t *testing.T) {
+		nav := newNavigator()
+		require.NoError(t, nav.Open("main_menu", nil))
+
+		err := nav.Navigate("main_menu/skills/inventory", nil)
+		require.ErrorIs(t, err, ErrScreenUnknown)
+		assert.Contains(t, err.Error(), `"skills"`)
+
+		assert.Equal(t, 1, nav.StackSize())
+		assert.Equal(t, "main_menu", nav.Current().ID())
+	})
+
+	t.Run("empty segment", func(t *testing.T) {
+		nav := newNavigator()
+
+		assert.ErrorIs(t, nav.Navigate("main_menu//inventory", nil), ErrInvalidRoute)
+		assert.ErrorIs(t, nav.Navigate("/", nil), ErrInvalidRoute)
+		assert.False(t, nav.HasScreens())
+	})
+}
@@ -11,7 +11,11 @@ type Renderer interface {
 	// Run starts the render loop.
 	Run() error
 
-	// Stop shuts down the renderer.
+	// RunSafe starts the render loop, recovering panics into an error
+	// and restoring the terminal.
+	RunSafe() error
+
+	// Stop shuts down the renderer. Calling it more than once is a no-op.
 	Stop() error
 
 	// Render renders a component immediately.
@@ -1,6 +1,7 @@
 package tea
 
 import (
+	"fmt"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -33,6 +34,9 @@ type Model struct {
 	lastContent string
 	needsRender bool
 	program     *tea.Program
+
+	// err holds recovered update panic
+	err error
 }
 
 // NewModel creates a new BubbleTea model.
@@ -73,7 +77,20 @@ func (m *Model) Init() tea.Cmd {
 }
 
 // Update implements tea.Model.
-func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// Panic during update is recovered into Err and program is asked to quit,
+// so terminal is restored by normal shutdown.
+func (m *Model) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.err = fmt.Errorf("%w: %v", ErrPanic, r)
+			model, cmd = m, tea.Quit
+		}
+	}()
+
+	return m.update(msg)
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
@@ -209,6 +226,11 @@ func (m *Model) Context() *component.Context {
 	return m.context
 }
 
+// Err returns error recovered from panicking update (nil if none).
+func (m *Model) Err() error {
+	return m.err
+}
+
 // RequestRender triggers a re-render.
 func (m *Model) RequestRender() {
 	m.needsRender = true
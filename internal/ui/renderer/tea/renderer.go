@@ -2,6 +2,8 @@ package tea
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/davidmovas/Depthborn/internal/ui/component"
@@ -11,6 +13,7 @@ import (
 
 var (
 	ErrNotInitialized = errors.New("renderer not initialized")
+	ErrPanic          = errors.New("renderer panic")
 )
 
 // Verify interface compliance
@@ -22,6 +25,10 @@ type Renderer struct {
 	navigator *navigation.Navigator
 	program   *tea.Program
 	model     *Model
+
+	mu      sync.Mutex
+	running bool
+	stopped bool
 }
 
 // New creates a new BubbleTea renderer.
@@ -67,13 +74,52 @@ func (r *Renderer) Run() error {
 		return ErrNotInitialized
 	}
 
+	r.mu.Lock()
+	r.running = true
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		r.running = false
+		r.mu.Unlock()
+	}()
+
 	_, err := r.program.Run()
+	if modelErr := r.model.Err(); modelErr != nil {
+		return modelErr
+	}
 	return err
 }
 
+// RunSafe implements renderer.Renderer.
+// Panic escaping Run releases terminal and is returned as ErrPanic.
+func (r *Renderer) RunSafe() (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			if r.program != nil {
+				_ = r.program.ReleaseTerminal()
+			}
+			err = fmt.Errorf("%w: %v", ErrPanic, rec)
+		}
+	}()
+
+	return r.Run()
+}
+
 // Stop implements renderer.Renderer.
+// Safe to call multiple times and before Run.
 func (r *Renderer) Stop() error {
-	if r.program != nil {
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return nil
+	}
+	r.stopped = true
+	running := r.running
+	r.mu.Unlock()
+
+	// Quit blocks until event loop picks it up, so only send it to running program
+	if running && r.program != nil {
 		r.program.Quit()
 	}
 	return nil
@@ -0,0 +1,63 @@
+package tea
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/ui/navigation"
+	"github.com/davidmovas/Depthborn/internal/ui/renderer"
+)
+
+type panicScreen struct {
+	*navigation.BaseScreen
+}
+
+func (s *panicScreen) OnUpdate() {
+	panic("screen exploded")
+}
+
+func TestRendererStop(t *testing.T) {
+	t.Run("twice before init", func(t *testing.T) {
+		r := New(renderer.DefaultConfig(), navigation.NewNavigator())
+
+		assert.NoError(t, r.Stop())
+		assert.NoError(t, r.Stop())
+	})
+
+	t.Run("twice after init", func(t *testing.T) {
+		r := New(renderer.DefaultConfig(), navigation.NewNavigator())
+		require.NoError(t, r.Init())
+
+		assert.NoError(t, r.Stop())
+		assert.NoError(t, r.Stop())
+	})
+}
+
+func TestRendererRunSafeNotInitialized(t *testing.T) {
+	r := New(renderer.DefaultConfig(), navigation.NewNavigator())
+
+	assert.ErrorIs(t, r.RunSafe(), ErrNotInitialized)
+}
+
+func TestModelUpdatePanic(t *testing.T) {
+	nav := navigation.NewNavigator()
+	nav.Register("boom", func() navigation.Screen {
+		return &panicScreen{BaseScreen: navigation.NewBaseScreen("boom")}
+	})
+	require.NoError(t, nav.Open("boom", nil))
+
+	m := NewModel(nav, 60)
+
+	var cmd tea.Cmd
+	require.NotPanics(t, func() {
+		_, cmd = m.Update(tickMsg{})
+	})
+
+	require.ErrorIs(t, m.Err(), ErrPanic)
+	assert.Contains(t, m.Err().Error(), "screen exploded")
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
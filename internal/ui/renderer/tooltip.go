@@ -0,0 +1,181 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/item"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+	"github.com/davidmovas/Depthborn/internal/ui/style"
+)
+
+// durabilityBarWidth is number of cells in tooltip durability bar
+const durabilityBarWidth = 10
+
+// rarityColors maps item rarity to name color
+var rarityColors = map[item.Rarity]style.Color{
+	item.RarityCommon:    style.Grey300,
+	item.RarityUncommon:  style.Green500,
+	item.RarityRare:      style.Blue500,
+	item.RarityEpic:      style.Purple500,
+	item.RarityLegendary: style.Orange500,
+	item.RarityMythic:    style.Red500,
+}
+
+// RarityColor returns display color for rarity
+func RarityColor(rarity item.Rarity) style.Color {
+	if color, ok := rarityColors[rarity]; ok {
+		return color
+	}
+	return style.Grey300
+}
+
+// ItemTooltip renders standard multi-line item tooltip: rarity-colored name,
+// rarity and type, level, stack, affixes, sockets, durability bar and value.
+// Equipment-only sections are omitted for other items.
+func ItemTooltip(itm item.Item) string {
+	if itm == nil {
+		return ""
+	}
+
+	muted := style.Fg(style.Grey500)
+	lines := []string{
+		style.Bold.Foreground(RarityColor(itm.Rarity())).Render(itm.Name()),
+		muted.Render(fmt.Sprintf("%s %s", itm.Rarity(), humanize(string(itm.ItemType())))),
+	}
+
+	if itm.Level() > 0 {
+		lines = append(lines, fmt.Sprintf("Level %d", itm.Level()))
+	}
+	if itm.MaxStackSize() > 1 {
+		lines = append(lines, fmt.Sprintf("Stack %d/%d", itm.StackSize(), itm.MaxStackSize()))
+	}
+
+	value := itm.Value()
+	if equip, ok := itm.(item.Equipment); ok {
+		value = equip.EffectiveValue()
+
+		if affixLines := tooltipAffixes(equip.Affixes()); len(affixLines) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, affixLines...)
+		}
+		if equip.SocketCount() > 0 {
+			lines = append(lines, tooltipSockets(equip))
+		}
+		lines = append(lines, tooltipDurability(equip))
+	} else if desc := itm.Description(); desc != "" {
+		lines = append(lines, "", style.Italic.Render(desc))
+	}
+
+	lines = append(lines, "", muted.Render(fmt.Sprintf("Value %d", value)))
+	return strings.Join(lines, "\n")
+}
+
+// tooltipAffixes lists rolled affix modifiers tagged with affix tier,
+// implicits first then prefixes and suffixes
+func tooltipAffixes(set affix.Set) []string {
+	if set == nil {
+		return nil
+	}
+
+	accent := style.Fg(style.Blue300)
+	var lines []string
+	for _, affixType := range []affix.Type{affix.TypeImplicit, affix.TypePrefix, affix.TypeSuffix, affix.TypeEnchant, affix.TypeCorrupted} {
+		for _, inst := range set.GetByTypeOrdered(affixType) {
+			tier := ""
+			if template := inst.Affix(); template != nil && template.Tier() > 0 {
+				tier = fmt.Sprintf(" (T%d)", template.Tier())
+			}
+			for _, rolled := range inst.RolledValues() {
+				lines = append(lines, accent.Render(formatModifier(rolled.Template.Attribute, rolled.Template.ModType, rolled.Value)+tier))
+			}
+		}
+	}
+	return lines
+}
+
+// tooltipSockets renders socket row, empty sockets shown as [ ]
+func tooltipSockets(equip item.Equipment) string {
+	cells := make([]string, equip.SocketCount())
+	for i := range cells {
+		if gem, ok := equip.GetSocket(i); ok && gem != nil {
+			cells[i] = "[" + gem.Name() + "]"
+		} else {
+			cells[i] = "[ ]"
+		}
+	}
+	return "Sockets " + strings.Join(cells, " ")
+}
+
+// tooltipDurability renders durability bar colored by remaining ratio
+func tooltipDurability(equip item.Equipment) string {
+	current, maxDurability := equip.Durability(), equip.MaxDurability()
+	ratio := 0.0
+	if maxDurability > 0 {
+		ratio = current / maxDurability
+	}
+
+	filled := int(ratio*durabilityBarWidth + 0.5)
+	filled = max(0, min(filled, durabilityBarWidth))
+
+	color := style.Green500
+	switch {
+	case equip.IsBroken():
+		color = style.Red500
+	case ratio < 0.25:
+		color = style.Orange500
+	case ratio < 0.5:
+		color = style.Yellow500
+	}
+
+	bar := style.Fg(color).Render(strings.Repeat("█", filled)) +
+		style.Fg(style.Grey700).Render(strings.Repeat("░", durabilityBarWidth-filled))
+	line := fmt.Sprintf("Durability %s %.0f/%.0f", bar, current, maxDurability)
+	if equip.IsBroken() {
+		line += " " + style.Fg(style.Red500).Render("(Broken)")
+	}
+	return line
+}
+
+// formatModifier describes single rolled modifier, e.g. "+12% increased Fire Damage"
+func formatModifier(attr attribute.Type, modType attribute.ModifierType, value float64) string {
+	name := humanize(string(attr))
+	switch modType {
+	case attribute.ModIncreased:
+		return fmt.Sprintf("%s%% increased %s", signed(value), name)
+	case attribute.ModMore:
+		return fmt.Sprintf("%s%% more %s", signed(value), name)
+	case attribute.ModOverride:
+		return fmt.Sprintf("%s is %s", name, formatNumber(value))
+	default:
+		return fmt.Sprintf("%s %s", signed(value), name)
+	}
+}
+
+// signed formats value with explicit sign
+func signed(value float64) string {
+	if value >= 0 {
+		return "+" + formatNumber(value)
+	}
+	return formatNumber(value)
+}
+
+// formatNumber drops decimals for whole values
+func formatNumber(value float64) string {
+	if value == float64(int64(value)) {
+		return fmt.Sprintf("%d", int64(value))
+	}
+	return fmt.Sprintf("%.1f", value)
+}
+
+// humanize turns snake_case identifiers into title-cased words
+func humanize(id string) string {
+	words := strings.Split(id, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,92 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/internal/core/attribute"
+	"github.com/davidmovas/Depthborn/internal/item"
+	"github.com/davidmovas/Depthborn/internal/item/affix"
+	"github.com/davidmovas/Depthborn/internal/ui/style"
+)
+
+func TestItemTooltip(t *testing.T) {
+	plainLines := func(itm item.Item) []string {
+		return strings.Split(style.StripAnsi(ItemTooltip(itm)), "\n")
+	}
+
+	t.Run("rolled weapon", func(t *testing.T) {
+		sword := item.NewEquipmentWithConfig(item.EquipmentConfig{
+			BaseItemConfig: item.BaseItemConfig{
+				Name:     "Iron Sword",
+				ItemType: item.TypeWeaponMelee,
+				Rarity:   item.RarityRare,
+				Level:    12,
+				Value:    100,
+			},
+			Slot:          item.SlotMainHand,
+			MaxDurability: 100,
+			SocketCount:   2,
+			SocketTypes:   []item.SocketType{item.SocketTypeGem, item.SocketTypeGem},
+		})
+		addAffix := func(id string, tier int, affixType affix.Type, modType attribute.ModifierType, attr attribute.Type, value float64) {
+			template := affix.NewBaseAffix(id, id, affixType).WithTier(tier).
+				AddModifier(affix.ModifierTemplate{Attribute: attr, ModType: modType, MinValue: 1, MaxValue: 20})
+			rolled := []affix.RolledModifier{{Template: template.Modifiers()[0], Value: value}}
+			require.NoError(t, sword.Affixes().Add(affix.NewBaseInstance(template, rolled)))
+		}
+		addAffix("sharp", 2, affix.TypePrefix, attribute.ModFlat, attribute.AttrPhysicalDamage, 7)
+		addAffix("of_might", 0, affix.TypeSuffix, attribute.ModIncreased, attribute.AttrStrength, 12)
+		require.NoError(t, sword.SetSocket(0, item.NewBaseSocketable("gem-1", item.TypeGem, "Ruby", item.SocketTypeGem)))
+		sword.SetDurability(60)
+
+		lines := plainLines(sword)
+
+		assert.Equal(t, "Iron Sword", lines[0])
+		assert.Equal(t, "Rare Weapon Melee", lines[1])
+		assert.Contains(t, lines, "Level 12")
+		assert.Contains(t, lines, "+7 Physical Damage (T2)")
+		assert.Contains(t, lines, "+12% increased Strength")
+		assert.Contains(t, lines, "Sockets [Ruby] [ ]")
+		assert.Contains(t, lines, "Durability ██████░░░░ 60/100")
+		assert.Contains(t, lines, fmt.Sprintf("Value %d", sword.EffectiveValue()))
+		assert.NotContains(t, strings.Join(lines, "\n"), "Stack")
+	})
+
+	t.Run("broken equipment is flagged", func(t *testing.T) {
+		helm := item.NewBaseEquipment("", item.TypeArmorHead, "Cap", item.SlotHead)
+		helm.DamageItem(helm.MaxDurability())
+
+		assert.Contains(t, plainLines(helm), "Durability ░░░░░░░░░░ 0/100 (Broken)")
+	})
+
+	t.Run("non-equipment degrades gracefully", func(t *testing.T) {
+		ore := item.NewBaseItemWithConfig(item.BaseItemConfig{
+			Name:         "Iron Ore",
+			Description:  "Smelts into bars.",
+			ItemType:     item.TypeMaterial,
+			MaxStackSize: 20,
+			Value:        5,
+		})
+		ore.AddStack(4)
+
+		lines := plainLines(ore)
+
+		assert.Equal(t, "Iron Ore", lines[0])
+		assert.Equal(t, "Common Material", lines[1])
+		assert.Contains(t, lines, "Stack 5/20")
+		assert.Contains(t, lines, "Smelts into bars.")
+		assert.Contains(t, lines, "Value 5")
+		text := strings.Join(lines, "\n")
+		assert.NotContains(t, text, "Durability")
+		assert.NotContains(t, text, "Sockets")
+	})
+
+	t.Run("nil item", func(t *testing.T) {
+		assert.Empty(t, ItemTooltip(nil))
+	})
+}
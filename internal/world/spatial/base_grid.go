@@ -0,0 +1,284 @@
+package spatial
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var _ Grid = (*BaseGrid)(nil)
+
+// =============================================================================
+// ERRORS
+// =============================================================================
+
+var (
+	// ErrOutOfBounds is returned when position is outside grid
+	ErrOutOfBounds = errors.New("position out of bounds")
+
+	// ErrNotWalkable is returned when position cannot be traversed
+	ErrNotWalkable = errors.New("position not walkable")
+
+	// ErrOccupied is returned when position already holds another entity
+	ErrOccupied = errors.New("position occupied")
+
+	// ErrNotOccupied is returned when removing occupant from empty position
+	ErrNotOccupied = errors.New("position not occupied")
+
+	// ErrNoPath is returned when destination cannot be reached
+	ErrNoPath = errors.New("no path")
+)
+
+// =============================================================================
+// BASE GRID
+// =============================================================================
+
+// BaseGrid is in-memory grid; cells default to floor tiles
+type BaseGrid struct {
+	mu sync.RWMutex
+
+	width  int
+	height int
+	minZ   int
+	maxZ   int
+
+	tiles     map[Position]TileType
+	costs     map[Position]float64 // terrain cost overrides
+	occupants map[Position]string
+	entities  map[string]Position
+}
+
+// NewBaseGrid creates single level grid
+func NewBaseGrid(width, height int) *BaseGrid {
+	return NewBaseGridWithLevels(width, height, 0, 0)
+}
+
+// NewBaseGridWithLevels creates grid spanning height levels minZ..maxZ
+func NewBaseGridWithLevels(width, height, minZ, maxZ int) *BaseGrid {
+	return &BaseGrid{
+		width:     width,
+		height:    height,
+		minZ:      minZ,
+		maxZ:      maxZ,
+		tiles:     make(map[Position]TileType),
+		costs:     make(map[Position]float64),
+		occupants: make(map[Position]string),
+		entities:  make(map[string]Position),
+	}
+}
+
+func (g *BaseGrid) Width() int  { return g.width }
+func (g *BaseGrid) Height() int { return g.height }
+func (g *BaseGrid) MinZ() int   { return g.minZ }
+func (g *BaseGrid) MaxZ() int   { return g.maxZ }
+
+func (g *BaseGrid) IsValid(pos Position) bool {
+	return pos.X >= 0 && pos.X < g.width &&
+		pos.Y >= 0 && pos.Y < g.height &&
+		pos.Z >= g.minZ && pos.Z <= g.maxZ
+}
+
+func (g *BaseGrid) IsWalkable(pos Position) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.isWalkableLocked(pos)
+}
+
+func (g *BaseGrid) isWalkableLocked(pos Position) bool {
+	return g.IsValid(pos) && g.tileLocked(pos).IsWalkable()
+}
+
+func (g *BaseGrid) IsOccupied(pos Position) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	_, exists := g.occupants[pos]
+	return exists
+}
+
+func (g *BaseGrid) GetOccupant(pos Position) (string, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	entityID, exists := g.occupants[pos]
+	return entityID, exists
+}
+
+// SetOccupant places entity at position, moving it off previous position
+func (g *BaseGrid) SetOccupant(pos Position, entityID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.IsValid(pos) {
+		return fmt.Errorf("%w: %v", ErrOutOfBounds, pos)
+	}
+	if !g.tileLocked(pos).IsWalkable() {
+		return fmt.Errorf("%w: %v", ErrNotWalkable, pos)
+	}
+	if current, exists := g.occupants[pos]; exists && current != entityID {
+		return fmt.Errorf("%w: %v by %s", ErrOccupied, pos, current)
+	}
+
+	if previous, exists := g.entities[entityID]; exists {
+		delete(g.occupants, previous)
+	}
+	g.occupants[pos] = entityID
+	g.entities[entityID] = pos
+	return nil
+}
+
+func (g *BaseGrid) RemoveOccupant(pos Position) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entityID, exists := g.occupants[pos]
+	if !exists {
+		return fmt.Errorf("%w: %v", ErrNotOccupied, pos)
+	}
+
+	delete(g.occupants, pos)
+	delete(g.entities, entityID)
+	return nil
+}
+
+func (g *BaseGrid) GetTile(pos Position) TileType {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.tileLocked(pos)
+}
+
+func (g *BaseGrid) tileLocked(pos Position) TileType {
+	if !g.IsValid(pos) {
+		return TileVoid
+	}
+	if tile, exists := g.tiles[pos]; exists {
+		return tile
+	}
+	return TileFloor
+}
+
+func (g *BaseGrid) SetTile(pos Position, tile TileType) {
+	if !g.IsValid(pos) {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.tiles[pos] = tile
+}
+
+func (g *BaseGrid) MovementCost(pos Position) float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.movementCostLocked(pos)
+}
+
+func (g *BaseGrid) movementCostLocked(pos Position) float64 {
+	if cost, exists := g.costs[pos]; exists {
+		return cost
+	}
+	return g.tileLocked(pos).MovementCost()
+}
+
+// SetMovementCost overrides terrain cost; costs below 1 are raised to 1
+// so distance stays a lower bound for pathfinding
+func (g *BaseGrid) SetMovementCost(pos Position, cost float64) {
+	if !g.IsValid(pos) {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if cost <= 0 {
+		delete(g.costs, pos)
+		return
+	}
+	g.costs[pos] = max(cost, 1)
+}
+
+// GetNeighbors returns walkable neighbors on same level; diagonal steps
+// are not allowed to cut past blocked corners
+func (g *BaseGrid) GetNeighbors(pos Position) []Position {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.neighborsLocked(pos)
+}
+
+func (g *BaseGrid) neighborsLocked(pos Position) []Position {
+	neighbors := make([]Position, 0, 8)
+	for _, next := range pos.Neighbors() {
+		if !g.isWalkableLocked(next) {
+			continue
+		}
+		if next.X != pos.X && next.Y != pos.Y &&
+			(!g.isWalkableLocked(Position{X: next.X, Y: pos.Y, Z: pos.Z}) ||
+				!g.isWalkableLocked(Position{X: pos.X, Y: next.Y, Z: pos.Z})) {
+			continue
+		}
+		neighbors = append(neighbors, next)
+	}
+	return neighbors
+}
+
+// InLineOfSight traces line on source level; endpoints never block
+func (g *BaseGrid) InLineOfSight(from, to Position) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	dx, dy := abs(to.X-from.X), -abs(to.Y-from.Y)
+	sx, sy := sign(to.X-from.X), sign(to.Y-from.Y)
+	x, y := from.X, from.Y
+	errTerm := dx + dy
+
+	for {
+		if x == to.X && y == to.Y {
+			return true
+		}
+		pos := Position{X: x, Y: y, Z: from.Z}
+		if !pos.Equals(from) && !g.tileLocked(pos).IsTransparent() {
+			return false
+		}
+
+		doubled := 2 * errTerm
+		if doubled >= dy {
+			errTerm += dy
+			x += sx
+		}
+		if doubled <= dx {
+			errTerm += dx
+			y += sy
+		}
+	}
+}
+
+// GetEntitiesInRange returns entity IDs within radius sorted by ID
+func (g *BaseGrid) GetEntitiesInRange(center Position, radius float64) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	result := make([]string, 0)
+	for pos, entityID := range g.occupants {
+		if pos.InRange(center, radius) {
+			result = append(result, entityID)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetEntitiesInArea returns entity IDs inside area sorted by ID
+func (g *BaseGrid) GetEntitiesInArea(area Area) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	result := make([]string, 0)
+	for pos, entityID := range g.occupants {
+		if area.Contains(pos) {
+			result = append(result, entityID)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
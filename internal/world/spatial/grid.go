@@ -41,6 +41,18 @@ type Grid interface {
 	// FindPath calculates path between positions
 	FindPath(from, to Position) ([]Position, error)
 
+	// FindPathWithCost calculates cheapest path and its total movement cost
+	FindPathWithCost(from, to Position) ([]Position, float64, error)
+
+	// MovementCost returns cost of entering position
+	MovementCost(pos Position) float64
+
+	// SetMovementCost overrides terrain cost of position (0 restores tile default)
+	SetMovementCost(pos Position, cost float64)
+
+	// ReachableWithin returns positions reachable within movement budget with their cost
+	ReachableWithin(from Position, budget float64) map[Position]float64
+
 	// GetNeighbors returns walkable neighboring positions
 	GetNeighbors(pos Position) []Position
 
@@ -91,3 +103,15 @@ func (t TileType) IsTransparent() bool {
 		return true
 	}
 }
+
+// MovementCost returns base cost of entering tile
+func (t TileType) MovementCost() float64 {
+	switch t {
+	case TileSand, TileStairs:
+		return 2
+	case TileIce:
+		return 1.5
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,152 @@
+package spatial
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+)
+
+// =============================================================================
+// PATHFINDING
+// =============================================================================
+
+// FindPath returns cheapest path from start to destination, both inclusive
+func (g *BaseGrid) FindPath(from, to Position) ([]Position, error) {
+	path, _, err := g.FindPathWithCost(from, to)
+	return path, err
+}
+
+// FindPathWithCost runs A* where each step costs the movement cost of the
+// entered cell. Occupied cells block except the destination itself.
+func (g *BaseGrid) FindPathWithCost(from, to Position) ([]Position, float64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.IsValid(from) || !g.IsValid(to) {
+		return nil, 0, fmt.Errorf("%w: %v -> %v", ErrOutOfBounds, from, to)
+	}
+	if !g.isWalkableLocked(to) {
+		return nil, 0, fmt.Errorf("%w: %v", ErrNotWalkable, to)
+	}
+	if from.Equals(to) {
+		return []Position{from}, 0, nil
+	}
+
+	cameFrom := make(map[Position]Position)
+	costSoFar := map[Position]float64{from: 0}
+	open := &pathQueue{}
+	heap.Push(open, &pathNode{pos: from, priority: chebyshev(from, to)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*pathNode)
+		if current.pos.Equals(to) {
+			return reconstructPath(cameFrom, from, to), costSoFar[to], nil
+		}
+		if current.cost > costSoFar[current.pos] {
+			continue // stale entry
+		}
+
+		for _, next := range g.neighborsLocked(current.pos) {
+			if _, occupied := g.occupants[next]; occupied && !next.Equals(to) {
+				continue
+			}
+			cost := current.cost + g.movementCostLocked(next)
+			if known, seen := costSoFar[next]; seen && known <= cost {
+				continue
+			}
+			costSoFar[next] = cost
+			cameFrom[next] = current.pos
+			heap.Push(open, &pathNode{pos: next, cost: cost, priority: cost + chebyshev(next, to), seq: open.seq})
+		}
+	}
+
+	return nil, 0, fmt.Errorf("%w: %v -> %v", ErrNoPath, from, to)
+}
+
+// ReachableWithin returns free cells whose cheapest path cost from start fits
+// into budget, start included at zero cost
+func (g *BaseGrid) ReachableWithin(from Position, budget float64) map[Position]float64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	reachable := make(map[Position]float64)
+	if !g.IsValid(from) {
+		return reachable
+	}
+
+	reachable[from] = 0
+	open := &pathQueue{}
+	heap.Push(open, &pathNode{pos: from})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*pathNode)
+		if current.cost > reachable[current.pos] {
+			continue
+		}
+
+		for _, next := range g.neighborsLocked(current.pos) {
+			if _, occupied := g.occupants[next]; occupied {
+				continue
+			}
+			cost := current.cost + g.movementCostLocked(next)
+			if cost > budget {
+				continue
+			}
+			if known, seen := reachable[next]; seen && known <= cost {
+				continue
+			}
+			reachable[next] = cost
+			heap.Push(open, &pathNode{pos: next, cost: cost, priority: cost, seq: open.seq})
+		}
+	}
+	return reachable
+}
+
+func reconstructPath(cameFrom map[Position]Position, from, to Position) []Position {
+	path := []Position{to}
+	for current := to; !current.Equals(from); {
+		current = cameFrom[current]
+		path = append(path, current)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// chebyshev is admissible heuristic since every step costs at least 1
+func chebyshev(a, b Position) float64 {
+	return float64(max(abs(a.X-b.X), abs(a.Y-b.Y)))
+}
+
+type pathNode struct {
+	pos      Position
+	cost     float64
+	priority float64
+	seq      int // insertion order keeps ties deterministic
+}
+
+type pathQueue struct {
+	nodes []*pathNode
+	seq   int
+}
+
+func (q *pathQueue) Len() int { return len(q.nodes) }
+
+func (q *pathQueue) Less(i, j int) bool {
+	if q.nodes[i].priority != q.nodes[j].priority {
+		return q.nodes[i].priority < q.nodes[j].priority
+	}
+	return q.nodes[i].seq < q.nodes[j].seq
+}
+
+func (q *pathQueue) Swap(i, j int) { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+
+func (q *pathQueue) Push(x any) {
+	q.nodes = append(q.nodes, x.(*pathNode))
+	q.seq++
+}
+
+func (q *pathQueue) Pop() any {
+	last := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return last
+}
@@ -0,0 +1,97 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseGridPathfinding(t *testing.T) {
+	start, goal := NewPosition(0, 1, 0), NewPosition(4, 1, 0)
+	swamp := NewPosition(2, 1, 0)
+
+	t.Run("open floor costs one per step", func(t *testing.T) {
+		grid := NewBaseGrid(5, 3)
+
+		path, cost, err := grid.FindPathWithCost(start, goal)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4.0, cost)
+		assert.Equal(t, start, path[0])
+		assert.Equal(t, goal, path[len(path)-1])
+	})
+
+	t.Run("routes around expensive swamp when cheaper", func(t *testing.T) {
+		grid := NewBaseGrid(5, 3)
+		grid.SetMovementCost(swamp, 10)
+
+		path, cost, err := grid.FindPathWithCost(start, goal)
+		require.NoError(t, err)
+
+		assert.NotContains(t, path, swamp)
+		assert.Equal(t, 4.0, cost)
+	})
+
+	t.Run("crosses swamp when it is the only way", func(t *testing.T) {
+		grid := NewBaseGrid(5, 3)
+		grid.SetMovementCost(swamp, 10)
+		grid.SetTile(NewPosition(2, 0, 0), TileWall)
+		grid.SetTile(NewPosition(2, 2, 0), TileWall)
+
+		path, cost, err := grid.FindPathWithCost(start, goal)
+		require.NoError(t, err)
+
+		assert.Contains(t, path, swamp)
+		assert.Equal(t, 13.0, cost)
+	})
+
+	t.Run("tile terrain cost applies without override", func(t *testing.T) {
+		grid := NewBaseGrid(5, 1)
+		grid.SetTile(NewPosition(1, 0, 0), TileSand)
+
+		_, cost, err := grid.FindPathWithCost(NewPosition(0, 0, 0), NewPosition(2, 0, 0))
+		require.NoError(t, err)
+		assert.Equal(t, TileSand.MovementCost()+1, cost)
+
+		grid.SetMovementCost(NewPosition(1, 0, 0), 0)
+		assert.Equal(t, TileSand.MovementCost(), grid.MovementCost(NewPosition(1, 0, 0)))
+	})
+
+	t.Run("blocked destination has no path", func(t *testing.T) {
+		grid := NewBaseGrid(5, 3)
+		for y := range 3 {
+			grid.SetTile(NewPosition(2, y, 0), TileWall)
+		}
+
+		_, _, err := grid.FindPathWithCost(start, goal)
+		assert.ErrorIs(t, err, ErrNoPath)
+
+		_, _, err = grid.FindPathWithCost(start, NewPosition(2, 1, 0))
+		assert.ErrorIs(t, err, ErrNotWalkable)
+	})
+
+	t.Run("occupants block passage but not destination", func(t *testing.T) {
+		grid := NewBaseGrid(3, 1)
+		require.NoError(t, grid.SetOccupant(NewPosition(1, 0, 0), "goblin"))
+
+		_, _, err := grid.FindPathWithCost(NewPosition(0, 0, 0), NewPosition(2, 0, 0))
+		assert.ErrorIs(t, err, ErrNoPath)
+
+		path, err := grid.FindPath(NewPosition(0, 0, 0), NewPosition(1, 0, 0))
+		require.NoError(t, err)
+		assert.Len(t, path, 2)
+	})
+
+	t.Run("movement budget limits reachable cells", func(t *testing.T) {
+		grid := NewBaseGrid(5, 3)
+		grid.SetMovementCost(swamp, 10)
+
+		reachable := grid.ReachableWithin(start, 2)
+
+		assert.Equal(t, 0.0, reachable[start])
+		assert.Equal(t, 2.0, reachable[NewPosition(2, 0, 0)])
+		assert.NotContains(t, reachable, swamp)
+		assert.NotContains(t, reachable, NewPosition(3, 1, 0))
+	})
+}
@@ -0,0 +1,231 @@
+package persist
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/davidmovas/Depthborn/pkg/persist/codec"
+)
+
+// Migration errors.
+var (
+	ErrMigrationExists  = errors.New("migration already registered")
+	ErrMigrationMissing = errors.New("migration step missing")
+	ErrFutureVersion    = errors.New("stored version is newer than supported")
+	ErrNotVersioned     = errors.New("data is not schema-tagged")
+)
+
+// SchemaVersionKey holds schema version in state maps stamped by Stamp.
+const SchemaVersionKey = "schema_version"
+
+// MigrationFunc upgrades decoded fields by exactly one version.
+// Fields are the generic map form of the stored struct and are modified in place.
+type MigrationFunc func(fields map[string]any) error
+
+// versionedEnvelope wraps stored state with schema name and version.
+type versionedEnvelope struct {
+	Schema  string         `msgpack:"schema" json:"schema"`
+	Version int            `msgpack:"version" json:"version"`
+	Fields  map[string]any `msgpack:"fields" json:"fields"`
+}
+
+// Migrator is a registry of per-schema migrations.
+// Each schema's current version is one past its highest registered step,
+// so a schema without migrations is at version 0.
+type Migrator struct {
+	mu      sync.RWMutex
+	codec   codec.Codec
+	schemas map[string]map[int]MigrationFunc
+}
+
+// NewMigrator creates a migrator using the default codec.
+func NewMigrator() *Migrator {
+	return &Migrator{
+		codec:   codec.Default,
+		schemas: make(map[string]map[int]MigrationFunc),
+	}
+}
+
+// WithCodec sets a custom codec.
+func (m *Migrator) WithCodec(c codec.Codec) *Migrator {
+	m.codec = c
+	return m
+}
+
+// Register adds migration upgrading schema from fromVersion to fromVersion+1.
+func (m *Migrator) Register(schema string, fromVersion int, fn MigrationFunc) error {
+	if schema == "" || fromVersion < 0 || fn == nil {
+		return fmt.Errorf("invalid migration for schema %q from version %d", schema, fromVersion)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	steps, ok := m.schemas[schema]
+	if !ok {
+		steps = make(map[int]MigrationFunc)
+		m.schemas[schema] = steps
+	}
+	if _, exists := steps[fromVersion]; exists {
+		return fmt.Errorf("%w: %s v%d", ErrMigrationExists, schema, fromVersion)
+	}
+
+	steps[fromVersion] = fn
+	return nil
+}
+
+// CurrentVersion returns version that Encode stamps for schema.
+func (m *Migrator) CurrentVersion(schema string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.currentVersionLocked(schema)
+}
+
+func (m *Migrator) currentVersionLocked(schema string) int {
+	current := 0
+	for from := range m.schemas[schema] {
+		if from+1 > current {
+			current = from + 1
+		}
+	}
+	return current
+}
+
+// Encode serializes v tagged with schema and its current version.
+func (m *Migrator) Encode(schema string, v any) ([]byte, error) {
+	raw, err := m.codec.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := m.codec.Decode(raw, &fields); err != nil {
+		return nil, fmt.Errorf("schema %s must encode as a map: %w", schema, err)
+	}
+
+	return m.codec.Encode(versionedEnvelope{
+		Schema:  schema,
+		Version: m.CurrentVersion(schema),
+		Fields:  fields,
+	})
+}
+
+// DecodeMigrated reads stored schema and version, runs chained migrations
+// up to the current version and decodes the result into out.
+func (m *Migrator) DecodeMigrated(data []byte, out any) error {
+	var env versionedEnvelope
+	if err := m.codec.Decode(data, &env); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotVersioned, err)
+	}
+	if env.Schema == "" {
+		return ErrNotVersioned
+	}
+	if env.Fields == nil {
+		env.Fields = make(map[string]any)
+	}
+
+	if _, err := m.migrate(env.Schema, env.Version, env.Fields); err != nil {
+		return err
+	}
+
+	raw, err := m.codec.Encode(env.Fields)
+	if err != nil {
+		return err
+	}
+	return m.codec.Decode(raw, out)
+}
+
+// Stamp tags state map with current schema version under SchemaVersionKey.
+func (m *Migrator) Stamp(schema string, fields map[string]any) {
+	fields[SchemaVersionKey] = m.CurrentVersion(schema)
+}
+
+// MigrateState returns shallow copy of state map upgraded from version under
+// SchemaVersionKey (missing means 0) to current and restamped.
+// Top level of state is left untouched.
+func (m *Migrator) MigrateState(schema string, state map[string]any) (map[string]any, error) {
+	fields := maps.Clone(state)
+	if fields == nil {
+		fields = make(map[string]any)
+	}
+
+	version := 0
+	if raw, ok := fields[SchemaVersionKey]; ok {
+		v, ok := toVersion(raw)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s has version %v", ErrNotVersioned, schema, raw)
+		}
+		version = v
+	}
+
+	current, err := m.migrate(schema, version, fields)
+	if err != nil {
+		return nil, err
+	}
+	fields[SchemaVersionKey] = current
+	return fields, nil
+}
+
+// migrate runs chained steps of schema from version to current and returns current.
+func (m *Migrator) migrate(schema string, version int, fields map[string]any) (int, error) {
+	m.mu.RLock()
+	current := m.currentVersionLocked(schema)
+	steps := make([]MigrationFunc, 0, max(current-version, 0))
+	for v := version; v < current; v++ {
+		fn, ok := m.schemas[schema][v]
+		if !ok {
+			m.mu.RUnlock()
+			return 0, fmt.Errorf("%w: %s v%d -> v%d", ErrMigrationMissing, schema, v, v+1)
+		}
+		steps = append(steps, fn)
+	}
+	m.mu.RUnlock()
+
+	if version > current {
+		return 0, fmt.Errorf("%w: %s v%d (current v%d)", ErrFutureVersion, schema, version, current)
+	}
+
+	for i, fn := range steps {
+		if err := fn(fields); err != nil {
+			return 0, fmt.Errorf("migrate %s v%d -> v%d: %w", schema, version+i, version+i+1, err)
+		}
+	}
+	return current, nil
+}
+
+// toVersion reads version number decoded by any codec.
+func toVersion(raw any) (int, bool) {
+	switch v := raw.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	default:
+		return 0, false
+	}
+}
+
+var defaultMigrator = NewMigrator()
+
+// DefaultMigrator returns the shared migrator that state types register with.
+func DefaultMigrator() *Migrator {
+	return defaultMigrator
+}
@@ -0,0 +1,134 @@
+package persist_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/pkg/persist"
+	"github.com/davidmovas/Depthborn/pkg/persist/codec"
+)
+
+const schemaBag = "inventory.bag"
+
+// bagStateV0 is the original save layout.
+type bagStateV0 struct {
+	Items []string `msgpack:"items" json:"items"`
+}
+
+// bagState is the current layout: v1 added max_weight, v2 renamed items to item_ids.
+type bagState struct {
+	ItemIDs   []string `msgpack:"item_ids" json:"item_ids"`
+	MaxWeight float64  `msgpack:"max_weight" json:"max_weight"`
+}
+
+// registerBagMigrations shows typical steps: add field with default, then rename.
+func registerBagMigrations(t *testing.T, m *persist.Migrator) {
+	t.Helper()
+
+	require.NoError(t, m.Register(schemaBag, 0, func(fields map[string]any) error {
+		if _, ok := fields["max_weight"]; !ok {
+			fields["max_weight"] = 100.0
+		}
+		return nil
+	}))
+	require.NoError(t, m.Register(schemaBag, 1, func(fields map[string]any) error {
+		fields["item_ids"] = fields["items"]
+		delete(fields, "items")
+		return nil
+	}))
+}
+
+func TestMigrator(t *testing.T) {
+	for _, c := range []codec.Codec{codec.NewMsgPack(), codec.NewJSON()} {
+		t.Run(c.Name(), func(t *testing.T) {
+			t.Run("decodes old blob through two steps", func(t *testing.T) {
+				old := persist.NewMigrator().WithCodec(c)
+				data, err := old.Encode(schemaBag, bagStateV0{Items: []string{"sword", "potion"}})
+				require.NoError(t, err)
+
+				m := persist.NewMigrator().WithCodec(c)
+				registerBagMigrations(t, m)
+				require.Equal(t, 2, m.CurrentVersion(schemaBag))
+
+				var state bagState
+				require.NoError(t, m.DecodeMigrated(data, &state))
+				assert.Equal(t, []string{"sword", "potion"}, state.ItemIDs)
+				assert.Equal(t, 100.0, state.MaxWeight)
+			})
+
+			t.Run("current blob skips migrations", func(t *testing.T) {
+				m := persist.NewMigrator().WithCodec(c)
+				registerBagMigrations(t, m)
+
+				data, err := m.Encode(schemaBag, bagState{ItemIDs: []string{"shield"}, MaxWeight: 40})
+				require.NoError(t, err)
+
+				var state bagState
+				require.NoError(t, m.DecodeMigrated(data, &state))
+				assert.Equal(t, []string{"shield"}, state.ItemIDs)
+				assert.Equal(t, 40.0, state.MaxWeight)
+			})
+		})
+	}
+
+	t.Run("newer blob is rejected", func(t *testing.T) {
+		newer := persist.NewMigrator()
+		registerBagMigrations(t, newer)
+		data, err := newer.Encode(schemaBag, bagState{})
+		require.NoError(t, err)
+
+		var state bagState
+		assert.ErrorIs(t, persist.NewMigrator().DecodeMigrated(data, &state), persist.ErrFutureVersion)
+	})
+
+	t.Run("gap in chain is reported", func(t *testing.T) {
+		data, err := persist.NewMigrator().Encode(schemaBag, bagStateV0{})
+		require.NoError(t, err)
+
+		m := persist.NewMigrator()
+		require.NoError(t, m.Register(schemaBag, 1, func(map[string]any) error { return nil }))
+
+		var state bagState
+		assert.ErrorIs(t, m.DecodeMigrated(data, &state), persist.ErrMigrationMissing)
+	})
+
+	t.Run("duplicate step is rejected", func(t *testing.T) {
+		m := persist.NewMigrator()
+		registerBagMigrations(t, m)
+		err := m.Register(schemaBag, 0, func(map[string]any) error { return nil })
+		assert.ErrorIs(t, err, persist.ErrMigrationExists)
+	})
+
+	t.Run("state map migrates from stamped version", func(t *testing.T) {
+		m := persist.NewMigrator()
+		registerBagMigrations(t, m)
+
+		// Untagged map is version 0
+		old := map[string]any{"items": []any{"sword"}}
+		migrated, err := m.MigrateState(schemaBag, old)
+		require.NoError(t, err)
+		assert.Equal(t, []any{"sword"}, migrated["item_ids"])
+		assert.Equal(t, 100.0, migrated["max_weight"])
+		assert.Equal(t, 2, migrated[persist.SchemaVersionKey])
+		assert.NotContains(t, old, "item_ids")
+
+		current := map[string]any{"item_ids": []any{"shield"}}
+		m.Stamp(schemaBag, current)
+		migrated, err = m.MigrateState(schemaBag, current)
+		require.NoError(t, err)
+		assert.Equal(t, current, migrated)
+
+		_, err = m.MigrateState(schemaBag, map[string]any{persist.SchemaVersionKey: int8(3)})
+		assert.ErrorIs(t, err, persist.ErrFutureVersion)
+	})
+
+	t.Run("untagged data is rejected", func(t *testing.T) {
+		data, err := persist.DefaultCodec().Encode(bagState{})
+		require.NoError(t, err)
+
+		var state bagState
+		assert.ErrorIs(t, persist.NewMigrator().DecodeMigrated(data, &state), persist.ErrNotVersioned)
+	})
+}
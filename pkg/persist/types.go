@@ -0,0 +1,200 @@
+package persist
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/davidmovas/Depthborn/pkg/persist/codec"
+)
+
+// Type registry errors.
+var (
+	ErrTypeExists   = errors.New("type already registered")
+	ErrTypeUnknown  = errors.New("type not registered")
+	ErrInvalidType  = errors.New("invalid type registration")
+	ErrTypeMismatch = errors.New("decoded value has unexpected type")
+)
+
+// TypedValue is an encoded value tagged with its registered type name.
+// Store it in place of interface-typed fields so decoding can rebuild
+// the concrete type.
+type TypedValue struct {
+	Type string `msgpack:"type" json:"type"`
+	Data []byte `msgpack:"data" json:"data"`
+}
+
+// TypeRegistry maps type names to factories of concrete types.
+// Values implementing codec.BinaryCodec serialize themselves;
+// other values go through the registry codec.
+type TypeRegistry struct {
+	mu        sync.RWMutex
+	codec     codec.Codec
+	factories map[string]func() any
+	names     map[reflect.Type]string
+}
+
+// NewTypeRegistry creates an empty registry using the default codec.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		codec:     codec.Default,
+		factories: make(map[string]func() any),
+		names:     make(map[reflect.Type]string),
+	}
+}
+
+// WithCodec sets a custom codec.
+func (r *TypeRegistry) WithCodec(c codec.Codec) *TypeRegistry {
+	r.codec = c
+	return r
+}
+
+// RegisterType binds name to factory. Factory must return a non-nil pointer
+// to a fresh zero value; its dynamic type is what Wrap recognizes.
+func (r *TypeRegistry) RegisterType(name string, factory func() any) error {
+	if name == "" || factory == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidType, name)
+	}
+	sample := factory()
+	typ := reflect.TypeOf(sample)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return fmt.Errorf("%w: %s factory must return a pointer, got %T", ErrInvalidType, name, sample)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("%w: %s", ErrTypeExists, name)
+	}
+	if other, exists := r.names[typ]; exists {
+		return fmt.Errorf("%w: %s already registered as %s", ErrTypeExists, typ, other)
+	}
+
+	r.factories[name] = factory
+	r.names[typ] = name
+	return nil
+}
+
+// TypeName returns registered name of v's concrete type.
+func (r *TypeRegistry) TypeName(v any) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.names[reflect.TypeOf(v)]
+	return name, ok
+}
+
+// Wrap encodes v and tags it with its registered type name.
+func (r *TypeRegistry) Wrap(v any) (TypedValue, error) {
+	if v == nil {
+		return TypedValue{}, codec.ErrNilValue
+	}
+	name, ok := r.TypeName(v)
+	if !ok {
+		return TypedValue{}, fmt.Errorf("%w: %T", ErrTypeUnknown, v)
+	}
+
+	var data []byte
+	var err error
+	if m, ok := v.(Marshaler); ok {
+		data, err = m.MarshalBinary()
+	} else {
+		data, err = r.codec.Encode(v)
+	}
+	if err != nil {
+		return TypedValue{}, fmt.Errorf("encode %s: %w", name, err)
+	}
+
+	return TypedValue{Type: name, Data: data}, nil
+}
+
+// Unwrap rebuilds concrete value from tagged data.
+// Result is the pointer produced by the registered factory.
+func (r *TypeRegistry) Unwrap(tv TypedValue) (any, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[tv.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTypeUnknown, tv.Type)
+	}
+
+	v := factory()
+	var err error
+	if u, ok := v.(Unmarshaler); ok {
+		err = u.UnmarshalBinary(tv.Data)
+	} else {
+		err = r.codec.Decode(tv.Data, v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", tv.Type, err)
+	}
+
+	return v, nil
+}
+
+// Encode serializes v as tagged value.
+func (r *TypeRegistry) Encode(v any) ([]byte, error) {
+	tv, err := r.Wrap(v)
+	if err != nil {
+		return nil, err
+	}
+	return r.codec.Encode(tv)
+}
+
+// Decode deserializes data produced by Encode into its concrete type.
+func (r *TypeRegistry) Decode(data []byte) (any, error) {
+	var tv TypedValue
+	if err := r.codec.Decode(data, &tv); err != nil {
+		return nil, err
+	}
+	return r.Unwrap(tv)
+}
+
+// WrapSlice tags every element of values.
+func WrapSlice[T any](r *TypeRegistry, values []T) ([]TypedValue, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]TypedValue, len(values))
+	for i, v := range values {
+		tv, err := r.Wrap(v)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result[i] = tv
+	}
+	return result, nil
+}
+
+// UnwrapSlice rebuilds elements and asserts each implements T.
+func UnwrapSlice[T any](r *TypeRegistry, values []TypedValue) ([]T, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]T, len(values))
+	for i, tv := range values {
+		v, err := r.Unwrap(tv)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		typed, ok := v.(T)
+		if !ok {
+			return nil, fmt.Errorf("%w: element %d is %T", ErrTypeMismatch, i, v)
+		}
+		result[i] = typed
+	}
+	return result, nil
+}
+
+var defaultTypeRegistry = NewTypeRegistry()
+
+// DefaultTypeRegistry returns the shared registry that game types register with.
+func DefaultTypeRegistry() *TypeRegistry {
+	return defaultTypeRegistry
+}
+
+// RegisterType binds name to factory in the default registry.
+func RegisterType(name string, factory func() any) error {
+	return defaultTypeRegistry.RegisterType(name, factory)
+}
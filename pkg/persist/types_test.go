@@ -0,0 +1,110 @@
+package persist_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/davidmovas/Depthborn/pkg/persist"
+	"github.com/davidmovas/Depthborn/pkg/persist/codec"
+)
+
+type shape interface {
+	Area() float64
+}
+
+// square is encoded through the registry codec.
+type square struct {
+	Side float64 `msgpack:"side" json:"side"`
+}
+
+func (s *square) Area() float64 { return s.Side * s.Side }
+
+// circle serializes itself and keeps fields unexported.
+type circle struct {
+	radius float64
+}
+
+func (c *circle) Area() float64 { return 3 * c.radius * c.radius }
+
+func (c *circle) MarshalBinary() ([]byte, error) {
+	return codec.Default.Encode(c.radius)
+}
+
+func (c *circle) UnmarshalBinary(data []byte) error {
+	return codec.Default.Decode(data, &c.radius)
+}
+
+func newShapeRegistry(t *testing.T, c codec.Codec) *persist.TypeRegistry {
+	t.Helper()
+
+	r := persist.NewTypeRegistry().WithCodec(c)
+	require.NoError(t, r.RegisterType("square", func() any { return &square{} }))
+	require.NoError(t, r.RegisterType("circle", func() any { return &circle{} }))
+	return r
+}
+
+func TestTypeRegistry(t *testing.T) {
+	for _, c := range []codec.Codec{codec.NewMsgPack(), codec.NewJSON()} {
+		t.Run(c.Name(), func(t *testing.T) {
+			t.Run("round-trips mixed interface slice", func(t *testing.T) {
+				r := newShapeRegistry(t, c)
+				shapes := []shape{&square{Side: 2}, &circle{radius: 1}, &square{Side: 3}}
+
+				wrapped, err := persist.WrapSlice(r, shapes)
+				require.NoError(t, err)
+				data, err := c.Encode(struct {
+					Shapes []persist.TypedValue `msgpack:"shapes" json:"shapes"`
+				}{wrapped})
+				require.NoError(t, err)
+
+				var stored struct {
+					Shapes []persist.TypedValue `msgpack:"shapes" json:"shapes"`
+				}
+				require.NoError(t, c.Decode(data, &stored))
+				restored, err := persist.UnwrapSlice[shape](r, stored.Shapes)
+				require.NoError(t, err)
+
+				assert.Equal(t, shapes, restored)
+			})
+
+			t.Run("encodes single value", func(t *testing.T) {
+				r := newShapeRegistry(t, c)
+
+				data, err := r.Encode(&circle{radius: 2})
+				require.NoError(t, err)
+				v, err := r.Decode(data)
+				require.NoError(t, err)
+
+				assert.Equal(t, &circle{radius: 2}, v)
+			})
+		})
+	}
+
+	t.Run("unregistered type", func(t *testing.T) {
+		r := persist.NewTypeRegistry()
+
+		_, err := r.Wrap(&square{Side: 1})
+		assert.ErrorIs(t, err, persist.ErrTypeUnknown)
+		_, err = r.Unwrap(persist.TypedValue{Type: "square"})
+		assert.ErrorIs(t, err, persist.ErrTypeUnknown)
+	})
+
+	t.Run("duplicate registration", func(t *testing.T) {
+		r := newShapeRegistry(t, codec.NewMsgPack())
+
+		assert.ErrorIs(t, r.RegisterType("square", func() any { return &square{} }), persist.ErrTypeExists)
+		assert.ErrorIs(t, r.RegisterType("box", func() any { return &square{} }), persist.ErrTypeExists)
+		assert.ErrorIs(t, r.RegisterType("value", func() any { return square{} }), persist.ErrInvalidType)
+	})
+
+	t.Run("unwrapped type must implement target", func(t *testing.T) {
+		r := newShapeRegistry(t, codec.NewMsgPack())
+		wrapped, err := persist.WrapSlice(r, []shape{&square{Side: 1}})
+		require.NoError(t, err)
+
+		_, err = persist.UnwrapSlice[interface{ Volume() float64 }](r, wrapped)
+		assert.ErrorIs(t, err, persist.ErrTypeMismatch)
+	})
+}